@@ -14,32 +14,47 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"google.golang.org/grpc"
 	_ "log"
 
 	"pvz-service-avito-internship/internal/config"
 	"pvz-service-avito-internship/internal/domain"
 	httpHandler "pvz-service-avito-internship/internal/handler/http"
+	"pvz-service-avito-internship/internal/health"
 	promMetrics "pvz-service-avito-internship/internal/metrics"
 	mw "pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/ratelimit"
+	"pvz-service-avito-internship/internal/repository/filestore"
 	"pvz-service-avito-internship/internal/repository/postgres"
 	"pvz-service-avito-internship/internal/service"
+	"pvz-service-avito-internship/internal/tracing"
+	"pvz-service-avito-internship/internal/transport/gateway"
 	grpcTransport "pvz-service-avito-internship/internal/transport/grpc"
 	"pvz-service-avito-internship/pkg/database"
 	"pvz-service-avito-internship/pkg/hash"
+	"pvz-service-avito-internship/pkg/validator"
 )
 
 type App struct {
-	cfg           *config.Config
-	log           *slog.Logger
-	dbPool        *pgxpool.Pool
-	router        *gin.Engine
-	server        *http.Server
-	grpcServer    *grpcTransport.Server
-	metricsServer *http.Server
+	cfg             *config.Config
+	log             *slog.Logger
+	dbPool          *pgxpool.Pool
+	router          *gin.Engine
+	server          *http.Server
+	grpcServer      *grpcTransport.Server
+	gateway         *gateway.Gateway
+	metricsServer   *http.Server
+	healthChecker   *health.Checker
+	reportWorker    *service.ReportWorker
+	staleReceptions *StaleReceptionScheduler   // nil, если планировщик отключен
+	occupancy       *OccupancyMetricsRefresher // nil, если обновление метрик заполненности отключено
+	idempotencyGC   *IdempotencyKeyCleaner     // nil, если поддержка Idempotency-Key отключена
+	shutdownTracing func(context.Context) error
 }
 
 func MustNewApp(cfg *config.Config, log *slog.Logger) *App {
@@ -63,9 +78,8 @@ func MustNewApp(cfg *config.Config, log *slog.Logger) *App {
 	}
 	log.Info("Successfully connected to the main database")
 
-	migrationsPath := os.Getenv("MIGRATIONS_PATH")
-	if migrationsPath == "" {
-		migrationsPath = "file://migrations"
+	migrationsPath := getMigrationsPath()
+	if os.Getenv("MIGRATIONS_PATH") == "" {
 		log.Warn("MIGRATIONS_PATH environment variable not set, using default local path", slog.String("path", migrationsPath))
 	} else {
 		log.Info("Using migrations path from MIGRATIONS_PATH env var", slog.String("path", migrationsPath))
@@ -95,69 +109,202 @@ func NewApp(cfg *config.Config, log *slog.Logger, dbPool *pgxpool.Pool) *App {
 	log = log.With(slog.String("op", op))
 	log.Info("Initializing application components...")
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("CRITICAL: Failed to initialize tracing", slog.String("error", err.Error()))
+		panic(fmt.Sprintf("failed to initialize tracing: %v", err))
+	}
+	log.Info("Tracing configured", slog.String("exporter", cfg.Tracing.Exporter))
+
+	healthChecker := health.NewChecker(log, cfg.Metrics.ReadinessTimeout)
+	healthChecker.AddReadinessCheck("database", health.PingCheck(dbPool))
+	if expectedVersion, err := latestMigrationVersion(getMigrationsPath()); err != nil {
+		log.Warn("Failed to determine latest migration version, migration readiness check disabled", slog.String("error", err.Error()))
+	} else {
+		healthChecker.AddReadinessCheck("migrations", health.MigrationVersionCheck(dbPool, expectedVersion))
+	}
+
 	metricsCollector := promMetrics.NewCollector()
-	metricsServer := promMetrics.RunMetricsServer(":" + cfg.Metrics.Port)
+	metricsServer := promMetrics.RunMetricsServer(":"+cfg.Metrics.Port, healthChecker.Register)
 	log.Info("Metrics server configured", slog.String("port", cfg.Metrics.Port))
 
 	pvzRepo := postgres.NewPVZRepository(dbPool, log)
 	receptionRepo := postgres.NewReceptionRepository(dbPool, log)
 	productRepo := postgres.NewProductRepository(dbPool, log)
 	userRepo := postgres.NewUserRepository(dbPool, log)
+	statsRepo := postgres.NewStatsRepository(dbPool, log)
+	exportRepo := postgres.NewExportRepository(dbPool, log)
+	reportRepo := postgres.NewReportRepository(dbPool, log)
+	shipmentRepo := postgres.NewExpectedShipmentRepository(dbPool, log)
+	dispatchRepo := postgres.NewDispatchRepository(dbPool, log)
+	idempotencyRepo := postgres.NewIdempotencyRepository(dbPool, log)
+
+	reportStore, err := filestore.NewLocalBlobStore(cfg.Reports.StorageDir, log)
+	if err != nil {
+		log.Error("CRITICAL: Failed to initialize report storage", slog.String("error", err.Error()))
+		panic(fmt.Sprintf("failed to initialize report storage: %v", err))
+	}
 
 	hasher := hash.NewBcryptHasher(cfg.Hasher.BcryptCost)
 
 	authService := service.NewAuthService(log, cfg.Auth.JWTSecret, cfg.Auth.JWTttl, userRepo, hasher)
-	pvzService := service.NewPVZService(log, pvzRepo, receptionRepo, metricsCollector)
-	receptionService := service.NewReceptionService(log, pvzRepo, receptionRepo, metricsCollector)
-	productService := service.NewProductService(log, receptionRepo, productRepo, metricsCollector)
+	pvzService := service.NewPVZService(log, pvzRepo, receptionRepo, productRepo, metricsCollector)
+	receptionService := service.NewReceptionService(log, pvzRepo, receptionRepo, shipmentRepo, metricsCollector)
+	productService := service.NewProductService(log, receptionRepo, productRepo, dispatchRepo, metricsCollector)
+	dispatchService := service.NewDispatchService(log, dispatchRepo, productRepo)
+	shipmentService := service.NewShipmentService(log, pvzRepo, receptionRepo, shipmentRepo)
+	statsService := service.NewStatsService(log, statsRepo)
+	exportService := service.NewExportService(log, exportRepo)
+	reportService := service.NewReportService(log, reportRepo, reportStore)
+	reportWorker := service.NewReportWorker(log, reportRepo, statsService, reportStore, cfg.Reports)
+
+	var staleReceptions *StaleReceptionScheduler
+	if cfg.StaleReceptions.Enabled {
+		locker := postgres.NewAdvisoryLocker(dbPool, log)
+		staleReceptions, err = NewStaleReceptionScheduler(log, receptionRepo, receptionService, locker, metricsCollector, cfg.StaleReceptions)
+		if err != nil {
+			log.Error("CRITICAL: Failed to initialize stale reception scheduler", slog.String("error", err.Error()))
+			panic(fmt.Sprintf("failed to initialize stale reception scheduler: %v", err))
+		}
+	}
+
+	var occupancy *OccupancyMetricsRefresher
+	if cfg.Metrics.OccupancyInterval > 0 {
+		occupancy, err = NewOccupancyMetricsRefresher(log, pvzRepo, metricsCollector, cfg.Metrics.OccupancyInterval)
+		if err != nil {
+			log.Error("CRITICAL: Failed to initialize occupancy metrics refresher", slog.String("error", err.Error()))
+			panic(fmt.Sprintf("failed to initialize occupancy metrics refresher: %v", err))
+		}
+	}
+
+	var idempotencyGC *IdempotencyKeyCleaner
+	if cfg.Idempotency.Enabled {
+		idempotencyGC, err = NewIdempotencyKeyCleaner(log, idempotencyRepo, cfg.Idempotency.CleanupInterval)
+		if err != nil {
+			log.Error("CRITICAL: Failed to initialize idempotency key cleaner", slog.String("error", err.Error()))
+			panic(fmt.Sprintf("failed to initialize idempotency key cleaner: %v", err))
+		}
+	}
+
+	passwordPolicy, err := newPasswordPolicy(cfg.Password)
+	if err != nil {
+		log.Error("CRITICAL: Failed to initialize password policy", slog.String("error", err.Error()))
+		panic(fmt.Sprintf("failed to initialize password policy: %v", err))
+	}
+
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		limiter, err = ratelimit.NewLimiter(log, ratelimit.NewMemoryStore(), cfg.RateLimit)
+		if err != nil {
+			log.Error("CRITICAL: Failed to initialize rate limiter", slog.String("error", err.Error()))
+			panic(fmt.Sprintf("failed to initialize rate limiter: %v", err))
+		}
+	}
 
-	authHandler := httpHandler.NewAuthHandler(log, authService)
+	authHandler := httpHandler.NewAuthHandler(log, authService, validator.NewCustomValidator(passwordPolicy))
 	pvzHandler := httpHandler.NewPVZHandler(log, pvzService, receptionService, productService)
 	receptionHandler := httpHandler.NewReceptionHandler(log, receptionService)
 	productHandler := httpHandler.NewProductHandler(log, productService)
+	shipmentHandler := httpHandler.NewShipmentHandler(log, shipmentService)
+	dispatchHandler := httpHandler.NewDispatchHandler(log, dispatchService)
+	statsHandler := httpHandler.NewStatsHandler(log, statsService)
+	exportHandler := httpHandler.NewExportHandler(log, exportService, cfg.HTTPServer.ExportWriteTimeout)
+	reportHandler := httpHandler.NewReportHandler(log, reportService, cfg.HTTPServer.ExportWriteTimeout)
 
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 
 	router.Use(mw.Recovery(log))
+	// Span запроса начинается до логирования: Request ID берется из ID трассировки.
+	router.Use(otelgin.Middleware(cfg.Tracing.ServiceName))
+	router.Use(mw.Locale())
 	logMiddleware := mw.NewLoggingMiddleware(log)
 	router.Use(logMiddleware.LogRequest)
 	router.Use(mw.PrometheusMiddleware(metricsCollector))
 	authMiddleware := mw.NewAuthMiddleware(log, cfg.Auth.JWTSecret)
+	// Лимиты запросов и ключи идемпотентности разделяются по пользователям, поэтому в защищенной группе
+	// эти middleware стоят после Authorize. Лимит проверяется первым, чтобы отклоненный запрос не захватывал ключ.
+	rateLimitMiddleware := mw.NewRateLimitMiddleware(log, limiter, metricsCollector)
+	idempotencyMiddleware := mw.NewIdempotencyMiddleware(log, idempotencyRepo, cfg.Idempotency)
 
-	router.POST("/dummyLogin", authHandler.PostDummyLogin)
-	router.POST("/register", authHandler.PostRegister)
-	router.POST("/login", authHandler.PostLogin)
+	// Маршруты аутентификации не идемпотентны: сохраненный ответ содержал бы JWT, а ключи анонимных
+	// клиентов попадали бы в общее пространство.
+	router.POST("/dummyLogin", rateLimitMiddleware.Handle, authHandler.PostDummyLogin)
+	router.POST("/register", rateLimitMiddleware.Handle, authHandler.PostRegister)
+	router.POST("/login", rateLimitMiddleware.Handle, authHandler.PostLogin)
 
 	apiGroup := router.Group("/")
-	apiGroup.Use(authMiddleware.Authorize)
+	apiGroup.Use(authMiddleware.Authorize, rateLimitMiddleware.Handle, idempotencyMiddleware.Handle)
 	{
 		pvzGroup := apiGroup.Group("/pvz")
 		{
 			pvzGroup.POST("", mw.RequireRole(domain.RoleModerator), pvzHandler.PostPvz)
 			pvzGroup.GET("", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), pvzHandler.GetPvz)
 			pvzWithIDGroup := pvzGroup.Group("/:pvzId")
-			pvzWithIDGroup.Use(mw.RequireRole(domain.RoleEmployee))
 			{
-				pvzWithIDGroup.POST("/close_last_reception", pvzHandler.CloseLastReception)
-				pvzWithIDGroup.POST("/delete_last_product", pvzHandler.DeleteLastProduct)
+				pvzWithIDGroup.GET("", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), pvzHandler.GetPvzByID)
+				pvzWithIDGroup.GET("/receptions", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), pvzHandler.GetPvzReceptions)
+				pvzWithIDGroup.GET("/receptions/current", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), pvzHandler.GetCurrentReception)
+				pvzWithIDGroup.POST("/close_last_reception", mw.RequireRole(domain.RoleEmployee), pvzHandler.CloseLastReception)
+				pvzWithIDGroup.POST("/delete_last_product", mw.RequireRole(domain.RoleEmployee), pvzHandler.DeleteLastProduct)
+				pvzWithIDGroup.GET("/occupancy", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), pvzHandler.GetPvzOccupancy)
+				pvzWithIDGroup.PUT("/capacity", mw.RequireRole(domain.RoleModerator), pvzHandler.PutPvzCapacity)
 			}
 		}
 		receptionsGroup := apiGroup.Group("/receptions")
-		receptionsGroup.Use(mw.RequireRole(domain.RoleEmployee))
 		{
-			receptionsGroup.POST("", receptionHandler.PostReceptions)
+			receptionsGroup.POST("", mw.RequireRole(domain.RoleEmployee), receptionHandler.PostReceptions)
+			receptionsGroup.GET("/:receptionId", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), receptionHandler.GetReception)
+			receptionsGroup.GET("/:receptionId/products", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), productHandler.GetReceptionProducts)
+			receptionsGroup.GET("/:receptionId/reconciliation", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), shipmentHandler.GetReceptionReconciliation)
+		}
+		shipmentsGroup := apiGroup.Group("/expected_shipments")
+		{
+			shipmentsGroup.POST("", mw.RequireRole(domain.RoleModerator), shipmentHandler.PostExpectedShipments)
+			shipmentsGroup.GET("/:shipmentId", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), shipmentHandler.GetExpectedShipment)
 		}
 		productsGroup := apiGroup.Group("/products")
-		productsGroup.Use(mw.RequireRole(domain.RoleEmployee))
 		{
-			productsGroup.POST("", productHandler.PostProducts)
+			productsGroup.POST("", mw.RequireRole(domain.RoleEmployee), productHandler.PostProducts)
+			productsGroup.POST("/:productId/issue", mw.RequireRole(domain.RoleEmployee), productHandler.IssueProduct)
+			productsGroup.POST("/:productId/return", mw.RequireRole(domain.RoleEmployee), productHandler.ReturnProduct)
+			productsGroup.POST("/:productId/mark_lost", mw.RequireRole(domain.RoleModerator), productHandler.MarkProductLost)
+		}
+		dispatchesGroup := apiGroup.Group("/dispatches")
+		{
+			dispatchesGroup.GET("/:dispatchId", mw.RequireRole(domain.RoleModerator, domain.RoleEmployee), dispatchHandler.GetDispatch)
+			dispatchesGroup.POST("/:dispatchId/send", mw.RequireRole(domain.RoleEmployee), dispatchHandler.SendDispatch)
+		}
+		statsGroup := apiGroup.Group("/stats")
+		statsGroup.Use(mw.RequireRole(domain.RoleModerator))
+		{
+			statsGroup.GET("/receptions", statsHandler.GetReceptionStats)
+		}
+		exportsGroup := apiGroup.Group("/exports")
+		exportsGroup.Use(mw.RequireRole(domain.RoleModerator))
+		{
+			exportsGroup.GET("/receptions", exportHandler.GetReceptionsExport)
+		}
+		reportsGroup := apiGroup.Group("/reports")
+		reportsGroup.Use(mw.RequireRole(domain.RoleModerator))
+		{
+			reportsGroup.POST("", reportHandler.PostReports)
+			reportsGroup.GET("/:reportId", reportHandler.GetReport)
+			reportsGroup.GET("/:reportId/download", reportHandler.DownloadReport)
 		}
 	}
 
-	grpcServer := grpcTransport.NewServer(log, pvzRepo, cfg.GRPCServer.Port)
+	grpcServer := grpcTransport.NewServer(log, pvzRepo, receptionService, productService, metricsCollector, limiter, dbPool, cfg.Auth.JWTSecret, cfg.GRPCServer)
 	log.Info("gRPC server configured", slog.String("port", cfg.GRPCServer.Port))
 
+	// HTTP/JSON шлюз ходит в собственный gRPC сервер через loopback, чтобы переиспользовать его интерцепторы.
+	grpcGateway, err := gateway.New(context.Background(), log, "localhost:"+cfg.GRPCServer.Port)
+	if err != nil {
+		log.Error("CRITICAL: Failed to initialize HTTP/JSON gateway", slog.String("error", err.Error()))
+		panic(fmt.Sprintf("failed to initialize gateway: %v", err))
+	}
+	router.Any(gateway.PathPrefix+"/*path", gin.WrapH(grpcGateway))
+
 	httpServer := &http.Server{
 		Addr:         ":" + cfg.HTTPServer.Port,
 		Handler:      router,
@@ -170,13 +317,79 @@ func NewApp(cfg *config.Config, log *slog.Logger, dbPool *pgxpool.Pool) *App {
 	log.Info("Application components initialized successfully")
 
 	return &App{
-		cfg:           cfg,
-		log:           log,
-		dbPool:        dbPool,
-		router:        router,
-		server:        httpServer,
-		grpcServer:    grpcServer,
-		metricsServer: metricsServer,
+		cfg:             cfg,
+		log:             log,
+		dbPool:          dbPool,
+		router:          router,
+		server:          httpServer,
+		grpcServer:      grpcServer,
+		gateway:         grpcGateway,
+		metricsServer:   metricsServer,
+		healthChecker:   healthChecker,
+		reportWorker:    reportWorker,
+		staleReceptions: staleReceptions,
+		occupancy:       occupancy,
+		idempotencyGC:   idempotencyGC,
+		shutdownTracing: shutdownTracing,
+	}
+}
+
+// newPasswordPolicy строит требования к паролю из конфигурации и загружает список скомпрометированных паролей.
+func newPasswordPolicy(cfg config.Password) (validator.PasswordPolicy, error) {
+	if cfg.MinLength < 0 || cfg.MaxLength < cfg.MinLength {
+		return validator.PasswordPolicy{}, fmt.Errorf("invalid password length range [%d, %d]", cfg.MinLength, cfg.MaxLength)
+	}
+	if cfg.MinSymbol > 0 && cfg.Symbols == "" {
+		return validator.PasswordPolicy{}, errors.New("password symbols must not be empty when min_symbol is set")
+	}
+	policy := validator.PasswordPolicy{
+		MinLength: cfg.MinLength,
+		MaxLength: cfg.MaxLength,
+		MinLower:  cfg.MinLower,
+		MinUpper:  cfg.MinUpper,
+		MinDigit:  cfg.MinDigit,
+		MinSymbol: cfg.MinSymbol,
+		Symbols:   cfg.Symbols,
+	}
+	if cfg.BlocklistFile != "" {
+		blocklist, err := validator.LoadPasswordBlocklist(cfg.BlocklistFile)
+		if err != nil {
+			return validator.PasswordPolicy{}, err
+		}
+		policy.Blocklist = blocklist
+	}
+	return policy, nil
+}
+
+func getMigrationsPath() string {
+	if migrationsPath := os.Getenv("MIGRATIONS_PATH"); migrationsPath != "" {
+		return migrationsPath
+	}
+	return "file://migrations"
+}
+
+// latestMigrationVersion возвращает номер последней миграции в источнике - версию схемы,
+// которую ожидает текущая сборка приложения.
+func latestMigrationVersion(migrationsPath string) (uint, error) {
+	src, err := source.Open(migrationsPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open migrations source: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read first migration: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migration after version %d: %w", version, err)
+		}
+		version = next
 	}
 }
 
@@ -274,6 +487,17 @@ func (a *App) Run() {
 		}
 	}()
 
+	a.reportWorker.Start()
+	if a.staleReceptions != nil {
+		a.staleReceptions.Start()
+	}
+	if a.occupancy != nil {
+		a.occupancy.Start()
+	}
+	if a.idempotencyGC != nil {
+		a.idempotencyGC.Start()
+	}
+
 	shutdownChan := make(chan os.Signal, 1)
 	signal.Notify(shutdownChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -285,26 +509,57 @@ func (a *App) Run() {
 	}
 
 	log.Info("Starting graceful shutdown...")
+	a.healthChecker.SetShuttingDown()
+	log.Info("Readiness switched to not ready")
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer shutdownCancel()
 
+	// HTTP сервер останавливается раньше gRPC: запросы шлюза /v2 проксируются в gRPC и должны успеть завершиться.
+	if err := a.server.Shutdown(shutdownCtx); err != nil {
+		log.Error("HTTP server graceful shutdown failed", slog.String("error", err.Error()))
+	} else {
+		log.Info("HTTP server stopped gracefully")
+	}
+
+	if err := a.gateway.Close(); err != nil {
+		log.Error("Failed to close gateway gRPC connection", slog.String("error", err.Error()))
+	}
+
 	a.grpcServer.Stop()
 
+	// Обработчики отчетов останавливаются до закрытия пула: прерванные задачи возвращаются в очередь через БД.
+	if err := a.reportWorker.Stop(shutdownCtx); err != nil {
+		log.Error("Report workers shutdown failed", slog.String("error", err.Error()))
+	}
+	if a.staleReceptions != nil {
+		if err := a.staleReceptions.Stop(shutdownCtx); err != nil {
+			log.Error("Stale reception scheduler shutdown failed", slog.String("error", err.Error()))
+		}
+	}
+	if a.occupancy != nil {
+		if err := a.occupancy.Stop(shutdownCtx); err != nil {
+			log.Error("Occupancy metrics refresher shutdown failed", slog.String("error", err.Error()))
+		}
+	}
+	if a.idempotencyGC != nil {
+		if err := a.idempotencyGC.Stop(shutdownCtx); err != nil {
+			log.Error("Idempotency key cleaner shutdown failed", slog.String("error", err.Error()))
+		}
+	}
+
 	if err := a.metricsServer.Shutdown(shutdownCtx); err != nil {
 		log.Error("Metrics server graceful shutdown failed", slog.String("error", err.Error()))
 	} else {
 		log.Info("Metrics server stopped gracefully")
 	}
 
-	if err := a.server.Shutdown(shutdownCtx); err != nil {
-		log.Error("HTTP server graceful shutdown failed", slog.String("error", err.Error()))
-	} else {
-		log.Info("HTTP server stopped gracefully")
-	}
-
 	log.Info("Closing database connection pool...")
 	a.dbPool.Close()
 	log.Info("Database connection pool closed")
 
+	if err := a.shutdownTracing(shutdownCtx); err != nil {
+		log.Error("Failed to flush traces", slog.String("error", err.Error()))
+	}
+
 	log.Info("Graceful shutdown completed")
 }
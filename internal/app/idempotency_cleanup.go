@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// IdempotencyKeyCleaner периодически удаляет ключи идемпотентности с истекшим сроком хранения.
+// Удаление идемпотентно, поэтому несколько экземпляров сервиса могут выполнять его одновременно.
+type IdempotencyKeyCleaner struct {
+	log      *slog.Logger
+	repo     domain.IdempotencyRepository
+	interval time.Duration
+
+	runner *periodicRunner
+}
+
+// NewIdempotencyKeyCleaner создает новый экземпляр IdempotencyKeyCleaner.
+// Возвращает ошибку при неположительном интервале.
+func NewIdempotencyKeyCleaner(log *slog.Logger, repo domain.IdempotencyRepository, interval time.Duration) (*IdempotencyKeyCleaner, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid idempotency cleanup interval %s", interval)
+	}
+	return &IdempotencyKeyCleaner{
+		log:      log.With(slog.String("component", "IdempotencyKeyCleaner")),
+		repo:     repo,
+		interval: interval,
+	}, nil
+}
+
+// Start запускает очистку раз в interval в фоне, первый проход выполняется сразу.
+func (c *IdempotencyKeyCleaner) Start() {
+	c.runner = runPeriodic(c.interval, c.RunOnce)
+
+	c.log.Info("Idempotency key cleaner started", slog.Duration("interval", c.interval))
+}
+
+// Stop останавливает очистку. Прерванное удаление повторится при следующем запуске сервиса.
+func (c *IdempotencyKeyCleaner) Stop(ctx context.Context) error {
+	if c.runner == nil {
+		return nil
+	}
+	if err := c.runner.stop(ctx); err != nil {
+		return fmt.Errorf("idempotency key cleaner did not stop in time: %w", err)
+	}
+	c.log.Info("Idempotency key cleaner stopped")
+	return nil
+}
+
+// RunOnce удаляет истекшие ключи один раз.
+func (c *IdempotencyKeyCleaner) RunOnce(ctx context.Context) {
+	const op = "IdempotencyKeyCleaner.RunOnce"
+
+	deleted, err := c.repo.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		if ctx.Err() == nil {
+			c.log.Error("Failed to delete expired idempotency keys", slog.String("op", op), slog.String("error", err.Error()))
+		}
+		return
+	}
+	if deleted > 0 {
+		c.log.Info("Expired idempotency keys deleted", slog.String("op", op), slog.Int64("count", deleted))
+	}
+}
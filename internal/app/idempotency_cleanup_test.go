@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestIdempotencyKeyCleaner_RunOnce(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	repo := mocks.NewIdempotencyRepository(t)
+	repo.On("DeleteExpired", ctx, mock.MatchedBy(func(now time.Time) bool {
+		return time.Since(now) < time.Minute
+	})).Return(int64(3), nil).Once()
+
+	c, err := NewIdempotencyKeyCleaner(logger, repo, time.Hour)
+	require.NoError(t, err)
+	c.RunOnce(ctx)
+}
+
+func TestNewIdempotencyKeyCleaner_InvalidInterval(t *testing.T) {
+	_, err := NewIdempotencyKeyCleaner(slog.Default(), nil, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// OccupancyMetricsRefresher периодически читает заполненность ПВЗ из БД и обновляет метрики Prometheus.
+// Счетчики ведет триггер на products, поэтому каждый экземпляр сервиса экспортирует одинаковые значения,
+// включая изменения, сделанные другими экземплярами.
+type OccupancyMetricsRefresher struct {
+	log              *slog.Logger
+	pvzRepo          domain.PVZRepository // Зависимость для чтения заполненности
+	metricsCollector domain.MetricsCollector
+	interval         time.Duration
+
+	runner *periodicRunner
+}
+
+// NewOccupancyMetricsRefresher создает новый экземпляр OccupancyMetricsRefresher.
+// Возвращает ошибку при неположительном интервале.
+func NewOccupancyMetricsRefresher(
+	log *slog.Logger,
+	pvzRepo domain.PVZRepository,
+	metricsCollector domain.MetricsCollector,
+	interval time.Duration,
+) (*OccupancyMetricsRefresher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid occupancy metrics interval %s", interval)
+	}
+	return &OccupancyMetricsRefresher{
+		log:              log.With(slog.String("component", "OccupancyMetricsRefresher")),
+		pvzRepo:          pvzRepo,
+		metricsCollector: metricsCollector,
+		interval:         interval,
+	}, nil
+}
+
+// Start запускает обновление метрик раз в interval в фоне, первый проход выполняется сразу.
+func (r *OccupancyMetricsRefresher) Start() {
+	r.runner = runPeriodic(r.interval, r.RunOnce)
+
+	r.log.Info("Occupancy metrics refresher started", slog.Duration("interval", r.interval))
+}
+
+// Stop останавливает обновление. Возвращает ошибку, если текущий проход не завершился до дедлайна ctx.
+func (r *OccupancyMetricsRefresher) Stop(ctx context.Context) error {
+	if r.runner == nil {
+		return nil
+	}
+	if err := r.runner.stop(ctx); err != nil {
+		return fmt.Errorf("occupancy metrics refresher did not stop in time: %w", err)
+	}
+	r.log.Info("Occupancy metrics refresher stopped")
+	return nil
+}
+
+// RunOnce обновляет метрики один раз. При ошибке чтения метрики сохраняют предыдущие значения.
+func (r *OccupancyMetricsRefresher) RunOnce(ctx context.Context) {
+	const op = "OccupancyMetricsRefresher.RunOnce"
+
+	occupancies, err := r.pvzRepo.ListOccupancy(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Error("Failed to refresh occupancy metrics", slog.String("op", op), slog.String("error", err.Error()))
+		}
+		return
+	}
+	r.metricsCollector.SetPVZOccupancy(occupancies)
+	r.log.Debug("Occupancy metrics refreshed", slog.String("op", op), slog.Int("pvz_count", len(occupancies)))
+}
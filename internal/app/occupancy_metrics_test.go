@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestOccupancyMetricsRefresher_RunOnce(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	t.Run("Exports_Occupancy", func(t *testing.T) {
+		pvzRepo := mocks.NewPVZRepository(t)
+		metricsCollector := mocks.NewMetricsCollector(t)
+		occupancies := []domain.PVZOccupancy{{PVZID: uuid.New(), City: domain.Moscow, Occupied: 4}}
+		pvzRepo.On("ListOccupancy", ctx).Return(occupancies, nil).Once()
+		metricsCollector.On("SetPVZOccupancy", occupancies).Once()
+
+		r, err := NewOccupancyMetricsRefresher(logger, pvzRepo, metricsCollector, time.Minute)
+		require.NoError(t, err)
+		r.RunOnce(ctx)
+	})
+
+	t.Run("Keeps_Previous_Values_On_Error", func(t *testing.T) {
+		pvzRepo := mocks.NewPVZRepository(t)
+		metricsCollector := mocks.NewMetricsCollector(t)
+		pvzRepo.On("ListOccupancy", ctx).Return(nil, errors.New("connection refused")).Once()
+
+		r, err := NewOccupancyMetricsRefresher(logger, pvzRepo, metricsCollector, time.Minute)
+		require.NoError(t, err)
+		r.RunOnce(ctx)
+		metricsCollector.AssertNotCalled(t, "SetPVZOccupancy", mock.Anything)
+	})
+}
+
+func TestNewOccupancyMetricsRefresher_InvalidInterval(t *testing.T) {
+	_, err := NewOccupancyMetricsRefresher(slog.Default(), nil, nil, 0)
+	assert.Error(t, err)
+}
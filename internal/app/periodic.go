@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"time"
+)
+
+// periodicRunner выполняет фоновую задачу по таймеру. Общий для всех периодических задач сервиса.
+type periodicRunner struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// runPeriodic вызывает fn сразу и затем раз в interval в отдельной горутине, пока раннер не остановлен.
+// Контекст fn отменяется при остановке.
+func runPeriodic(interval time.Duration, fn func(ctx context.Context)) *periodicRunner {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &periodicRunner{cancel: cancel, done: make(chan struct{})}
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			fn(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return r
+}
+
+// stop отменяет контекст задачи и ждет завершения текущего вызова fn. Если ctx истекает раньше,
+// возвращает его ошибку.
+func (r *periodicRunner) stop(ctx context.Context) error {
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
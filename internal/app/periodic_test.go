@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPeriodic(t *testing.T) {
+	t.Run("Runs_Immediately_And_On_Tick", func(t *testing.T) {
+		var calls atomic.Int32
+		runner := runPeriodic(10*time.Millisecond, func(context.Context) { calls.Add(1) })
+
+		assert.Eventually(t, func() bool { return calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+		require.NoError(t, runner.stop(context.Background()))
+
+		stopped := calls.Load()
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, stopped, calls.Load(), "fn must not run after stop")
+	})
+
+	t.Run("Stop_Cancels_Running_Call", func(t *testing.T) {
+		started := make(chan struct{})
+		runner := runPeriodic(time.Hour, func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+		})
+		<-started
+
+		assert.NoError(t, runner.stop(context.Background()))
+	})
+
+	t.Run("Stop_Times_Out", func(t *testing.T) {
+		started, release := make(chan struct{}), make(chan struct{})
+		runner := runPeriodic(time.Hour, func(context.Context) {
+			close(started)
+			<-release
+		})
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, runner.stop(ctx), context.DeadlineExceeded)
+
+		close(release)
+		require.NoError(t, runner.stop(context.Background()))
+	})
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+)
+
+const (
+	// staleReceptionsLockKey - ключ advisory-блокировки, под которой работает планировщик.
+	// Один проход выполняет только один экземпляр сервиса.
+	staleReceptionsLockKey int64 = 0x7076_7a5f_7374_6c65 // "pvz_stle"
+
+	// Действия планировщика над забытой приемкой.
+	staleActionClose = "close"
+	staleActionFlag  = "flag"
+)
+
+// StaleReceptionScheduler периодически находит приемки, которые сотрудники забыли закрыть
+// (открыты дольше MaxAge или начаты до последнего закрытия ПВЗ), и закрывает или помечает их.
+// Пока забытая приемка не закрыта, CreateReception для ПВЗ возвращает ErrReceptionInProgress.
+type StaleReceptionScheduler struct {
+	log              *slog.Logger
+	receptionRepo    domain.ReceptionRepository // Зависимость для поиска и закрытия приемок
+	receptionService domain.ReceptionService    // Зависимость для сверки закрытых приемок с поставками
+	locker           domain.Locker              // Блокировка, общая для всех экземпляров сервиса
+	metricsCollector domain.MetricsCollector
+	cfg              config.StaleReceptions
+
+	closingHour, closingMinute int
+	hasClosingTime             bool
+	location                   *time.Location
+	now                        func() time.Time
+
+	runner *periodicRunner
+}
+
+// NewStaleReceptionScheduler создает новый экземпляр StaleReceptionScheduler.
+// Возвращает ошибку при некорректном действии, времени закрытия или часовом поясе в cfg.
+func NewStaleReceptionScheduler(
+	log *slog.Logger,
+	receptionRepo domain.ReceptionRepository,
+	receptionService domain.ReceptionService,
+	locker domain.Locker,
+	metricsCollector domain.MetricsCollector,
+	cfg config.StaleReceptions,
+) (*StaleReceptionScheduler, error) {
+	if cfg.Action != staleActionClose && cfg.Action != staleActionFlag {
+		return nil, fmt.Errorf("invalid stale receptions action '%s': expected '%s' or '%s'", cfg.Action, staleActionClose, staleActionFlag)
+	}
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("invalid stale receptions interval %s", cfg.Interval)
+	}
+
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stale receptions timezone '%s': %w", cfg.Timezone, err)
+	}
+
+	s := &StaleReceptionScheduler{
+		log:              log.With(slog.String("component", "StaleReceptionScheduler")),
+		receptionRepo:    receptionRepo,
+		receptionService: receptionService,
+		locker:           locker,
+		metricsCollector: metricsCollector,
+		cfg:              cfg,
+		location:         location,
+		now:              time.Now,
+	}
+
+	if cfg.ClosingTime != "" {
+		closing, err := time.Parse("15:04", cfg.ClosingTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stale receptions closing time '%s': expected HH:MM", cfg.ClosingTime)
+		}
+		s.closingHour, s.closingMinute, s.hasClosingTime = closing.Hour(), closing.Minute(), true
+	}
+
+	return s, nil
+}
+
+// Start запускает периодическую проверку. Не блокирует.
+func (s *StaleReceptionScheduler) Start() {
+	s.runner = runPeriodic(s.cfg.Interval, s.RunOnce)
+
+	s.log.Info("Stale reception scheduler started",
+		slog.Duration("interval", s.cfg.Interval),
+		slog.Duration("max_age", s.cfg.MaxAge),
+		slog.String("closing_time", s.cfg.ClosingTime),
+		slog.String("action", s.cfg.Action),
+	)
+}
+
+// Stop останавливает планировщик и ждет завершения текущего прохода, но не дольше дедлайна ctx.
+func (s *StaleReceptionScheduler) Stop(ctx context.Context) error {
+	if s.runner == nil {
+		return nil
+	}
+	if err := s.runner.stop(ctx); err != nil {
+		return fmt.Errorf("stale reception scheduler did not stop in time: %w", err)
+	}
+	s.log.Info("Stale reception scheduler stopped")
+	return nil
+}
+
+// RunOnce выполняет один проход, если блокировку не удерживает другой экземпляр сервиса.
+func (s *StaleReceptionScheduler) RunOnce(ctx context.Context) {
+	const op = "StaleReceptionScheduler.RunOnce"
+	log := s.log.With(slog.String("op", op))
+
+	acquired, err := s.locker.TryWithLock(ctx, staleReceptionsLockKey, s.sweep)
+	switch {
+	case err != nil:
+		if ctx.Err() == nil {
+			log.Error("Stale receptions sweep failed", slog.String("error", err.Error()))
+		}
+	case !acquired:
+		log.Debug("Stale receptions sweep is running on another instance, skipping")
+	}
+}
+
+// sweep обрабатывает забытые приемки по каждому критерию. Сначала проверяется возраст приемки,
+// поэтому приемка, подходящая под оба критерия, получает причину max_age.
+func (s *StaleReceptionScheduler) sweep(ctx context.Context) error {
+	now := s.now()
+
+	if s.cfg.MaxAge > 0 {
+		if err := s.handle(ctx, now.Add(-s.cfg.MaxAge), domain.StaleReasonMaxAge); err != nil {
+			return err
+		}
+	}
+	if s.hasClosingTime {
+		if err := s.handle(ctx, s.lastClosing(now), domain.StaleReasonClosingTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StaleReceptionScheduler) handle(ctx context.Context, openedBefore time.Time, reason domain.StaleReason) error {
+	const op = "StaleReceptionScheduler.handle"
+	log := s.log.With(slog.String("op", op), slog.String("reason", string(reason)), slog.String("action", s.cfg.Action))
+
+	var (
+		receptions []domain.Reception
+		err        error
+	)
+	if s.cfg.Action == staleActionFlag {
+		receptions, err = s.receptionRepo.FlagStale(ctx, openedBefore, reason)
+	} else {
+		receptions, err = s.receptionRepo.CloseStale(ctx, openedBefore, reason)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, rec := range receptions {
+		s.metricsCollector.IncStaleReceptions(s.cfg.Action, reason)
+		log.Warn("Stale reception handled",
+			slog.String("reception_id", rec.ID.String()),
+			slog.String("pvz_id", rec.PVZID.String()),
+			slog.Time("opened_at", rec.DateTime),
+		)
+		// Закрытая приемка сверяется с поставкой сразу, как при ручном закрытии, чтобы расхождение
+		// попало в список поставок с расхождениями. Ошибка сверки не прерывает проход.
+		if s.cfg.Action == staleActionClose && rec.ExpectedShipmentID != nil {
+			if err := s.receptionService.ReconcileReception(ctx, &rec); err != nil {
+				log.Error("Failed to reconcile auto-closed reception with expected shipment",
+					slog.String("reception_id", rec.ID.String()), slog.String("error", err.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+// lastClosing возвращает последний момент закрытия ПВЗ, не позже now.
+func (s *StaleReceptionScheduler) lastClosing(now time.Time) time.Time {
+	local := now.In(s.location)
+	closing := time.Date(local.Year(), local.Month(), local.Day(), s.closingHour, s.closingMinute, 0, 0, s.location)
+	if closing.After(local) {
+		closing = closing.AddDate(0, 0, -1)
+	}
+	return closing
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/mocks"
+)
+
+func testStaleReceptionsConfig() config.StaleReceptions {
+	return config.StaleReceptions{
+		Enabled:     true,
+		Interval:    time.Minute,
+		MaxAge:      12 * time.Hour,
+		ClosingTime: "22:00",
+		Timezone:    "Europe/Moscow",
+		Action:      staleActionClose,
+	}
+}
+
+// lockAcquired настраивает мок блокировки так, чтобы переданная функция выполнялась сразу.
+func lockAcquired(locker *mocks.Locker) {
+	locker.On("TryWithLock", mock.Anything, staleReceptionsLockKey, mock.Anything).
+		Return(func(ctx context.Context, _ int64, fn func(ctx context.Context) error) (bool, error) {
+			return true, fn(ctx)
+		}).Once()
+}
+
+func TestStaleReceptionScheduler_RunOnce(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	now := time.Date(2025, 4, 20, 10, 0, 0, 0, moscow)
+	lastClosing := time.Date(2025, 4, 19, 22, 0, 0, 0, moscow)
+	stale := []domain.Reception{{ID: uuid.New(), PVZID: uuid.New(), Status: domain.StatusClosed}}
+
+	newScheduler := func(t *testing.T, cfg config.StaleReceptions) (*StaleReceptionScheduler, *mocks.ReceptionRepository, *mocks.Locker, *mocks.MetricsCollector) {
+		receptionRepo := mocks.NewReceptionRepository(t)
+		locker := mocks.NewLocker(t)
+		metricsCollector := mocks.NewMetricsCollector(t)
+		s, err := NewStaleReceptionScheduler(logger, receptionRepo, mocks.NewReceptionService(t), locker, metricsCollector, cfg)
+		require.NoError(t, err)
+		s.now = func() time.Time { return now }
+		return s, receptionRepo, locker, metricsCollector
+	}
+
+	t.Run("Closes_By_Age_And_Closing_Time", func(t *testing.T) {
+		s, receptionRepo, locker, metricsCollector := newScheduler(t, testStaleReceptionsConfig())
+		lockAcquired(locker)
+		receptionRepo.On("CloseStale", mock.Anything, now.Add(-12*time.Hour), domain.StaleReasonMaxAge).Return(stale, nil).Once()
+		receptionRepo.On("CloseStale", mock.Anything, lastClosing, domain.StaleReasonClosingTime).Return([]domain.Reception{}, nil).Once()
+		metricsCollector.On("IncStaleReceptions", staleActionClose, domain.StaleReasonMaxAge).Once()
+
+		s.RunOnce(ctx)
+	})
+
+	t.Run("Reconciles_Closed_Receptions_With_Shipment", func(t *testing.T) {
+		cfg := testStaleReceptionsConfig()
+		cfg.ClosingTime = ""
+		s, receptionRepo, locker, metricsCollector := newScheduler(t, cfg)
+		receptionService := mocks.NewReceptionService(t)
+		s.receptionService = receptionService
+
+		shipmentID, failingShipmentID := uuid.New(), uuid.New()
+		withShipment := domain.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: domain.StatusClosed, ExpectedShipmentID: &shipmentID}
+		failing := domain.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: domain.StatusClosed, ExpectedShipmentID: &failingShipmentID}
+		withoutShipment := domain.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: domain.StatusClosed}
+		lockAcquired(locker)
+		receptionRepo.On("CloseStale", mock.Anything, now.Add(-12*time.Hour), domain.StaleReasonMaxAge).
+			Return([]domain.Reception{failing, withShipment, withoutShipment}, nil).Once()
+		metricsCollector.On("IncStaleReceptions", staleActionClose, domain.StaleReasonMaxAge).Times(3)
+		// Ошибка сверки одной приемки не мешает сверить остальные.
+		receptionService.On("ReconcileReception", mock.Anything, mock.MatchedBy(func(rec *domain.Reception) bool { return rec.ID == failing.ID })).
+			Return(errors.New("connection refused")).Once()
+		receptionService.On("ReconcileReception", mock.Anything, mock.MatchedBy(func(rec *domain.Reception) bool { return rec.ID == withShipment.ID })).
+			Return(nil).Once()
+
+		s.RunOnce(ctx)
+	})
+
+	t.Run("Flags_Without_Closing", func(t *testing.T) {
+		cfg := testStaleReceptionsConfig()
+		cfg.Action = staleActionFlag
+		cfg.ClosingTime = ""
+		s, receptionRepo, locker, metricsCollector := newScheduler(t, cfg)
+		lockAcquired(locker)
+		receptionRepo.On("FlagStale", mock.Anything, now.Add(-12*time.Hour), domain.StaleReasonMaxAge).Return(stale, nil).Once()
+		metricsCollector.On("IncStaleReceptions", staleActionFlag, domain.StaleReasonMaxAge).Once()
+
+		s.RunOnce(ctx)
+		receptionRepo.AssertNotCalled(t, "CloseStale", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Lock_Held_By_Another_Instance", func(t *testing.T) {
+		s, receptionRepo, locker, _ := newScheduler(t, testStaleReceptionsConfig())
+		locker.On("TryWithLock", mock.Anything, staleReceptionsLockKey, mock.Anything).Return(false, nil).Once()
+
+		s.RunOnce(ctx)
+		receptionRepo.AssertNotCalled(t, "CloseStale", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Repo_Error_Stops_Sweep", func(t *testing.T) {
+		s, receptionRepo, locker, _ := newScheduler(t, testStaleReceptionsConfig())
+		lockAcquired(locker)
+		receptionRepo.On("CloseStale", mock.Anything, now.Add(-12*time.Hour), domain.StaleReasonMaxAge).
+			Return(nil, errors.New("connection refused")).Once()
+
+		s.RunOnce(ctx)
+		receptionRepo.AssertNumberOfCalls(t, "CloseStale", 1)
+	})
+}
+
+func TestStaleReceptionScheduler_LastClosing(t *testing.T) {
+	s, err := NewStaleReceptionScheduler(slog.Default(), nil, nil, nil, nil, testStaleReceptionsConfig())
+	require.NoError(t, err)
+
+	// 19:30 UTC = 22:30 по Москве: ПВЗ уже закрылся сегодня.
+	afterClosing := time.Date(2025, 4, 20, 19, 30, 0, 0, time.UTC)
+	assert.True(t, s.lastClosing(afterClosing).Equal(time.Date(2025, 4, 20, 19, 0, 0, 0, time.UTC)))
+
+	// 07:00 UTC = 10:00 по Москве: последнее закрытие было вчера.
+	beforeClosing := time.Date(2025, 4, 20, 7, 0, 0, 0, time.UTC)
+	assert.True(t, s.lastClosing(beforeClosing).Equal(time.Date(2025, 4, 19, 19, 0, 0, 0, time.UTC)))
+}
+
+func TestNewStaleReceptionScheduler_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.StaleReceptions)
+	}{
+		{name: "Unknown_Action", modify: func(cfg *config.StaleReceptions) { cfg.Action = "delete" }},
+		{name: "Invalid_Closing_Time", modify: func(cfg *config.StaleReceptions) { cfg.ClosingTime = "25:00" }},
+		{name: "Unknown_Timezone", modify: func(cfg *config.StaleReceptions) { cfg.Timezone = "Mars/Olympus" }},
+		{name: "Zero_Interval", modify: func(cfg *config.StaleReceptions) { cfg.Interval = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testStaleReceptionsConfig()
+			tt.modify(&cfg)
+			_, err := NewStaleReceptionScheduler(slog.Default(), nil, nil, nil, nil, cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package apperror сопоставляет доменные ошибки с кодами ошибок, HTTP статусами и кодами gRPC.
+// Используется обоими транспортами (HTTP хендлерами и gRPC сервером), чтобы они отвечали одинаково.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/i18n"
+)
+
+// errorMapping описывает, как доменная ошибка передается клиенту. Заголовок и описание ошибки
+// берутся из каталога сообщений (internal/i18n) по коду.
+type errorMapping struct {
+	err      error
+	status   int
+	grpcCode codes.Code
+	code     string
+}
+
+// errorCatalog проверяется по порядку, побеждает первое совпадение errors.Is, поэтому общие ошибки
+// (ErrConflict, ErrDatabaseError) стоят после частных.
+var errorCatalog = []errorMapping{
+	{domain.ErrNotFound, http.StatusNotFound, codes.NotFound, CodeNotFound},
+	{domain.ErrValidation, http.StatusBadRequest, codes.InvalidArgument, CodeValidationFailed},
+	{domain.ErrMalformedBody, http.StatusBadRequest, codes.InvalidArgument, CodeMalformedBody},
+	{domain.ErrForbidden, http.StatusForbidden, codes.PermissionDenied, CodeForbidden},
+	{domain.ErrUnauthorized, http.StatusUnauthorized, codes.Unauthenticated, CodeUnauthorized},
+	{domain.ErrPVZCityNotAllowed, http.StatusBadRequest, codes.InvalidArgument, CodePVZCityNotAllowed},
+	{domain.ErrPVZFull, http.StatusConflict, codes.ResourceExhausted, CodePVZFull},
+	{domain.ErrReceptionInProgress, http.StatusBadRequest, codes.FailedPrecondition, CodeReceptionInProgress},
+	{domain.ErrNoOpenReception, http.StatusBadRequest, codes.FailedPrecondition, CodeNoOpenReception},
+	{domain.ErrReceptionClosed, http.StatusBadRequest, codes.FailedPrecondition, CodeReceptionClosed},
+	{domain.ErrProductDeletionOrder, http.StatusBadRequest, codes.FailedPrecondition, CodeProductDeletionOrder},
+	{domain.ErrNoProductsToDelete, http.StatusBadRequest, codes.FailedPrecondition, CodeNoProductsToDelete},
+	{domain.ErrProductNotOnHand, http.StatusConflict, codes.FailedPrecondition, CodeProductNotOnHand},
+	{domain.ErrProductReceptionOpen, http.StatusBadRequest, codes.FailedPrecondition, CodeProductReceptionOpen},
+	{domain.ErrDispatchAlreadySent, http.StatusConflict, codes.FailedPrecondition, CodeDispatchAlreadySent},
+	{domain.ErrDispatchEmpty, http.StatusBadRequest, codes.FailedPrecondition, CodeDispatchEmpty},
+	{domain.ErrShipmentAlreadyAttached, http.StatusConflict, codes.FailedPrecondition, CodeShipmentAlreadyAttached},
+	{domain.ErrNoExpectedShipment, http.StatusNotFound, codes.NotFound, CodeNoExpectedShipment},
+	{domain.ErrReportNotReady, http.StatusConflict, codes.FailedPrecondition, CodeReportNotReady},
+	{domain.ErrReportExpired, http.StatusGone, codes.NotFound, CodeReportExpired},
+	{domain.ErrPreconditionFailed, http.StatusPreconditionFailed, codes.Aborted, CodePreconditionFailed},
+	{domain.ErrConflict, http.StatusConflict, codes.AlreadyExists, CodeConflict},
+	{domain.ErrDatabaseError, http.StatusInternalServerError, codes.Internal, CodeDatabaseError},
+}
+
+// Describe сопоставляет доменную ошибку с ответом клиенту и кодом gRPC. Тексты ответа не заполнены,
+// их подставляет Problem.Localize на языке клиента.
+func Describe(err error) (Problem, codes.Code) {
+	for _, m := range errorCatalog {
+		if !errors.Is(err, m.err) {
+			continue
+		}
+		problem := Problem{Status: m.status, Code: m.code}
+		if m.err == domain.ErrValidation {
+			problem.Detail, problem.Errors = validationDetail(err)
+		}
+		return problem, m.grpcCode
+	}
+	return Problem{Status: http.StatusInternalServerError, Code: CodeInternal}, codes.Internal
+}
+
+// validationDetail возвращает ошибки полей, а для ошибки проверки без полей - ее описание без служебных префиксов.
+// Пустое описание заполняется при локализации.
+func validationDetail(err error) (string, []domain.FieldError) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return "", validationErr.Fields
+	}
+	if _, detail, ok := strings.Cut(err.Error(), domain.ErrValidation.Error()+": "); ok {
+		return detail, nil
+	}
+	return "", nil
+}
+
+// Map сопоставляет доменную ошибку с HTTP статусом, gRPC кодом и сообщением для клиента на языке по умолчанию.
+func Map(err error) (int, codes.Code, string) {
+	problem, grpcCode := Describe(err)
+	return problem.Status, grpcCode, problem.Localize(i18n.DefaultLocale).Detail
+}
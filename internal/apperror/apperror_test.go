@@ -0,0 +1,155 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/i18n"
+)
+
+func TestDescribe(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedGRPC   codes.Code
+		expectedCode   string
+		expectedDetail string
+		expectedFields []domain.FieldError
+	}{
+		{
+			name:           "Reception_In_Progress",
+			err:            fmt.Errorf("ReceptionService.CreateReception: %w", domain.ErrReceptionInProgress),
+			expectedStatus: http.StatusBadRequest,
+			expectedGRPC:   codes.FailedPrecondition,
+			expectedCode:   CodeReceptionInProgress,
+			expectedDetail: domain.ErrReceptionInProgress.Error(),
+		},
+		{
+			name:           "No_Open_Reception",
+			err:            fmt.Errorf("ProductService.AddProduct: %w", domain.ErrNoOpenReception),
+			expectedStatus: http.StatusBadRequest,
+			expectedGRPC:   codes.FailedPrecondition,
+			expectedCode:   CodeNoOpenReception,
+			expectedDetail: domain.ErrNoOpenReception.Error(),
+		},
+		{
+			name:           "Field_Error",
+			err:            fmt.Errorf("PVZService.SetCapacity: %w", domain.NewFieldError("total", "must not be negative")),
+			expectedStatus: http.StatusBadRequest,
+			expectedGRPC:   codes.InvalidArgument,
+			expectedCode:   CodeValidationFailed,
+			expectedDetail: "total: must not be negative",
+			expectedFields: []domain.FieldError{{Field: "total", Message: "must not be negative"}},
+		},
+		{
+			name:           "Validation_Without_Fields",
+			err:            fmt.Errorf("ShipmentService.Create: %w: expected shipment must contain at least one line", domain.ErrValidation),
+			expectedStatus: http.StatusBadRequest,
+			expectedGRPC:   codes.InvalidArgument,
+			expectedCode:   CodeValidationFailed,
+			expectedDetail: "expected shipment must contain at least one line",
+		},
+		{
+			name:           "Malformed_Body",
+			err:            fmt.Errorf("PVZHandler.PostPvz: %w", domain.ErrMalformedBody),
+			expectedStatus: http.StatusBadRequest,
+			expectedGRPC:   codes.InvalidArgument,
+			expectedCode:   CodeMalformedBody,
+			expectedDetail: "Request body could not be read or is not valid JSON",
+		},
+		{
+			name:           "Database_Error_Hides_Cause",
+			err:            fmt.Errorf("%w: connection refused", domain.ErrDatabaseError),
+			expectedStatus: http.StatusInternalServerError,
+			expectedGRPC:   codes.Internal,
+			expectedCode:   CodeDatabaseError,
+			expectedDetail: "Internal server error (database operation failed)",
+		},
+		{
+			name:           "Unknown_Error",
+			err:            errors.New("boom"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedGRPC:   codes.Internal,
+			expectedCode:   CodeInternal,
+			expectedDetail: "Internal server error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			problem, grpcCode := Describe(tc.err)
+			problem = problem.Localize(i18n.DefaultLocale)
+
+			assert.Equal(t, tc.expectedStatus, problem.Status)
+			assert.Equal(t, tc.expectedGRPC, grpcCode)
+			assert.Equal(t, tc.expectedCode, problem.Code)
+			assert.Equal(t, tc.expectedDetail, problem.Detail)
+			assert.NotEmpty(t, problem.Title)
+			assert.Equal(t, tc.expectedFields, problem.Errors)
+		})
+	}
+}
+
+func TestDescribe_Localized(t *testing.T) {
+	t.Run("Domain_Error_In_Russian", func(t *testing.T) {
+		problem, _ := Describe(fmt.Errorf("op: %w", domain.ErrReceptionInProgress))
+		problem = problem.Localize(i18n.LocaleRU)
+
+		assert.Equal(t, CodeReceptionInProgress, problem.Code)
+		assert.Equal(t, "Приемка не закрыта", problem.Title)
+		assert.Equal(t, "Предыдущая приемка еще не закрыта", problem.Detail)
+	})
+
+	t.Run("Rule_Errors_In_Both_Languages", func(t *testing.T) {
+		err := fmt.Errorf("op: %w", domain.NewRuleError("password", "password_length", "8", "32"))
+		problem, _ := Describe(err)
+
+		en := problem.Localize(i18n.LocaleEN)
+		assert.Equal(t, "password: must be between 8 and 32 characters", en.Detail)
+		require.Len(t, en.Errors, 1)
+		assert.Equal(t, "must be between 8 and 32 characters", en.Errors[0].Message)
+
+		ru := problem.Localize(i18n.LocaleRU)
+		assert.Equal(t, "Некорректные данные запроса", ru.Title)
+		assert.Equal(t, "password: должен содержать от 8 до 32 символов", ru.Detail)
+		assert.Empty(t, problem.Errors[0].Message, "Localize must not modify the original problem")
+	})
+}
+
+func TestGRPCError_Details(t *testing.T) {
+	err := GRPCError(fmt.Errorf("op: %w", domain.NewFieldError("pvz_id", "invalid UUID format")))
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "pvz_id: invalid UUID format", st.Message())
+
+	var (
+		info       *errdetails.ErrorInfo
+		badRequest *errdetails.BadRequest
+	)
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	require.NotNil(t, info)
+	assert.Equal(t, CodeValidationFailed, info.GetReason())
+	assert.Equal(t, errorDomain, info.GetDomain())
+	require.NotNil(t, badRequest)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "pvz_id", badRequest.GetFieldViolations()[0].GetField())
+}
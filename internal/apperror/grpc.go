@@ -0,0 +1,45 @@
+package apperror
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/i18n"
+)
+
+// errorDomain - домен ошибок в google.rpc.ErrorInfo ответов gRPC.
+const errorDomain = "pvz-service"
+
+// GRPCError преобразует доменную ошибку в ошибку gRPC с сообщением на языке по умолчанию. Код ошибки передается
+// в деталях google.rpc.ErrorInfo, ошибки полей - в google.rpc.BadRequest.
+func GRPCError(err error) error {
+	problem, grpcCode := Describe(err)
+	problem = problem.Localize(i18n.DefaultLocale)
+	return newGRPCError(grpcCode, problem.Code, problem.Detail, problem.Errors)
+}
+
+// NewGRPCError создает ошибку gRPC с кодом ошибки code в деталях google.rpc.ErrorInfo.
+func NewGRPCError(grpcCode codes.Code, code, message string) error {
+	return newGRPCError(grpcCode, code, message, nil)
+}
+
+func newGRPCError(grpcCode codes.Code, code, message string, fieldErrors []domain.FieldError) error {
+	st := status.New(grpcCode, message)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: code, Domain: errorDomain}}
+	if len(fieldErrors) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, fe := range fieldErrors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations,
+				&errdetails.BadRequest_FieldViolation{Field: fe.Field, Description: fe.Message})
+		}
+		details = append(details, badRequest)
+	}
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
@@ -0,0 +1,122 @@
+package apperror
+
+import (
+	"net/http"
+	"strings"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/i18n"
+)
+
+// Стабильные коды ошибок. Клиенты различают ошибки по ним, текст detail может меняться.
+const (
+	CodeBadRequest               = "bad_request"
+	CodeValidationFailed         = "validation_failed"
+	CodeMalformedBody            = "malformed_body"
+	CodeUnauthorized             = "unauthorized"
+	CodeForbidden                = "forbidden"
+	CodeNotFound                 = "not_found"
+	CodeConflict                 = "conflict"
+	CodeGone                     = "gone"
+	CodePreconditionFailed       = "precondition_failed"
+	CodeUnprocessableEntity      = "unprocessable_entity"
+	CodeTooManyRequests          = "too_many_requests"
+	CodeInternal                 = "internal_error"
+	CodeDatabaseError            = "database_error"
+	CodePVZCityNotAllowed        = "pvz_city_not_allowed"
+	CodePVZFull                  = "pvz_full"
+	CodeReceptionInProgress      = "reception_in_progress"
+	CodeNoOpenReception          = "no_open_reception"
+	CodeReceptionClosed          = "reception_closed"
+	CodeProductDeletionOrder     = "product_deletion_order"
+	CodeNoProductsToDelete       = "no_products_to_delete"
+	CodeProductNotOnHand         = "product_not_on_hand"
+	CodeProductReceptionOpen     = "product_reception_open"
+	CodeDispatchAlreadySent      = "dispatch_already_sent"
+	CodeDispatchEmpty            = "dispatch_empty"
+	CodeShipmentAlreadyAttached  = "shipment_already_attached"
+	CodeNoExpectedShipment       = "no_expected_shipment"
+	CodeReportNotReady           = "report_not_ready"
+	CodeReportExpired            = "report_expired"
+	CodeIdempotencyKeyReused     = "idempotency_key_reused"
+	CodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+	CodeRateLimitExceeded        = "rate_limit_exceeded"
+)
+
+// Problem - ошибка для клиента. Пустые Title и Detail заполняются из каталога сообщений по коду ошибки
+// (Detail ошибки проверки - из ошибок полей) на языке запроса.
+type Problem struct {
+	Status int
+	Code   string
+	Title  string
+	Detail string
+	Errors []domain.FieldError
+}
+
+// Localize возвращает копию ошибки с заголовком, описанием и сообщениями полей на языке locale.
+func (p Problem) Localize(locale i18n.Locale) Problem {
+	if p.Title == "" {
+		title, ok := i18n.Lookup(locale, "title."+p.Code)
+		if !ok {
+			title = http.StatusText(p.Status)
+		}
+		p.Title = title
+	}
+
+	if len(p.Errors) > 0 {
+		fieldErrors := make([]domain.FieldError, 0, len(p.Errors))
+		messages := make([]string, 0, len(p.Errors))
+		for _, fe := range p.Errors {
+			if fe.Rule != "" {
+				params := make([]any, 0, len(fe.Params))
+				for _, param := range fe.Params {
+					params = append(params, param)
+				}
+				if message, ok := i18n.Lookup(locale, "validation."+fe.Rule, params...); ok {
+					fe.Message = message
+				}
+			}
+			fieldErrors = append(fieldErrors, fe)
+			messages = append(messages, fe.Field+": "+fe.Message)
+		}
+		p.Errors = fieldErrors
+		if p.Detail == "" {
+			p.Detail = strings.Join(messages, "; ")
+		}
+	}
+
+	if p.Detail == "" {
+		detail, ok := i18n.Lookup(locale, "detail."+p.Code)
+		if !ok {
+			detail = p.Title
+		}
+		p.Detail = detail
+	}
+	return p
+}
+
+// CodeForStatus возвращает общий код ошибки для HTTP статуса.
+func CodeForStatus(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case http.StatusUnprocessableEntity:
+		return CodeUnprocessableEntity
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	default:
+		return CodeInternal
+	}
+}
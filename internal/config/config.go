@@ -11,32 +11,72 @@ import (
 
 // Config определяет общую структуру конфигурации всего приложения.
 type Config struct {
-	HTTPServer   `yaml:"http"`     // Конфигурация основного HTTP сервера
-	GRPCServer   `yaml:"grpc"`     // Конфигурация gRPC сервера
-	Metrics      `yaml:"metrics"`  // Конфигурация сервера метрик Prometheus
-	Database     `yaml:"database"` // Конфигурация основной базы данных
-	Auth         `yaml:"auth"`     // Конфигурация аутентификации и JWT
-	Logger       `yaml:"logger"`   // Конфигурация логгера
-	Hasher       `yaml:"hasher"`   // Конфигурация хэшера паролей
-	TestDatabase Database          `yaml:"test_database"` // Конфигурация тестовой базы данных (используется только в тестах)
+	HTTPServer      `yaml:"http"`             // Конфигурация основного HTTP сервера
+	GRPCServer      `yaml:"grpc"`             // Конфигурация gRPC сервера
+	Metrics         `yaml:"metrics"`          // Конфигурация сервера метрик Prometheus
+	Database        `yaml:"database"`         // Конфигурация основной базы данных
+	Auth            `yaml:"auth"`             // Конфигурация аутентификации и JWT
+	Logger          `yaml:"logger"`           // Конфигурация логгера
+	Tracing         `yaml:"tracing"`          // Конфигурация трассировки OpenTelemetry
+	Hasher          `yaml:"hasher"`           // Конфигурация хэшера паролей
+	Password        `yaml:"password"`         // Конфигурация требований к паролям
+	Reports         `yaml:"reports"`          // Конфигурация асинхронных отчетов
+	StaleReceptions `yaml:"stale_receptions"` // Конфигурация планировщика забытых приемок
+	Idempotency     `yaml:"idempotency"`      // Конфигурация обработки заголовка Idempotency-Key
+	RateLimit       `yaml:"rate_limit"`       // Конфигурация ограничения частоты запросов
+	TestDatabase    Database                  `yaml:"test_database"` // Конфигурация тестовой базы данных (используется только в тестах)
 }
 
 // HTTPServer содержит настройки для основного HTTP сервера.
 type HTTPServer struct {
 	// Port - порт, на котором будет слушать HTTP сервер.
 	Port string `yaml:"port" env:"HTTP_PORT" env-default:"8080"`
+	// ExportWriteTimeout - дедлайн записи ответа для потоковых выгрузок (вместо общего WriteTimeout сервера).
+	ExportWriteTimeout time.Duration `yaml:"export_write_timeout" env:"HTTP_EXPORT_WRITE_TIMEOUT" env-default:"10m"`
 }
 
 // GRPCServer содержит настройки для gRPC сервера.
 type GRPCServer struct {
 	// Port - порт, на котором будет слушать gRPC сервер.
 	Port string `yaml:"port" env:"GRPC_PORT" env-default:"3000"`
+	// Reflection - включает gRPC Server Reflection (нужен для grpcurl без .proto файлов).
+	// В production рекомендуется оставлять выключенным.
+	Reflection bool `yaml:"reflection" env:"GRPC_REFLECTION" env-default:"false"`
+	// MaxRecvMsgSize / MaxSendMsgSize - максимальный размер входящего/исходящего сообщения в байтах.
+	MaxRecvMsgSize int `yaml:"max_recv_msg_size" env:"GRPC_MAX_RECV_MSG_SIZE" env-default:"4194304"`
+	MaxSendMsgSize int `yaml:"max_send_msg_size" env:"GRPC_MAX_SEND_MSG_SIZE" env-default:"4194304"`
+	// Keepalive содержит параметры keepalive для соединений с клиентами.
+	Keepalive GRPCKeepalive `yaml:"keepalive"`
+	// HealthCheckInterval - период проверки пула соединений с БД для gRPC health сервиса.
+	HealthCheckInterval time.Duration `yaml:"health_check_interval" env:"GRPC_HEALTH_CHECK_INTERVAL" env-default:"5s"`
+	// HealthCheckTimeout - таймаут одного пинга БД при проверке здоровья.
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout" env:"GRPC_HEALTH_CHECK_TIMEOUT" env-default:"2s"`
+	// ShutdownTimeout - сколько ждать завершения активных вызовов при GracefulStop до принудительной остановки.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"GRPC_SHUTDOWN_TIMEOUT" env-default:"10s"`
+}
+
+// GRPCKeepalive содержит настройки keepalive gRPC сервера.
+type GRPCKeepalive struct {
+	// Time - через сколько простоя сервер пингует клиента.
+	Time time.Duration `yaml:"time" env:"GRPC_KEEPALIVE_TIME" env-default:"2m"`
+	// Timeout - сколько ждать ответа на пинг до закрытия соединения.
+	Timeout time.Duration `yaml:"timeout" env:"GRPC_KEEPALIVE_TIMEOUT" env-default:"20s"`
+	// MaxConnectionIdle - закрывать соединения без активных вызовов дольше этого времени.
+	MaxConnectionIdle time.Duration `yaml:"max_connection_idle" env:"GRPC_MAX_CONNECTION_IDLE" env-default:"15m"`
+	// MinClientPingInterval - минимально допустимый интервал пингов от клиента (защита от слишком частых пингов).
+	MinClientPingInterval time.Duration `yaml:"min_client_ping_interval" env:"GRPC_MIN_CLIENT_PING_INTERVAL" env-default:"30s"`
+	// PermitWithoutStream - разрешать клиенту пинговать соединение без активных стримов.
+	PermitWithoutStream bool `yaml:"permit_without_stream" env:"GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM" env-default:"true"`
 }
 
 // Metrics содержит настройки для сервера метрик Prometheus.
 type Metrics struct {
 	// Port - порт, на котором будет слушать сервер метрик (/metrics).
 	Port string `yaml:"port" env:"METRICS_PORT" env-default:"9000"`
+	// ReadinessTimeout - общий таймаут проверок зависимостей в /readyz.
+	ReadinessTimeout time.Duration `yaml:"readiness_timeout" env:"READINESS_TIMEOUT" env-default:"2s"`
+	// OccupancyInterval - период обновления метрик заполненности ПВЗ из БД. 0 отключает обновление.
+	OccupancyInterval time.Duration `yaml:"occupancy_interval" env:"METRICS_OCCUPANCY_INTERVAL" env-default:"30s"`
 }
 
 // Database содержит настройки для подключения к базе данных PostgreSQL.
@@ -59,6 +99,22 @@ type Logger struct {
 	Level string `yaml:"level" env:"LOG_LEVEL" env-default:"info"`
 }
 
+// Tracing содержит настройки трассировки OpenTelemetry.
+type Tracing struct {
+	// Exporter - куда отправляются спаны: none (трассировка только для Request ID и распространения traceparent),
+	// stdout (вывод в консоль для локального запуска) или otlp (OTLP/gRPC коллектор).
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	// ServiceName - имя сервиса в ресурсе трассировки (service.name).
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME" env-default:"pvz-service"`
+	// OTLPEndpoint - адрес OTLP/gRPC коллектора (host:port).
+	OTLPEndpoint string `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT" env-default:"localhost:4317"`
+	// OTLPInsecure - подключаться к коллектору без TLS.
+	OTLPInsecure bool `yaml:"otlp_insecure" env:"TRACING_OTLP_INSECURE" env-default:"true"`
+	// SampleRatio - доля трассировок, начатых сервисом, которые записываются (0..1).
+	// Для входящих запросов решение берется из traceparent вызывающей стороны.
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
 // Hasher содержит настройки для хэширования паролей.
 type Hasher struct {
 	// BcryptCost - определяет вычислительную сложность (стоимость) хеширования bcrypt.
@@ -66,6 +122,58 @@ type Hasher struct {
 	BcryptCost int `yaml:"bcrypt_cost" env:"BCRYPT_COST" env-default:"10"`
 }
 
+// Password содержит требования к паролям при регистрации и входе.
+type Password struct {
+	// MinLength / MaxLength - допустимая длина пароля в символах.
+	MinLength int `yaml:"min_length" env:"PASSWORD_MIN_LENGTH" env-default:"8"`
+	MaxLength int `yaml:"max_length" env:"PASSWORD_MAX_LENGTH" env-default:"32"`
+	// MinLower / MinUpper / MinDigit / MinSymbol - минимальное количество символов каждого класса. 0 отключает проверку класса.
+	MinLower  int `yaml:"min_lower" env:"PASSWORD_MIN_LOWER" env-default:"1"`
+	MinUpper  int `yaml:"min_upper" env:"PASSWORD_MIN_UPPER" env-default:"1"`
+	MinDigit  int `yaml:"min_digit" env:"PASSWORD_MIN_DIGIT" env-default:"1"`
+	MinSymbol int `yaml:"min_symbol" env:"PASSWORD_MIN_SYMBOL" env-default:"1"`
+	// Symbols - спецсимволы, учитываемые в MinSymbol.
+	Symbols string `yaml:"symbols" env:"PASSWORD_SYMBOLS" env-default:"!@#$%^&*"`
+	// BlocklistFile - файл со скомпрометированными паролями (по одному в строке). Пустое значение отключает проверку.
+	BlocklistFile string `yaml:"blocklist_file" env:"PASSWORD_BLOCKLIST_FILE" env-default:""`
+}
+
+// Reports содержит настройки асинхронного построения отчетов.
+type Reports struct {
+	// Workers - количество параллельных обработчиков очереди.
+	Workers int `yaml:"workers" env:"REPORT_WORKERS" env-default:"2"`
+	// PollInterval - как часто свободный обработчик проверяет очередь.
+	PollInterval time.Duration `yaml:"poll_interval" env:"REPORT_POLL_INTERVAL" env-default:"2s"`
+	// JobTimeout - максимальное время построения одного отчета. Задачи в running дольше этого времени
+	// считаются зависшими и возвращаются в очередь.
+	JobTimeout time.Duration `yaml:"job_timeout" env:"REPORT_JOB_TIMEOUT" env-default:"30m"`
+	// MaxAttempts - сколько раз задача может быть возвращена в очередь после прерывания.
+	MaxAttempts int `yaml:"max_attempts" env:"REPORT_MAX_ATTEMPTS" env-default:"3"`
+	// ResultTTL - срок хранения готового файла.
+	ResultTTL time.Duration `yaml:"result_ttl" env:"REPORT_RESULT_TTL" env-default:"168h"`
+	// CleanupInterval - период удаления устаревших результатов и поиска зависших задач.
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"REPORT_CLEANUP_INTERVAL" env-default:"10m"`
+	// StorageDir - каталог локального хранилища файлов отчетов.
+	StorageDir string `yaml:"storage_dir" env:"REPORT_STORAGE_DIR" env-default:"./data/reports"`
+}
+
+// StaleReceptions содержит настройки планировщика, который обрабатывает забытые незакрытые приемки.
+type StaleReceptions struct {
+	// Enabled - включает планировщик.
+	Enabled bool `yaml:"enabled" env:"STALE_RECEPTIONS_ENABLED" env-default:"true"`
+	// Interval - период проверки незакрытых приемок.
+	Interval time.Duration `yaml:"interval" env:"STALE_RECEPTIONS_INTERVAL" env-default:"5m"`
+	// MaxAge - приемки, открытые дольше этого времени, считаются забытыми. 0 отключает проверку.
+	MaxAge time.Duration `yaml:"max_age" env:"STALE_RECEPTIONS_MAX_AGE" env-default:"12h"`
+	// ClosingTime - время закрытия ПВЗ в формате HH:MM. Приемки, начатые до последнего закрытия ПВЗ,
+	// считаются забытыми. Пустое значение отключает проверку.
+	ClosingTime string `yaml:"closing_time" env:"STALE_RECEPTIONS_CLOSING_TIME" env-default:""`
+	// Timezone - часовой пояс, в котором задано ClosingTime.
+	Timezone string `yaml:"timezone" env:"STALE_RECEPTIONS_TIMEZONE" env-default:"Europe/Moscow"`
+	// Action - что делать с забытой приемкой: close (закрыть) или flag (только пометить).
+	Action string `yaml:"action" env:"STALE_RECEPTIONS_ACTION" env-default:"close"`
+}
+
 // Load загружает конфигурацию приложения.
 // Порядок приоритета:
 // 1. Переменные окружения (самый высокий приоритет).
@@ -135,3 +243,49 @@ func LoadTestConfig() *Config {
 
 	return cfg
 }
+
+// Idempotency содержит настройки обработки заголовка Idempotency-Key в POST запросах.
+type Idempotency struct {
+	// Enabled - включает сохранение и повтор ответов для запросов с Idempotency-Key.
+	Enabled bool `yaml:"enabled" env:"IDEMPOTENCY_ENABLED" env-default:"true"`
+	// TTL - срок хранения сохраненного ответа. После него ключ можно использовать заново.
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	// LockTimeout - через сколько незавершенный запрос считается брошенным и повтор с тем же ключом может его заменить.
+	LockTimeout time.Duration `yaml:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT" env-default:"1m"`
+	// WaitTimeout - сколько повторный запрос ждет завершения исходного, прежде чем получить 409.
+	WaitTimeout time.Duration `yaml:"wait_timeout" env:"IDEMPOTENCY_WAIT_TIMEOUT" env-default:"10s"`
+	// CleanupInterval - период удаления ключей с истекшим сроком хранения.
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"1h"`
+}
+
+// RateLimit содержит настройки ограничения частоты запросов (token bucket) к HTTP и gRPC API.
+type RateLimit struct {
+	// Enabled - включает ограничение частоты запросов.
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"true"`
+	// DefaultRate / DefaultBurst - политика для маршрутов без собственной политики: пополнение токенов в секунду
+	// и емкость bucket. DefaultRate 0 отключает ограничение для таких маршрутов.
+	DefaultRate  float64 `yaml:"default_rate" env:"RATE_LIMIT_DEFAULT_RATE" env-default:"50"`
+	DefaultBurst int     `yaml:"default_burst" env:"RATE_LIMIT_DEFAULT_BURST" env-default:"100"`
+	// DefaultKey - по какому ключу считаются запросы политики по умолчанию: user, api_key или ip.
+	DefaultKey string `yaml:"default_key" env:"RATE_LIMIT_DEFAULT_KEY" env-default:"user"`
+	// APIKeyHeader - HTTP заголовок (и ключ метаданных gRPC в нижнем регистре) с API ключом клиента.
+	APIKeyHeader string `yaml:"api_key_header" env:"RATE_LIMIT_API_KEY_HEADER" env-default:"X-API-Key"`
+	// APIKeys - выданные клиентам API ключи. Запросы считаются по API ключу, только если он есть в этом списке,
+	// иначе по IP клиента: случайные значения заголовка не должны получать отдельный bucket.
+	APIKeys []string `yaml:"api_keys" env:"RATE_LIMIT_API_KEYS" env-separator:","`
+	// Policies - политики отдельных маршрутов. Задаются только в YAML.
+	Policies []RateLimitPolicy `yaml:"policies"`
+}
+
+// RateLimitPolicy задает ограничение частоты запросов для маршрутов Routes.
+type RateLimitPolicy struct {
+	// Routes - HTTP маршруты в виде "METHOD /path" (путь как при регистрации, например "POST /pvz/:pvzId/close_last_reception")
+	// и полные имена gRPC методов (например "/pvz.v1.PVZService/AddProduct").
+	Routes []string `yaml:"routes"`
+	// Rate - пополнение токенов в секунду, Burst - емкость bucket.
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+	// Key - по какому ключу считаются запросы: user, api_key или ip. Если ключа нет в запросе
+	// (например, ID пользователя для публичного маршрута), используется следующий: user -> api_key -> ip.
+	Key string `yaml:"key"`
+}
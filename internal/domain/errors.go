@@ -1,10 +1,15 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	ErrInternalServer    = errors.New("internal server error")
 	ErrValidation        = errors.New("validation failed")
+	ErrMalformedBody     = errors.New("malformed request body")
 	ErrNotFound          = errors.New("entity not found")
 	ErrConflict          = errors.New("resource conflict")
 	ErrDatabaseError     = errors.New("database operation failed")
@@ -17,6 +22,7 @@ var (
 	ErrForbidden    = errors.New("access forbidden")
 
 	ErrPVZCityNotAllowed = errors.New("pvz creation is not allowed in this city")
+	ErrPVZFull           = errors.New("pvz has no free capacity for this product")
 
 	ErrReceptionInProgress = errors.New("previous reception is still in progress")
 	ErrNoOpenReception     = errors.New("no open reception found for this pvz")
@@ -24,4 +30,63 @@ var (
 
 	ErrProductDeletionOrder = errors.New("products can only be deleted in LIFO order from an open reception")
 	ErrNoProductsToDelete   = errors.New("no products available to delete in the current reception")
+
+	ErrProductNotOnHand     = errors.New("product is not on hand at the pvz")
+	ErrProductReceptionOpen = errors.New("product reception is still in progress")
+	ErrDispatchAlreadySent  = errors.New("dispatch is already sent")
+	ErrDispatchEmpty        = errors.New("dispatch has no products")
+
+	ErrShipmentAlreadyAttached = errors.New("expected shipment is already attached to a reception")
+	ErrNoExpectedShipment      = errors.New("no expected shipment attached to this reception")
+
+	ErrReportNotReady  = errors.New("report is not ready yet")
+	ErrReportExpired   = errors.New("report result has expired")
+	ErrReportClaimLost = errors.New("report job is no longer claimed by this run")
+
+	ErrPreconditionFailed = errors.New("resource has been modified since it was read")
 )
+
+// FieldError - ошибка проверки отдельного поля запроса.
+type FieldError struct {
+	Field   string
+	Message string
+	// Rule - нарушенное правило проверки (required, uuid, ...). Если задано, сообщение для клиента берется
+	// из каталога сообщений на его языке, Params подставляются в него по порядку.
+	Rule   string
+	Params []string
+}
+
+// ValidationError - ошибка проверки запроса с перечнем некорректных полей.
+// Сопоставляется с ErrValidation через errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// NewFieldError возвращает ValidationError с ошибкой одного поля.
+func NewFieldError(field, message string) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: message}}}
+}
+
+// NewRuleError возвращает ValidationError для поля, не прошедшего правило проверки rule.
+func NewRuleError(field, rule string, params ...string) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Rule: rule, Params: params}}}
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		message := f.Message
+		if message == "" {
+			message = fmt.Sprintf("failed on '%s' rule", f.Rule)
+			if len(f.Params) > 0 {
+				message += " (" + strings.Join(f.Params, ", ") + ")"
+			}
+		}
+		parts = append(parts, f.Field+": "+message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,17 +16,25 @@ type PVZRepository interface {
 	// Возвращает ErrNotFound, если ПВЗ не найден.
 	GetByID(ctx context.Context, id uuid.UUID) (*PVZ, error)
 
-	// ListIDsAndTotal возвращает слайс ID ПВЗ для текущей страницы с учетом фильтров
-	// и общее количество ПВЗ, удовлетворяющих фильтрам (без пагинации).
-	// Заменил старый метод List.
-	ListIDsAndTotal(ctx context.Context, limit, offset int, startDate, endDate *time.Time) ([]uuid.UUID, int, error)
+	// List возвращает страницу ПВЗ, отсортированную по (registration_date, id) по убыванию.
+	List(ctx context.Context, filter PVZListFilter) ([]PVZ, error)
+	// Count возвращает количество ПВЗ, удовлетворяющих фильтру по датам приемок (пагинация игнорируется).
+	Count(ctx context.Context, filter PVZListFilter) (int, error)
 
 	// GetByIDs находит и возвращает список ПВЗ по списку их ID.
-	// Добавлен для получения данных после ListIDsAndTotal.
 	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]PVZ, error)
 
 	// ListAll возвращает список всех ПВЗ без фильтрации и пагинации (для gRPC).
 	ListAll(ctx context.Context) ([]PVZ, error)
+
+	// SetCapacity заменяет вместимость ПВЗ (общую и по типам товаров) и увеличивает версию ПВЗ.
+	// Если expectedVersion не nil, изменение выполняется только при совпадении версии, иначе возвращается
+	// ErrPreconditionFailed. Возвращает ErrNotFound, если ПВЗ не найден.
+	SetCapacity(ctx context.Context, pvzID uuid.UUID, capacity PVZCapacity, expectedVersion *int64) error
+	// GetOccupancy возвращает заполненность ПВЗ. Возвращает ErrNotFound, если ПВЗ не найден.
+	GetOccupancy(ctx context.Context, pvzID uuid.UUID) (*PVZOccupancy, error)
+	// ListOccupancy возвращает заполненность всех ПВЗ (для метрик).
+	ListOccupancy(ctx context.Context) ([]PVZOccupancy, error)
 }
 
 // ReceptionRepository определяет методы для работы с сущностями Приемок.
@@ -35,15 +44,30 @@ type ReceptionRepository interface {
 	// GetByID находит приемку по ее уникальному идентификатору.
 	// Возвращает ErrNotFound, если приемка не найдена.
 	GetByID(ctx context.Context, id uuid.UUID) (*Reception, error)
+	// GetWithProducts находит приемку по ID вместе с ее товарами.
+	// Возвращает ErrNotFound, если приемка не найдена.
+	GetWithProducts(ctx context.Context, id uuid.UUID) (*ReceptionWithProducts, error)
 	// FindOpenByPVZID находит последнюю незавершенную приемку ('in_progress') для указанного ПВЗ.
 	// Возвращает ErrNoOpenReception, если открытых приемок нет.
 	FindOpenByPVZID(ctx context.Context, pvzID uuid.UUID) (*Reception, error)
-	// UpdateStatus обновляет статус приемки по ее ID.
-	// Возвращает ErrNotFound, если приемка не найдена.
-	UpdateStatus(ctx context.Context, id uuid.UUID, status ReceptionStatus) error
-	// ListByPVZIDsAndDate возвращает мапу приемок с товарами для указанных ПВЗ и диапазона дат.
+	// Close закрывает незакрытую приемку, фиксируя время закрытия и закрывшего пользователя (closedBy может быть nil),
+	// и возвращает обновленную приемку. Возвращает ErrNotFound, если незакрытой приемки с таким ID нет.
+	// Если expectedVersion не nil, приемка закрывается только при совпадении версии, иначе возвращается
+	// ErrPreconditionFailed.
+	Close(ctx context.Context, id uuid.UUID, closedBy *uuid.UUID, expectedVersion *int64) (*Reception, error)
+	// CloseStale закрывает все незакрытые приемки, начатые раньше openedBefore, помечая их как закрытые
+	// автоматически с причиной reason. Возвращает закрытые приемки.
+	CloseStale(ctx context.Context, openedBefore time.Time, reason StaleReason) ([]Reception, error)
+	// FlagStale помечает причиной reason незакрытые приемки, начатые раньше openedBefore, не закрывая их.
+	// Уже помеченные приемки пропускаются. Возвращает помеченные приемки.
+	FlagStale(ctx context.Context, openedBefore time.Time, reason StaleReason) ([]Reception, error)
+	// ListByPVZIDs возвращает мапу приемок (и, по запросу, их товаров) для указанных ПВЗ, от новых к старым.
 	// Ключ мапы - PVZ ID. Используется для обогащения данных в PVZService.ListPVZs.
-	ListByPVZIDsAndDate(ctx context.Context, pvzIDs []uuid.UUID, startDate, endDate *time.Time) (map[uuid.UUID][]ReceptionWithProducts, error)
+	ListByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, filter ReceptionEmbedFilter) (map[uuid.UUID][]ReceptionWithProducts, error)
+	// SummaryByPVZIDs возвращает сводку по приемкам указанных ПВЗ за период. ПВЗ без приемок в мапу не попадают.
+	SummaryByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, startDate, endDate *time.Time) (map[uuid.UUID]PVZSummary, error)
+	// ListByPVZID возвращает страницу приемок ПВЗ (от новых к старым) и их общее количество по фильтру.
+	ListByPVZID(ctx context.Context, pvzID uuid.UUID, filter ReceptionListFilter) ([]Reception, int, error)
 }
 
 // ProductRepository определяет методы для работы с сущностями Товаров.
@@ -59,6 +83,31 @@ type ProductRepository interface {
 	// ListByReceptionIDs возвращает мапу товаров для указанных ID приемок.
 	// Ключ мапы - Reception ID. Используется для обогащения данных в ReceptionRepository.
 	ListByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) (map[uuid.UUID][]Product, error)
+	// ListByReceptionID возвращает страницу товаров приемки (в порядке добавления) и их общее количество.
+	ListByReceptionID(ctx context.Context, receptionID uuid.UUID, limit, offset int) ([]Product, int, error)
+	// GetByID находит товар по ID. Возвращает ErrNotFound, если товар не найден.
+	GetByID(ctx context.Context, id uuid.UUID) (*Product, error)
+	// Transition переводит товар из состояния From в To и возвращает обновленный товар. Если задан DispatchID,
+	// товар включается в отправку, только пока она открыта. Возвращает ErrNotFound, если товар уже не в состоянии
+	// From или отправка закрыта.
+	Transition(ctx context.Context, transition ProductTransition) (*Product, error)
+	// ListByDispatchID возвращает товары отправки в порядке возврата.
+	ListByDispatchID(ctx context.Context, dispatchID uuid.UUID) ([]Product, error)
+	// InventoryByPVZIDs возвращает остатки указанных ПВЗ. ПВЗ без товаров в мапу не попадают.
+	InventoryByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID) (map[uuid.UUID]PVZInventory, error)
+}
+
+// DispatchRepository определяет методы для работы с отправками возвратов.
+type DispatchRepository interface {
+	// Create сохраняет новую открытую отправку. Возвращает ErrConflict, если у ПВЗ уже есть открытая отправка.
+	Create(ctx context.Context, dispatch *Dispatch) error
+	// GetByID находит отправку по ID. Возвращает ErrNotFound, если отправка не найдена.
+	GetByID(ctx context.Context, id uuid.UUID) (*Dispatch, error)
+	// FindOpenByPVZID находит открытую отправку ПВЗ. Возвращает ErrNotFound, если ее нет.
+	FindOpenByPVZID(ctx context.Context, pvzID uuid.UUID) (*Dispatch, error)
+	// MarkSent переводит открытую отправку в состояние sent и возвращает ее.
+	// Возвращает ErrNotFound, если открытой отправки с таким ID нет.
+	MarkSent(ctx context.Context, id uuid.UUID, sentAt time.Time, sentBy *uuid.UUID) (*Dispatch, error)
 }
 
 // UserRepository определяет методы для работы с сущностями Пользователей.
@@ -74,6 +123,90 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 }
 
+// StatsRepository определяет методы для расчета агрегированной статистики.
+type StatsRepository interface {
+	// ReceptionStats рассчитывает агрегаты приемок по ПВЗ и городам средствами SQL.
+	ReceptionStats(ctx context.Context, filter ReceptionStatsFilter) (*ReceptionStats, error)
+	// PVZActivity рассчитывает активность каждого ПВЗ за период.
+	PVZActivity(ctx context.Context, filter PVZActivityFilter) ([]PVZActivityRow, error)
+}
+
+// ExportRepository определяет методы для потоковой выгрузки данных.
+type ExportRepository interface {
+	// StreamReceptionRows читает строки выгрузки курсором и передает их в fn по одной, не загружая результат целиком.
+	StreamReceptionRows(ctx context.Context, filter ReceptionExportFilter, fn ReceptionExportRowFunc) error
+}
+
+// ReportRepository определяет методы для хранения задач построения отчетов.
+type ReportRepository interface {
+	// Create сохраняет новую задачу.
+	Create(ctx context.Context, report *Report) error
+	// GetByID находит задачу по ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*Report, error)
+	// ClaimNext атомарно переводит самую старую ожидающую задачу в состояние running и возвращает ее.
+	// Возвращает ErrNotFound, если ожидающих задач нет. Безопасен для конкурентных обработчиков.
+	ClaimNext(ctx context.Context) (*Report, error)
+	// MarkDone сохраняет ключ результата и срок его хранения. attempt - номер запуска из ClaimNext: если задачу
+	// уже выполняет другой запуск (RequeueStale вернул ее в очередь), ничего не меняет и возвращает ErrReportClaimLost.
+	MarkDone(ctx context.Context, id uuid.UUID, attempt int, blobKey string, expiresAt time.Time) error
+	// MarkFailed переводит задачу в состояние failed с указанием причины. attempt проверяется так же, как в MarkDone.
+	MarkFailed(ctx context.Context, id uuid.UUID, attempt int, reason string) error
+	// Release возвращает задачу в очередь без признака ошибки (например, при остановке обработчика).
+	// attempt проверяется так же, как в MarkDone.
+	Release(ctx context.Context, id uuid.UUID, attempt int) error
+	// RequeueStale возвращает в очередь задачи, зависшие в running с момента startedBefore
+	// (например, после перезапуска сервиса). Задачи, исчерпавшие maxAttempts, помечаются failed.
+	RequeueStale(ctx context.Context, startedBefore time.Time, maxAttempts int) (int64, error)
+	// ListExpired возвращает готовые задачи, срок хранения результата которых истек к моменту now.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]Report, error)
+	// MarkExpired переводит задачу в состояние expired и очищает ключ результата.
+	MarkExpired(ctx context.Context, id uuid.UUID) error
+}
+
+// Locker определяет блокировки, общие для всех экземпляров сервиса.
+type Locker interface {
+	// TryWithLock выполняет fn, удерживая блокировку key. Если блокировку держит другой экземпляр,
+	// fn не вызывается и возвращается false.
+	TryWithLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error)
+}
+
+// ExpectedShipmentRepository определяет методы для хранения ожидаемых поставок.
+type ExpectedShipmentRepository interface {
+	// Create сохраняет новую поставку.
+	Create(ctx context.Context, shipment *ExpectedShipment) error
+	// GetByID находит поставку по ID вместе с ID привязанной приемки.
+	// Возвращает ErrNotFound, если поставка не найдена.
+	GetByID(ctx context.Context, id uuid.UUID) (*ExpectedShipment, error)
+	// SaveReconciliation сохраняет итог сверки поставки с закрытой приемкой.
+	SaveReconciliation(ctx context.Context, id uuid.UUID, reconciliation Reconciliation) error
+}
+
+// IdempotencyRepository определяет методы для хранения ключей идемпотентности и ответов на запросы с ними.
+type IdempotencyRepository interface {
+	// Acquire захватывает ключ record.Scope/record.Key для текущего запроса, создавая запись в состоянии in_progress.
+	// Истекшая запись, а также брошенная запись с тем же хэшем запроса (захваченная раньше staleBefore)
+	// перезаписываются. Возвращает запись и true, если ключ захвачен, иначе существующую запись и false.
+	Acquire(ctx context.Context, record *IdempotencyRecord, staleBefore time.Time) (*IdempotencyRecord, bool, error)
+	// Complete сохраняет ответ и переводит запись в completed. Возвращает ErrNotFound,
+	// если ключ уже не принадлежит запросу record.LockID.
+	Complete(ctx context.Context, record *IdempotencyRecord) error
+	// Release удаляет незавершенную запись запроса lockID, чтобы запрос с тем же ключом можно было повторить.
+	Release(ctx context.Context, scope, key string, lockID uuid.UUID) error
+	// DeleteExpired удаляет записи, срок хранения которых истек к моменту now.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// BlobStore определяет хранилище файлов результатов (локальная ФС, объектное хранилище и т.п.).
+type BlobStore interface {
+	// Put сохраняет содержимое r под ключом key. Объект становится доступен только после успешного чтения r до конца;
+	// при ошибке чтения частично записанные данные удаляются.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open открывает объект key на чтение. Возвращает ErrNotFound, если объекта нет.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект key. Отсутствие объекта не является ошибкой.
+	Delete(ctx context.Context, key string) error
+}
+
 // --- Интерфейсы Сервисов ---
 // Определяют методы бизнес-логики (use cases).
 
@@ -91,28 +224,111 @@ type AuthService interface {
 type PVZService interface {
 	// CreatePVZ создает новый ПВЗ с учетом бизнес-правил (допустимые города).
 	CreatePVZ(ctx context.Context, city City) (*PVZ, error)
-	// ListPVZs возвращает список ПВЗ с деталями и пагинацией.
-	ListPVZs(ctx context.Context, limit, page int, startDate, endDate *time.Time) ([]PVZWithDetails, int, error)
+	// ListPVZs возвращает страницу ПВЗ с деталями. Поддерживает постраничную и keyset-пагинацию.
+	ListPVZs(ctx context.Context, params PVZListParams) (*PVZListPage, error)
+	// GetPVZ возвращает ПВЗ с текущей версией. Возвращает ErrNotFound, если ПВЗ не существует.
+	GetPVZ(ctx context.Context, id uuid.UUID) (*PVZ, error)
+	// SetCapacity задает вместимость ПВЗ и возвращает его заполненность. Если expectedVersion не nil и не совпадает
+	// с версией ПВЗ, возвращает ErrPreconditionFailed. Возвращает ErrNotFound, если ПВЗ не существует.
+	SetCapacity(ctx context.Context, pvzID uuid.UUID, capacity PVZCapacity, expectedVersion *int64) (*PVZOccupancy, error)
+	// GetOccupancy возвращает заполненность ПВЗ. Возвращает ErrNotFound, если ПВЗ не существует.
+	GetOccupancy(ctx context.Context, pvzID uuid.UUID) (*PVZOccupancy, error)
 }
 
 // ReceptionService определяет методы бизнес-логики для работы с приемками.
 type ReceptionService interface {
-	// CreateReception инициирует новую приемку для ПВЗ.
-	CreateReception(ctx context.Context, pvzID uuid.UUID) (*Reception, error)
-	// CloseReception закрывает последнюю активную приемку для ПВЗ.
-	CloseReception(ctx context.Context, pvzID uuid.UUID) (*Reception, error)
+	// CreateReception инициирует новую приемку для ПВЗ. Если передан shipmentID, к приемке привязывается
+	// ожидаемая поставка этого ПВЗ; поставка может быть привязана только к одной приемке.
+	CreateReception(ctx context.Context, pvzID uuid.UUID, shipmentID *uuid.UUID) (*Reception, error)
+	// CloseReception закрывает последнюю активную приемку для ПВЗ. Если expectedVersion не nil и не совпадает
+	// с версией открытой приемки, возвращает ErrPreconditionFailed.
+	CloseReception(ctx context.Context, pvzID uuid.UUID, expectedVersion *int64) (*Reception, error)
+	// ReconcileReception сверяет закрытую приемку с привязанной ожидаемой поставкой и сохраняет итог.
+	// Приемка без поставки не сверяется.
+	ReconcileReception(ctx context.Context, reception *Reception) error
+	// ListPVZReceptions возвращает страницу приемок ПВЗ и их общее количество.
+	// Возвращает ErrNotFound, если ПВЗ не существует.
+	ListPVZReceptions(ctx context.Context, pvzID uuid.UUID, filter ReceptionListFilter) ([]Reception, int, error)
+	// GetReception возвращает приемку с товарами и их количеством по типам.
+	// Возвращает ErrNotFound, если приемка не существует.
+	GetReception(ctx context.Context, id uuid.UUID) (*ReceptionDetails, error)
+	// GetCurrentReception возвращает незакрытую приемку ПВЗ с товарами.
+	// Возвращает ErrNotFound, если ПВЗ не существует или открытой приемки нет.
+	GetCurrentReception(ctx context.Context, pvzID uuid.UUID) (*ReceptionDetails, error)
 }
 
 // ProductService определяет методы бизнес-логики для работы с товарами.
 type ProductService interface {
-	// AddProduct добавляет товар в текущую открытую приемку ПВЗ.
-	AddProduct(ctx context.Context, pvzID uuid.UUID, productType ProductType) (*Product, error)
+	// AddProduct добавляет товар в текущую открытую приемку ПВЗ. itemID - необязательный идентификатор единицы
+	// для сверки с ожидаемой поставкой. Возвращает ErrPVZFull, если в ПВЗ нет места для товара этого типа.
+	AddProduct(ctx context.Context, pvzID uuid.UUID, productType ProductType, itemID *string) (*Product, error)
 	// DeleteLastProduct удаляет последний добавленный товар из открытой приемки (LIFO).
 	DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error
+	// ListReceptionProducts возвращает страницу товаров приемки и их общее количество.
+	// Возвращает ErrNotFound, если приемка не существует.
+	ListReceptionProducts(ctx context.Context, receptionID uuid.UUID, limit, offset int) ([]Product, int, error)
+	// IssueProduct отмечает выдачу товара клиенту.
+	IssueProduct(ctx context.Context, id uuid.UUID) (*Product, error)
+	// ReturnProduct возвращает товар на склад, включая его в открытую отправку ПВЗ (создается при необходимости).
+	ReturnProduct(ctx context.Context, id uuid.UUID) (*Product, error)
+	// MarkProductLost списывает утерянный товар.
+	MarkProductLost(ctx context.Context, id uuid.UUID) (*Product, error)
+}
+
+// DispatchService определяет методы бизнес-логики для отправок возвратов.
+type DispatchService interface {
+	// GetDispatch возвращает отправку с товарами. Возвращает ErrNotFound, если отправки нет.
+	GetDispatch(ctx context.Context, id uuid.UUID) (*DispatchDetails, error)
+	// SendDispatch фиксирует передачу отправки на склад. Пустую или уже отправленную отправку отправить нельзя.
+	SendDispatch(ctx context.Context, id uuid.UUID) (*DispatchDetails, error)
+}
+
+// ShipmentService определяет методы бизнес-логики для ожидаемых поставок и их сверки с приемками.
+type ShipmentService interface {
+	// CreateShipment регистрирует ожидаемую поставку в ПВЗ.
+	CreateShipment(ctx context.Context, pvzID uuid.UUID, reference *string, lines []ExpectedShipmentLine) (*ExpectedShipment, error)
+	// GetShipment возвращает поставку с привязанной приемкой и итогом сверки.
+	GetShipment(ctx context.Context, id uuid.UUID) (*ExpectedShipment, error)
+	// GetReconciliation возвращает расхождения приемки с привязанной поставкой. Для закрытой приемки
+	// возвращается сохраненный итог, для открытой - предварительная сверка.
+	// Возвращает ErrNotFound, если приемки нет, и ErrNoExpectedShipment, если поставка не привязана.
+	GetReconciliation(ctx context.Context, receptionID uuid.UUID) (*Reconciliation, error)
+}
+
+// StatsService определяет методы бизнес-логики для получения статистики.
+type StatsService interface {
+	// ReceptionStats возвращает статистику приемок за период с группировкой по дню, неделе или месяцу.
+	ReceptionStats(ctx context.Context, filter ReceptionStatsFilter) (*ReceptionStats, error)
+	// PVZActivity возвращает активность ПВЗ за период (по умолчанию - последние 30 дней).
+	PVZActivity(ctx context.Context, filter PVZActivityFilter) ([]PVZActivityRow, error)
+}
+
+// ExportService определяет методы бизнес-логики для выгрузки данных.
+type ExportService interface {
+	// ExportReceptions проверяет фильтр и передает строки выгрузки приемок в fn по мере чтения.
+	ExportReceptions(ctx context.Context, filter ReceptionExportFilter, fn ReceptionExportRowFunc) error
+}
+
+// ReportService определяет методы бизнес-логики для асинхронных отчетов.
+type ReportService interface {
+	// CreateReport проверяет параметры и ставит задачу построения отчета в очередь.
+	CreateReport(ctx context.Context, reportType ReportType, format ExportFormat, params ReportParams) (*Report, error)
+	// GetReport возвращает задачу с текущим состоянием.
+	GetReport(ctx context.Context, id uuid.UUID) (*Report, error)
+	// OpenReportResult открывает файл готового отчета. Возвращает ErrReportNotReady или ErrReportExpired,
+	// если результата еще или уже нет. Вызывающий обязан закрыть reader.
+	OpenReportResult(ctx context.Context, id uuid.UUID) (*Report, io.ReadCloser, error)
 }
 
 // --- Вспомогательные Интерфейсы ---
 
+// RateLimitStore хранит состояние token bucket по ключам. Реализация в памяти ограничивает запросы
+// в пределах одного экземпляра сервиса, распределенная (например, в Redis) - во всех экземплярах сразу.
+type RateLimitStore interface {
+	// Take пытается взять один токен из bucket key с параметрами limit на момент now.
+	Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitDecision, error)
+}
+
 // PasswordHasher определяет контракт для хеширования и сравнения паролей.
 type PasswordHasher interface {
 	// Hash генерирует хеш для заданного пароля.
@@ -125,7 +341,13 @@ type PasswordHasher interface {
 type MetricsCollector interface {
 	IncRequestsTotal(method, path, statusCode string)
 	ObserveRequestDuration(method, path string, duration float64)
+	IncGRPCRequestsTotal(method, code string)
+	ObserveGRPCRequestDuration(method string, duration float64)
 	IncPVZCreated()
 	IncReceptionsCreated()
 	IncProductsAdded()
+	IncStaleReceptions(action string, reason StaleReason)
+	IncProductTransitions(status ProductStatus)
+	SetPVZOccupancy(occupancies []PVZOccupancy)
+	IncRateLimited(transport, route, key string)
 }
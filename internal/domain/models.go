@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -60,11 +63,32 @@ func (c City) IsValid() bool {
 	return ok
 }
 
+// PVZStatus представляет состояние ПВЗ.
+type PVZStatus string
+
+// Константы для статусов ПВЗ.
+const (
+	PVZStatusActive   PVZStatus = "active"   // ПВЗ работает
+	PVZStatusInactive PVZStatus = "inactive" // ПВЗ выведен из работы
+)
+
+// IsValid проверяет, является ли строка допустимым статусом ПВЗ.
+func (s PVZStatus) IsValid() bool {
+	switch s {
+	case PVZStatusActive, PVZStatusInactive:
+		return true
+	default:
+		return false
+	}
+}
+
 // PVZ представляет Пункт Выдачи Заказов.
 type PVZ struct {
 	ID               uuid.UUID `json:"id"`               // Уникальный идентификатор
 	RegistrationDate time.Time `json:"registrationDate"` // Дата и время регистрации в системе
 	City             City      `json:"city"`             // Город расположения
+	Status           PVZStatus `json:"status"`           // Состояние ПВЗ
+	Version          int64     `json:"version"`          // Версия, увеличивается при каждом изменении ПВЗ
 }
 
 // --- Reception (Приёмка Товаров) ---
@@ -88,12 +112,32 @@ func (s ReceptionStatus) IsValid() bool {
 	}
 }
 
+// StaleReason описывает, почему приемка признана забытой планировщиком.
+type StaleReason string
+
+// Константы причин для забытых приемок.
+const (
+	StaleReasonMaxAge      StaleReason = "max_age"      // Приемка открыта дольше допустимого
+	StaleReasonClosingTime StaleReason = "closing_time" // Приемка не закрыта до окончания рабочего дня ПВЗ
+)
+
 // Reception представляет процесс приемки товаров на конкретном ПВЗ.
 type Reception struct {
 	ID       uuid.UUID       `json:"id"`       // Уникальный идентификатор приемки
 	DateTime time.Time       `json:"dateTime"` // Дата и время начала приемки
 	PVZID    uuid.UUID       `json:"pvzId"`    // Идентификатор ПВЗ, где проходит приемка
 	Status   ReceptionStatus `json:"status"`   // Текущий статус приемки
+	ClosedAt *time.Time      `json:"closedAt"` // Дата и время закрытия (nil, пока приемка активна)
+	OpenedBy *uuid.UUID      `json:"openedBy"` // Сотрудник, открывший приемку (nil для приемок, созданных до учета)
+	ClosedBy *uuid.UUID      `json:"closedBy"` // Сотрудник, закрывший приемку (nil, пока приемка активна)
+	// AutoClosed - приемка закрыта планировщиком забытых приемок.
+	AutoClosed bool `json:"autoClosed"`
+	// StaleReason - причина, по которой приемка признана забытой (nil для обычных приемок).
+	StaleReason *StaleReason `json:"staleReason"`
+	// ExpectedShipmentID - ожидаемая поставка, привязанная при открытии приемки.
+	ExpectedShipmentID *uuid.UUID `json:"expectedShipmentId"`
+	// Version - версия приемки, увеличивается при каждом изменении приемки или ее товаров.
+	Version int64 `json:"version"`
 }
 
 // --- Product (Товар) ---
@@ -121,12 +165,103 @@ func (pt ProductType) IsValid() bool {
 	return ok
 }
 
+// ProductStatus представляет состояние товара в жизненном цикле ПВЗ.
+type ProductStatus string
+
+// Константы для состояний товара.
+const (
+	ProductReceived ProductStatus = "received" // Товар принят и находится в ПВЗ
+	ProductIssued   ProductStatus = "issued"   // Товар выдан клиенту
+	ProductReturned ProductStatus = "returned" // Товар возвращен на склад в составе отправки
+	ProductLost     ProductStatus = "lost"     // Товар утерян
+)
+
+// IsValid проверяет, является ли строка допустимым состоянием товара.
+func (s ProductStatus) IsValid() bool {
+	switch s {
+	case ProductReceived, ProductIssued, ProductReturned, ProductLost:
+		return true
+	default:
+		return false
+	}
+}
+
 // Product представляет конкретный товар, принятый в рамках приемки.
 type Product struct {
-	ID          uuid.UUID   `json:"id"`          // Уникальный идентификатор товара
-	DateTime    time.Time   `json:"dateTime"`    // Дата и время добавления товара в систему (в рамках приемки)
-	Type        ProductType `json:"type"`        // Тип товара
-	ReceptionID uuid.UUID   `json:"receptionId"` // Идентификатор приемки, к которой относится товар
+	ID          uuid.UUID     `json:"id"`          // Уникальный идентификатор товара
+	DateTime    time.Time     `json:"dateTime"`    // Дата и время добавления товара в систему (в рамках приемки)
+	Type        ProductType   `json:"type"`        // Тип товара
+	ReceptionID uuid.UUID     `json:"receptionId"` // Идентификатор приемки, к которой относится товар
+	ItemID      *string       `json:"itemId"`      // Идентификатор единицы из поставки (штрихкод), если указан
+	Status      ProductStatus `json:"status"`      // Текущее состояние товара
+	// StatusChangedAt и StatusChangedBy - время и автор выдачи, возврата или списания (nil, пока товар в ПВЗ).
+	StatusChangedAt *time.Time `json:"statusChangedAt"`
+	StatusChangedBy *uuid.UUID `json:"statusChangedBy"`
+	// DispatchID - отправка, в которую включен возвращенный товар.
+	DispatchID *uuid.UUID `json:"dispatchId"`
+}
+
+// ProductTransition описывает перевод товара из одного состояния в другое.
+type ProductTransition struct {
+	ProductID  uuid.UUID
+	From       ProductStatus
+	To         ProductStatus
+	ChangedAt  time.Time
+	ChangedBy  *uuid.UUID
+	DispatchID *uuid.UUID // Открытая отправка для возвращаемого товара
+}
+
+// --- Dispatch (Отправка возвратов) ---
+
+// DispatchStatus представляет состояние исходящей отправки.
+type DispatchStatus string
+
+// Константы для состояний отправки.
+const (
+	DispatchOpen DispatchStatus = "open" // Отправка собирается, в нее попадают возвращаемые товары
+	DispatchSent DispatchStatus = "sent" // Отправка передана на склад
+)
+
+// Dispatch - партия возвращаемых на склад товаров ПВЗ.
+type Dispatch struct {
+	ID        uuid.UUID      `json:"id"`
+	PVZID     uuid.UUID      `json:"pvzId"`
+	Status    DispatchStatus `json:"status"`
+	CreatedAt time.Time      `json:"createdAt"`
+	CreatedBy *uuid.UUID     `json:"createdBy"`
+	SentAt    *time.Time     `json:"sentAt"` // nil, пока отправка собирается
+	SentBy    *uuid.UUID     `json:"sentBy"`
+}
+
+// DispatchDetails - отправка вместе с включенными в нее товарами.
+type DispatchDetails struct {
+	Dispatch Dispatch  `json:"dispatch"`
+	Products []Product `json:"products"`
+}
+
+// --- Capacity (Вместимость ПВЗ) ---
+
+// PVZCapacity задает вместимость ПВЗ. Total == nil снимает общее ограничение,
+// тип товара, отсутствующий в ByType, отдельно не ограничивается.
+type PVZCapacity struct {
+	Total  *int
+	ByType map[ProductType]int
+}
+
+// TypeOccupancy - заполненность ПВЗ товарами одного типа.
+type TypeOccupancy struct {
+	Occupied int  `json:"occupied"`
+	Capacity *int `json:"capacity"` // nil, если тип отдельно не ограничен
+}
+
+// PVZOccupancy - текущая заполненность ПВЗ (товары в статусе received) и ее ограничения.
+type PVZOccupancy struct {
+	PVZID    uuid.UUID                     `json:"pvzId"`
+	City     City                          `json:"city"`
+	Occupied int                           `json:"occupied"`
+	Capacity *int                          `json:"capacity"` // nil, если общая вместимость не ограничена
+	ByType   map[ProductType]TypeOccupancy `json:"byType"`
+	Version  int64                         `json:"version"` // Версия ПВЗ на момент чтения
 }
 
 // --- Вспомогательные структуры для комплексных запросов/ответов ---
@@ -134,11 +269,450 @@ type Product struct {
 // ReceptionWithProducts используется для представления приемки вместе со списком ее товаров.
 type ReceptionWithProducts struct {
 	Reception Reception `json:"reception"` // Данные о приемке
-	Products  []Product `json:"products"`  // Список товаров в этой приемке
+	Products  []Product `json:"products"`  // Список товаров в этой приемке (nil, если товары не загружались)
+}
+
+// ReceptionDetails - приемка с товарами и количеством товаров по типам.
+type ReceptionDetails struct {
+	Reception     Reception           `json:"reception"`
+	Products      []Product           `json:"products"`
+	ProductCounts map[ProductType]int `json:"productCounts"` // Количество товаров каждого типа в приемке
 }
 
-// PVZWithDetails используется для представления ПВЗ вместе со списком его приемок (и их товаров).
+// PVZSummary - сводные показатели приемок ПВЗ за запрошенный период.
+type PVZSummary struct {
+	ReceptionsCount     int        `json:"receptionsCount"`     // Количество приемок
+	OpenReceptionsCount int        `json:"openReceptionsCount"` // Количество незакрытых приемок
+	ProductsCount       int        `json:"productsCount"`       // Количество товаров во всех приемках
+	LastReceptionAt     *time.Time `json:"lastReceptionAt"`     // Время последней приемки (nil, если приемок нет)
+}
+
+// PVZInventory - текущие остатки ПВЗ: принятые и еще не выданные, не возвращенные и не утерянные товары.
+type PVZInventory struct {
+	OnHand int                 `json:"onHand"` // Количество товаров в ПВЗ
+	ByType map[ProductType]int `json:"byType"` // Количество товаров в ПВЗ по типам
+}
+
+// PVZWithDetails используется для представления ПВЗ вместе со сводкой и, по запросу, списком его приемок (и их товаров).
 type PVZWithDetails struct {
 	PVZ        PVZ                     `json:"pvz"`        // Данные о ПВЗ
-	Receptions []ReceptionWithProducts `json:"receptions"` // Список приемок в этом ПВЗ
+	Summary    PVZSummary              `json:"summary"`    // Сводка по приемкам за период
+	Inventory  PVZInventory            `json:"inventory"`  // Текущие остатки (не зависят от периода)
+	Receptions []ReceptionWithProducts `json:"receptions"` // Список приемок (nil, если встраивание не запрошено)
+}
+
+// ReceptionEmbedFilter - параметры выборки приемок, встраиваемых в список ПВЗ.
+type ReceptionEmbedFilter struct {
+	StartDate    *time.Time
+	EndDate      *time.Time
+	PerPVZLimit  int  // Максимум приемок на один ПВЗ (самые свежие); 0 - без ограничения
+	WithProducts bool // Загружать ли товары приемок
+}
+
+// ReceptionListFilter - параметры постраничной выборки приемок одного ПВЗ.
+type ReceptionListFilter struct {
+	StartDate  *time.Time
+	EndDate    *time.Time
+	Status     *ReceptionStatus
+	OpenedBy   *uuid.UUID
+	ClosedBy   *uuid.UUID
+	ClosedFrom *time.Time // Нижняя граница времени закрытия (незакрытые приемки исключаются)
+	ClosedTo   *time.Time // Верхняя граница времени закрытия (незакрытые приемки исключаются)
+	Limit      int
+	Offset     int
+}
+
+// --- Пагинация списка ПВЗ ---
+
+// PVZListCursor - позиция keyset-пагинации в списке ПВЗ, отсортированном по (registration_date, id).
+// SortOrder фиксирует направление, в котором выдан курсор: с другим направлением позиция не имеет смысла.
+type PVZListCursor struct {
+	RegistrationDate time.Time `json:"r"`
+	ID               uuid.UUID `json:"i"`
+	SortOrder        SortOrder `json:"o"`
+}
+
+// Encode возвращает непрозрачный токен курсора для передачи клиенту.
+func (c PVZListCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePVZListCursor разбирает токен, полученный от клиента. Ошибка оборачивает ErrValidation.
+func DecodePVZListCursor(token string) (*PVZListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrValidation)
+	}
+	var cursor PVZListCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil || cursor.RegistrationDate.IsZero() ||
+		!cursor.SortOrder.IsValid() {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrValidation)
+	}
+	return &cursor, nil
+}
+
+// PVZSortField задает поле сортировки списка ПВЗ.
+type PVZSortField string
+
+// Константы для полей сортировки списка ПВЗ.
+const (
+	PVZSortRegistrationDate PVZSortField = "registration_date" // Дата регистрации (по умолчанию)
+	PVZSortLastReception    PVZSortField = "last_reception"    // Время последней приемки
+	PVZSortProductCount     PVZSortField = "product_count"     // Количество принятых товаров
+)
+
+// IsValid проверяет, является ли строка допустимым полем сортировки.
+func (f PVZSortField) IsValid() bool {
+	switch f {
+	case PVZSortRegistrationDate, PVZSortLastReception, PVZSortProductCount:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortOrder задает направление сортировки.
+type SortOrder string
+
+// Константы для направлений сортировки.
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// IsValid проверяет, является ли строка допустимым направлением сортировки.
+func (o SortOrder) IsValid() bool {
+	return o == SortAsc || o == SortDesc
+}
+
+// PVZQuery - фильтры и сортировка списка ПВЗ, общие для сервиса и репозитория.
+// Условия по приемкам и товарам (кроме HasOpenReception) учитывают только приемки из диапазона StartDate/EndDate.
+type PVZQuery struct {
+	StartDate        *time.Time   // Оставить только ПВЗ с приемками не раньше этой даты
+	EndDate          *time.Time   // Оставить только ПВЗ с приемками не позже этой даты
+	Cities           []City       // Оставить ПВЗ из любого из городов
+	Status           *PVZStatus   // Оставить ПВЗ с указанным статусом
+	HasOpenReception *bool        // Наличие (true) или отсутствие (false) незакрытой приемки
+	ProductType      *ProductType // Оставить ПВЗ, принявшие хотя бы один товар этого типа
+	MinProducts      *int         // Минимальное количество принятых товаров
+	SortBy           PVZSortField // Пустое значение - PVZSortRegistrationDate
+	SortOrder        SortOrder    // Пустое значение - SortDesc
+}
+
+// PVZListFilter задает выборку страницы ПВЗ в репозитории.
+// After и Offset взаимоисключающие: After используется для keyset-пагинации
+// (только при сортировке по дате регистрации), Offset - для постраничной.
+type PVZListFilter struct {
+	PVZQuery
+	After  *PVZListCursor // Вернуть ПВЗ строго после этой позиции
+	Limit  int
+	Offset int
+}
+
+// PVZListParams - параметры запроса списка ПВЗ на уровне сервиса.
+type PVZListParams struct {
+	PVZQuery
+	Limit        int
+	Page         int            // Номер страницы (с 1), используется только без Cursor
+	Cursor       *PVZListCursor // Позиция, после которой начинается страница
+	IncludeTotal bool           // Считать ли общее количество ПВЗ (отдельный запрос)
+
+	IncludeReceptions bool // Встраивать ли приемки за период
+	IncludeProducts   bool // Встраивать ли товары приемок (имеет смысл только вместе с IncludeReceptions)
+	ReceptionsPerPVZ  int  // Максимум встраиваемых приемок на один ПВЗ; 0 - без ограничения
+}
+
+// PVZListPage - страница списка ПВЗ.
+type PVZListPage struct {
+	Items      []PVZWithDetails
+	Total      *int           // Заполняется только при PVZListParams.IncludeTotal
+	NextCursor *PVZListCursor // nil, если страница последняя
+}
+
+// --- Статистика приемок ---
+
+// StatsGroupBy задает шаг агрегации статистики по времени.
+type StatsGroupBy string
+
+// Константы для шагов агрегации.
+const (
+	GroupByDay   StatsGroupBy = "day"
+	GroupByWeek  StatsGroupBy = "week"
+	GroupByMonth StatsGroupBy = "month"
+)
+
+// IsValid проверяет, является ли строка допустимым шагом агрегации.
+func (g StatsGroupBy) IsValid() bool {
+	switch g {
+	case GroupByDay, GroupByWeek, GroupByMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReceptionStatsFilter задает параметры расчета статистики приемок.
+// Учитываются приемки, начатые в полуинтервале [StartDate, EndDate).
+type ReceptionStatsFilter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	GroupBy   StatsGroupBy
+}
+
+// ReceptionStatsRow - агрегаты приемок за один период для одного ПВЗ (PVZID != nil) или для города целиком (PVZID == nil).
+type ReceptionStatsRow struct {
+	Period              time.Time           `json:"period"`              // Начало периода (UTC)
+	City                City                `json:"city"`                // Город
+	PVZID               *uuid.UUID          `json:"pvzId,omitempty"`     // ПВЗ (nil для агрегата по городу)
+	ReceptionsCount     int                 `json:"receptionsCount"`     // Количество приемок, начатых в периоде
+	OpenReceptionsCount int                 `json:"openReceptionsCount"` // Из них все еще открытых
+	ProductsCount       int                 `json:"productsCount"`       // Общее количество товаров
+	ProductsByType      map[ProductType]int `json:"productsByType"`      // Количество товаров по типам
+	AvgDurationSeconds  *float64            `json:"avgDurationSeconds"`  // Средняя длительность закрытых приемок (nil, если закрытых нет)
+	P95DurationSeconds  *float64            `json:"p95DurationSeconds"`  // 95-й перцентиль длительности закрытых приемок
+}
+
+// ReceptionStats - результат расчета статистики приемок.
+type ReceptionStats struct {
+	StartDate time.Time           `json:"startDate"` // Фактическое начало диапазона (с учетом значений по умолчанию)
+	EndDate   time.Time           `json:"endDate"`   // Фактический конец диапазона
+	GroupBy   StatsGroupBy        `json:"groupBy"`   // Фактический шаг группировки
+	ByPVZ     []ReceptionStatsRow `json:"byPvz"`     // Агрегаты по ПВЗ
+	ByCity    []ReceptionStatsRow `json:"byCity"`    // Агрегаты по городам
+}
+
+// --- Выгрузка приемок ---
+
+// ExportFormat задает формат файла выгрузки.
+type ExportFormat string
+
+// Константы для форматов выгрузки.
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// IsValid проверяет, является ли строка поддерживаемым форматом выгрузки.
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportFormatCSV, ExportFormatXLSX:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReceptionExportFilter задает параметры выгрузки приемок.
+// Учитываются приемки, начатые в полуинтервале [StartDate, EndDate); nil означает отсутствие ограничения.
+type ReceptionExportFilter struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+	City      *City
+}
+
+// ReceptionExportRow - строка выгрузки: товар вместе с приемкой и ПВЗ.
+// Для приемки без товаров поля товара равны nil.
+type ReceptionExportRow struct {
+	PVZID           uuid.UUID       // ПВЗ
+	City            City            // Город ПВЗ
+	ReceptionID     uuid.UUID       // Приемка
+	ReceptionStatus ReceptionStatus // Статус приемки
+	OpenedAt        time.Time       // Время открытия приемки
+	ClosedAt        *time.Time      // Время закрытия приемки (nil для открытой)
+	ProductID       *uuid.UUID      // Товар
+	ProductType     *ProductType    // Тип товара
+	ProductDateTime *time.Time      // Время приемки товара
+}
+
+// ReceptionExportRowFunc вызывается для каждой строки выгрузки по мере чтения из БД.
+// Ошибка прерывает выгрузку.
+type ReceptionExportRowFunc func(row ReceptionExportRow) error
+
+// --- Отчеты ---
+
+// ReportType определяет вид асинхронного отчета.
+type ReportType string
+
+// Константы для видов отчетов.
+const (
+	ReportReceptionSummary ReportType = "reception_summary" // Приемки по ПВЗ и периодам
+	ReportProductBreakdown ReportType = "product_breakdown" // Товары по ПВЗ, периодам и типам
+	ReportPVZActivity      ReportType = "pvz_activity"      // Активность ПВЗ за период
+)
+
+// IsValid проверяет, является ли строка допустимым видом отчета.
+func (t ReportType) IsValid() bool {
+	switch t {
+	case ReportReceptionSummary, ReportProductBreakdown, ReportPVZActivity:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReportStatus представляет состояние задачи построения отчета.
+type ReportStatus string
+
+// Константы для состояний отчета.
+const (
+	ReportStatusPending ReportStatus = "pending" // Ожидает обработчика
+	ReportStatusRunning ReportStatus = "running" // Строится
+	ReportStatusDone    ReportStatus = "done"    // Готов к скачиванию
+	ReportStatusFailed  ReportStatus = "failed"  // Построение завершилось ошибкой
+	ReportStatusExpired ReportStatus = "expired" // Результат удален по истечении срока хранения
+)
+
+// ReportParams - параметры отчета, сохраняются вместе с задачей.
+type ReportParams struct {
+	StartDate *time.Time   `json:"startDate,omitempty"` // Начало периода (включительно)
+	EndDate   *time.Time   `json:"endDate,omitempty"`   // Конец периода (не включительно)
+	GroupBy   StatsGroupBy `json:"groupBy,omitempty"`   // Шаг группировки для отчетов по периодам
+	City      *City        `json:"city,omitempty"`      // Фильтр по городу
+}
+
+// Report представляет задачу построения отчета и ее результат.
+type Report struct {
+	ID          uuid.UUID    `json:"id"`                    // Уникальный идентификатор
+	Type        ReportType   `json:"type"`                  // Вид отчета
+	Format      ExportFormat `json:"format"`                // Формат файла результата
+	Params      ReportParams `json:"params"`                // Параметры отчета
+	Status      ReportStatus `json:"status"`                // Состояние задачи
+	RequestedBy *uuid.UUID   `json:"requestedBy,omitempty"` // Пользователь, создавший задачу
+	BlobKey     *string      `json:"-"`                     // Ключ результата в хранилище
+	Error       *string      `json:"error,omitempty"`       // Причина ошибки построения
+	Attempts    int          `json:"attempts"`              // Количество запусков построения
+	CreatedAt   time.Time    `json:"createdAt"`             // Время создания задачи
+	StartedAt   *time.Time   `json:"startedAt,omitempty"`   // Время начала последнего построения
+	FinishedAt  *time.Time   `json:"finishedAt,omitempty"`  // Время завершения построения
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`   // Время удаления результата
+}
+
+// PVZActivityFilter задает параметры отчета об активности ПВЗ.
+type PVZActivityFilter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	City      *City
+}
+
+// PVZActivityRow - активность одного ПВЗ за период. ПВЗ без приемок присутствуют с нулевыми счетчиками.
+type PVZActivityRow struct {
+	PVZID               uuid.UUID  // ПВЗ
+	City                City       // Город
+	RegistrationDate    time.Time  // Дата регистрации ПВЗ
+	ReceptionsCount     int        // Количество приемок, начатых в периоде
+	OpenReceptionsCount int        // Из них все еще открытых
+	ProductsCount       int        // Количество принятых товаров
+	LastReceptionAt     *time.Time // Время открытия последней приемки в периоде
+}
+
+// --- Ожидаемые поставки (ASN) ---
+
+// ExpectedShipmentLine - ожидаемое количество товаров одного типа в поставке.
+type ExpectedShipmentLine struct {
+	Type     ProductType `json:"type"`              // Тип товара
+	Quantity int         `json:"quantity"`          // Ожидаемое количество
+	ItemIDs  []string    `json:"itemIds,omitempty"` // Идентификаторы ожидаемых единиц (не больше Quantity)
+}
+
+// ExpectedShipment - уведомление логистики о поставке в ПВЗ. Привязывается к приемке при ее открытии
+// и сверяется с принятыми товарами при закрытии.
+type ExpectedShipment struct {
+	ID             uuid.UUID              `json:"id"`
+	PVZID          uuid.UUID              `json:"pvzId"`
+	Reference      *string                `json:"reference,omitempty"` // Номер уведомления у логистики
+	Lines          []ExpectedShipmentLine `json:"lines"`
+	CreatedBy      *uuid.UUID             `json:"createdBy,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	ReceptionID    *uuid.UUID             `json:"receptionId,omitempty"`    // Приемка, к которой привязана поставка
+	Reconciliation *Reconciliation        `json:"reconciliation,omitempty"` // Итог сверки (nil, пока приемка не закрыта)
+}
+
+// ReconciliationStatus - итог сверки приемки с поставкой.
+type ReconciliationStatus string
+
+// Константы итогов сверки.
+const (
+	ReconciliationMatched     ReconciliationStatus = "matched"     // Принято ровно то, что ожидалось
+	ReconciliationDiscrepancy ReconciliationStatus = "discrepancy" // Есть расхождения
+)
+
+// ReconciliationLine - сравнение ожидаемого и принятого количества товаров одного типа.
+type ReconciliationLine struct {
+	Type     ProductType `json:"type"`
+	Expected int         `json:"expected"`
+	Received int         `json:"received"`
+	Missing  int         `json:"missing"` // Не хватает до ожидаемого
+	Extra    int         `json:"extra"`   // Принято сверх ожидаемого
+}
+
+// ReconciliationItem - единица с идентификатором, которая есть только в поставке или только в приемке.
+type ReconciliationItem struct {
+	ItemID    string      `json:"itemId"`
+	Type      ProductType `json:"type"`
+	ProductID *uuid.UUID  `json:"productId,omitempty"` // Принятый товар (для лишних единиц)
+}
+
+// MismatchedItem - единица, принятая с другим типом, чем указано в поставке.
+type MismatchedItem struct {
+	ItemID       string      `json:"itemId"`
+	ProductID    uuid.UUID   `json:"productId"`
+	ExpectedType ProductType `json:"expectedType"`
+	ActualType   ProductType `json:"actualType"`
+}
+
+// Reconciliation - расхождения между ожидаемой поставкой и товарами приемки.
+type Reconciliation struct {
+	ShipmentID      uuid.UUID            `json:"shipmentId"`
+	ReceptionID     uuid.UUID            `json:"receptionId"`
+	Status          ReconciliationStatus `json:"status"`
+	Final           bool                 `json:"final"` // false - предварительная сверка по еще открытой приемке
+	ReconciledAt    time.Time            `json:"reconciledAt"`
+	Lines           []ReconciliationLine `json:"lines"`
+	MissingItems    []ReconciliationItem `json:"missingItems"`
+	ExtraItems      []ReconciliationItem `json:"extraItems"`
+	MismatchedItems []MismatchedItem     `json:"mismatchedItems"`
+}
+
+// --- Idempotency (Идемпотентные запросы) ---
+
+// IdempotencyStatus - состояние запроса с ключом идемпотентности.
+type IdempotencyStatus string
+
+// Константы состояний ключа идемпотентности.
+const (
+	IdempotencyInProgress IdempotencyStatus = "in_progress" // Запрос выполняется, ключ заблокирован
+	IdempotencyCompleted  IdempotencyStatus = "completed"   // Ответ сохранен и повторяется для повторных запросов
+)
+
+// IdempotencyRecord - ключ идемпотентности и сохраненный ответ на первый запрос с этим ключом.
+type IdempotencyRecord struct {
+	Scope           string            // Владелец ключа: ID пользователя или пустая строка для запросов без аутентификации
+	Key             string            // Значение заголовка Idempotency-Key
+	Method          string            // Метод первого запроса
+	Path            string            // Путь первого запроса
+	RequestHash     string            // SHA-256 метода, пути и тела первого запроса
+	Status          IdempotencyStatus // Состояние ключа
+	LockID          uuid.UUID         // Идентификатор запроса, который держит ключ
+	LockedAt        time.Time         // Когда ключ был захвачен
+	ResponseStatus  int               // HTTP статус сохраненного ответа
+	ResponseHeaders map[string]string // Сохраненные заголовки ответа (Content-Type, Location)
+	ResponseBody    []byte            // Тело сохраненного ответа
+	CreatedAt       time.Time
+	ExpiresAt       time.Time // После этого момента ключ можно использовать заново
+}
+
+// RateLimit - параметры token bucket: емкость Burst токенов, пополнение Rate токенов в секунду.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitDecision - результат попытки взять токен из bucket.
+type RateLimitDecision struct {
+	Allowed    bool          // Токен взят, запрос можно выполнять
+	Limit      int           // Емкость bucket
+	Remaining  int           // Целых токенов, оставшихся после запроса
+	ResetAfter time.Duration // Через сколько bucket заполнится полностью
+	RetryAfter time.Duration // Через сколько появится токен для отклоненного запроса
 }
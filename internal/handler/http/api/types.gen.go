@@ -13,6 +13,12 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for DispatchStatus.
+const (
+	Open DispatchStatus = "open"
+	Sent DispatchStatus = "sent"
+)
+
 // Defines values for PVZCity.
 const (
 	Казань         PVZCity = "Казань"
@@ -20,6 +26,20 @@ const (
 	СанктПетербург PVZCity = "Санкт-Петербург"
 )
 
+// Defines values for PVZStatus.
+const (
+	Active   PVZStatus = "active"
+	Inactive PVZStatus = "inactive"
+)
+
+// Defines values for ProductStatus.
+const (
+	Issued   ProductStatus = "issued"
+	Lost     ProductStatus = "lost"
+	Received ProductStatus = "received"
+	Returned ProductStatus = "returned"
+)
+
 // Defines values for ProductType.
 const (
 	ProductTypeОбувь       ProductType = "обувь"
@@ -27,12 +47,73 @@ const (
 	ProductTypeЭлектроника ProductType = "электроника"
 )
 
+// Defines values for ReceptionStaleReason.
+const (
+	ClosingTime ReceptionStaleReason = "closing_time"
+	MaxAge      ReceptionStaleReason = "max_age"
+)
+
 // Defines values for ReceptionStatus.
 const (
 	Close      ReceptionStatus = "close"
 	InProgress ReceptionStatus = "in_progress"
 )
 
+// Defines values for ReceptionStatsGroupBy.
+const (
+	ReceptionStatsGroupByDay   ReceptionStatsGroupBy = "day"
+	ReceptionStatsGroupByMonth ReceptionStatsGroupBy = "month"
+	ReceptionStatsGroupByWeek  ReceptionStatsGroupBy = "week"
+)
+
+// Defines values for ReconciliationStatus.
+const (
+	Discrepancy ReconciliationStatus = "discrepancy"
+	Matched     ReconciliationStatus = "matched"
+)
+
+// Defines values for ReportFormat.
+const (
+	ReportFormatCsv  ReportFormat = "csv"
+	ReportFormatXlsx ReportFormat = "xlsx"
+)
+
+// Defines values for ReportStatus.
+const (
+	Done    ReportStatus = "done"
+	Expired ReportStatus = "expired"
+	Failed  ReportStatus = "failed"
+	Pending ReportStatus = "pending"
+	Running ReportStatus = "running"
+)
+
+// Defines values for ReportType.
+const (
+	ReportTypeProductBreakdown ReportType = "product_breakdown"
+	ReportTypePvzActivity      ReportType = "pvz_activity"
+	ReportTypeReceptionSummary ReportType = "reception_summary"
+)
+
+// Defines values for ReportParamsGroupBy.
+const (
+	ReportParamsGroupByDay   ReportParamsGroupBy = "day"
+	ReportParamsGroupByMonth ReportParamsGroupBy = "month"
+	ReportParamsGroupByWeek  ReportParamsGroupBy = "week"
+)
+
+// Defines values for ReportRequestFormat.
+const (
+	ReportRequestFormatCsv  ReportRequestFormat = "csv"
+	ReportRequestFormatXlsx ReportRequestFormat = "xlsx"
+)
+
+// Defines values for ReportRequestType.
+const (
+	ReportRequestTypeProductBreakdown ReportRequestType = "product_breakdown"
+	ReportRequestTypePvzActivity      ReportRequestType = "pvz_activity"
+	ReportRequestTypeReceptionSummary ReportRequestType = "reception_summary"
+)
+
 // Defines values for UserRole.
 const (
 	UserRoleEmployee  UserRole = "employee"
@@ -45,6 +126,12 @@ const (
 	PostDummyLoginJSONBodyRoleModerator PostDummyLoginJSONBodyRole = "moderator"
 )
 
+// Defines values for GetExportsReceptionsParamsFormat.
+const (
+	Csv  GetExportsReceptionsParamsFormat = "csv"
+	Xlsx GetExportsReceptionsParamsFormat = "xlsx"
+)
+
 // Defines values for PostProductsJSONBodyType.
 const (
 	PostProductsJSONBodyTypeОбувь       PostProductsJSONBodyType = "обувь"
@@ -52,52 +139,404 @@ const (
 	PostProductsJSONBodyTypeЭлектроника PostProductsJSONBodyType = "электроника"
 )
 
+// Defines values for GetPvzParamsSortBy.
+const (
+	LastReception    GetPvzParamsSortBy = "last_reception"
+	ProductCount     GetPvzParamsSortBy = "product_count"
+	RegistrationDate GetPvzParamsSortBy = "registration_date"
+)
+
+// Defines values for GetPvzParamsSortOrder.
+const (
+	Asc  GetPvzParamsSortOrder = "asc"
+	Desc GetPvzParamsSortOrder = "desc"
+)
+
 // Defines values for PostRegisterJSONBodyRole.
 const (
 	Employee  PostRegisterJSONBodyRole = "employee"
 	Moderator PostRegisterJSONBodyRole = "moderator"
 )
 
-// Error defines model for Error.
+// Defines values for GetStatsReceptionsParamsGroupBy.
+const (
+	Day   GetStatsReceptionsParamsGroupBy = "day"
+	Month GetStatsReceptionsParamsGroupBy = "month"
+	Week  GetStatsReceptionsParamsGroupBy = "week"
+)
+
+// Dispatch Партия возвращаемых на склад товаров ПВЗ
+type Dispatch struct {
+	CreatedAt time.Time           `json:"createdAt"`
+	CreatedBy *openapi_types.UUID `json:"createdBy,omitempty"`
+	Id        openapi_types.UUID  `json:"id"`
+	Products  []Product           `json:"products"`
+	PvzId     openapi_types.UUID  `json:"pvzId"`
+	SentAt    *time.Time          `json:"sentAt,omitempty"`
+	SentBy    *openapi_types.UUID `json:"sentBy,omitempty"`
+	Status    DispatchStatus      `json:"status"`
+}
+
+// DispatchStatus defines model for Dispatch.Status.
+type DispatchStatus string
+
+// Error Описание ошибки в формате RFC 7807 (Content-Type application/problem+json). Клиенты должны различать ошибки по стабильному полю code, а не по тексту detail.
 type Error struct {
+	// Code Стабильный машиночитаемый код ошибки (например, reception_in_progress, no_open_reception)
+	Code string `json:"code"`
+
+	// Detail Описание конкретного случая ошибки
+	Detail string `json:"detail"`
+
+	// Errors Ошибки отдельных полей (только для code=validation_failed)
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Request ID запроса, в котором произошла ошибка
+	Instance *string `json:"instance,omitempty"`
+
+	// Message Совпадает с detail, сохранено для обратной совместимости
+	// Deprecated:
+	Message string `json:"message"`
+
+	// Status HTTP статус ответа
+	Status int `json:"status"`
+
+	// Title Краткое описание типа ошибки
+	Title string `json:"title"`
+
+	// Type URI типа ошибки (urn:problem-type:pvz-service:<code>)
+	Type string `json:"type"`
+}
+
+// ExpectedShipment defines model for ExpectedShipment.
+type ExpectedShipment struct {
+	CreatedAt time.Time              `json:"createdAt"`
+	CreatedBy *openapi_types.UUID    `json:"createdBy,omitempty"`
+	Id        openapi_types.UUID     `json:"id"`
+	Lines     []ExpectedShipmentLine `json:"lines"`
+	PvzId     openapi_types.UUID     `json:"pvzId"`
+
+	// ReceptionId Приемка, к которой привязана поставка (отсутствует, пока не привязана)
+	ReceptionId *openapi_types.UUID `json:"receptionId,omitempty"`
+
+	// Reconciliation Расхождения между ожидаемой поставкой и товарами приемки
+	Reconciliation *Reconciliation `json:"reconciliation,omitempty"`
+
+	// Reference Номер уведомления об отгрузке у логистики
+	Reference *string `json:"reference,omitempty"`
+}
+
+// ExpectedShipmentLine defines model for ExpectedShipmentLine.
+type ExpectedShipmentLine struct {
+	// ItemIds Идентификаторы ожидаемых единиц (не больше quantity, уникальны в пределах поставки)
+	ItemIds  *[]string `json:"itemIds,omitempty"`
+	Quantity int       `json:"quantity"`
+
+	// Type Тип товара (электроника, одежда, обувь)
+	Type string `json:"type"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Имя поля или параметра запроса
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
+// MismatchedItem defines model for MismatchedItem.
+type MismatchedItem struct {
+	ActualType   string             `json:"actualType"`
+	ExpectedType string             `json:"expectedType"`
+	ItemId       string             `json:"itemId"`
+	ProductId    openapi_types.UUID `json:"productId"`
+}
+
 // PVZ defines model for PVZ.
 type PVZ struct {
 	City             PVZCity             `json:"city"`
 	Id               *openapi_types.UUID `json:"id,omitempty"`
 	RegistrationDate *time.Time          `json:"registrationDate,omitempty"`
+
+	// Status Состояние ПВЗ (задается сервером, новые ПВЗ создаются в статусе active)
+	Status *PVZStatus `json:"status,omitempty"`
+
+	// Version Версия ПВЗ, увеличивается при каждом изменении (совпадает со значением ETag)
+	Version *int64 `json:"version,omitempty"`
 }
 
 // PVZCity defines model for PVZ.City.
 type PVZCity string
 
+// PVZCapacity Вместимость ПВЗ. Отсутствующие поля снимают соответствующие ограничения
+type PVZCapacity struct {
+	// ByType Вместимость по типам товаров (электроника, одежда, обувь)
+	ByType *map[string]int `json:"byType,omitempty"`
+
+	// Total Общая вместимость ПВЗ
+	Total *int `json:"total,omitempty"`
+}
+
+// PVZInventory Текущие остатки ПВЗ - принятые и еще не выданные, не возвращенные и не утерянные товары
+type PVZInventory struct {
+	// ByType Количество товаров по типам
+	ByType map[string]int `json:"byType"`
+	OnHand int            `json:"onHand"`
+}
+
+// PVZOccupancy Текущая заполненность ПВЗ - товары в статусе received
+type PVZOccupancy struct {
+	ByType map[string]TypeOccupancy `json:"byType"`
+
+	// Capacity Общая вместимость (отсутствует, если не ограничена)
+	Capacity *int               `json:"capacity,omitempty"`
+	Occupied int                `json:"occupied"`
+	PvzId    openapi_types.UUID `json:"pvzId"`
+}
+
+// PVZStatus Состояние ПВЗ (задается сервером, новые ПВЗ создаются в статусе active)
+type PVZStatus string
+
+// PVZSummary Сводка по приемкам ПВЗ за период startDate/endDate
+type PVZSummary struct {
+	// LastReceptionAt Время последней приемки (отсутствует, если приемок не было)
+	LastReceptionAt     *time.Time `json:"lastReceptionAt,omitempty"`
+	OpenReceptionsCount int        `json:"openReceptionsCount"`
+	ProductsCount       int        `json:"productsCount"`
+	ReceptionsCount     int        `json:"receptionsCount"`
+}
+
 // Product defines model for Product.
 type Product struct {
-	DateTime    *time.Time          `json:"dateTime,omitempty"`
-	Id          *openapi_types.UUID `json:"id,omitempty"`
-	ReceptionId openapi_types.UUID  `json:"receptionId"`
-	Type        ProductType         `json:"type"`
+	DateTime *time.Time `json:"dateTime,omitempty"`
+
+	// DispatchId Отправка, в которую включен возвращенный товар
+	DispatchId *openapi_types.UUID `json:"dispatchId,omitempty"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+
+	// ItemId Идентификатор единицы из поставки (штрихкод)
+	ItemId      *string            `json:"itemId,omitempty"`
+	ReceptionId openapi_types.UUID `json:"receptionId"`
+
+	// Status Состояние товара
+	Status *ProductStatus `json:"status,omitempty"`
+
+	// StatusChangedAt Время выдачи, возврата или списания (отсутствует, пока товар в ПВЗ)
+	StatusChangedAt *time.Time `json:"statusChangedAt,omitempty"`
+
+	// StatusChangedBy Пользователь, изменивший состояние товара
+	StatusChangedBy *openapi_types.UUID `json:"statusChangedBy,omitempty"`
+	Type            ProductType         `json:"type"`
 }
 
+// ProductStatus Состояние товара
+type ProductStatus string
+
 // ProductType defines model for Product.Type.
 type ProductType string
 
 // Reception defines model for Reception.
 type Reception struct {
+	// AutoClosed Приемка закрыта автоматически планировщиком забытых приемок
+	AutoClosed *bool `json:"autoClosed,omitempty"`
+
+	// ClosedAt Дата и время закрытия (отсутствует, пока приемка активна)
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+
+	// ClosedBy ID сотрудника, закрывшего приемку
+	ClosedBy *openapi_types.UUID `json:"closedBy,omitempty"`
 	DateTime time.Time           `json:"dateTime"`
-	Id       *openapi_types.UUID `json:"id,omitempty"`
+
+	// ExpectedShipmentId Ожидаемая поставка, привязанная при открытии приемки
+	ExpectedShipmentId *openapi_types.UUID `json:"expectedShipmentId,omitempty"`
+	Id                 *openapi_types.UUID `json:"id,omitempty"`
+
+	// OpenedBy ID сотрудника, открывшего приемку
+	OpenedBy *openapi_types.UUID `json:"openedBy,omitempty"`
 	PvzId    openapi_types.UUID  `json:"pvzId"`
-	Status   ReceptionStatus     `json:"status"`
+
+	// StaleReason Причина, по которой приемка признана забытой (открыта дольше допустимого или не закрыта до окончания рабочего дня ПВЗ)
+	StaleReason *ReceptionStaleReason `json:"staleReason,omitempty"`
+	Status      ReceptionStatus       `json:"status"`
+
+	// Version Версия приемки, увеличивается при каждом изменении приемки и ее товаров (совпадает со значением ETag)
+	Version *int64 `json:"version,omitempty"`
 }
 
+// ReceptionStaleReason Причина, по которой приемка признана забытой (открыта дольше допустимого или не закрыта до окончания рабочего дня ПВЗ)
+type ReceptionStaleReason string
+
 // ReceptionStatus defines model for Reception.Status.
 type ReceptionStatus string
 
+// ReceptionDetails defines model for ReceptionDetails.
+type ReceptionDetails struct {
+	// ProductCounts Количество товаров по типам (электроника, одежда, обувь); отсутствующие типы не включаются
+	ProductCounts map[string]int `json:"productCounts"`
+
+	// Products Товары в порядке добавления
+	Products  []Product `json:"products"`
+	Reception Reception `json:"reception"`
+}
+
+// ReceptionStats defines model for ReceptionStats.
+type ReceptionStats struct {
+	Cities    []ReceptionStatsItem  `json:"cities"`
+	EndDate   time.Time             `json:"endDate"`
+	GroupBy   ReceptionStatsGroupBy `json:"groupBy"`
+	Pvz       []ReceptionStatsItem  `json:"pvz"`
+	StartDate time.Time             `json:"startDate"`
+}
+
+// ReceptionStatsGroupBy defines model for ReceptionStats.GroupBy.
+type ReceptionStatsGroupBy string
+
+// ReceptionStatsItem Агрегаты приемок за период по ПВЗ (pvzId заполнен) или по городу (pvzId отсутствует)
+type ReceptionStatsItem struct {
+	// AvgDurationSeconds Средняя длительность закрытых приемок (от открытия до закрытия) в секундах
+	AvgDurationSeconds *float64 `json:"avgDurationSeconds"`
+
+	// City Город ПВЗ (Москва, Санкт-Петербург, Казань)
+	City string `json:"city"`
+
+	// OpenReceptionsCount Количество приемок из периода, которые все еще открыты
+	OpenReceptionsCount int `json:"openReceptionsCount"`
+
+	// P95DurationSeconds 95-й перцентиль длительности закрытых приемок в секундах
+	P95DurationSeconds *float64 `json:"p95DurationSeconds"`
+
+	// Period Начало периода (UTC)
+	Period time.Time `json:"period"`
+
+	// ProductsByType Количество товаров по типам (электроника, одежда, обувь)
+	ProductsByType map[string]int      `json:"productsByType"`
+	ProductsCount  int                 `json:"productsCount"`
+	PvzId          *openapi_types.UUID `json:"pvzId,omitempty"`
+
+	// ReceptionsCount Количество приемок, начатых в периоде
+	ReceptionsCount int `json:"receptionsCount"`
+}
+
+// Reconciliation Расхождения между ожидаемой поставкой и товарами приемки
+type Reconciliation struct {
+	// ExtraItems Принятые единицы, которых нет в поставке
+	ExtraItems []ReconciliationItem `json:"extraItems"`
+
+	// Final false - предварительная сверка по еще открытой приемке
+	Final bool                 `json:"final"`
+	Lines []ReconciliationLine `json:"lines"`
+
+	// MismatchedItems Единицы, принятые с другим типом
+	MismatchedItems []MismatchedItem `json:"mismatchedItems"`
+
+	// MissingItems Ожидаемые единицы, которые не были приняты
+	MissingItems []ReconciliationItem `json:"missingItems"`
+	ReceptionId  openapi_types.UUID   `json:"receptionId"`
+	ReconciledAt time.Time            `json:"reconciledAt"`
+	ShipmentId   openapi_types.UUID   `json:"shipmentId"`
+	Status       ReconciliationStatus `json:"status"`
+}
+
+// ReconciliationStatus defines model for Reconciliation.Status.
+type ReconciliationStatus string
+
+// ReconciliationItem defines model for ReconciliationItem.
+type ReconciliationItem struct {
+	ItemId string `json:"itemId"`
+
+	// ProductId Принятый товар (только для лишних единиц)
+	ProductId *openapi_types.UUID `json:"productId,omitempty"`
+	Type      string              `json:"type"`
+}
+
+// ReconciliationLine defines model for ReconciliationLine.
+type ReconciliationLine struct {
+	Expected int `json:"expected"`
+
+	// Extra Принято сверх ожидаемого количества
+	Extra int `json:"extra"`
+
+	// Missing Не хватает до ожидаемого количества
+	Missing  int    `json:"missing"`
+	Received int    `json:"received"`
+	Type     string `json:"type"`
+}
+
+// Report defines model for Report.
+type Report struct {
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DownloadUrl Ссылка на скачивание (только для status = done)
+	DownloadUrl *string `json:"downloadUrl,omitempty"`
+
+	// Error Причина ошибки (для status = failed)
+	Error *string `json:"error,omitempty"`
+
+	// ExpiresAt Время удаления результата
+	ExpiresAt  *time.Time         `json:"expiresAt,omitempty"`
+	FinishedAt *time.Time         `json:"finishedAt,omitempty"`
+	Format     ReportFormat       `json:"format"`
+	Id         openapi_types.UUID `json:"id"`
+
+	// Params Параметры отчета
+	Params    ReportParams `json:"params"`
+	StartedAt *time.Time   `json:"startedAt,omitempty"`
+	Status    ReportStatus `json:"status"`
+	Type      ReportType   `json:"type"`
+}
+
+// ReportFormat defines model for Report.Format.
+type ReportFormat string
+
+// ReportStatus defines model for Report.Status.
+type ReportStatus string
+
+// ReportType defines model for Report.Type.
+type ReportType string
+
+// ReportParams Параметры отчета
+type ReportParams struct {
+	// City Город ПВЗ (Москва, Санкт-Петербург, Казань)
+	City *string `json:"city,omitempty"`
+
+	// EndDate Конец периода (не включительно). По умолчанию - момент построения
+	EndDate *time.Time `json:"endDate,omitempty"`
+
+	// GroupBy Шаг группировки (для reception_summary и product_breakdown)
+	GroupBy *ReportParamsGroupBy `json:"groupBy,omitempty"`
+
+	// StartDate Начало периода (включительно). По умолчанию - за 30 дней до endDate
+	StartDate *time.Time `json:"startDate,omitempty"`
+}
+
+// ReportParamsGroupBy Шаг группировки (для reception_summary и product_breakdown)
+type ReportParamsGroupBy string
+
+// ReportRequest defines model for ReportRequest.
+type ReportRequest struct {
+	Format *ReportRequestFormat `json:"format,omitempty"`
+
+	// Params Параметры отчета
+	Params *ReportParams     `json:"params,omitempty"`
+	Type   ReportRequestType `json:"type"`
+}
+
+// ReportRequestFormat defines model for ReportRequest.Format.
+type ReportRequestFormat string
+
+// ReportRequestType defines model for ReportRequest.Type.
+type ReportRequestType string
+
 // Token defines model for Token.
 type Token = string
 
+// TypeOccupancy defines model for TypeOccupancy.
+type TypeOccupancy struct {
+	// Capacity Вместимость для типа (отсутствует, если тип отдельно не ограничен)
+	Capacity *int `json:"capacity,omitempty"`
+	Occupied int  `json:"occupied"`
+}
+
 // User defines model for User.
 type User struct {
 	Email openapi_types.Email `json:"email"`
@@ -108,6 +547,30 @@ type User struct {
 // UserRole defines model for User.Role.
 type UserRole string
 
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// IdempotencyKeyReused Описание ошибки в формате RFC 7807 (Content-Type application/problem+json). Клиенты должны различать ошибки по стабильному полю code, а не по тексту detail.
+type IdempotencyKeyReused = Error
+
+// PreconditionFailed Описание ошибки в формате RFC 7807 (Content-Type application/problem+json). Клиенты должны различать ошибки по стабильному полю code, а не по тексту detail.
+type PreconditionFailed = Error
+
+// TooManyRequests Описание ошибки в формате RFC 7807 (Content-Type application/problem+json). Клиенты должны различать ошибки по стабильному полю code, а не по тексту detail.
+type TooManyRequests = Error
+
+// PostDispatchesDispatchIdSendParams defines parameters for PostDispatchesDispatchIdSend.
+type PostDispatchesDispatchIdSendParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // PostDummyLoginJSONBody defines parameters for PostDummyLogin.
 type PostDummyLoginJSONBody struct {
 	Role PostDummyLoginJSONBodyRole `json:"role"`
@@ -116,6 +579,37 @@ type PostDummyLoginJSONBody struct {
 // PostDummyLoginJSONBodyRole defines parameters for PostDummyLogin.
 type PostDummyLoginJSONBodyRole string
 
+// PostExpectedShipmentsJSONBody defines parameters for PostExpectedShipments.
+type PostExpectedShipmentsJSONBody struct {
+	Lines     []ExpectedShipmentLine `json:"lines"`
+	PvzId     openapi_types.UUID     `json:"pvzId"`
+	Reference *string                `json:"reference,omitempty"`
+}
+
+// PostExpectedShipmentsParams defines parameters for PostExpectedShipments.
+type PostExpectedShipmentsParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetExportsReceptionsParams defines parameters for GetExportsReceptions.
+type GetExportsReceptionsParams struct {
+	// Format Формат файла
+	Format *GetExportsReceptionsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// StartDate Начало диапазона по дате открытия приемки (включительно)
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конец диапазона по дате открытия приемки (не включительно)
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// City Город ПВЗ (Москва, Санкт-Петербург, Казань)
+	City *string `form:"city,omitempty" json:"city,omitempty"`
+}
+
+// GetExportsReceptionsParamsFormat defines parameters for GetExportsReceptions.
+type GetExportsReceptionsParamsFormat string
+
 // PostLoginJSONBody defines parameters for PostLogin.
 type PostLoginJSONBody struct {
 	Email    openapi_types.Email `json:"email"`
@@ -124,13 +618,39 @@ type PostLoginJSONBody struct {
 
 // PostProductsJSONBody defines parameters for PostProducts.
 type PostProductsJSONBody struct {
-	PvzId openapi_types.UUID       `json:"pvzId"`
-	Type  PostProductsJSONBodyType `json:"type"`
+	// ItemId Идентификатор единицы (штрихкод) для сверки с ожидаемой поставкой
+	ItemId *string                  `json:"itemId,omitempty"`
+	PvzId  openapi_types.UUID       `json:"pvzId"`
+	Type   PostProductsJSONBodyType `json:"type"`
+}
+
+// PostProductsParams defines parameters for PostProducts.
+type PostProductsParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
 }
 
 // PostProductsJSONBodyType defines parameters for PostProducts.
 type PostProductsJSONBodyType string
 
+// PostProductsProductIdIssueParams defines parameters for PostProductsProductIdIssue.
+type PostProductsProductIdIssueParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostProductsProductIdMarkLostParams defines parameters for PostProductsProductIdMarkLost.
+type PostProductsProductIdMarkLostParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostProductsProductIdReturnParams defines parameters for PostProductsProductIdReturn.
+type PostProductsProductIdReturnParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // GetPvzParams defines parameters for GetPvz.
 type GetPvzParams struct {
 	// StartDate Начальная дата диапазона
@@ -139,16 +659,140 @@ type GetPvzParams struct {
 	// EndDate Конечная дата диапазона
 	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
 
-	// Page Номер страницы
+	// Page Номер страницы (постраничная пагинация, не совместим с cursor)
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
-	// Limit Количество элементов на странице
+	// Limit Количество элементов на странице (максимум 30 для page, 100 для cursor)
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Значение nextCursor из предыдущего ответа (keyset-пагинация по дате регистрации и ID)
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// IncludeTotal Возвращать ли общее количество ПВЗ. По умолчанию true без cursor и false с cursor
+	IncludeTotal *bool `form:"includeTotal,omitempty" json:"includeTotal,omitempty"`
+
+	// City Город ПВЗ (Москва, Санкт-Петербург, Казань). Можно повторять или перечислять через запятую
+	City *[]string `form:"city,omitempty" json:"city,omitempty"`
+
+	// Status Статус ПВЗ
+	Status *PVZStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// HasOpenReception Только ПВЗ с незакрытой приемкой (true) или без нее (false)
+	HasOpenReception *bool `form:"hasOpenReception,omitempty" json:"hasOpenReception,omitempty"`
+
+	// ProductType Только ПВЗ, принявшие товар этого типа (электроника, одежда, обувь) за период startDate/endDate
+	ProductType *string `form:"productType,omitempty" json:"productType,omitempty"`
+
+	// MinProducts Минимальное количество товаров, принятых за период startDate/endDate
+	MinProducts *int `form:"minProducts,omitempty" json:"minProducts,omitempty"`
+
+	// SortBy Поле сортировки. cursor поддерживается только для registration_date
+	SortBy *GetPvzParamsSortBy `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Направление сортировки. cursor принимается только с тем же направлением, с которым был выдан
+	SortOrder *GetPvzParamsSortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+
+	// Include Встраиваемые данные: receptions (приемки за период) и/или products (товары приемок, подразумевает receptions). Можно повторять или перечислять через запятую. По умолчанию возвращается только сводка
+	Include *[]string `form:"include,omitempty" json:"include,omitempty"`
+
+	// ReceptionsLimit Максимум встраиваемых приемок (самых свежих) на один ПВЗ, учитывается только с include
+	ReceptionsLimit *int `form:"receptionsLimit,omitempty" json:"receptionsLimit,omitempty"`
+}
+
+// GetPvzParamsSortBy defines parameters for GetPvz.
+type GetPvzParamsSortBy string
+
+// GetPvzParamsSortOrder defines parameters for GetPvz.
+type GetPvzParamsSortOrder string
+
+// PostPvzParams defines parameters for PostPvz.
+type PostPvzParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetPvzPvzIdParams defines parameters for GetPvzPvzId.
+type GetPvzPvzIdParams struct {
+	// IfNoneMatch ETag ранее полученного ответа. Если ресурс не изменился, возвращается 304 без тела.
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PutPvzPvzIdCapacityParams defines parameters for PutPvzPvzIdCapacity.
+type PutPvzPvzIdCapacityParams struct {
+	// IfMatch ETag ресурса, полученный при чтении. Изменение выполняется, только если ресурс не изменился с тех пор, иначе возвращается 412. Без заголовка (или со значением *) изменение выполняется безусловно.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostPvzPvzIdCloseLastReceptionParams defines parameters for PostPvzPvzIdCloseLastReception.
+type PostPvzPvzIdCloseLastReceptionParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag ресурса, полученный при чтении. Изменение выполняется, только если ресурс не изменился с тех пор, иначе возвращается 412. Без заголовка (или со значением *) изменение выполняется безусловно.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostPvzPvzIdDeleteLastProductParams defines parameters for PostPvzPvzIdDeleteLastProduct.
+type PostPvzPvzIdDeleteLastProductParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetPvzPvzIdReceptionsParams defines parameters for GetPvzPvzIdReceptions.
+type GetPvzPvzIdReceptionsParams struct {
+	// StartDate Начальная дата диапазона
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конечная дата диапазона
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Status Статус приемки (in_progress, close)
+	Status *string `form:"status,omitempty" json:"status,omitempty"`
+
+	// OpenedBy ID сотрудника, открывшего приемку
+	OpenedBy *openapi_types.UUID `form:"openedBy,omitempty" json:"openedBy,omitempty"`
+
+	// ClosedBy ID сотрудника, закрывшего приемку
+	ClosedBy *openapi_types.UUID `form:"closedBy,omitempty" json:"closedBy,omitempty"`
+
+	// ClosedFrom Нижняя граница времени закрытия (незакрытые приемки не возвращаются)
+	ClosedFrom *time.Time `form:"closedFrom,omitempty" json:"closedFrom,omitempty"`
+
+	// ClosedTo Верхняя граница времени закрытия (незакрытые приемки не возвращаются)
+	ClosedTo *time.Time `form:"closedTo,omitempty" json:"closedTo,omitempty"`
+	Page     *int       `form:"page,omitempty" json:"page,omitempty"`
+	Limit    *int       `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPvzPvzIdReceptionsCurrentParams defines parameters for GetPvzPvzIdReceptionsCurrent.
+type GetPvzPvzIdReceptionsCurrentParams struct {
+	// IfNoneMatch ETag ранее полученного ответа. Если ресурс не изменился, возвращается 304 без тела.
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
 }
 
 // PostReceptionsJSONBody defines parameters for PostReceptions.
 type PostReceptionsJSONBody struct {
-	PvzId openapi_types.UUID `json:"pvzId"`
+	// ExpectedShipmentId Ожидаемая поставка этого ПВЗ, которая привязывается к приемке
+	ExpectedShipmentId *openapi_types.UUID `json:"expectedShipmentId,omitempty"`
+	PvzId              openapi_types.UUID  `json:"pvzId"`
+}
+
+// PostReceptionsParams defines parameters for PostReceptions.
+type PostReceptionsParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetReceptionsReceptionIdParams defines parameters for GetReceptionsReceptionId.
+type GetReceptionsReceptionIdParams struct {
+	// IfNoneMatch ETag ранее полученного ответа. Если ресурс не изменился, возвращается 304 без тела.
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetReceptionsReceptionIdProductsParams defines parameters for GetReceptionsReceptionIdProducts.
+type GetReceptionsReceptionIdProductsParams struct {
+	Page  *int `form:"page,omitempty" json:"page,omitempty"`
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // PostRegisterJSONBody defines parameters for PostRegister.
@@ -161,9 +805,33 @@ type PostRegisterJSONBody struct {
 // PostRegisterJSONBodyRole defines parameters for PostRegister.
 type PostRegisterJSONBodyRole string
 
+// PostReportsParams defines parameters for PostReports.
+type PostReportsParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ (кроме 5xx) сохраняется на срок idempotency.ttl и возвращается для повторов с тем же ключом и тем же запросом (с заголовком Idempotent-Replayed). Повтор во время выполнения первого запроса ждет его завершения, затем получает 409. Ключи разделены по пользователям.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetStatsReceptionsParams defines parameters for GetStatsReceptions.
+type GetStatsReceptionsParams struct {
+	// StartDate Начало диапазона (включительно). По умолчанию - за 30 дней до endDate
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конец диапазона (не включительно). По умолчанию - текущий момент
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// GroupBy Шаг группировки по времени
+	GroupBy *GetStatsReceptionsParamsGroupBy `form:"groupBy,omitempty" json:"groupBy,omitempty"`
+}
+
+// GetStatsReceptionsParamsGroupBy defines parameters for GetStatsReceptions.
+type GetStatsReceptionsParamsGroupBy string
+
 // PostDummyLoginJSONRequestBody defines body for PostDummyLogin for application/json ContentType.
 type PostDummyLoginJSONRequestBody PostDummyLoginJSONBody
 
+// PostExpectedShipmentsJSONRequestBody defines body for PostExpectedShipments for application/json ContentType.
+type PostExpectedShipmentsJSONRequestBody PostExpectedShipmentsJSONBody
+
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody PostLoginJSONBody
 
@@ -173,8 +841,14 @@ type PostProductsJSONRequestBody PostProductsJSONBody
 // PostPvzJSONRequestBody defines body for PostPvz for application/json ContentType.
 type PostPvzJSONRequestBody = PVZ
 
+// PutPvzPvzIdCapacityJSONRequestBody defines body for PutPvzPvzIdCapacity for application/json ContentType.
+type PutPvzPvzIdCapacityJSONRequestBody = PVZCapacity
+
 // PostReceptionsJSONRequestBody defines body for PostReceptions for application/json ContentType.
 type PostReceptionsJSONRequestBody PostReceptionsJSONBody
 
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody PostRegisterJSONBody
+
+// PostReportsJSONRequestBody defines body for PostReports for application/json ContentType.
+type PostReportsJSONRequestBody = ReportRequest
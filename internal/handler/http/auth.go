@@ -2,7 +2,6 @@ package http
 
 import (
 	"errors"
-	"fmt"
 	"log/slog"
 	"net/http"
 	"pvz-service-avito-internship/pkg/validator"
@@ -17,12 +16,14 @@ import (
 type AuthHandler struct {
 	BaseHandler
 	authService domain.AuthService
+	validator   *validator.CustomValidator
 }
 
-func NewAuthHandler(log *slog.Logger, authService domain.AuthService) *AuthHandler {
+func NewAuthHandler(log *slog.Logger, authService domain.AuthService, validator *validator.CustomValidator) *AuthHandler {
 	return &AuthHandler{
 		BaseHandler: *NewBaseHandler(log),
 		authService: authService,
+		validator:   validator,
 	}
 }
 
@@ -35,7 +36,7 @@ func (h *AuthHandler) PostDummyLogin(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Warn("Failed to bind request", slog.String("error", err.Error()))
-		response.SendError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+		h.handleError(c, op, bindError(err))
 		return
 	}
 
@@ -61,22 +62,21 @@ func (h *AuthHandler) PostRegister(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Warn("Failed to bind request", slog.String("error", err.Error()))
-		response.SendError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+		h.handleError(c, op, bindError(err))
 		return
 	}
 
 	type loginValidation struct {
-		Password string `validate:"required,password"`
+		Password string `json:"password" validate:"required,password"`
 	}
 
 	validateStruct := loginValidation{
 		Password: reqBody.Password,
 	}
 
-	customValidator := validator.NewCustomValidator()
-	if err := customValidator.Validate(&validateStruct); err != nil {
+	if err := h.validator.Validate(&validateStruct); err != nil {
 		log.Warn("Failed to validate request", slog.String("error", err.Error()))
-		response.SendError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request data: %s", err.Error()))
+		h.handleError(c, op, validationError(err))
 		return
 	}
 
@@ -104,22 +104,23 @@ func (h *AuthHandler) PostLogin(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Warn("Failed to bind request", slog.String("error", err.Error()))
-		response.SendError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+		h.handleError(c, op, bindError(err))
 		return
 	}
 
+	// Политика паролей проверяется только при регистрации: ее ужесточение не должно блокировать вход
+	// существующих пользователей и раскрывать, что пароль попал в список скомпрометированных.
 	type loginValidation struct {
-		Password string `validate:"required,password"`
+		Password string `json:"password" validate:"required"`
 	}
 
 	validateStruct := loginValidation{
 		Password: reqBody.Password,
 	}
 
-	customValidator := validator.NewCustomValidator()
-	if err := customValidator.Validate(&validateStruct); err != nil {
+	if err := h.validator.Validate(&validateStruct); err != nil {
 		log.Warn("Failed to validate request", slog.String("error", err.Error()))
-		response.SendError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request data: %s", err.Error()))
+		h.handleError(c, op, validationError(err))
 		return
 	}
 
@@ -129,7 +130,7 @@ func (h *AuthHandler) PostLogin(c *gin.Context) {
 	token, err := h.authService.Login(c.Request.Context(), emailStr, reqBody.Password)
 	if err != nil {
 		if errors.Is(err, domain.ErrPassIsRequired) {
-			response.SendError(c, http.StatusBadRequest, domain.ErrPassIsRequired.Error())
+			h.handleError(c, op, domain.NewRuleError("password", "required"))
 			return
 		}
 
@@ -9,11 +9,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"pvz-service-avito-internship/internal/domain"
 	httpHandler "pvz-service-avito-internship/internal/handler/http"
+	"pvz-service-avito-internship/pkg/validator"
 	"testing"
 )
 
@@ -53,7 +55,7 @@ func TestIntegration_PostProducts(t *testing.T) {
 			Type: domain.ProductType("электроника"),
 		}
 
-		mockService.On("AddProduct", mock.Anything, uuid.MustParse(pvzID), domain.ProductType("электроника")).
+		mockService.On("AddProduct", mock.Anything, uuid.MustParse(pvzID), domain.ProductType("электроника"), (*string)(nil)).
 			Return(product, nil)
 
 		body := map[string]interface{}{
@@ -87,14 +89,14 @@ func TestAuthHandler_PostRegister(t *testing.T) {
 	logger := slog.Default()
 
 	mockAuthService := new(MockAuthService)
-	handler := httpHandler.NewAuthHandler(logger, mockAuthService)
+	handler := httpHandler.NewAuthHandler(logger, mockAuthService, validator.NewCustomValidator(validator.DefaultPasswordPolicy()))
 
 	router := gin.New()
 	router.POST("/register", handler.PostRegister)
 
 	t.Run("успешная регистрация", func(t *testing.T) {
 		mockAuthService.
-			On("Register", mock.Anything, "test@example.com", "password123", domain.UserRole("user")).
+			On("Register", mock.Anything, "test@example.com", "Password123!", domain.UserRole("user")).
 			Return(&domain.User{
 				ID:   uuid.New(),
 				Role: "user",
@@ -102,7 +104,7 @@ func TestAuthHandler_PostRegister(t *testing.T) {
 
 		body := map[string]interface{}{
 			"email":    "test@example.com",
-			"password": "password123",
+			"password": "Password123!",
 			"role":     "user",
 		}
 		bodyBytes, _ := json.Marshal(body)
@@ -125,6 +127,19 @@ func TestAuthHandler_PostRegister(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
+
+	t.Run("ошибка при коротком пароле", func(t *testing.T) {
+		bodyBytes, _ := json.Marshal(map[string]any{"email": "short@example.com", "password": "123", "role": "employee"})
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"password"`)
+		mockAuthService.AssertNotCalled(t, "Register", mock.Anything, "short@example.com", mock.Anything, mock.Anything)
+	})
 }
 
 func TestAuthHandler_PostLogin(t *testing.T) {
@@ -132,7 +147,7 @@ func TestAuthHandler_PostLogin(t *testing.T) {
 	logger := slog.Default()
 
 	mockAuthService := new(MockAuthService)
-	handler := httpHandler.NewAuthHandler(logger, mockAuthService)
+	handler := httpHandler.NewAuthHandler(logger, mockAuthService, validator.NewCustomValidator(validator.DefaultPasswordPolicy()))
 
 	router := gin.New()
 	router.POST("/login", handler.PostLogin)
@@ -191,7 +206,7 @@ func TestAuthHandler_PostLogin(t *testing.T) {
 
 	t.Run("ошибка при пустом теле запроса", func(t *testing.T) {
 		mockAuthService := new(MockAuthService)
-		handler := httpHandler.NewAuthHandler(logger, mockAuthService)
+		handler := httpHandler.NewAuthHandler(logger, mockAuthService, validator.NewCustomValidator(validator.DefaultPasswordPolicy()))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -219,6 +234,42 @@ func TestAuthHandler_PostLogin(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.JSONEq(t, `{"message":"password is required"}`, w.Body.String())
+		var problem struct {
+			Code   string `json:"code"`
+			Status int    `json:"status"`
+			Errors []struct {
+				Field string `json:"field"`
+			} `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "validation_failed", problem.Code)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "password", problem.Errors[0].Field)
 	})
 }
+
+func TestAuthHandler_PostLogin_IgnoresPasswordPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	policy := validator.DefaultPasswordPolicy()
+	policy.Blocklist = map[string]struct{}{"qwerty!1q": {}}
+	mockAuthService := new(MockAuthService)
+	handler := httpHandler.NewAuthHandler(slog.Default(), mockAuthService, validator.NewCustomValidator(policy))
+
+	router := gin.New()
+	router.POST("/login", handler.PostLogin)
+
+	// Пароль из списка скомпрометированных при регистрации был бы отклонен, но вход проверяет только учетные данные.
+	require.NotEmpty(t, policy.Check("password", "Qwerty!1q"))
+	mockAuthService.On("Login", mock.Anything, "old@example.com", "Qwerty!1q").Return("login-token", nil).Once()
+
+	bodyBytes, _ := json.Marshal(map[string]any{"email": "old@example.com", "password": "Qwerty!1q"})
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockAuthService.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/handler/http/api"
+	"pvz-service-avito-internship/internal/handler/http/response"
+	mw "pvz-service-avito-internship/internal/middleware"
+)
+
+type DispatchHandler struct {
+	BaseHandler
+	dispatchService domain.DispatchService
+}
+
+func NewDispatchHandler(log *slog.Logger, dispatchService domain.DispatchService) *DispatchHandler {
+	return &DispatchHandler{
+		BaseHandler:     *NewBaseHandler(log),
+		dispatchService: dispatchService,
+	}
+}
+
+// GetDispatch возвращает отправку возвратов с товарами.
+func (h *DispatchHandler) GetDispatch(c *gin.Context) {
+	const op = "DispatchHandler.GetDispatch"
+
+	dispatchID, err := h.parseUUID(c, "dispatchId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	details, err := h.dispatchService.GetDispatch(c.Request.Context(), dispatchID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	response.SendSuccess(c, http.StatusOK, toDispatchResponse(*details))
+}
+
+// SendDispatch фиксирует передачу отправки на склад.
+func (h *DispatchHandler) SendDispatch(c *gin.Context) {
+	const op = "DispatchHandler.SendDispatch"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	dispatchID, err := h.parseUUID(c, "dispatchId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	details, err := h.dispatchService.SendDispatch(c.Request.Context(), dispatchID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log.Info("Dispatch sent successfully", slog.String("dispatch_id", dispatchID.String()), slog.Int("products_count", len(details.Products)))
+	response.SendSuccess(c, http.StatusOK, toDispatchResponse(*details))
+}
+
+func toDispatchResponse(details domain.DispatchDetails) api.Dispatch {
+	products := make([]api.Product, 0, len(details.Products))
+	for _, p := range details.Products {
+		products = append(products, toProductResponse(p))
+	}
+	resp := api.Dispatch{
+		Id:        details.Dispatch.ID,
+		PvzId:     details.Dispatch.PVZID,
+		Status:    api.DispatchStatus(details.Dispatch.Status),
+		CreatedAt: details.Dispatch.CreatedAt.UTC(),
+		CreatedBy: details.Dispatch.CreatedBy,
+		SentBy:    details.Dispatch.SentBy,
+		Products:  products,
+	}
+	if details.Dispatch.SentAt != nil {
+		sentAt := details.Dispatch.SentAt.UTC()
+		resp.SentAt = &sentAt
+	}
+	return resp
+}
@@ -0,0 +1,101 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	httpHandler "pvz-service-avito-internship/internal/handler/http"
+	"pvz-service-avito-internship/internal/handler/http/api"
+)
+
+type MockDispatchService struct {
+	mock.Mock
+}
+
+func (m *MockDispatchService) GetDispatch(ctx context.Context, id uuid.UUID) (*domain.DispatchDetails, error) {
+	args := m.Called(ctx, id)
+	if details, ok := args.Get(0).(*domain.DispatchDetails); ok {
+		return details, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDispatchService) SendDispatch(ctx context.Context, id uuid.UUID) (*domain.DispatchDetails, error) {
+	args := m.Called(ctx, id)
+	if details, ok := args.Get(0).(*domain.DispatchDetails); ok {
+		return details, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestDispatchHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	createdAt := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	dispatchID := uuid.New()
+
+	newContext := func(method, path string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(method, path, nil)
+		c.Params = []gin.Param{{Key: "dispatchId", Value: dispatchID.String()}}
+		return w, c
+	}
+
+	t.Run("GetDispatch_Success", func(t *testing.T) {
+		mockService := new(MockDispatchService)
+		handler := httpHandler.NewDispatchHandler(logger, mockService)
+		details := &domain.DispatchDetails{
+			Dispatch: domain.Dispatch{ID: dispatchID, PVZID: uuid.New(), Status: domain.DispatchOpen, CreatedAt: createdAt},
+			Products: []domain.Product{{ID: uuid.New(), Type: domain.TypeShoes, Status: domain.ProductReturned, DispatchID: &dispatchID}},
+		}
+		mockService.On("GetDispatch", mock.Anything, dispatchID).Return(details, nil).Once()
+
+		w, c := newContext(http.MethodGet, "/dispatches/"+dispatchID.String())
+		handler.GetDispatch(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.Dispatch
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, dispatchID, resp.Id)
+		assert.Equal(t, api.Open, resp.Status)
+		assert.Nil(t, resp.SentAt)
+		assert.Len(t, resp.Products, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("SendDispatch_Already_Sent", func(t *testing.T) {
+		mockService := new(MockDispatchService)
+		handler := httpHandler.NewDispatchHandler(logger, mockService)
+		mockService.On("SendDispatch", mock.Anything, dispatchID).Return(nil, domain.ErrDispatchAlreadySent).Once()
+
+		w, c := newContext(http.MethodPost, "/dispatches/"+dispatchID.String()+"/send")
+		handler.SendDispatch(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("SendDispatch_Empty", func(t *testing.T) {
+		mockService := new(MockDispatchService)
+		handler := httpHandler.NewDispatchHandler(logger, mockService)
+		mockService.On("SendDispatch", mock.Anything, dispatchID).Return(nil, domain.ErrDispatchEmpty).Once()
+
+		w, c := newContext(http.MethodPost, "/dispatches/"+dispatchID.String()+"/send")
+		handler.SendDispatch(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
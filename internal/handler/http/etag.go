@@ -0,0 +1,70 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/handler/http/response"
+)
+
+// formatETag возвращает сильный ETag для версии ресурса.
+func formatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseIfMatch возвращает версию из заголовка If-Match. nil означает, что условия нет (заголовок отсутствует или "*").
+// Значение, которое не может совпасть с ETag ресурса (например, слабый ETag), дает ErrPreconditionFailed.
+func parseIfMatch(c *gin.Context) (*int64, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+	if strings.Contains(value, ",") {
+		return nil, domain.NewFieldError("If-Match", "must contain a single ETag")
+	}
+
+	unquoted, ok := strings.CutPrefix(value, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if !ok || err != nil {
+		return nil, fmt.Errorf("%w: If-Match %s does not match the current ETag", domain.ErrPreconditionFailed, value)
+	}
+	return &version, nil
+}
+
+// matchesIfNoneMatch проверяет, совпадает ли etag с одним из значений If-None-Match (слабое сравнение).
+func matchesIfNoneMatch(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// sendWithETag отправляет ответ 200 с ETag версии ресурса. Если клиент прислал совпадающий If-None-Match,
+// отвечает 304 без тела.
+func (h *BaseHandler) sendWithETag(c *gin.Context, version int64, data interface{}) {
+	etag := formatETag(version)
+	c.Header("ETag", etag)
+	if matchesIfNoneMatch(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	response.SendSuccess(c, http.StatusOK, data)
+}
@@ -0,0 +1,129 @@
+package http_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pvz-service-avito-internship/internal/domain"
+	httpHandler "pvz-service-avito-internship/internal/handler/http"
+)
+
+func TestPVZHandler_GetPvzByID_ETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	pvzID := uuid.New()
+	pvz := &domain.PVZ{ID: pvzID, City: domain.Moscow, Status: domain.PVZStatusActive, Version: 7}
+
+	testCases := []struct {
+		name         string
+		ifNoneMatch  string
+		expectedCode int
+	}{
+		{name: "без If-None-Match", expectedCode: http.StatusOK},
+		{name: "совпадающий ETag", ifNoneMatch: `"7"`, expectedCode: http.StatusNotModified},
+		{name: "совпадающий слабый ETag в списке", ifNoneMatch: `"5", W/"7"`, expectedCode: http.StatusNotModified},
+		{name: "устаревший ETag", ifNoneMatch: `"6"`, expectedCode: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockPVZService)
+			handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+			mockService.On("GetPVZ", mock.Anything, pvzID).Return(pvz, nil).Once()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+			c.Request = httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String(), nil)
+			if tc.ifNoneMatch != "" {
+				c.Request.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+
+			handler.GetPvzByID(c)
+			c.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Equal(t, `"7"`, w.Header().Get("ETag"))
+			if tc.expectedCode == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"version":7`)
+			} else {
+				assert.Empty(t, w.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPVZHandler_CloseLastReception_IfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+	pvzID := uuid.New()
+
+	newContext := func(ifMatch string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+		c.Request = httptest.NewRequest(http.MethodPost, "/pvz/"+pvzID.String()+"/close_last_reception", nil)
+		c.Request.Header.Set("If-Match", ifMatch)
+		return c, w
+	}
+
+	t.Run("версия совпадает", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+		version := int64(3)
+		mockReceptionService.On("CloseReception", mock.Anything, pvzID, &version).
+			Return(&domain.Reception{ID: uuid.New(), PVZID: pvzID, Status: domain.StatusClosed, Version: 4}, nil).Once()
+
+		c, w := newContext(`"3"`)
+		handler.CloseLastReception(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		mockReceptionService.AssertExpectations(t)
+	})
+
+	t.Run("приемка изменена", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+		version := int64(2)
+		mockReceptionService.On("CloseReception", mock.Anything, pvzID, &version).
+			Return(nil, domain.ErrPreconditionFailed).Once()
+
+		c, w := newContext(`"2"`)
+		handler.CloseLastReception(c)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		mockReceptionService.AssertExpectations(t)
+	})
+
+	t.Run("слабый ETag не совпадает", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		c, w := newContext(`W/"3"`)
+		handler.CloseLastReception(c)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		mockReceptionService.AssertNotCalled(t, "CloseReception", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("несколько ETag", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		c, w := newContext(`"3", "4"`)
+		handler.CloseLastReception(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockReceptionService.AssertNotCalled(t, "CloseReception", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvz-service-avito-internship/internal/domain"
+	mw "pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/pkg/tabular"
+)
+
+// exportFlushEvery - через сколько строк принудительно отправлять накопленные данные клиенту.
+const exportFlushEvery = 500
+
+// receptionExportColumns - заголовок выгрузки приемок, порядок совпадает с receptionExportCells.
+var receptionExportColumns = []string{
+	"pvz_id", "city", "reception_id", "reception_status", "opened_at", "closed_at",
+	"product_id", "product_type", "product_date_time",
+}
+
+type ExportHandler struct {
+	BaseHandler
+	exportService domain.ExportService
+	writeTimeout  time.Duration
+}
+
+func NewExportHandler(log *slog.Logger, exportService domain.ExportService, writeTimeout time.Duration) *ExportHandler {
+	return &ExportHandler{
+		BaseHandler:   *NewBaseHandler(log),
+		exportService: exportService,
+		writeTimeout:  writeTimeout,
+	}
+}
+
+// GetReceptionsExport отдает выгрузку приемок и товаров в CSV или XLSX, записывая строки по мере чтения из БД.
+// Заголовки ответа отправляются вместе с первой строкой, поэтому ошибки валидации и запроса к БД
+// возвращаются обычным JSON. Если ошибка произошла после начала записи, ответ обрывается.
+func (h *ExportHandler) GetReceptionsExport(c *gin.Context) {
+	const op = "ExportHandler.GetReceptionsExport"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	format := domain.ExportFormat(c.DefaultQuery("format", string(domain.ExportFormatCSV)))
+	if !format.IsValid() {
+		h.handleError(c, op, domain.NewRuleError("format", "oneof", "csv, xlsx"))
+		return
+	}
+
+	var filter domain.ReceptionExportFilter
+	var err error
+	if filter.StartDate, err = h.parseDateTimeQuery(c, "startDate"); err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	if filter.EndDate, err = h.parseDateTimeQuery(c, "endDate"); err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	if cityStr := c.Query("city"); cityStr != "" {
+		city := domain.City(cityStr)
+		filter.City = &city
+	}
+
+	// Общий WriteTimeout сервера слишком мал для больших выгрузок, продлеваем дедлайн только для этого ответа.
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(h.writeTimeout)); err != nil {
+		log.Debug("Failed to extend write deadline", slog.String("error", err.Error()))
+	}
+
+	var (
+		writer tabular.Writer
+		rows   int
+	)
+	begin := func() error {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+			"filename": receptionExportFileName(format, filter),
+		}))
+		c.Header("Content-Type", tabular.ContentType(string(format)))
+		c.Status(http.StatusOK)
+		var errBegin error
+		writer, errBegin = tabular.NewWriter(string(format), c.Writer, "receptions")
+		if errBegin != nil {
+			return errBegin
+		}
+		return writer.WriteRow(receptionExportColumns)
+	}
+
+	err = h.exportService.ExportReceptions(c.Request.Context(), filter, func(row domain.ReceptionExportRow) error {
+		if writer == nil {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		if err := writer.WriteRow(receptionExportCells(row)); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil && writer == nil {
+		// Пустая выгрузка: отдаем файл только с заголовком.
+		err = begin()
+	}
+	if err != nil {
+		if writer == nil {
+			h.handleError(c, op, err)
+			return
+		}
+		log.Error("Export interrupted after response started", slog.Int("rows", rows), slog.String("error", err.Error()))
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Error("Failed to finish export", slog.Int("rows", rows), slog.String("error", err.Error()))
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	log.Info("Receptions export sent", slog.String("format", string(format)), slog.Int("rows", rows))
+}
+
+func receptionExportCells(row domain.ReceptionExportRow) []string {
+	cells := []string{
+		row.PVZID.String(),
+		string(row.City),
+		row.ReceptionID.String(),
+		string(row.ReceptionStatus),
+		formatExportTime(&row.OpenedAt),
+		formatExportTime(row.ClosedAt),
+		"", "", "",
+	}
+	if row.ProductID != nil {
+		cells[6] = row.ProductID.String()
+	}
+	if row.ProductType != nil {
+		cells[7] = string(*row.ProductType)
+	}
+	cells[8] = formatExportTime(row.ProductDateTime)
+	return cells
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// receptionExportFileName формирует имя файла вида receptions_20250401-20250501_Москва.csv.
+func receptionExportFileName(format domain.ExportFormat, filter domain.ReceptionExportFilter) string {
+	const dateLayout = "20060102"
+	from, to := "all", "now"
+	if filter.StartDate != nil {
+		from = filter.StartDate.UTC().Format(dateLayout)
+	}
+	if filter.EndDate != nil {
+		to = filter.EndDate.UTC().Format(dateLayout)
+	}
+
+	parts := []string{"receptions", from + "-" + to}
+	if filter.City != nil {
+		parts = append(parts, string(*filter.City))
+	}
+	return strings.Join(parts, "_") + "." + string(format)
+}
@@ -0,0 +1,163 @@
+package http_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	httpHandler "pvz-service-avito-internship/internal/handler/http"
+)
+
+type MockExportService struct {
+	mock.Mock
+}
+
+func (m *MockExportService) ExportReceptions(ctx context.Context, filter domain.ReceptionExportFilter, fn domain.ReceptionExportRowFunc) error {
+	args := m.Called(ctx, filter, fn)
+	if rows, ok := args.Get(0).([]domain.ReceptionExportRow); ok {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func TestExportHandler_GetReceptionsExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	openedAt := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	productID := uuid.New()
+	productType := domain.TypeShoes
+	productTime := openedAt.Add(time.Minute)
+	rows := []domain.ReceptionExportRow{
+		{
+			PVZID: uuid.New(), City: domain.Moscow, ReceptionID: uuid.New(), ReceptionStatus: domain.StatusInProgress,
+			OpenedAt: openedAt, ProductID: &productID, ProductType: &productType, ProductDateTime: &productTime,
+		},
+		{
+			PVZID: uuid.New(), City: domain.Kazan, ReceptionID: uuid.New(), ReceptionStatus: domain.StatusInProgress,
+			OpenedAt: openedAt,
+		},
+	}
+
+	newRequest := func(target string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		return w, c
+	}
+
+	t.Run("Success_CSV", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handler := httpHandler.NewExportHandler(logger, mockService, time.Minute)
+		moscow := domain.Moscow
+		startDate := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		mockService.On("ExportReceptions", mock.Anything,
+			domain.ReceptionExportFilter{StartDate: &startDate, City: &moscow}, mock.Anything).Return(rows, nil).Once()
+
+		w, c := newRequest("/exports/receptions?startDate=2025-04-01T00:00:00Z&city=%D0%9C%D0%BE%D1%81%D0%BA%D0%B2%D0%B0")
+		handler.GetReceptionsExport(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "receptions_20250401-now_")
+
+		body := w.Body.String()
+		require.True(t, strings.HasPrefix(body, "\xEF\xBB\xBF"), "CSV should start with UTF-8 BOM")
+		records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(body, "\xEF\xBB\xBF"))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "pvz_id", records[0][0])
+		assert.Equal(t, productID.String(), records[1][6])
+		assert.Equal(t, string(domain.TypeShoes), records[1][7])
+		assert.Equal(t, "2025-04-10T09:00:00Z", records[1][4])
+		assert.Equal(t, "", records[2][6])
+	})
+
+	t.Run("Success_XLSX", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handler := httpHandler.NewExportHandler(logger, mockService, time.Minute)
+		mockService.On("ExportReceptions", mock.Anything, domain.ReceptionExportFilter{}, mock.Anything).Return(rows, nil).Once()
+
+		w, c := newRequest("/exports/receptions?format=xlsx")
+		handler.GetReceptionsExport(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "receptions_all-now.xlsx")
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		var sheet []byte
+		for _, f := range archive.File {
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				rc, errOpen := f.Open()
+				require.NoError(t, errOpen)
+				sheet, err = io.ReadAll(rc)
+				require.NoError(t, err)
+				require.NoError(t, rc.Close())
+			}
+		}
+		require.NotEmpty(t, sheet, "sheet1.xml should be present")
+		assert.Contains(t, string(sheet), `<row r="3">`)
+		assert.Contains(t, string(sheet), productID.String())
+		assert.Contains(t, string(sheet), string(domain.Kazan))
+	})
+
+	t.Run("Empty_Export_Has_Header", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handler := httpHandler.NewExportHandler(logger, mockService, time.Minute)
+		mockService.On("ExportReceptions", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+		w, c := newRequest("/exports/receptions")
+		handler.GetReceptionsExport(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "\xEF\xBB\xBFpvz_id,city,reception_id,reception_status,opened_at,closed_at,product_id,product_type,product_date_time\n", w.Body.String())
+	})
+
+	t.Run("Invalid_Format", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handler := httpHandler.NewExportHandler(logger, mockService, time.Minute)
+
+		w, c := newRequest("/exports/receptions?format=pdf")
+		handler.GetReceptionsExport(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ExportReceptions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error_Before_Streaming_Returns_JSON", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handler := httpHandler.NewExportHandler(logger, mockService, time.Minute)
+		mockService.On("ExportReceptions", mock.Anything, mock.Anything, mock.Anything).Return(nil, domain.ErrDatabaseError).Once()
+
+		w, c := newRequest("/exports/receptions")
+		handler.GetReceptionsExport(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `"code":"database_error"`)
+	})
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -12,10 +13,13 @@ import (
 	"github.com/google/uuid"
 	openapitypes "github.com/oapi-codegen/runtime/types"
 
+	"pvz-service-avito-internship/internal/apperror"
 	"pvz-service-avito-internship/internal/domain"
 	"pvz-service-avito-internship/internal/handler/http/api"
 	"pvz-service-avito-internship/internal/handler/http/response"
+	"pvz-service-avito-internship/internal/i18n"
 	mw "pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/pkg/validator"
 )
 
 type BaseHandler struct {
@@ -26,57 +30,63 @@ func NewBaseHandler(log *slog.Logger) *BaseHandler {
 	return &BaseHandler{log: log}
 }
 
-func (h *BaseHandler) mapError(err error) (int, string) {
-	switch {
-	case errors.Is(err, domain.ErrNotFound):
-		return http.StatusNotFound, "Resource not found"
-	case errors.Is(err, domain.ErrValidation):
-		rootCause := GetRootCause(err)
-		return http.StatusBadRequest, fmt.Sprintf("Invalid request data: %s", rootCause.Error())
-	case errors.Is(err, domain.ErrForbidden):
-		return http.StatusForbidden, "Access forbidden"
-	case errors.Is(err, domain.ErrUnauthorized):
-		return http.StatusUnauthorized, "Unauthorized"
-	case errors.Is(err, domain.ErrPVZCityNotAllowed):
-		return http.StatusBadRequest, domain.ErrPVZCityNotAllowed.Error()
-	case errors.Is(err, domain.ErrReceptionInProgress):
-		return http.StatusBadRequest, domain.ErrReceptionInProgress.Error()
-	case errors.Is(err, domain.ErrNoOpenReception):
-		return http.StatusBadRequest, domain.ErrNoOpenReception.Error()
-	case errors.Is(err, domain.ErrReceptionClosed):
-		return http.StatusBadRequest, domain.ErrReceptionClosed.Error()
-	case errors.Is(err, domain.ErrProductDeletionOrder):
-		return http.StatusBadRequest, domain.ErrProductDeletionOrder.Error()
-	case errors.Is(err, domain.ErrNoProductsToDelete):
-		return http.StatusBadRequest, domain.ErrNoProductsToDelete.Error()
-	case errors.Is(err, domain.ErrConflict):
-		return http.StatusConflict, "Resource conflict"
-	case errors.Is(err, domain.ErrDatabaseError):
+// logInternalError логирует ошибку, которая будет отправлена клиенту как 5xx.
+func (h *BaseHandler) logInternalError(err error) {
+	if errors.Is(err, domain.ErrDatabaseError) {
 		h.log.Error("Database error occurred", slog.String("original_error", err.Error()))
-		return http.StatusInternalServerError, "Internal server error (database operation failed)"
-	default:
+	} else {
 		h.log.Error("Unknown internal error occurred", slog.String("error_type", fmt.Sprintf("%T", err)), slog.String("error", err.Error()))
-		return http.StatusInternalServerError, "Internal server error"
 	}
 }
 
 func (h *BaseHandler) handleError(c *gin.Context, op string, err error) {
 	reqID := mw.GetRequestIDFromContext(c)
 	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
-	statusCode, message := h.mapError(err)
-	if statusCode >= http.StatusInternalServerError {
-		log.Error("Internal server error mapped", slog.Int("status", statusCode), slog.String("message", message), slog.String("original_error", err.Error()))
+	problem, _ := apperror.Describe(err)
+	// В лог пишется сообщение на языке по умолчанию, клиент получает его на своем языке.
+	message := problem.Localize(i18n.DefaultLocale).Detail
+	if problem.Status >= http.StatusInternalServerError {
+		h.logInternalError(err)
+		log.Error("Internal server error mapped", slog.Int("status", problem.Status), slog.String("code", problem.Code), slog.String("message", message), slog.String("original_error", err.Error()))
 	} else {
-		log.Warn("Client error mapped", slog.Int("status", statusCode), slog.String("message", message), slog.String("original_error", err.Error()))
+		log.Warn("Client error mapped", slog.Int("status", problem.Status), slog.String("code", problem.Code), slog.String("message", message), slog.String("original_error", err.Error()))
+	}
+	response.SendProblem(c, problem)
+}
+
+// validationError преобразует ошибки проверки pkg/validator в ошибки полей с правилами для перевода сообщений.
+func validationError(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		validationErr := &domain.ValidationError{Fields: make([]domain.FieldError, 0, len(fieldErrs))}
+		for _, fieldErr := range fieldErrs {
+			validationErr.Fields = append(validationErr.Fields, domain.FieldError{Field: fieldErr.Field, Rule: fieldErr.Tag, Params: fieldErr.Params})
+		}
+		return validationErr
+	}
+	var fieldErr *validator.FieldError
+	if errors.As(err, &fieldErr) {
+		return domain.NewRuleError(fieldErr.Field, fieldErr.Tag, fieldErr.Params...)
+	}
+	return fmt.Errorf("%w: %v", domain.ErrValidation, err)
+}
+
+// bindError преобразует ошибку разбора тела запроса в ошибку для клиента. Если поле известно
+// (неверный тип значения), оно передается клиенту в ошибках полей, иначе возвращается ErrMalformedBody:
+// текст ошибки декодера клиенту не передается, хендлеры пишут его в лог.
+func bindError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return domain.NewRuleError(typeErr.Field, "type", typeErr.Type.String())
 	}
-	response.SendError(c, statusCode, message)
+	return domain.ErrMalformedBody
 }
 
 func (h *BaseHandler) parseUUID(c *gin.Context, paramName string) (uuid.UUID, error) {
 	idStr := c.Param(paramName)
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("%w: invalid UUID format for path parameter '%s'", domain.ErrValidation, paramName)
+		return uuid.Nil, domain.NewRuleError(paramName, "uuid")
 	}
 	return id, nil
 }
@@ -88,11 +98,45 @@ func (h *BaseHandler) parseIntQuery(c *gin.Context, paramName string, defaultVal
 	}
 	value, err := strconv.Atoi(valueStr)
 	if err != nil {
-		return 0, fmt.Errorf("%w: invalid integer format for query parameter '%s'", domain.ErrValidation, paramName)
+		return 0, domain.NewRuleError(paramName, "integer")
 	}
 	return value, nil
 }
 
+// parseNestedPagination разбирает page/limit для вложенных списков (приемки ПВЗ, товары приемки).
+func (h *BaseHandler) parseNestedPagination(c *gin.Context) (int, int, error) {
+	page, err := h.parseIntQuery(c, "page", 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	limit, err := h.parseIntQuery(c, "limit", defaultNestedListLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > maxNestedListLimit {
+		limit = maxNestedListLimit
+	}
+	return page, limit, nil
+}
+
+func (h *BaseHandler) parseUUIDQuery(c *gin.Context, paramName string) (*uuid.UUID, error) {
+	valueStr := c.Query(paramName)
+	if valueStr == "" {
+		return nil, nil
+	}
+	value, err := uuid.Parse(valueStr)
+	if err != nil {
+		return nil, domain.NewRuleError(paramName, "uuid")
+	}
+	return &value, nil
+}
+
 func (h *BaseHandler) parseDateTimeQuery(c *gin.Context, paramName string) (*time.Time, error) {
 	valueStr := c.Query(paramName)
 	if valueStr == "" {
@@ -100,20 +144,20 @@ func (h *BaseHandler) parseDateTimeQuery(c *gin.Context, paramName string) (*tim
 	}
 	value, err := time.Parse(time.RFC3339, valueStr)
 	if err != nil {
-		return nil, fmt.Errorf("%w: invalid date-time format (RFC3339 required) for query parameter '%s'", domain.ErrValidation, paramName)
+		return nil, domain.NewRuleError(paramName, "datetime")
 	}
 	t := value.UTC()
 	return &t, nil
 }
 
-func (h *BaseHandler) parseBoolQuery(c *gin.Context, s string, b bool) (interface{}, interface{}) {
-	valueStr := c.Query(s)
+func (h *BaseHandler) parseBoolQuery(c *gin.Context, paramName string, defaultValue bool) (bool, error) {
+	valueStr := c.Query(paramName)
 	if valueStr == "" {
-		return b, nil
+		return defaultValue, nil
 	}
 	value, err := strconv.ParseBool(valueStr)
 	if err != nil {
-		return nil, fmt.Errorf("%w: invalid boolean format for query parameter '%s'", domain.ErrValidation, s)
+		return false, domain.NewRuleError(paramName, "boolean")
 	}
 	return value, nil
 }
@@ -131,86 +175,212 @@ func GetRootCause(err error) error {
 func toPVZResponse(pvz domain.PVZ) api.PVZ {
 	regDate := pvz.RegistrationDate.UTC()
 	apiID := pvz.ID
-	return api.PVZ{
+	resp := api.PVZ{
 		Id:               &apiID,
 		RegistrationDate: &regDate,
 		City:             api.PVZCity(pvz.City),
 	}
+	if pvz.Version > 0 {
+		version := pvz.Version
+		resp.Version = &version
+	}
+	if pvz.Status != "" {
+		status := api.PVZStatus(pvz.Status)
+		resp.Status = &status
+	}
+	return resp
 }
 
 func toProductResponse(product domain.Product) api.Product {
 	dateTime := product.DateTime.UTC()
 	apiID := product.ID
 	receptionID := product.ReceptionID
-	return api.Product{
-		Id:          &apiID,
-		DateTime:    &dateTime,
-		Type:        api.ProductType(product.Type),
-		ReceptionId: receptionID,
+	resp := api.Product{
+		Id:              &apiID,
+		DateTime:        &dateTime,
+		Type:            api.ProductType(product.Type),
+		ReceptionId:     receptionID,
+		ItemId:          product.ItemID,
+		StatusChangedBy: product.StatusChangedBy,
+		DispatchId:      product.DispatchID,
+	}
+	if product.Status != "" {
+		status := api.ProductStatus(product.Status)
+		resp.Status = &status
 	}
+	if product.StatusChangedAt != nil {
+		changedAt := product.StatusChangedAt.UTC()
+		resp.StatusChangedAt = &changedAt
+	}
+	return resp
 }
 
 func toReceptionResponse(reception domain.Reception) api.Reception {
 	dateTime := reception.DateTime.UTC()
 	apiID := reception.ID
 	pvzID := reception.PVZID
-	return api.Reception{
-		Id:       &apiID,
-		DateTime: dateTime,
-		PvzId:    pvzID,
-		Status:   api.ReceptionStatus(reception.Status),
+	autoClosed := reception.AutoClosed
+	resp := api.Reception{
+		Id:                 &apiID,
+		DateTime:           dateTime,
+		PvzId:              pvzID,
+		Status:             api.ReceptionStatus(reception.Status),
+		OpenedBy:           reception.OpenedBy,
+		ClosedBy:           reception.ClosedBy,
+		AutoClosed:         &autoClosed,
+		ExpectedShipmentId: reception.ExpectedShipmentID,
+	}
+	if reception.Version > 0 {
+		version := reception.Version
+		resp.Version = &version
+	}
+	if reception.ClosedAt != nil {
+		closedAt := reception.ClosedAt.UTC()
+		resp.ClosedAt = &closedAt
+	}
+	if reception.StaleReason != nil {
+		staleReason := api.ReceptionStaleReason(*reception.StaleReason)
+		resp.StaleReason = &staleReason
+	}
+	return resp
+}
+
+func toReceptionDetailsResponse(details domain.ReceptionDetails) api.ReceptionDetails {
+	products := make([]api.Product, 0, len(details.Products))
+	for _, p := range details.Products {
+		products = append(products, toProductResponse(p))
+	}
+	counts := make(map[string]int, len(details.ProductCounts))
+	for productType, count := range details.ProductCounts {
+		counts[string(productType)] = count
+	}
+	return api.ReceptionDetails{
+		Reception:     toReceptionResponse(details.Reception),
+		Products:      products,
+		ProductCounts: counts,
 	}
 }
 
 type PVZListResponseItem struct {
-	Pvz        api.PVZ                         `json:"pvz"`
-	Receptions []ReceptionWithProductsResponse `json:"receptions"`
+	Pvz        api.PVZ                          `json:"pvz"`
+	Summary    PVZSummaryResponse               `json:"summary"`
+	Inventory  api.PVZInventory                 `json:"inventory"`
+	Receptions *[]ReceptionWithProductsResponse `json:"receptions,omitempty"`
+}
+
+type PVZSummaryResponse struct {
+	ReceptionsCount     int        `json:"receptionsCount"`
+	OpenReceptionsCount int        `json:"openReceptionsCount"`
+	ProductsCount       int        `json:"productsCount"`
+	LastReceptionAt     *time.Time `json:"lastReceptionAt,omitempty"`
 }
 
 type ReceptionWithProductsResponse struct {
-	Reception api.Reception `json:"reception"`
-	Products  []api.Product `json:"products"`
+	Reception api.Reception  `json:"reception"`
+	Products  *[]api.Product `json:"products,omitempty"`
 }
 type ListPVZResponse struct {
-	Items []PVZListResponseItem `json:"items"`
-	Total int                   `json:"total"`
-	Page  int                   `json:"page"`
-	Limit int                   `json:"limit"`
+	Items      []PVZListResponseItem `json:"items"`
+	Total      *int                  `json:"total,omitempty"`
+	Page       int                   `json:"page,omitempty"`
+	Limit      int                   `json:"limit"`
+	NextCursor *string               `json:"nextCursor,omitempty"`
+}
+
+type ListReceptionsResponse struct {
+	Items []api.Reception `json:"items"`
+	Total int             `json:"total"`
+	Page  int             `json:"page"`
+	Limit int             `json:"limit"`
+}
+
+type ListProductsResponse struct {
+	Items []api.Product `json:"items"`
+	Total int           `json:"total"`
+	Page  int           `json:"page"`
+	Limit int           `json:"limit"`
 }
 
+// toReceptionWithProductsResponse оставляет products пустым, если товары не запрашивались (rwp.Products == nil).
 func toReceptionWithProductsResponse(rwp domain.ReceptionWithProducts) ReceptionWithProductsResponse {
-	products := make([]api.Product, 0, len(rwp.Products))
-	for _, p := range rwp.Products {
-		products = append(products, toProductResponse(p))
+	resp := ReceptionWithProductsResponse{Reception: toReceptionResponse(rwp.Reception)}
+	if rwp.Products != nil {
+		products := make([]api.Product, 0, len(rwp.Products))
+		for _, p := range rwp.Products {
+			products = append(products, toProductResponse(p))
+		}
+		resp.Products = &products
+	}
+	return resp
+}
+
+func toPVZSummaryResponse(summary domain.PVZSummary) PVZSummaryResponse {
+	resp := PVZSummaryResponse{
+		ReceptionsCount:     summary.ReceptionsCount,
+		OpenReceptionsCount: summary.OpenReceptionsCount,
+		ProductsCount:       summary.ProductsCount,
 	}
-	return ReceptionWithProductsResponse{
-		Reception: toReceptionResponse(rwp.Reception),
-		Products:  products,
+	if summary.LastReceptionAt != nil {
+		lastAt := summary.LastReceptionAt.UTC()
+		resp.LastReceptionAt = &lastAt
 	}
+	return resp
 }
 
+func toPVZInventoryResponse(inventory domain.PVZInventory) api.PVZInventory {
+	byType := make(map[string]int, len(inventory.ByType))
+	for productType, count := range inventory.ByType {
+		byType[string(productType)] = count
+	}
+	return api.PVZInventory{OnHand: inventory.OnHand, ByType: byType}
+}
+
+func toPVZOccupancyResponse(occupancy domain.PVZOccupancy) api.PVZOccupancy {
+	byType := make(map[string]api.TypeOccupancy, len(occupancy.ByType))
+	for productType, t := range occupancy.ByType {
+		byType[string(productType)] = api.TypeOccupancy{Occupied: t.Occupied, Capacity: t.Capacity}
+	}
+	return api.PVZOccupancy{
+		PvzId:    occupancy.PVZID,
+		Occupied: occupancy.Occupied,
+		Capacity: occupancy.Capacity,
+		ByType:   byType,
+	}
+}
+
+// toPVZListResponseItem оставляет receptions пустым, если приемки не запрашивались (pvzd.Receptions == nil).
 func toPVZListResponseItem(pvzd domain.PVZWithDetails) PVZListResponseItem {
-	receptions := make([]ReceptionWithProductsResponse, 0, len(pvzd.Receptions))
-	for _, rwp := range pvzd.Receptions {
-		receptions = append(receptions, toReceptionWithProductsResponse(rwp))
+	item := PVZListResponseItem{
+		Pvz:       toPVZResponse(pvzd.PVZ),
+		Summary:   toPVZSummaryResponse(pvzd.Summary),
+		Inventory: toPVZInventoryResponse(pvzd.Inventory),
 	}
-	return PVZListResponseItem{
-		Pvz:        toPVZResponse(pvzd.PVZ),
-		Receptions: receptions,
+	if pvzd.Receptions != nil {
+		receptions := make([]ReceptionWithProductsResponse, 0, len(pvzd.Receptions))
+		for _, rwp := range pvzd.Receptions {
+			receptions = append(receptions, toReceptionWithProductsResponse(rwp))
+		}
+		item.Receptions = &receptions
 	}
+	return item
 }
 
-func toPVZListResponse(items []domain.PVZWithDetails, total, page, limit int) ListPVZResponse {
-	respItems := make([]PVZListResponseItem, 0, len(items))
-	for _, item := range items {
+func toPVZListResponse(listPage domain.PVZListPage, page, limit int) ListPVZResponse {
+	respItems := make([]PVZListResponseItem, 0, len(listPage.Items))
+	for _, item := range listPage.Items {
 		respItems = append(respItems, toPVZListResponseItem(item))
 	}
-	return ListPVZResponse{
+	resp := ListPVZResponse{
 		Items: respItems,
-		Total: total,
+		Total: listPage.Total,
 		Page:  page,
 		Limit: limit,
 	}
+	if listPage.NextCursor != nil {
+		nextCursor := listPage.NextCursor.Encode()
+		resp.NextCursor = &nextCursor
+	}
+	return resp
 }
 
 func toUserResponse(user domain.User) api.User {
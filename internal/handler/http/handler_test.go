@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"pvz-service-avito-internship/internal/domain"
 	"pvz-service-avito-internship/internal/handler/http/response"
+	mw "pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/pkg/validator"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -29,13 +31,82 @@ func TestBaseHandler_HandleError(t *testing.T) {
 	router.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusNotFound, rec.Code)
-	assert.JSONEq(t, `{"message":"Resource not found"}`, rec.Body.String())
+	assert.Equal(t, response.ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "urn:problem-type:pvz-service:not_found",
+		"title": "Resource not found",
+		"status": 404,
+		"detail": "Resource not found",
+		"code": "not_found",
+		"message": "Resource not found"
+	}`, rec.Body.String())
 
 	logOutput := logBuffer.String()
 	assert.Contains(t, logOutput, `"level":"WARN"`)
 	assert.Contains(t, logOutput, `"msg":"Client error mapped"`)
 }
 
+func TestBindError(t *testing.T) {
+	handler := NewBaseHandler(slog.New(slog.NewJSONHandler(new(bytes.Buffer), nil)))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(mw.Locale())
+	router.POST("/test", func(c *gin.Context) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			handler.handleError(c, "bind", bindError(err))
+			return
+		}
+		response.SendSuccess(c, http.StatusOK, body)
+	})
+
+	testCases := map[string]struct {
+		body           string
+		acceptLanguage string
+		expected       string
+	}{
+		"пустое тело": {body: ``, acceptLanguage: "en", expected: `{
+			"type": "urn:problem-type:pvz-service:malformed_body",
+			"title": "Malformed request body",
+			"status": 400,
+			"detail": "Request body could not be read or is not valid JSON",
+			"code": "malformed_body",
+			"message": "Request body could not be read or is not valid JSON"
+		}`},
+		"синтаксическая ошибка на русском": {body: `{"name":`, acceptLanguage: "ru", expected: `{
+			"type": "urn:problem-type:pvz-service:malformed_body",
+			"title": "Некорректное тело запроса",
+			"status": 400,
+			"detail": "Тело запроса не удалось прочитать или оно не является корректным JSON",
+			"code": "malformed_body",
+			"message": "Тело запроса не удалось прочитать или оно не является корректным JSON"
+		}`},
+		"неверный тип всего тела": {body: `42`, acceptLanguage: "en", expected: `{
+			"type": "urn:problem-type:pvz-service:malformed_body",
+			"title": "Malformed request body",
+			"status": 400,
+			"detail": "Request body could not be read or is not valid JSON",
+			"code": "malformed_body",
+			"message": "Request body could not be read or is not valid JSON"
+		}`},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader([]byte(tc.body)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.JSONEq(t, tc.expected, rec.Body.String())
+		})
+	}
+}
+
 func TestBaseHandler_ParseUUID(t *testing.T) {
 	logBuffer := new(bytes.Buffer)
 	logger := slog.New(slog.NewJSONHandler(logBuffer, &slog.HandlerOptions{Level: slog.LevelWarn}))
@@ -62,7 +133,15 @@ func TestBaseHandler_ParseUUID(t *testing.T) {
 	rec = httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, `{"message":"Invalid request data: validation failed"}`, rec.Body.String())
+	assert.JSONEq(t, `{
+		"type": "urn:problem-type:pvz-service:validation_failed",
+		"title": "Invalid request data",
+		"status": 400,
+		"detail": "id: invalid UUID format",
+		"code": "validation_failed",
+		"errors": [{"field": "id", "message": "invalid UUID format"}],
+		"message": "id: invalid UUID format"
+	}`, rec.Body.String())
 
 	logOutput := logBuffer.String()
 	assert.Contains(t, logOutput, `"level":"WARN"`)
@@ -95,7 +174,8 @@ func TestBaseHandler_ParseIntQuery(t *testing.T) {
 	rec = httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, `{"message":"Invalid request data: validation failed"}`, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"errors":[{"field":"page","message":"invalid integer format"}]`)
 
 	logOutput := logBuffer.String()
 	assert.Contains(t, logOutput, `"level":"WARN"`)
@@ -165,7 +245,8 @@ func TestBaseHandler_parseIntQuery(t *testing.T) {
 	rec = httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, `{"message":"Invalid request data: validation failed"}`, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"errors":[{"field":"page","message":"invalid integer format"}]`)
 
 	logOutput := logBuffer.String()
 	assert.Contains(t, logOutput, `"level":"WARN"`)
@@ -203,3 +284,19 @@ func TestBaseHandler_parseBoolQuery(t *testing.T) {
 	logOutput := logBuffer.String()
 	assert.NotContains(t, logOutput, `"level":"WARN"`)
 }
+
+func TestValidationError_AllFields(t *testing.T) {
+	err := validationError(validator.ValidationErrors{
+		{Field: "password", Tag: "password_length", Params: []string{"8", "32"}},
+		{Field: "password", Tag: "password_digit", Params: []string{"1"}},
+	})
+
+	var validationErr *domain.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, []domain.FieldError{
+			{Field: "password", Rule: "password_length", Params: []string{"8", "32"}},
+			{Field: "password", Rule: "password_digit", Params: []string{"1"}},
+		}, validationErr.Fields)
+	}
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
@@ -1,7 +1,7 @@
 package http
 
 import (
-	"fmt"
+	"context"
 	"log/slog"
 	"net/http"
 
@@ -34,26 +34,26 @@ func (h *ProductHandler) PostProducts(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Warn("Failed to bind request", slog.String("error", err.Error()))
-		response.SendError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+		h.handleError(c, op, bindError(err))
 		return
 	}
 
 	if reqBody.PvzId == uuid.Nil {
 		log.Warn("pvzId is missing or invalid in request body")
-		response.SendError(c, http.StatusBadRequest, "pvzId is required and must be a valid UUID")
+		h.handleError(c, op, domain.NewRuleError("pvzId", "required"))
 		return
 	}
 
 	if reqBody.Type == "" {
 		log.Warn("type is missing or empty in request body")
-		response.SendError(c, http.StatusBadRequest, "type is required and cannot be empty")
+		h.handleError(c, op, domain.NewRuleError("type", "required"))
 		return
 	}
 
 	validTypes := map[string]bool{"электроника": true, "одежда": true, "обувь": true}
 	if !validTypes[string(reqBody.Type)] {
 		log.Warn("invalid product type in request body", slog.String("type", string(reqBody.Type)))
-		response.SendError(c, http.StatusBadRequest, "invalid product type")
+		h.handleError(c, op, domain.NewRuleError("type", "oneof", "электроника, одежда, обувь"))
 		return
 	}
 
@@ -61,7 +61,7 @@ func (h *ProductHandler) PostProducts(c *gin.Context) {
 	domainProductType := domain.ProductType(reqBody.Type)
 	log = log.With(slog.String("pvz_id", domainPvzID.String()), slog.String("type", string(domainProductType)))
 
-	product, err := h.productService.AddProduct(c.Request.Context(), domainPvzID, domainProductType)
+	product, err := h.productService.AddProduct(c.Request.Context(), domainPvzID, domainProductType, reqBody.ItemId)
 	if err != nil {
 		h.handleError(c, op, err)
 		return
@@ -70,3 +70,76 @@ func (h *ProductHandler) PostProducts(c *gin.Context) {
 	log.Info("Product added successfully", slog.String("product_id", product.ID.String()))
 	response.SendSuccess(c, http.StatusCreated, toProductResponse(*product))
 }
+
+// GetReceptionProducts возвращает постраничный список товаров приемки в порядке добавления.
+func (h *ProductHandler) GetReceptionProducts(c *gin.Context) {
+	const op = "ProductHandler.GetReceptionProducts"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	receptionID, err := h.parseUUID(c, "receptionId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	log = log.With(slog.String("reception_id", receptionID.String()))
+
+	page, limit, err := h.parseNestedPagination(c)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	products, total, err := h.productService.ListReceptionProducts(c.Request.Context(), receptionID, limit, (page-1)*limit)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	items := make([]api.Product, 0, len(products))
+	for _, p := range products {
+		items = append(items, toProductResponse(p))
+	}
+
+	log.Info("Reception products listed successfully", slog.Int("count", len(items)), slog.Int("total", total))
+	response.SendSuccess(c, http.StatusOK, ListProductsResponse{Items: items, Total: total, Page: page, Limit: limit})
+}
+
+// IssueProduct отмечает выдачу товара клиенту.
+func (h *ProductHandler) IssueProduct(c *gin.Context) {
+	h.transitionProduct(c, "ProductHandler.IssueProduct", h.productService.IssueProduct)
+}
+
+// ReturnProduct возвращает товар на склад в открытую отправку ПВЗ.
+func (h *ProductHandler) ReturnProduct(c *gin.Context) {
+	h.transitionProduct(c, "ProductHandler.ReturnProduct", h.productService.ReturnProduct)
+}
+
+// MarkProductLost списывает утерянный товар.
+func (h *ProductHandler) MarkProductLost(c *gin.Context) {
+	h.transitionProduct(c, "ProductHandler.MarkProductLost", h.productService.MarkProductLost)
+}
+
+func (h *ProductHandler) transitionProduct(
+	c *gin.Context,
+	op string,
+	transition func(ctx context.Context, id uuid.UUID) (*domain.Product, error),
+) {
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	productID, err := h.parseUUID(c, "productId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	product, err := transition(c.Request.Context(), productID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log.Info("Product status changed successfully", slog.String("product_id", product.ID.String()), slog.String("status", string(product.Status)))
+	response.SendSuccess(c, http.StatusOK, toProductResponse(*product))
+}
@@ -28,7 +28,7 @@ func TestProductHandler_PostProducts(t *testing.T) {
 			Type: domain.ProductType("electronics"),
 		}
 
-		mockService.On("AddProduct", mock.Anything, pvzID, domain.ProductType("электроника")).
+		mockService.On("AddProduct", mock.Anything, pvzID, domain.ProductType("электроника"), (*string)(nil)).
 			Return(product, nil)
 
 		w := httptest.NewRecorder()
@@ -76,7 +76,7 @@ func TestProductHandler_PostProducts(t *testing.T) {
 
 		pvzID := uuid.New()
 
-		mockService.On("AddProduct", mock.Anything, pvzID, domain.ProductType("электроника")).
+		mockService.On("AddProduct", mock.Anything, pvzID, domain.ProductType("электроника"), (*string)(nil)).
 			Return(nil, errors.New("service error"))
 
 		w := httptest.NewRecorder()
@@ -156,7 +156,7 @@ func TestProductHandler_PostProducts(t *testing.T) {
 
 		pvzID := uuid.New()
 
-		mockService.On("AddProduct", mock.Anything, pvzID, domain.ProductType("электроника")).
+		mockService.On("AddProduct", mock.Anything, pvzID, domain.ProductType("электроника"), (*string)(nil)).
 			Return(nil, errors.New("database error"))
 
 		w := httptest.NewRecorder()
@@ -254,7 +254,7 @@ func TestProductHandler_PostProducts(t *testing.T) {
 
 		pvzID := uuid.New()
 
-		mockService.On("AddProduct", mock.Anything, pvzID, domain.ProductType("электроника")).
+		mockService.On("AddProduct", mock.Anything, pvzID, domain.ProductType("электроника"), (*string)(nil)).
 			Return(nil, errors.New("service error"))
 
 		w := httptest.NewRecorder()
@@ -268,3 +268,144 @@ func TestProductHandler_PostProducts(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 }
+
+func TestProductHandler_PostProducts_PVZFull(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockProductService)
+	handler := httpHandler.NewProductHandler(slog.Default(), mockService)
+
+	pvzID := uuid.New()
+	mockService.On("AddProduct", mock.Anything, pvzID, domain.TypeShoes, (*string)(nil)).Return(nil, domain.ErrPVZFull).Once()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/products", stringToReader(`{"pvzId":"`+pvzID.String()+`","type":"обувь"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.PostProducts(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), domain.ErrPVZFull.Error())
+	mockService.AssertExpectations(t)
+}
+
+func TestProductHandler_GetReceptionProducts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	receptionID := uuid.New()
+	newContext := func(id, rawQuery string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "receptionId", Value: id}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/receptions/"+id+"/products?"+rawQuery, nil)
+		return w, c
+	}
+
+	t.Run("успешное получение страницы товаров", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := httpHandler.NewProductHandler(logger, mockService)
+
+		products := []domain.Product{{ID: uuid.New(), ReceptionID: receptionID, Type: domain.TypeShoes}}
+		mockService.On("ListReceptionProducts", mock.Anything, receptionID, 100, 200).Return(products, 201, nil).Once()
+
+		w, c := newContext(receptionID.String(), "page=3&limit=1000")
+		handler.GetReceptionProducts(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"total":201`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("приемка не найдена", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := httpHandler.NewProductHandler(logger, mockService)
+
+		mockService.On("ListReceptionProducts", mock.Anything, receptionID, 20, 0).Return(nil, 0, domain.ErrNotFound).Once()
+
+		w, c := newContext(receptionID.String(), "")
+		handler.GetReceptionProducts(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("некорректный UUID приемки", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := httpHandler.NewProductHandler(logger, mockService)
+
+		w, c := newContext("not-a-uuid", "")
+		handler.GetReceptionProducts(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListReceptionProducts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductHandler_Transitions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	productID := uuid.New()
+	dispatchID := uuid.New()
+	newContext := func(id, action string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "productId", Value: id}}
+		c.Request = httptest.NewRequest(http.MethodPost, "/products/"+id+"/"+action, nil)
+		return w, c
+	}
+
+	t.Run("успешный возврат товара", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := httpHandler.NewProductHandler(logger, mockService)
+
+		product := &domain.Product{ID: productID, Type: domain.TypeShoes, Status: domain.ProductReturned, DispatchID: &dispatchID}
+		mockService.On("ReturnProduct", mock.Anything, productID).Return(product, nil).Once()
+
+		w, c := newContext(productID.String(), "return")
+		handler.ReturnProduct(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"returned"`)
+		assert.Contains(t, w.Body.String(), `"dispatchId":"`+dispatchID.String()+`"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("товар уже выдан", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := httpHandler.NewProductHandler(logger, mockService)
+
+		mockService.On("IssueProduct", mock.Anything, productID).Return(nil, domain.ErrProductNotOnHand).Once()
+
+		w, c := newContext(productID.String(), "issue")
+		handler.IssueProduct(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("приемка товара еще открыта", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := httpHandler.NewProductHandler(logger, mockService)
+
+		mockService.On("MarkProductLost", mock.Anything, productID).Return(nil, domain.ErrProductReceptionOpen).Once()
+
+		w, c := newContext(productID.String(), "mark_lost")
+		handler.MarkProductLost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("некорректный UUID товара", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := httpHandler.NewProductHandler(logger, mockService)
+
+		w, c := newContext("not-a-uuid", "issue")
+		handler.IssueProduct(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "IssueProduct", mock.Anything, mock.Anything)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+
 	"pvz-service-avito-internship/internal/handler/http/api"
 
 	"github.com/gin-gonic/gin"
@@ -30,11 +32,49 @@ func NewPVZHandler(log *slog.Logger, pvzService domain.PVZService, receptionServ
 	}
 }
 
+const (
+	defaultPVZListLimit = 10
+	// maxPVZPageLimit ограничивает limit при постраничной пагинации (page), maxPVZCursorLimit - при курсорной.
+	maxPVZPageLimit   = 30
+	maxPVZCursorLimit = 100
+
+	// defaultEmbeddedReceptionsLimit и maxEmbeddedReceptionsLimit ограничивают число приемок, встраиваемых в один ПВЗ.
+	defaultEmbeddedReceptionsLimit = 10
+	maxEmbeddedReceptionsLimit     = 100
+
+	// defaultNestedListLimit и maxNestedListLimit задают limit для списков приемок ПВЗ и товаров приемки.
+	defaultNestedListLimit = 20
+	maxNestedListLimit     = 100
+)
+
+const (
+	includeReceptions = "receptions"
+	includeProducts   = "products"
+)
+
+// GetPvz возвращает список ПВЗ. Без cursor работает постранично (page/limit) и по умолчанию считает total,
+// с cursor - читает страницу после курсора и total считает только по includeTotal=true.
+// По умолчанию каждый ПВЗ содержит только сводку по приемкам; сами приемки и товары встраиваются
+// по include=receptions,products (не более receptionsLimit самых свежих приемок на ПВЗ).
 func (h *PVZHandler) GetPvz(c *gin.Context) {
 	const op = "PVZHandler.GetPvz"
 	reqID := mw.GetRequestIDFromContext(c)
 	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
 
+	var cursor *domain.PVZListCursor
+	if token := c.Query("cursor"); token != "" {
+		if c.Query("page") != "" {
+			h.handleError(c, op, domain.NewFieldError("cursor", "must not be combined with page"))
+			return
+		}
+		var err error
+		cursor, err = domain.DecodePVZListCursor(token)
+		if err != nil {
+			h.handleError(c, op, err)
+			return
+		}
+	}
+
 	page, err := h.parseIntQuery(c, "page", 1)
 	if err != nil {
 		h.handleError(c, op, err)
@@ -44,45 +84,293 @@ func (h *PVZHandler) GetPvz(c *gin.Context) {
 		page = 1
 	}
 
-	limit, err := h.parseIntQuery(c, "limit", 10)
+	limit, err := h.parseIntQuery(c, "limit", defaultPVZListLimit)
 	if err != nil {
 		h.handleError(c, op, err)
 		return
 	}
+	maxLimit := maxPVZPageLimit
+	if cursor != nil {
+		maxLimit = maxPVZCursorLimit
+	}
 	if limit < 1 {
 		limit = 1
 	}
-	if limit > 30 {
-		limit = 30
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	includeTotal, err := h.parseBoolQuery(c, "includeTotal", cursor == nil)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	query, err := h.parsePVZQuery(c)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	if cursor != nil && query.SortBy != domain.PVZSortRegistrationDate {
+		h.handleError(c, op, domain.NewFieldError("cursor", fmt.Sprintf("is supported only with sortBy=%s", domain.PVZSortRegistrationDate)))
+		return
+	}
+	if cursor != nil && cursor.SortOrder != query.SortOrder {
+		h.handleError(c, op, domain.NewFieldError("cursor", fmt.Sprintf("was issued for sortOrder=%s", cursor.SortOrder)))
+		return
+	}
+
+	params := domain.PVZListParams{
+		PVZQuery:     query,
+		Limit:        limit,
+		Cursor:       cursor,
+		IncludeTotal: includeTotal,
+	}
+	if cursor == nil {
+		params.Page = page
+	}
+	if err := h.parsePVZInclude(c, &params); err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log = log.With(slog.Int("page", params.Page), slog.Int("limit", limit), slog.Bool("cursor", cursor != nil),
+		slog.String("sortBy", string(query.SortBy)), slog.String("sortOrder", string(query.SortOrder)),
+		slog.Bool("include_receptions", params.IncludeReceptions), slog.Bool("include_products", params.IncludeProducts))
+	if query.StartDate != nil {
+		log = log.With(slog.Time("startDate", *query.StartDate))
+	}
+	if query.EndDate != nil {
+		log = log.With(slog.Time("endDate", *query.EndDate))
+	}
+
+	listPage, err := h.pvzService.ListPVZs(c.Request.Context(), params)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log.Info("PVZs listed successfully", slog.Int("count", len(listPage.Items)), slog.Bool("has_next", listPage.NextCursor != nil))
+	response.SendSuccess(c, http.StatusOK, toPVZListResponse(*listPage, params.Page, limit))
+}
+
+// parsePVZQuery разбирает и проверяет фильтры и сортировку списка ПВЗ.
+// Параметр city можно повторять (city=Москва&city=Казань) или передавать через запятую.
+func (h *PVZHandler) parsePVZQuery(c *gin.Context) (domain.PVZQuery, error) {
+	var (
+		query domain.PVZQuery
+		err   error
+	)
+
+	if query.StartDate, err = h.parseDateTimeQuery(c, "startDate"); err != nil {
+		return query, err
+	}
+	if query.EndDate, err = h.parseDateTimeQuery(c, "endDate"); err != nil {
+		return query, err
+	}
+	if query.StartDate != nil && query.EndDate != nil && query.StartDate.After(*query.EndDate) {
+		return query, domain.NewFieldError("startDate", "must not be after endDate")
+	}
+
+	for _, value := range c.QueryArray("city") {
+		for _, name := range strings.Split(value, ",") {
+			city := domain.City(strings.TrimSpace(name))
+			if !city.IsValid() {
+				return query, domain.NewRuleError("city", "invalid", name)
+			}
+			query.Cities = append(query.Cities, city)
+		}
+	}
+
+	if value := c.Query("status"); value != "" {
+		status := domain.PVZStatus(value)
+		if !status.IsValid() {
+			return query, domain.NewRuleError("status", "invalid", value)
+		}
+		query.Status = &status
+	}
+
+	if c.Query("hasOpenReception") != "" {
+		hasOpen, err := h.parseBoolQuery(c, "hasOpenReception", false)
+		if err != nil {
+			return query, err
+		}
+		query.HasOpenReception = &hasOpen
+	}
+
+	if value := c.Query("productType"); value != "" {
+		productType := domain.ProductType(value)
+		if !productType.IsValid() {
+			return query, domain.NewRuleError("productType", "invalid", value)
+		}
+		query.ProductType = &productType
+	}
+
+	if c.Query("minProducts") != "" {
+		minProducts, err := h.parseIntQuery(c, "minProducts", 0)
+		if err != nil {
+			return query, err
+		}
+		if minProducts < 0 {
+			return query, domain.NewRuleError("minProducts", "nonnegative")
+		}
+		query.MinProducts = &minProducts
+	}
+
+	query.SortBy = domain.PVZSortField(c.DefaultQuery("sortBy", string(domain.PVZSortRegistrationDate)))
+	if !query.SortBy.IsValid() {
+		return query, domain.NewRuleError("sortBy", "invalid", string(query.SortBy))
 	}
+	query.SortOrder = domain.SortOrder(c.DefaultQuery("sortOrder", string(domain.SortDesc)))
+	if !query.SortOrder.IsValid() {
+		return query, domain.NewRuleError("sortOrder", "invalid", string(query.SortOrder))
+	}
+
+	return query, nil
+}
 
-	startDate, err := h.parseDateTimeQuery(c, "startDate")
+// parsePVZInclude разбирает параметр include (повторяемый или через запятую) и лимит встраиваемых приемок.
+// include=products подразумевает include=receptions.
+func (h *PVZHandler) parsePVZInclude(c *gin.Context, params *domain.PVZListParams) error {
+	for _, value := range c.QueryArray("include") {
+		for _, name := range strings.Split(value, ",") {
+			switch strings.TrimSpace(name) {
+			case includeReceptions:
+				params.IncludeReceptions = true
+			case includeProducts:
+				params.IncludeReceptions = true
+				params.IncludeProducts = true
+			default:
+				return domain.NewRuleError("include", "invalid", name)
+			}
+		}
+	}
+	if !params.IncludeReceptions {
+		return nil
+	}
+
+	receptionsLimit, err := h.parseIntQuery(c, "receptionsLimit", defaultEmbeddedReceptionsLimit)
+	if err != nil {
+		return err
+	}
+	if receptionsLimit < 1 {
+		receptionsLimit = 1
+	}
+	if receptionsLimit > maxEmbeddedReceptionsLimit {
+		receptionsLimit = maxEmbeddedReceptionsLimit
+	}
+	params.ReceptionsPerPVZ = receptionsLimit
+	return nil
+}
+
+// GetPvzReceptions возвращает постраничный список приемок ПВЗ (от новых к старым) с фильтрами по периоду, статусу,
+// сотрудникам, открывшему и закрывшему приемку, и времени закрытия.
+func (h *PVZHandler) GetPvzReceptions(c *gin.Context) {
+	const op = "PVZHandler.GetPvzReceptions"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	pvzID, err := h.parseUUID(c, "pvzId")
 	if err != nil {
 		h.handleError(c, op, err)
 		return
 	}
-	endDate, err := h.parseDateTimeQuery(c, "endDate")
+	log = log.With(slog.String("pvz_id", pvzID.String()))
+
+	page, limit, err := h.parseNestedPagination(c)
 	if err != nil {
 		h.handleError(c, op, err)
 		return
 	}
 
-	log = log.With(slog.Int("page", page), slog.Int("limit", limit))
-	if startDate != nil {
-		log = log.With(slog.Time("startDate", *startDate))
+	filter, err := h.parseReceptionListFilter(c)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+
+	receptions, total, err := h.receptionService.ListPVZReceptions(c.Request.Context(), pvzID, filter)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
 	}
-	if endDate != nil {
-		log = log.With(slog.Time("endDate", *endDate))
+
+	items := make([]api.Reception, 0, len(receptions))
+	for _, rec := range receptions {
+		items = append(items, toReceptionResponse(rec))
+	}
+
+	log.Info("PVZ receptions listed successfully", slog.Int("count", len(items)), slog.Int("total", total))
+	response.SendSuccess(c, http.StatusOK, ListReceptionsResponse{Items: items, Total: total, Page: page, Limit: limit})
+}
+
+// GetCurrentReception возвращает незакрытую приемку ПВЗ с товарами или 404, если ее нет.
+func (h *PVZHandler) GetCurrentReception(c *gin.Context) {
+	const op = "PVZHandler.GetCurrentReception"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	pvzID, err := h.parseUUID(c, "pvzId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
 	}
+	log = log.With(slog.String("pvz_id", pvzID.String()))
 
-	pvzs, total, err := h.pvzService.ListPVZs(c.Request.Context(), limit, page, startDate, endDate)
+	details, err := h.receptionService.GetCurrentReception(c.Request.Context(), pvzID)
 	if err != nil {
 		h.handleError(c, op, err)
 		return
 	}
 
-	log.Info("PVZs listed successfully", slog.Int("count", len(pvzs)), slog.Int("total", total))
-	response.SendSuccess(c, http.StatusOK, toPVZListResponse(pvzs, total, page, limit))
+	log.Info("Current reception retrieved successfully", slog.String("reception_id", details.Reception.ID.String()))
+	h.sendWithETag(c, details.Reception.Version, toReceptionDetailsResponse(*details))
+}
+
+// parseReceptionListFilter разбирает фильтры списка приемок ПВЗ (без пагинации).
+func (h *PVZHandler) parseReceptionListFilter(c *gin.Context) (domain.ReceptionListFilter, error) {
+	var (
+		filter domain.ReceptionListFilter
+		err    error
+	)
+
+	if filter.StartDate, err = h.parseDateTimeQuery(c, "startDate"); err != nil {
+		return filter, err
+	}
+	if filter.EndDate, err = h.parseDateTimeQuery(c, "endDate"); err != nil {
+		return filter, err
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return filter, domain.NewFieldError("startDate", "must not be after endDate")
+	}
+
+	if value := c.Query("status"); value != "" {
+		status := domain.ReceptionStatus(value)
+		if !status.IsValid() {
+			return filter, domain.NewRuleError("status", "invalid", value)
+		}
+		filter.Status = &status
+	}
+
+	if filter.OpenedBy, err = h.parseUUIDQuery(c, "openedBy"); err != nil {
+		return filter, err
+	}
+	if filter.ClosedBy, err = h.parseUUIDQuery(c, "closedBy"); err != nil {
+		return filter, err
+	}
+	if filter.ClosedFrom, err = h.parseDateTimeQuery(c, "closedFrom"); err != nil {
+		return filter, err
+	}
+	if filter.ClosedTo, err = h.parseDateTimeQuery(c, "closedTo"); err != nil {
+		return filter, err
+	}
+	if filter.ClosedFrom != nil && filter.ClosedTo != nil && filter.ClosedFrom.After(*filter.ClosedTo) {
+		return filter, domain.NewFieldError("closedFrom", "must not be after closedTo")
+	}
+
+	return filter, nil
 }
 
 func (h *PVZHandler) PostPvz(c *gin.Context) {
@@ -92,7 +380,7 @@ func (h *PVZHandler) PostPvz(c *gin.Context) {
 	var reqBody api.PostPvzJSONRequestBody
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Warn("Failed to bind request", slog.String("error", err.Error()))
-		response.SendError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+		h.handleError(c, op, bindError(err))
 		return
 	}
 	log = log.With(slog.String("city", string(reqBody.City)))
@@ -117,14 +405,20 @@ func (h *PVZHandler) CloseLastReception(c *gin.Context) {
 	}
 
 	log = log.With(slog.String("pvz_id", pvzID.String()))
-	reception, err := h.receptionService.CloseReception(c.Request.Context(), pvzID)
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
 
+	reception, err := h.receptionService.CloseReception(c.Request.Context(), pvzID, expectedVersion)
 	if err != nil {
 		h.handleError(c, op, err)
 		return
 	}
 
 	log.Info("Reception closed successfully", slog.String("reception_id", reception.ID.String()))
+	c.Header("ETag", formatETag(reception.Version))
 	response.SendSuccess(c, http.StatusOK, toReceptionResponse(*reception))
 }
 
@@ -146,3 +440,86 @@ func (h *PVZHandler) DeleteLastProduct(c *gin.Context) {
 	log.Info("Last product deleted successfully")
 	response.SendSuccess(c, http.StatusOK, nil)
 }
+
+// GetPvzByID возвращает ПВЗ с ETag его версии. Совпадающий If-None-Match дает 304.
+func (h *PVZHandler) GetPvzByID(c *gin.Context) {
+	const op = "PVZHandler.GetPvzByID"
+
+	pvzID, err := h.parseUUID(c, "pvzId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	pvz, err := h.pvzService.GetPVZ(c.Request.Context(), pvzID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	h.sendWithETag(c, pvz.Version, toPVZResponse(*pvz))
+}
+
+// GetPvzOccupancy возвращает текущую заполненность ПВЗ и ее ограничения.
+func (h *PVZHandler) GetPvzOccupancy(c *gin.Context) {
+	const op = "PVZHandler.GetPvzOccupancy"
+
+	pvzID, err := h.parseUUID(c, "pvzId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	occupancy, err := h.pvzService.GetOccupancy(c.Request.Context(), pvzID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	response.SendSuccess(c, http.StatusOK, toPVZOccupancyResponse(*occupancy))
+}
+
+// PutPvzCapacity заменяет вместимость ПВЗ: общую и по типам товаров.
+func (h *PVZHandler) PutPvzCapacity(c *gin.Context) {
+	const op = "PVZHandler.PutPvzCapacity"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	pvzID, err := h.parseUUID(c, "pvzId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	log = log.With(slog.String("pvz_id", pvzID.String()))
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	var reqBody api.PutPvzPvzIdCapacityJSONRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Warn("Failed to bind request", slog.String("error", err.Error()))
+		h.handleError(c, op, bindError(err))
+		return
+	}
+
+	capacity := domain.PVZCapacity{Total: reqBody.Total}
+	if reqBody.ByType != nil {
+		capacity.ByType = make(map[domain.ProductType]int, len(*reqBody.ByType))
+		for productType, limit := range *reqBody.ByType {
+			capacity.ByType[domain.ProductType(productType)] = limit
+		}
+	}
+
+	occupancy, err := h.pvzService.SetCapacity(c.Request.Context(), pvzID, capacity, expectedVersion)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log.Info("PVZ capacity updated successfully")
+	c.Header("ETag", formatETag(occupancy.Version))
+	response.SendSuccess(c, http.StatusOK, toPVZOccupancyResponse(*occupancy))
+}
@@ -2,6 +2,7 @@ package http_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"pvz-service-avito-internship/internal/domain"
 	httpHandler "pvz-service-avito-internship/internal/handler/http"
 	"strings"
@@ -30,12 +32,48 @@ func (m *MockPVZService) CreatePVZ(ctx context.Context, city domain.City) (*doma
 	return args.Get(0).(*domain.PVZ), args.Error(1)
 }
 
-func (m *MockPVZService) ListPVZs(ctx context.Context, limit, page int, startDate, endDate *time.Time) ([]domain.PVZWithDetails, int, error) {
-	args := m.Called(ctx, limit, page, startDate, endDate)
+func (m *MockPVZService) ListPVZs(ctx context.Context, params domain.PVZListParams) (*domain.PVZListPage, error) {
+	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
-		return nil, args.Int(1), args.Error(2)
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PVZListPage), args.Error(1)
+}
+
+func (m *MockPVZService) GetPVZ(ctx context.Context, id uuid.UUID) (*domain.PVZ, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PVZ), args.Error(1)
+}
+
+func (m *MockPVZService) SetCapacity(ctx context.Context, pvzID uuid.UUID, capacity domain.PVZCapacity, expectedVersion *int64) (*domain.PVZOccupancy, error) {
+	args := m.Called(ctx, pvzID, capacity, expectedVersion)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PVZOccupancy), args.Error(1)
+}
+
+func (m *MockPVZService) GetOccupancy(ctx context.Context, pvzID uuid.UUID) (*domain.PVZOccupancy, error) {
+	args := m.Called(ctx, pvzID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return args.Get(0).([]domain.PVZWithDetails), args.Int(1), args.Error(2)
+	return args.Get(0).(*domain.PVZOccupancy), args.Error(1)
+}
+
+func defaultPVZQuery() domain.PVZQuery {
+	return domain.PVZQuery{SortBy: domain.PVZSortRegistrationDate, SortOrder: domain.SortDesc}
+}
+
+func pageParams(limit, page int) domain.PVZListParams {
+	return domain.PVZListParams{PVZQuery: defaultPVZQuery(), Limit: limit, Page: page, IncludeTotal: true}
+}
+
+func intPtr(v int) *int {
+	return &v
 }
 
 func TestPVZHandler_GetPvz(t *testing.T) {
@@ -51,8 +89,8 @@ func TestPVZHandler_GetPvz(t *testing.T) {
 			{PVZ: domain.PVZ{ID: uuid.New(), City: "Saint Petersburg"}},
 		}
 
-		mockService.On("ListPVZs", mock.Anything, 10, 1, (*time.Time)(nil), (*time.Time)(nil)).
-			Return(pvzList, 2, nil)
+		mockService.On("ListPVZs", mock.Anything, pageParams(10, 1)).
+			Return(&domain.PVZListPage{Items: pvzList, Total: intPtr(2)}, nil)
 
 		req, err := http.NewRequest(http.MethodGet, "/pvz?page=1&limit=10", nil)
 		require.NoError(t, err)
@@ -71,8 +109,8 @@ func TestPVZHandler_GetPvz(t *testing.T) {
 		mockService := new(MockPVZService)
 		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
 
-		mockService.On("ListPVZs", mock.Anything, 10, 1, (*time.Time)(nil), (*time.Time)(nil)).
-			Return([]domain.PVZWithDetails{}, 0, errors.New("service error"))
+		mockService.On("ListPVZs", mock.Anything, pageParams(10, 1)).
+			Return(nil, errors.New("service error"))
 
 		req, err := http.NewRequest(http.MethodGet, "/pvz?page=1&limit=10", nil)
 		require.NoError(t, err)
@@ -115,8 +153,8 @@ func TestPVZHandler_GetPvz_InvalidParams(t *testing.T) {
 			{PVZ: domain.PVZ{ID: uuid.New(), City: "Moscow"}},
 		}
 
-		mockService.On("ListPVZs", mock.Anything, 30, 1, (*time.Time)(nil), (*time.Time)(nil)).
-			Return(pvzList, 1, nil)
+		mockService.On("ListPVZs", mock.Anything, pageParams(30, 1)).
+			Return(&domain.PVZListPage{Items: pvzList, Total: intPtr(1)}, nil)
 
 		req, err := http.NewRequest(http.MethodGet, "/pvz?page=1&limit=50", nil)
 		require.NoError(t, err)
@@ -132,12 +170,416 @@ func TestPVZHandler_GetPvz_InvalidParams(t *testing.T) {
 	})
 }
 
+func TestPVZHandler_GetPvz_Cursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	cursor := domain.PVZListCursor{RegistrationDate: time.Date(2025, 4, 10, 9, 0, 0, 123000, time.UTC), ID: uuid.New(), SortOrder: domain.SortDesc}
+	next := domain.PVZListCursor{RegistrationDate: cursor.RegistrationDate.Add(-time.Hour), ID: uuid.New(), SortOrder: domain.SortDesc}
+
+	newContext := func(target string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		return w, c
+	}
+
+	t.Run("страница после курсора", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		mockService.On("ListPVZs", mock.Anything, mock.MatchedBy(func(params domain.PVZListParams) bool {
+			return params.Cursor != nil && params.Cursor.ID == cursor.ID && params.Cursor.RegistrationDate.Equal(cursor.RegistrationDate) &&
+				params.Limit == 100 && params.Page == 0 && !params.IncludeTotal
+		})).Return(&domain.PVZListPage{
+			Items:      []domain.PVZWithDetails{{PVZ: domain.PVZ{ID: next.ID, City: domain.Kazan}}},
+			NextCursor: &next,
+		}, nil).Once()
+
+		w, c := newContext("/pvz?limit=500&cursor=" + cursor.Encode())
+		handler.GetPvz(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp httpHandler.ListPVZResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Nil(t, resp.Total)
+		assert.Zero(t, resp.Page)
+		require.NotNil(t, resp.NextCursor)
+		decoded, err := domain.DecodePVZListCursor(*resp.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, next.ID, decoded.ID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("курсор с запросом total", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		mockService.On("ListPVZs", mock.Anything, mock.MatchedBy(func(params domain.PVZListParams) bool {
+			return params.Cursor != nil && params.IncludeTotal && params.Limit == 10
+		})).Return(&domain.PVZListPage{Items: []domain.PVZWithDetails{}, Total: intPtr(12)}, nil).Once()
+
+		w, c := newContext("/pvz?includeTotal=true&cursor=" + cursor.Encode())
+		handler.GetPvz(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"items":[],"total":12,"limit":10}`, w.Body.String())
+	})
+
+	t.Run("некорректный курсор", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		w, c := newContext("/pvz?cursor=not-a-cursor")
+		handler.GetPvz(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListPVZs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("page и cursor одновременно", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		w, c := newContext("/pvz?page=2&cursor=" + cursor.Encode())
+		handler.GetPvz(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListPVZs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPVZHandler_GetPvz_Filters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	newContext := func(target string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		return w, c
+	}
+
+	t.Run("все фильтры и сортировка", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		status := domain.PVZStatusActive
+		hasOpen := false
+		productType := domain.TypeShoes
+		minProducts := 3
+		expected := pageParams(10, 2)
+		expected.PVZQuery = domain.PVZQuery{
+			Cities:           []domain.City{domain.Moscow, domain.Kazan, domain.SaintPetersburg},
+			Status:           &status,
+			HasOpenReception: &hasOpen,
+			ProductType:      &productType,
+			MinProducts:      &minProducts,
+			SortBy:           domain.PVZSortProductCount,
+			SortOrder:        domain.SortAsc,
+		}
+		mockService.On("ListPVZs", mock.Anything, expected).
+			Return(&domain.PVZListPage{Items: []domain.PVZWithDetails{}, Total: intPtr(0)}, nil).Once()
+
+		query := url.Values{
+			"page":             {"2"},
+			"city":             {"Москва,Казань", "Санкт-Петербург"},
+			"status":           {"active"},
+			"hasOpenReception": {"false"},
+			"productType":      {"обувь"},
+			"minProducts":      {"3"},
+			"sortBy":           {"product_count"},
+			"sortOrder":        {"asc"},
+		}
+		w, c := newContext("/pvz?" + query.Encode())
+		handler.GetPvz(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	invalid := map[string]string{
+		"неизвестный город":           "city=Тверь",
+		"неизвестный статус":          "status=closed",
+		"некорректный bool":           "hasOpenReception=maybe",
+		"неизвестный тип товара":      "productType=мебель",
+		"отрицательный minProducts":   "minProducts=-1",
+		"неизвестное поле сортировки": "sortBy=city",
+		"неизвестное направление":     "sortOrder=up",
+		"startDate позже endDate":     "startDate=2025-04-02T00:00:00Z&endDate=2025-04-01T00:00:00Z",
+		"cursor с другой сортировкой": "sortBy=last_reception&cursor=" +
+			domain.PVZListCursor{RegistrationDate: time.Now().UTC(), ID: uuid.New(), SortOrder: domain.SortDesc}.Encode(),
+		"cursor с другим направлением": "sortOrder=asc&cursor=" +
+			domain.PVZListCursor{RegistrationDate: time.Now().UTC(), ID: uuid.New(), SortOrder: domain.SortDesc}.Encode(),
+	}
+	for name, rawQuery := range invalid {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(MockPVZService)
+			handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+			w, c := newContext("/pvz?" + rawQuery)
+			handler.GetPvz(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "ListPVZs", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPVZHandler_GetPvz_Include(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	pvzID := uuid.New()
+	lastReceptionAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	summary := domain.PVZSummary{ReceptionsCount: 2, OpenReceptionsCount: 1, ProductsCount: 7, LastReceptionAt: &lastReceptionAt}
+
+	t.Run("по умолчанию только сводка", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		mockService.On("ListPVZs", mock.Anything, pageParams(10, 1)).
+			Return(&domain.PVZListPage{Items: []domain.PVZWithDetails{
+				{PVZ: domain.PVZ{ID: pvzID, City: domain.Moscow}, Summary: summary},
+			}, Total: intPtr(1)}, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/pvz", nil)
+		handler.GetPvz(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Items []map[string]json.RawMessage `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Items, 1)
+		assert.NotContains(t, body.Items[0], "receptions")
+		assert.JSONEq(t, `{"receptionsCount":2,"openReceptionsCount":1,"productsCount":7,"lastReceptionAt":"2025-04-01T10:00:00Z"}`,
+			string(body.Items[0]["summary"]))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("include=products встраивает приемки и товары", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		expected := pageParams(10, 1)
+		expected.IncludeReceptions = true
+		expected.IncludeProducts = true
+		expected.ReceptionsPerPVZ = 10
+		reception := domain.Reception{ID: uuid.New(), PVZID: pvzID, Status: domain.StatusClosed}
+		mockService.On("ListPVZs", mock.Anything, expected).
+			Return(&domain.PVZListPage{Items: []domain.PVZWithDetails{{
+				PVZ:        domain.PVZ{ID: pvzID, City: domain.Moscow},
+				Receptions: []domain.ReceptionWithProducts{{Reception: reception, Products: []domain.Product{}}},
+			}}, Total: intPtr(1)}, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/pvz?include=products", nil)
+		handler.GetPvz(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Items []struct {
+				Receptions []map[string]json.RawMessage `json:"receptions"`
+			} `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Items, 1)
+		require.Len(t, body.Items[0].Receptions, 1)
+		assert.JSONEq(t, `[]`, string(body.Items[0].Receptions[0]["products"]))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("receptionsLimit ограничивается максимумом", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		expected := pageParams(10, 1)
+		expected.IncludeReceptions = true
+		expected.ReceptionsPerPVZ = 100
+		mockService.On("ListPVZs", mock.Anything, expected).
+			Return(&domain.PVZListPage{Items: []domain.PVZWithDetails{}, Total: intPtr(0)}, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/pvz?include=receptions&receptionsLimit=500", nil)
+		handler.GetPvz(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("неизвестное значение include", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/pvz?include=receptions,users", nil)
+		handler.GetPvz(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListPVZs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPVZHandler_GetPvzReceptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	pvzID := uuid.New()
+	newContext := func(rawQuery string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"/receptions?"+rawQuery, nil)
+		return w, c
+	}
+
+	t.Run("успешное получение страницы приемок", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		status := domain.StatusInProgress
+		startDate := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		filter := domain.ReceptionListFilter{StartDate: &startDate, Status: &status, Limit: 5, Offset: 5}
+		receptions := []domain.Reception{{ID: uuid.New(), PVZID: pvzID, Status: domain.StatusInProgress}}
+		mockReceptionService.On("ListPVZReceptions", mock.Anything, pvzID, filter).Return(receptions, 6, nil).Once()
+
+		w, c := newContext("page=2&limit=5&status=in_progress&startDate=2025-04-01T00:00:00Z")
+		handler.GetPvzReceptions(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body httpHandler.ListReceptionsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Len(t, body.Items, 1)
+		assert.Equal(t, 6, body.Total)
+		assert.Equal(t, 2, body.Page)
+		assert.Equal(t, 5, body.Limit)
+		mockReceptionService.AssertExpectations(t)
+	})
+
+	t.Run("ПВЗ не найден", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		mockReceptionService.On("ListPVZReceptions", mock.Anything, pvzID, domain.ReceptionListFilter{Limit: 20}).
+			Return(nil, 0, domain.ErrNotFound).Once()
+
+		w, c := newContext("")
+		handler.GetPvzReceptions(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockReceptionService.AssertExpectations(t)
+	})
+
+	t.Run("неизвестный статус приемки", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		w, c := newContext("status=open")
+		handler.GetPvzReceptions(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockReceptionService.AssertNotCalled(t, "ListPVZReceptions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("фильтр по сотрудникам и времени закрытия", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		openedBy := uuid.New()
+		closedBy := uuid.New()
+		closedFrom := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		filter := domain.ReceptionListFilter{OpenedBy: &openedBy, ClosedBy: &closedBy, ClosedFrom: &closedFrom, Limit: 20}
+		mockReceptionService.On("ListPVZReceptions", mock.Anything, pvzID, filter).Return([]domain.Reception{}, 0, nil).Once()
+
+		w, c := newContext("openedBy=" + openedBy.String() + "&closedBy=" + closedBy.String() + "&closedFrom=2025-04-01T00:00:00Z")
+		handler.GetPvzReceptions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockReceptionService.AssertExpectations(t)
+	})
+
+	t.Run("некорректный closedBy", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		w, c := newContext("closedBy=not-a-uuid")
+		handler.GetPvzReceptions(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockReceptionService.AssertNotCalled(t, "ListPVZReceptions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("closedFrom позже closedTo", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		w, c := newContext("closedFrom=2025-05-01T00:00:00Z&closedTo=2025-04-01T00:00:00Z")
+		handler.GetPvzReceptions(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockReceptionService.AssertNotCalled(t, "ListPVZReceptions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPVZHandler_GetCurrentReception(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	pvzID := uuid.New()
+	newContext := func() (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"/receptions/current", nil)
+		return w, c
+	}
+
+	t.Run("открытая приемка", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		details := &domain.ReceptionDetails{
+			Reception:     domain.Reception{ID: uuid.New(), PVZID: pvzID, Status: domain.StatusInProgress},
+			Products:      []domain.Product{},
+			ProductCounts: map[domain.ProductType]int{},
+		}
+		mockReceptionService.On("GetCurrentReception", mock.Anything, pvzID).Return(details, nil).Once()
+
+		w, c := newContext()
+		handler.GetCurrentReception(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"in_progress"`)
+		assert.NotContains(t, w.Body.String(), "closedAt")
+		mockReceptionService.AssertExpectations(t)
+	})
+
+	t.Run("нет открытой приемки", func(t *testing.T) {
+		mockReceptionService := new(MockReceptionService)
+		handler := httpHandler.NewPVZHandler(logger, nil, mockReceptionService, nil)
+
+		mockReceptionService.On("GetCurrentReception", mock.Anything, pvzID).Return(nil, domain.ErrNotFound).Once()
+
+		w, c := newContext()
+		handler.GetCurrentReception(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockReceptionService.AssertExpectations(t)
+	})
+}
+
 type MockProductService struct {
 	mock.Mock
 }
 
-func (m *MockProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, productType domain.ProductType) (*domain.Product, error) {
-	args := m.Called(ctx, pvzID, productType)
+func (m *MockProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, productType domain.ProductType, itemID *string) (*domain.Product, error) {
+	args := m.Called(ctx, pvzID, productType, itemID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -149,6 +591,33 @@ func (m *MockProductService) DeleteLastProduct(ctx context.Context, pvzID uuid.U
 	return args.Error(0)
 }
 
+func (m *MockProductService) ListReceptionProducts(ctx context.Context, receptionID uuid.UUID, limit, offset int) ([]domain.Product, int, error) {
+	args := m.Called(ctx, receptionID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Product), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductService) IssueProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	return m.transition(m.Called(ctx, id))
+}
+
+func (m *MockProductService) ReturnProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	return m.transition(m.Called(ctx, id))
+}
+
+func (m *MockProductService) MarkProductLost(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	return m.transition(m.Called(ctx, id))
+}
+
+func (m *MockProductService) transition(args mock.Arguments) (*domain.Product, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
 func TestPVZHandler_PostPvz(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	logger := slog.Default()
@@ -191,7 +660,7 @@ func TestPVZHandler_CloseLastReception(t *testing.T) {
 			PVZID: pvzID,
 		}
 
-		mockReceptionService.On("CloseReception", mock.Anything, pvzID).
+		mockReceptionService.On("CloseReception", mock.Anything, pvzID, (*int64)(nil)).
 			Return(reception, nil)
 
 		w := httptest.NewRecorder()
@@ -230,6 +699,77 @@ func TestPVZHandler_DeleteLastProduct(t *testing.T) {
 	})
 }
 
+func TestPVZHandler_Capacity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	pvzID := uuid.New()
+	total := 10
+	shoesLimit := 2
+	occupancy := &domain.PVZOccupancy{
+		PVZID: pvzID, City: domain.Moscow, Occupied: 3, Capacity: &total,
+		ByType: map[domain.ProductType]domain.TypeOccupancy{domain.TypeShoes: {Occupied: 2, Capacity: &shoesLimit}},
+	}
+
+	t.Run("успешное задание вместимости", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		capacity := domain.PVZCapacity{Total: &total, ByType: map[domain.ProductType]int{domain.TypeShoes: shoesLimit}}
+		mockService.On("SetCapacity", mock.Anything, pvzID, capacity, (*int64)(nil)).Return(occupancy, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+		c.Request = httptest.NewRequest(http.MethodPut, "/pvz/"+pvzID.String()+"/capacity", stringToReader(`{"total":10,"byType":{"обувь":2}}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.PutPvzCapacity(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"occupied":3`)
+		assert.Contains(t, w.Body.String(), `"обувь":{"capacity":2,"occupied":2}`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("снятие ограничений пустым телом", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		mockService.On("SetCapacity", mock.Anything, pvzID, domain.PVZCapacity{}, (*int64)(nil)).
+			Return(&domain.PVZOccupancy{PVZID: pvzID, ByType: map[domain.ProductType]domain.TypeOccupancy{}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+		c.Request = httptest.NewRequest(http.MethodPut, "/pvz/"+pvzID.String()+"/capacity", stringToReader(`{}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.PutPvzCapacity(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), `"capacity"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("заполненность несуществующего ПВЗ", func(t *testing.T) {
+		mockService := new(MockPVZService)
+		handler := httpHandler.NewPVZHandler(logger, mockService, nil, nil)
+
+		mockService.On("GetOccupancy", mock.Anything, pvzID).Return(nil, domain.ErrNotFound).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"/occupancy", nil)
+
+		handler.GetPvzOccupancy(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
 func stringToReader(s string) *strings.Reader {
 	return strings.NewReader(s)
 }
@@ -2,7 +2,6 @@ package http
 
 import (
 	"errors"
-	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -35,20 +34,20 @@ func (h *ReceptionHandler) PostReceptions(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Warn("Failed to bind request", slog.String("error", err.Error()))
-		response.SendError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+		h.handleError(c, op, bindError(err))
 		return
 	}
 
 	if reqBody.PvzId == uuid.Nil {
 		log.Warn("pvzId is missing or invalid in request body")
-		response.SendError(c, http.StatusBadRequest, "pvzId is required and must be a valid UUID")
+		h.handleError(c, op, domain.NewRuleError("pvzId", "required"))
 		return
 	}
 
 	domainPvzID := reqBody.PvzId
 	log = log.With(slog.String("pvz_id", domainPvzID.String()))
 
-	reception, err := h.receptionService.CreateReception(c.Request.Context(), domainPvzID)
+	reception, err := h.receptionService.CreateReception(c.Request.Context(), domainPvzID, reqBody.ExpectedShipmentId)
 	if err != nil {
 		h.handleError(c, op, err)
 		return
@@ -58,6 +57,29 @@ func (h *ReceptionHandler) PostReceptions(c *gin.Context) {
 	response.SendSuccess(c, http.StatusCreated, toReceptionResponse(*reception))
 }
 
+// GetReception возвращает приемку с товарами и количеством товаров по типам.
+func (h *ReceptionHandler) GetReception(c *gin.Context) {
+	const op = "ReceptionHandler.GetReception"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	receptionID, err := h.parseUUID(c, "receptionId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	log = log.With(slog.String("reception_id", receptionID.String()))
+
+	details, err := h.receptionService.GetReception(c.Request.Context(), receptionID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log.Info("Reception retrieved successfully")
+	h.sendWithETag(c, details.Reception.Version, toReceptionDetailsResponse(*details))
+}
+
 func (h *ReceptionHandler) CloseLastReception(c *gin.Context) {
 	pvzIDParam := c.Param("pvzId")
 	pvzID, err := uuid.Parse(pvzIDParam)
@@ -66,7 +88,7 @@ func (h *ReceptionHandler) CloseLastReception(c *gin.Context) {
 		return
 	}
 
-	reception, err := h.receptionService.CloseReception(c.Request.Context(), pvzID)
+	reception, err := h.receptionService.CloseReception(c.Request.Context(), pvzID, nil)
 	if err != nil {
 		if errors.Is(err, domain.ErrPVZNotFound) || errors.Is(err, domain.ErrReceptionNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -16,28 +16,58 @@ import (
 	"pvz-service-avito-internship/internal/domain"
 	httpHandler "pvz-service-avito-internship/internal/handler/http"
 	"testing"
+	"time"
 )
 
 type MockReceptionService struct {
 	mock.Mock
 }
 
-func (m *MockReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID) (*domain.Reception, error) {
-	args := m.Called(ctx, pvzID)
+func (m *MockReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID, expectedVersion *int64) (*domain.Reception, error) {
+	args := m.Called(ctx, pvzID, expectedVersion)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Reception), args.Error(1)
 }
 
-func (m *MockReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID) (*domain.Reception, error) {
-	args := m.Called(ctx, pvzID)
+func (m *MockReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID, shipmentID *uuid.UUID) (*domain.Reception, error) {
+	args := m.Called(ctx, pvzID, shipmentID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Reception), args.Error(1)
 }
 
+func (m *MockReceptionService) ReconcileReception(ctx context.Context, reception *domain.Reception) error {
+	args := m.Called(ctx, reception)
+	return args.Error(0)
+}
+
+func (m *MockReceptionService) ListPVZReceptions(ctx context.Context, pvzID uuid.UUID, filter domain.ReceptionListFilter) ([]domain.Reception, int, error) {
+	args := m.Called(ctx, pvzID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Reception), args.Int(1), args.Error(2)
+}
+
+func (m *MockReceptionService) GetReception(ctx context.Context, id uuid.UUID) (*domain.ReceptionDetails, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReceptionDetails), args.Error(1)
+}
+
+func (m *MockReceptionService) GetCurrentReception(ctx context.Context, pvzID uuid.UUID) (*domain.ReceptionDetails, error) {
+	args := m.Called(ctx, pvzID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReceptionDetails), args.Error(1)
+}
+
 func TestReceptionHandler_CreationReception(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	logger := slog.Default()
@@ -54,7 +84,7 @@ func TestReceptionHandler_CreationReception(t *testing.T) {
 			Status: domain.StatusInProgress,
 		}
 
-		mockService.On("CreateReception", mock.Anything, pvzID).Return(expectedReception, nil)
+		mockService.On("CreateReception", mock.Anything, pvzID, (*uuid.UUID)(nil)).Return(expectedReception, nil)
 
 		reqBody := map[string]interface{}{
 			"pvzId": pvzID.String(),
@@ -128,7 +158,7 @@ func TestReceptionHandler_CreationReception(t *testing.T) {
 		handler := httpHandler.NewReceptionHandler(logger, mockService)
 
 		pvzID := uuid.New()
-		mockService.On("CreateReception", mock.Anything, pvzID).
+		mockService.On("CreateReception", mock.Anything, pvzID, (*uuid.UUID)(nil)).
 			Return(nil, errors.New("service error"))
 
 		reqBody := map[string]interface{}{
@@ -168,7 +198,7 @@ func TestReceptionHandler_CloseLastReception(t *testing.T) {
 			Status: domain.StatusClosed,
 		}
 
-		mockService.On("CloseReception", mock.Anything, pvzID).Return(expectedReception, nil)
+		mockService.On("CloseReception", mock.Anything, pvzID, (*int64)(nil)).Return(expectedReception, nil)
 
 		req, err := http.NewRequest(http.MethodPost, "/receptions/"+pvzID.String()+"/close_last", nil)
 		require.NoError(t, err)
@@ -193,3 +223,77 @@ func TestReceptionHandler_CloseLastReception(t *testing.T) {
 		assert.Equal(t, string(domain.StatusClosed), response["status"])
 	})
 }
+
+func TestReceptionHandler_GetReception(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.Default()
+
+	receptionID := uuid.New()
+	newContext := func(id string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "receptionId", Value: id}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/receptions/"+id, nil)
+		return w, c
+	}
+
+	t.Run("успешное получение приемки", func(t *testing.T) {
+		mockService := new(MockReceptionService)
+		handler := httpHandler.NewReceptionHandler(logger, mockService)
+
+		openedBy := uuid.New()
+		closedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+		details := &domain.ReceptionDetails{
+			Reception: domain.Reception{
+				ID: receptionID, PVZID: uuid.New(), Status: domain.StatusClosed,
+				DateTime: closedAt.Add(-time.Hour), ClosedAt: &closedAt, OpenedBy: &openedBy,
+			},
+			Products: []domain.Product{
+				{ID: uuid.New(), ReceptionID: receptionID, Type: domain.TypeShoes},
+				{ID: uuid.New(), ReceptionID: receptionID, Type: domain.TypeShoes},
+			},
+			ProductCounts: map[domain.ProductType]int{domain.TypeShoes: 2},
+		}
+		mockService.On("GetReception", mock.Anything, receptionID).Return(details, nil).Once()
+
+		w, c := newContext(receptionID.String())
+		handler.GetReception(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Reception     map[string]interface{} `json:"reception"`
+			Products      []interface{}          `json:"products"`
+			ProductCounts map[string]int         `json:"productCounts"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "2025-04-01T12:00:00Z", body.Reception["closedAt"])
+		assert.Equal(t, openedBy.String(), body.Reception["openedBy"])
+		assert.Len(t, body.Products, 2)
+		assert.Equal(t, map[string]int{"обувь": 2}, body.ProductCounts)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("приемка не найдена", func(t *testing.T) {
+		mockService := new(MockReceptionService)
+		handler := httpHandler.NewReceptionHandler(logger, mockService)
+
+		mockService.On("GetReception", mock.Anything, receptionID).Return(nil, domain.ErrNotFound).Once()
+
+		w, c := newContext(receptionID.String())
+		handler.GetReception(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("некорректный UUID", func(t *testing.T) {
+		mockService := new(MockReceptionService)
+		handler := httpHandler.NewReceptionHandler(logger, mockService)
+
+		w, c := newContext("123")
+		handler.GetReception(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetReception", mock.Anything, mock.Anything)
+	})
+}
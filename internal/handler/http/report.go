@@ -0,0 +1,175 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/handler/http/api"
+	"pvz-service-avito-internship/internal/handler/http/response"
+	mw "pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/pkg/tabular"
+)
+
+type ReportHandler struct {
+	BaseHandler
+	reportService domain.ReportService
+	writeTimeout  time.Duration
+}
+
+func NewReportHandler(log *slog.Logger, reportService domain.ReportService, writeTimeout time.Duration) *ReportHandler {
+	return &ReportHandler{
+		BaseHandler:   *NewBaseHandler(log),
+		reportService: reportService,
+		writeTimeout:  writeTimeout,
+	}
+}
+
+// PostReports ставит отчет в очередь и сразу отвечает 202 с адресом задачи в заголовке Location.
+func (h *ReportHandler) PostReports(c *gin.Context) {
+	const op = "ReportHandler.PostReports"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	var reqBody api.PostReportsJSONRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Warn("Failed to bind request", slog.String("error", err.Error()))
+		h.handleError(c, op, bindError(err))
+		return
+	}
+
+	var format domain.ExportFormat
+	if reqBody.Format != nil {
+		format = domain.ExportFormat(*reqBody.Format)
+	}
+	var params domain.ReportParams
+	if reqBody.Params != nil {
+		params = toReportParams(*reqBody.Params)
+	}
+
+	report, err := h.reportService.CreateReport(c.Request.Context(), domain.ReportType(reqBody.Type), format, params)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log.Info("Report job accepted", slog.String("report_id", report.ID.String()))
+	c.Header("Location", reportPath(*report))
+	response.SendSuccess(c, http.StatusAccepted, toReportResponse(*report))
+}
+
+// GetReport возвращает состояние задачи и ссылку на скачивание, если отчет готов.
+func (h *ReportHandler) GetReport(c *gin.Context) {
+	const op = "ReportHandler.GetReport"
+
+	reportID, err := h.parseUUID(c, "reportId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	report, err := h.reportService.GetReport(c.Request.Context(), reportID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	response.SendSuccess(c, http.StatusOK, toReportResponse(*report))
+}
+
+// DownloadReport отдает файл готового отчета из хранилища.
+func (h *ReportHandler) DownloadReport(c *gin.Context) {
+	const op = "ReportHandler.DownloadReport"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	reportID, err := h.parseUUID(c, "reportId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	report, content, err := h.reportService.OpenReportResult(c.Request.Context(), reportID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	defer content.Close()
+
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(h.writeTimeout)); err != nil {
+		log.Debug("Failed to extend write deadline", slog.String("error", err.Error()))
+	}
+
+	fileName := fmt.Sprintf("%s_%s.%s", report.Type, report.ID, report.Format)
+	c.Header("Content-Type", tabular.ContentType(string(report.Format)))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	c.Status(http.StatusOK)
+
+	written, err := io.Copy(c.Writer, content)
+	if err != nil {
+		log.Error("Report download interrupted", slog.Int64("bytes", written), slog.String("error", err.Error()))
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	log.Info("Report downloaded", slog.String("report_id", report.ID.String()), slog.Int64("bytes", written))
+}
+
+func reportPath(report domain.Report) string {
+	return "/reports/" + report.ID.String()
+}
+
+func toReportParams(params api.ReportParams) domain.ReportParams {
+	result := domain.ReportParams{
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+	}
+	if params.GroupBy != nil {
+		result.GroupBy = domain.StatsGroupBy(*params.GroupBy)
+	}
+	if params.City != nil {
+		city := domain.City(*params.City)
+		result.City = &city
+	}
+	return result
+}
+
+func toReportResponse(report domain.Report) api.Report {
+	params := api.ReportParams{
+		StartDate: report.Params.StartDate,
+		EndDate:   report.Params.EndDate,
+	}
+	if report.Params.GroupBy != "" {
+		groupBy := api.ReportParamsGroupBy(report.Params.GroupBy)
+		params.GroupBy = &groupBy
+	}
+	if report.Params.City != nil {
+		city := string(*report.Params.City)
+		params.City = &city
+	}
+
+	resp := api.Report{
+		Id:         report.ID,
+		Type:       api.ReportType(report.Type),
+		Format:     api.ReportFormat(report.Format),
+		Params:     params,
+		Status:     api.ReportStatus(report.Status),
+		Error:      report.Error,
+		Attempts:   report.Attempts,
+		CreatedAt:  report.CreatedAt.UTC(),
+		StartedAt:  report.StartedAt,
+		FinishedAt: report.FinishedAt,
+		ExpiresAt:  report.ExpiresAt,
+	}
+	if report.Status == domain.ReportStatusDone {
+		downloadURL := reportPath(report) + "/download"
+		resp.DownloadUrl = &downloadURL
+	}
+	return resp
+}
@@ -0,0 +1,198 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	httpHandler "pvz-service-avito-internship/internal/handler/http"
+	"pvz-service-avito-internship/internal/handler/http/api"
+)
+
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) CreateReport(ctx context.Context, reportType domain.ReportType, format domain.ExportFormat, params domain.ReportParams) (*domain.Report, error) {
+	args := m.Called(ctx, reportType, format, params)
+	if report, ok := args.Get(0).(*domain.Report); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReportService) GetReport(ctx context.Context, id uuid.UUID) (*domain.Report, error) {
+	args := m.Called(ctx, id)
+	if report, ok := args.Get(0).(*domain.Report); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReportService) OpenReportResult(ctx context.Context, id uuid.UUID) (*domain.Report, io.ReadCloser, error) {
+	args := m.Called(ctx, id)
+	report, _ := args.Get(0).(*domain.Report)
+	content, _ := args.Get(1).(io.ReadCloser)
+	return report, content, args.Error(2)
+}
+
+func TestReportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	createdAt := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("PostReports_Accepted", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handler := httpHandler.NewReportHandler(logger, mockService, time.Minute)
+
+		kazan := domain.Kazan
+		report := &domain.Report{
+			ID: uuid.New(), Type: domain.ReportProductBreakdown, Format: domain.ExportFormatXLSX,
+			Params: domain.ReportParams{GroupBy: domain.GroupByWeek, City: &kazan},
+			Status: domain.ReportStatusPending, CreatedAt: createdAt,
+		}
+		mockService.On("CreateReport", mock.Anything, domain.ReportProductBreakdown, domain.ExportFormatXLSX,
+			domain.ReportParams{GroupBy: domain.GroupByWeek, City: &kazan}).Return(report, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"type":"product_breakdown","format":"xlsx","params":{"groupBy":"week","city":"Казань"}}`
+		c.Request = httptest.NewRequest(http.MethodPost, "/reports", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.PostReports(c)
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "/reports/"+report.ID.String(), w.Header().Get("Location"))
+		var resp api.Report
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, api.ReportStatus("pending"), resp.Status)
+		assert.Nil(t, resp.DownloadUrl)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("PostReports_Validation_Error", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handler := httpHandler.NewReportHandler(logger, mockService, time.Minute)
+		mockService.On("CreateReport", mock.Anything, domain.ReportType("unknown"), domain.ExportFormat(""), domain.ReportParams{}).
+			Return(nil, domain.ErrValidation).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/reports", bytes.NewBufferString(`{"type":"unknown"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.PostReports(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("GetReport_Done_Has_Download_Url", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handler := httpHandler.NewReportHandler(logger, mockService, time.Minute)
+		report := &domain.Report{
+			ID: uuid.New(), Type: domain.ReportPVZActivity, Format: domain.ExportFormatCSV,
+			Status: domain.ReportStatusDone, Attempts: 1, CreatedAt: createdAt,
+		}
+		mockService.On("GetReport", mock.Anything, report.ID).Return(report, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/reports/"+report.ID.String(), nil)
+		c.Params = []gin.Param{{Key: "reportId", Value: report.ID.String()}}
+
+		handler.GetReport(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.Report
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.DownloadUrl)
+		assert.Equal(t, "/reports/"+report.ID.String()+"/download", *resp.DownloadUrl)
+	})
+
+	t.Run("GetReport_Not_Found", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handler := httpHandler.NewReportHandler(logger, mockService, time.Minute)
+		reportID := uuid.New()
+		mockService.On("GetReport", mock.Anything, reportID).Return(nil, domain.ErrNotFound).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/reports/"+reportID.String(), nil)
+		c.Params = []gin.Param{{Key: "reportId", Value: reportID.String()}}
+
+		handler.GetReport(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("DownloadReport_Not_Ready", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handler := httpHandler.NewReportHandler(logger, mockService, time.Minute)
+		reportID := uuid.New()
+		mockService.On("OpenReportResult", mock.Anything, reportID).Return(nil, nil, domain.ErrReportNotReady).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/reports/"+reportID.String()+"/download", nil)
+		c.Params = []gin.Param{{Key: "reportId", Value: reportID.String()}}
+
+		handler.DownloadReport(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("DownloadReport_Expired", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handler := httpHandler.NewReportHandler(logger, mockService, time.Minute)
+		reportID := uuid.New()
+		mockService.On("OpenReportResult", mock.Anything, reportID).Return(nil, nil, domain.ErrReportExpired).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/reports/"+reportID.String()+"/download", nil)
+		c.Params = []gin.Param{{Key: "reportId", Value: reportID.String()}}
+
+		handler.DownloadReport(c)
+
+		assert.Equal(t, http.StatusGone, w.Code)
+	})
+
+	t.Run("DownloadReport_Success", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handler := httpHandler.NewReportHandler(logger, mockService, time.Minute)
+		report := &domain.Report{
+			ID: uuid.New(), Type: domain.ReportReceptionSummary, Format: domain.ExportFormatCSV,
+			Status: domain.ReportStatusDone, CreatedAt: createdAt,
+		}
+		content := io.NopCloser(strings.NewReader("period_start,city\n"))
+		mockService.On("OpenReportResult", mock.Anything, report.ID).Return(report, content, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/reports/"+report.ID.String()+"/download", nil)
+		c.Params = []gin.Param{{Key: "reportId", Value: report.ID.String()}}
+
+		handler.DownloadReport(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=reception_summary_`+report.ID.String()+`.csv`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "period_start,city\n", w.Body.String())
+	})
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"pvz-service-avito-internship/internal/apperror"
+	"pvz-service-avito-internship/internal/handler/http/api"
+	"pvz-service-avito-internship/internal/i18n"
+	"pvz-service-avito-internship/pkg/requestid"
+)
+
+const (
+	// ProblemContentType - тип содержимого ответов об ошибках (RFC 7807).
+	ProblemContentType = "application/problem+json"
+	// problemTypePrefix - префикс URI типа ошибки, за которым следует ее код.
+	problemTypePrefix = "urn:problem-type:pvz-service:"
+)
+
+// SendProblem отправляет ошибку в формате application/problem+json на языке запроса (см. middleware.Locale).
+// В instance передается Request ID запроса.
+func SendProblem(c *gin.Context, problem apperror.Problem) {
+	locale := i18n.FromContext(c.Request.Context())
+	problem = problem.Localize(locale)
+	body := api.Error{
+		Type:    problemTypePrefix + problem.Code,
+		Title:   problem.Title,
+		Status:  problem.Status,
+		Detail:  problem.Detail,
+		Code:    problem.Code,
+		Message: problem.Detail,
+	}
+	if reqID := requestid.FromContext(c.Request.Context()); reqID != "" {
+		body.Instance = &reqID
+	}
+	if len(problem.Errors) > 0 {
+		fieldErrors := make([]api.FieldError, 0, len(problem.Errors))
+		for _, fe := range problem.Errors {
+			fieldErrors = append(fieldErrors, api.FieldError{Field: fe.Field, Message: fe.Message})
+		}
+		body.Errors = &fieldErrors
+	}
+
+	c.Header("Content-Type", ProblemContentType)
+	c.Header("Content-Language", string(locale))
+	c.JSON(problem.Status, body)
+}
@@ -3,14 +3,12 @@ package response
 import (
 	"github.com/gin-gonic/gin"
 
-	"pvz-service-avito-internship/internal/handler/http/api"
+	"pvz-service-avito-internship/internal/apperror"
 )
 
+// SendError отправляет ошибку в формате problem+json с общим кодом для статуса statusCode.
 func SendError(c *gin.Context, statusCode int, message string) {
-	errorResponse := api.Error{
-		Message: message,
-	}
-	c.JSON(statusCode, errorResponse)
+	SendProblem(c, apperror.Problem{Status: statusCode, Code: apperror.CodeForStatus(statusCode), Detail: message})
 }
 
 func SendSuccess(c *gin.Context, statusCode int, data interface{}) {
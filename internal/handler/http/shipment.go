@@ -0,0 +1,169 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/handler/http/api"
+	"pvz-service-avito-internship/internal/handler/http/response"
+	mw "pvz-service-avito-internship/internal/middleware"
+)
+
+type ShipmentHandler struct {
+	BaseHandler
+	shipmentService domain.ShipmentService
+}
+
+func NewShipmentHandler(log *slog.Logger, shipmentService domain.ShipmentService) *ShipmentHandler {
+	return &ShipmentHandler{
+		BaseHandler:     *NewBaseHandler(log),
+		shipmentService: shipmentService,
+	}
+}
+
+// PostExpectedShipments регистрирует ожидаемую поставку в ПВЗ.
+func (h *ShipmentHandler) PostExpectedShipments(c *gin.Context) {
+	const op = "ShipmentHandler.PostExpectedShipments"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	var reqBody api.PostExpectedShipmentsJSONRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Warn("Failed to bind request", slog.String("error", err.Error()))
+		h.handleError(c, op, bindError(err))
+		return
+	}
+
+	if reqBody.PvzId == uuid.Nil {
+		log.Warn("pvzId is missing or invalid in request body")
+		h.handleError(c, op, domain.NewRuleError("pvzId", "required"))
+		return
+	}
+
+	lines := make([]domain.ExpectedShipmentLine, 0, len(reqBody.Lines))
+	for _, line := range reqBody.Lines {
+		domainLine := domain.ExpectedShipmentLine{Type: domain.ProductType(line.Type), Quantity: line.Quantity}
+		if line.ItemIds != nil {
+			domainLine.ItemIDs = *line.ItemIds
+		}
+		lines = append(lines, domainLine)
+	}
+
+	shipment, err := h.shipmentService.CreateShipment(c.Request.Context(), reqBody.PvzId, reqBody.Reference, lines)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log.Info("Expected shipment created successfully", slog.String("shipment_id", shipment.ID.String()))
+	response.SendSuccess(c, http.StatusCreated, toExpectedShipmentResponse(*shipment))
+}
+
+// GetExpectedShipment возвращает поставку с привязанной приемкой и итогом сверки.
+func (h *ShipmentHandler) GetExpectedShipment(c *gin.Context) {
+	const op = "ShipmentHandler.GetExpectedShipment"
+
+	shipmentID, err := h.parseUUID(c, "shipmentId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	shipment, err := h.shipmentService.GetShipment(c.Request.Context(), shipmentID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	response.SendSuccess(c, http.StatusOK, toExpectedShipmentResponse(*shipment))
+}
+
+// GetReceptionReconciliation возвращает расхождения приемки с привязанной поставкой.
+func (h *ShipmentHandler) GetReceptionReconciliation(c *gin.Context) {
+	const op = "ShipmentHandler.GetReceptionReconciliation"
+
+	receptionID, err := h.parseUUID(c, "receptionId")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	reconciliation, err := h.shipmentService.GetReconciliation(c.Request.Context(), receptionID)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	response.SendSuccess(c, http.StatusOK, toReconciliationResponse(*reconciliation))
+}
+
+func toExpectedShipmentResponse(shipment domain.ExpectedShipment) api.ExpectedShipment {
+	lines := make([]api.ExpectedShipmentLine, 0, len(shipment.Lines))
+	for _, line := range shipment.Lines {
+		apiLine := api.ExpectedShipmentLine{Type: string(line.Type), Quantity: line.Quantity}
+		if len(line.ItemIDs) > 0 {
+			itemIDs := line.ItemIDs
+			apiLine.ItemIds = &itemIDs
+		}
+		lines = append(lines, apiLine)
+	}
+
+	resp := api.ExpectedShipment{
+		Id:          shipment.ID,
+		PvzId:       shipment.PVZID,
+		Reference:   shipment.Reference,
+		Lines:       lines,
+		CreatedBy:   shipment.CreatedBy,
+		CreatedAt:   shipment.CreatedAt.UTC(),
+		ReceptionId: shipment.ReceptionID,
+	}
+	if shipment.Reconciliation != nil {
+		reconciliation := toReconciliationResponse(*shipment.Reconciliation)
+		resp.Reconciliation = &reconciliation
+	}
+	return resp
+}
+
+func toReconciliationResponse(reconciliation domain.Reconciliation) api.Reconciliation {
+	resp := api.Reconciliation{
+		ShipmentId:      reconciliation.ShipmentID,
+		ReceptionId:     reconciliation.ReceptionID,
+		Status:          api.ReconciliationStatus(reconciliation.Status),
+		Final:           reconciliation.Final,
+		ReconciledAt:    reconciliation.ReconciledAt.UTC(),
+		Lines:           make([]api.ReconciliationLine, 0, len(reconciliation.Lines)),
+		MissingItems:    toReconciliationItemsResponse(reconciliation.MissingItems),
+		ExtraItems:      toReconciliationItemsResponse(reconciliation.ExtraItems),
+		MismatchedItems: make([]api.MismatchedItem, 0, len(reconciliation.MismatchedItems)),
+	}
+	for _, line := range reconciliation.Lines {
+		resp.Lines = append(resp.Lines, api.ReconciliationLine{
+			Type:     string(line.Type),
+			Expected: line.Expected,
+			Received: line.Received,
+			Missing:  line.Missing,
+			Extra:    line.Extra,
+		})
+	}
+	for _, item := range reconciliation.MismatchedItems {
+		resp.MismatchedItems = append(resp.MismatchedItems, api.MismatchedItem{
+			ItemId:       item.ItemID,
+			ProductId:    item.ProductID,
+			ExpectedType: string(item.ExpectedType),
+			ActualType:   string(item.ActualType),
+		})
+	}
+	return resp
+}
+
+func toReconciliationItemsResponse(items []domain.ReconciliationItem) []api.ReconciliationItem {
+	resp := make([]api.ReconciliationItem, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, api.ReconciliationItem{ItemId: item.ItemID, Type: string(item.Type), ProductId: item.ProductID})
+	}
+	return resp
+}
@@ -0,0 +1,178 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	httpHandler "pvz-service-avito-internship/internal/handler/http"
+	"pvz-service-avito-internship/internal/handler/http/api"
+)
+
+type MockShipmentService struct {
+	mock.Mock
+}
+
+func (m *MockShipmentService) CreateShipment(ctx context.Context, pvzID uuid.UUID, reference *string, lines []domain.ExpectedShipmentLine) (*domain.ExpectedShipment, error) {
+	args := m.Called(ctx, pvzID, reference, lines)
+	if shipment, ok := args.Get(0).(*domain.ExpectedShipment); ok {
+		return shipment, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShipmentService) GetShipment(ctx context.Context, id uuid.UUID) (*domain.ExpectedShipment, error) {
+	args := m.Called(ctx, id)
+	if shipment, ok := args.Get(0).(*domain.ExpectedShipment); ok {
+		return shipment, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShipmentService) GetReconciliation(ctx context.Context, receptionID uuid.UUID) (*domain.Reconciliation, error) {
+	args := m.Called(ctx, receptionID)
+	if reconciliation, ok := args.Get(0).(*domain.Reconciliation); ok {
+		return reconciliation, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestShipmentHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	createdAt := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	pvzID := uuid.New()
+
+	t.Run("PostExpectedShipments_Created", func(t *testing.T) {
+		mockService := new(MockShipmentService)
+		handler := httpHandler.NewShipmentHandler(logger, mockService)
+
+		lines := []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 2, ItemIDs: []string{"S-1"}}}
+		shipment := &domain.ExpectedShipment{ID: uuid.New(), PVZID: pvzID, Lines: lines, CreatedAt: createdAt}
+		mockService.On("CreateShipment", mock.Anything, pvzID, (*string)(nil), lines).Return(shipment, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"pvzId":"` + pvzID.String() + `","lines":[{"type":"обувь","quantity":2,"itemIds":["S-1"]}]}`
+		c.Request = httptest.NewRequest(http.MethodPost, "/expected_shipments", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.PostExpectedShipments(c)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp api.ExpectedShipment
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, shipment.ID, resp.Id)
+		require.Len(t, resp.Lines, 1)
+		assert.Equal(t, "обувь", resp.Lines[0].Type)
+		assert.Nil(t, resp.ReceptionId)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("PostExpectedShipments_Validation_Error", func(t *testing.T) {
+		mockService := new(MockShipmentService)
+		handler := httpHandler.NewShipmentHandler(logger, mockService)
+		mockService.On("CreateShipment", mock.Anything, pvzID, (*string)(nil), mock.Anything).Return(nil, domain.ErrValidation).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"pvzId":"` + pvzID.String() + `","lines":[{"type":"мебель","quantity":1}]}`
+		c.Request = httptest.NewRequest(http.MethodPost, "/expected_shipments", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.PostExpectedShipments(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("GetExpectedShipment_NotFound", func(t *testing.T) {
+		mockService := new(MockShipmentService)
+		handler := httpHandler.NewShipmentHandler(logger, mockService)
+		shipmentID := uuid.New()
+		mockService.On("GetShipment", mock.Anything, shipmentID).Return(nil, domain.ErrNotFound).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/expected_shipments/"+shipmentID.String(), nil)
+		c.Params = []gin.Param{{Key: "shipmentId", Value: shipmentID.String()}}
+
+		handler.GetExpectedShipment(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetReceptionReconciliation_Success", func(t *testing.T) {
+		mockService := new(MockShipmentService)
+		handler := httpHandler.NewShipmentHandler(logger, mockService)
+		receptionID := uuid.New()
+		productID := uuid.New()
+		reconciliation := &domain.Reconciliation{
+			ShipmentID: uuid.New(), ReceptionID: receptionID, Status: domain.ReconciliationDiscrepancy, Final: true,
+			ReconciledAt:    createdAt,
+			Lines:           []domain.ReconciliationLine{{Type: domain.TypeShoes, Expected: 2, Received: 1, Missing: 1}},
+			MissingItems:    []domain.ReconciliationItem{{ItemID: "S-2", Type: domain.TypeShoes}},
+			MismatchedItems: []domain.MismatchedItem{{ItemID: "C-1", ProductID: productID, ExpectedType: domain.TypeClothing, ActualType: domain.TypeShoes}},
+		}
+		mockService.On("GetReconciliation", mock.Anything, receptionID).Return(reconciliation, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/receptions/"+receptionID.String()+"/reconciliation", nil)
+		c.Params = []gin.Param{{Key: "receptionId", Value: receptionID.String()}}
+
+		handler.GetReceptionReconciliation(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.Reconciliation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, api.ReconciliationStatus("discrepancy"), resp.Status)
+		assert.True(t, resp.Final)
+		require.Len(t, resp.MissingItems, 1)
+		assert.Equal(t, "S-2", resp.MissingItems[0].ItemId)
+		assert.NotNil(t, resp.ExtraItems)
+		require.Len(t, resp.MismatchedItems, 1)
+		assert.Equal(t, productID, resp.MismatchedItems[0].ProductId)
+	})
+
+	t.Run("GetReceptionReconciliation_No_Shipment", func(t *testing.T) {
+		mockService := new(MockShipmentService)
+		handler := httpHandler.NewShipmentHandler(logger, mockService)
+		receptionID := uuid.New()
+		mockService.On("GetReconciliation", mock.Anything, receptionID).Return(nil, domain.ErrNoExpectedShipment).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/receptions/"+receptionID.String()+"/reconciliation", nil)
+		c.Params = []gin.Param{{Key: "receptionId", Value: receptionID.String()}}
+
+		handler.GetReceptionReconciliation(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetReceptionReconciliation_Invalid_ID", func(t *testing.T) {
+		handler := httpHandler.NewShipmentHandler(logger, new(MockShipmentService))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/receptions/bad/reconciliation", nil)
+		c.Params = []gin.Param{{Key: "receptionId", Value: "bad"}}
+
+		handler.GetReceptionReconciliation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/handler/http/api"
+	"pvz-service-avito-internship/internal/handler/http/response"
+	mw "pvz-service-avito-internship/internal/middleware"
+)
+
+type StatsHandler struct {
+	BaseHandler
+	statsService domain.StatsService
+}
+
+func NewStatsHandler(log *slog.Logger, statsService domain.StatsService) *StatsHandler {
+	return &StatsHandler{
+		BaseHandler:  *NewBaseHandler(log),
+		statsService: statsService,
+	}
+}
+
+func (h *StatsHandler) GetReceptionStats(c *gin.Context) {
+	const op = "StatsHandler.GetReceptionStats"
+	reqID := mw.GetRequestIDFromContext(c)
+	log := h.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	startDate, err := h.parseDateTimeQuery(c, "startDate")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+	endDate, err := h.parseDateTimeQuery(c, "endDate")
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	filter := domain.ReceptionStatsFilter{GroupBy: domain.StatsGroupBy(c.Query("groupBy"))}
+	if startDate != nil {
+		filter.StartDate = *startDate
+	}
+	if endDate != nil {
+		filter.EndDate = *endDate
+	}
+
+	stats, err := h.statsService.ReceptionStats(c.Request.Context(), filter)
+	if err != nil {
+		h.handleError(c, op, err)
+		return
+	}
+
+	log.Info("Reception stats returned", slog.Int("pvz_rows", len(stats.ByPVZ)), slog.Int("city_rows", len(stats.ByCity)))
+	response.SendSuccess(c, http.StatusOK, toReceptionStatsResponse(*stats))
+}
+
+func toReceptionStatsItem(row domain.ReceptionStatsRow) api.ReceptionStatsItem {
+	productsByType := make(map[string]int, len(row.ProductsByType))
+	for productType, count := range row.ProductsByType {
+		productsByType[string(productType)] = count
+	}
+	return api.ReceptionStatsItem{
+		Period:              row.Period.UTC(),
+		City:                string(row.City),
+		PvzId:               row.PVZID,
+		ReceptionsCount:     row.ReceptionsCount,
+		OpenReceptionsCount: row.OpenReceptionsCount,
+		ProductsCount:       row.ProductsCount,
+		ProductsByType:      productsByType,
+		AvgDurationSeconds:  row.AvgDurationSeconds,
+		P95DurationSeconds:  row.P95DurationSeconds,
+	}
+}
+
+func toReceptionStatsResponse(stats domain.ReceptionStats) api.ReceptionStats {
+	pvzItems := make([]api.ReceptionStatsItem, 0, len(stats.ByPVZ))
+	for _, row := range stats.ByPVZ {
+		pvzItems = append(pvzItems, toReceptionStatsItem(row))
+	}
+	cityItems := make([]api.ReceptionStatsItem, 0, len(stats.ByCity))
+	for _, row := range stats.ByCity {
+		cityItems = append(cityItems, toReceptionStatsItem(row))
+	}
+	return api.ReceptionStats{
+		StartDate: stats.StartDate.UTC(),
+		EndDate:   stats.EndDate.UTC(),
+		GroupBy:   api.ReceptionStatsGroupBy(stats.GroupBy),
+		Pvz:       pvzItems,
+		Cities:    cityItems,
+	}
+}
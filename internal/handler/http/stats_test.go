@@ -0,0 +1,124 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	httpHandler "pvz-service-avito-internship/internal/handler/http"
+	"pvz-service-avito-internship/internal/handler/http/api"
+)
+
+type MockStatsService struct {
+	mock.Mock
+}
+
+func (m *MockStatsService) ReceptionStats(ctx context.Context, filter domain.ReceptionStatsFilter) (*domain.ReceptionStats, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReceptionStats), args.Error(1)
+}
+
+func (m *MockStatsService) PVZActivity(ctx context.Context, filter domain.PVZActivityFilter) ([]domain.PVZActivityRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PVZActivityRow), args.Error(1)
+}
+
+func TestStatsHandler_GetReceptionStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	startDate := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockStatsService)
+		handler := httpHandler.NewStatsHandler(logger, mockService)
+
+		pvzID := uuid.New()
+		avg := 1800.0
+		filter := domain.ReceptionStatsFilter{StartDate: startDate, EndDate: endDate, GroupBy: domain.GroupByWeek}
+		mockService.On("ReceptionStats", mock.Anything, filter).Return(&domain.ReceptionStats{
+			StartDate: startDate,
+			EndDate:   endDate,
+			GroupBy:   domain.GroupByWeek,
+			ByPVZ: []domain.ReceptionStatsRow{{
+				Period: startDate, City: domain.Kazan, PVZID: &pvzID, ReceptionsCount: 3, OpenReceptionsCount: 1,
+				ProductsCount: 5, ProductsByType: map[domain.ProductType]int{domain.TypeElectronics: 2, domain.TypeClothing: 3},
+				AvgDurationSeconds: &avg, P95DurationSeconds: &avg,
+			}},
+			ByCity: []domain.ReceptionStatsRow{{
+				Period: startDate, City: domain.Kazan, ReceptionsCount: 3, OpenReceptionsCount: 1,
+				ProductsCount: 5, ProductsByType: map[domain.ProductType]int{domain.TypeElectronics: 2, domain.TypeClothing: 3},
+			}},
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet,
+			"/stats/receptions?startDate=2025-04-01T00:00:00Z&endDate=2025-05-01T00:00:00Z&groupBy=week", nil)
+
+		handler.GetReceptionStats(c)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+
+		var resp api.ReceptionStats
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, api.ReceptionStatsGroupByWeek, resp.GroupBy)
+		require.Len(t, resp.Pvz, 1)
+		assert.Equal(t, pvzID, *resp.Pvz[0].PvzId)
+		assert.Equal(t, 3, resp.Pvz[0].ProductsByType[string(domain.TypeClothing)])
+		require.NotNil(t, resp.Pvz[0].AvgDurationSeconds)
+		assert.Equal(t, avg, *resp.Pvz[0].AvgDurationSeconds)
+		require.Len(t, resp.Cities, 1)
+		assert.Nil(t, resp.Cities[0].PvzId)
+		assert.Nil(t, resp.Cities[0].P95DurationSeconds)
+	})
+
+	t.Run("Invalid_Date", func(t *testing.T) {
+		mockService := new(MockStatsService)
+		handler := httpHandler.NewStatsHandler(logger, mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/stats/receptions?startDate=yesterday", nil)
+
+		handler.GetReceptionStats(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ReceptionStats", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Validation_Error_From_Service", func(t *testing.T) {
+		mockService := new(MockStatsService)
+		handler := httpHandler.NewStatsHandler(logger, mockService)
+
+		mockService.On("ReceptionStats", mock.Anything, mock.Anything).Return(nil, domain.ErrValidation).Once()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/stats/receptions?groupBy=year", nil)
+
+		handler.GetReceptionStats(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
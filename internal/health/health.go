@@ -0,0 +1,196 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	// shutdownCheckName - имя проверки, которая проваливается после начала остановки приложения.
+	shutdownCheckName = "shutdown"
+)
+
+// CheckFunc - проверка зависимости. Должна уважать дедлайн контекста.
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult - результат одной проверки в ответе /readyz.
+type CheckResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Report - тело ответа /healthz и /readyz.
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker обслуживает эндпоинты liveness (/healthz) и readiness (/readyz).
+// Liveness отвечает 200, пока процесс способен обрабатывать HTTP запросы.
+// Readiness выполняет все зарегистрированные проверки и отвечает 503, если хотя бы одна из них не прошла
+// или приложение находится в процессе остановки.
+type Checker struct {
+	log          *slog.Logger
+	timeout      time.Duration
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+}
+
+// NewChecker создает Checker. timeout ограничивает время выполнения всех проверок одного запроса /readyz.
+func NewChecker(log *slog.Logger, timeout time.Duration) *Checker {
+	return &Checker{
+		log:     log,
+		timeout: timeout,
+	}
+}
+
+// AddReadinessCheck регистрирует проверку зависимости. Не потокобезопасен, вызывается при инициализации.
+func (c *Checker) AddReadinessCheck(name string, check CheckFunc) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// SetShuttingDown переводит readiness в состояние "не готов". Вызывается в начале graceful shutdown,
+// чтобы балансировщик перестал направлять трафик до остановки серверов.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Register добавляет /healthz и /readyz в переданный mux.
+func (c *Checker) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", c.handleLiveness)
+	mux.HandleFunc("GET /readyz", c.handleReadiness)
+}
+
+func (c *Checker) handleLiveness(w http.ResponseWriter, _ *http.Request) {
+	writeReport(w, http.StatusOK, Report{Status: StatusUp})
+}
+
+func (c *Checker) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	const op = "health.Checker.Readiness"
+
+	report := c.Readiness(r.Context())
+	statusCode := http.StatusOK
+	if report.Status != StatusUp {
+		statusCode = http.StatusServiceUnavailable
+		c.log.Warn("Readiness check failed", slog.String("op", op), slog.Any("checks", report.Checks))
+	}
+
+	writeReport(w, statusCode, report)
+}
+
+// Readiness выполняет все проверки параллельно и возвращает сводный отчет.
+func (c *Checker) Readiness(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(c.checks)+1)
+	var wg sync.WaitGroup
+	for i, nc := range c.checks {
+		wg.Add(1)
+		go func(i int, nc namedCheck) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, nc)
+		}(i, nc)
+	}
+	wg.Wait()
+
+	shutdownResult := CheckResult{Name: shutdownCheckName, Status: StatusUp}
+	if c.shuttingDown.Load() {
+		shutdownResult.Status = StatusDown
+		shutdownResult.Error = "application is shutting down"
+	}
+	results[len(results)-1] = shutdownResult
+
+	report := Report{Status: StatusUp, Checks: results}
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+			break
+		}
+	}
+	return report
+}
+
+func runCheck(ctx context.Context, nc namedCheck) CheckResult {
+	start := time.Now()
+	err := nc.check(ctx)
+	result := CheckResult{
+		Name:       nc.name,
+		Status:     StatusUp,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func writeReport(w http.ResponseWriter, statusCode int, report Report) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// Pinger - зависимость, доступность которой проверяется пингом. Реализуется *pgxpool.Pool.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingCheck возвращает проверку доступности БД.
+func PingCheck(pinger Pinger) CheckFunc {
+	return func(ctx context.Context) error {
+		if err := pinger.Ping(ctx); err != nil {
+			return fmt.Errorf("ping failed: %w", err)
+		}
+		return nil
+	}
+}
+
+// RowQuerier - минимальный интерфейс для чтения одной строки. Реализуется *pgxpool.Pool.
+type RowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// MigrationVersionCheck проверяет, что в таблице schema_migrations (golang-migrate) записана ожидаемая
+// версия схемы и миграция не осталась в состоянии dirty.
+func MigrationVersionCheck(db RowQuerier, expectedVersion uint) CheckFunc {
+	return func(ctx context.Context) error {
+		var version int64
+		var dirty bool
+		err := db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("no migrations applied, expected version %d", expectedVersion)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read migration version: %w", err)
+		}
+		if dirty {
+			return fmt.Errorf("migration version %d is dirty", version)
+		}
+		if version != int64(expectedVersion) {
+			return fmt.Errorf("migration version %d, expected %d", version, expectedVersion)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/health"
+)
+
+func newTestMux(checker *health.Checker) *http.ServeMux {
+	mux := http.NewServeMux()
+	checker.Register(mux)
+	return mux
+}
+
+func doRequest(t *testing.T, mux *http.ServeMux, path string) (int, health.Report) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var report health.Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	return rec.Code, report
+}
+
+func findCheck(report health.Report, name string) (health.CheckResult, bool) {
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return health.CheckResult{}, false
+}
+
+func TestChecker_Liveness(t *testing.T) {
+	checker := health.NewChecker(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Second)
+	checker.AddReadinessCheck("database", func(ctx context.Context) error { return errors.New("down") })
+
+	code, report := doRequest(t, newTestMux(checker), "/healthz")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusUp, report.Status)
+}
+
+func TestChecker_Readiness(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("All_Checks_Pass", func(t *testing.T) {
+		checker := health.NewChecker(logger, time.Second)
+		checker.AddReadinessCheck("database", func(ctx context.Context) error { return nil })
+
+		code, report := doRequest(t, newTestMux(checker), "/readyz")
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, health.StatusUp, report.Status)
+		dbCheck, ok := findCheck(report, "database")
+		require.True(t, ok)
+		assert.Equal(t, health.StatusUp, dbCheck.Status)
+	})
+
+	t.Run("Failing_Check", func(t *testing.T) {
+		checker := health.NewChecker(logger, time.Second)
+		checker.AddReadinessCheck("database", func(ctx context.Context) error { return nil })
+		checker.AddReadinessCheck("migrations", func(ctx context.Context) error { return errors.New("migration version 3, expected 4") })
+
+		code, report := doRequest(t, newTestMux(checker), "/readyz")
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, health.StatusDown, report.Status)
+		migrationsCheck, ok := findCheck(report, "migrations")
+		require.True(t, ok)
+		assert.Equal(t, health.StatusDown, migrationsCheck.Status)
+		assert.Contains(t, migrationsCheck.Error, "expected 4")
+	})
+
+	t.Run("Check_Exceeds_Timeout", func(t *testing.T) {
+		checker := health.NewChecker(logger, 50*time.Millisecond)
+		checker.AddReadinessCheck("database", health.PingCheck(blockingPinger{}))
+
+		start := time.Now()
+		code, report := doRequest(t, newTestMux(checker), "/readyz")
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		dbCheck, ok := findCheck(report, "database")
+		require.True(t, ok)
+		assert.Contains(t, dbCheck.Error, context.DeadlineExceeded.Error())
+	})
+
+	t.Run("Shutting_Down", func(t *testing.T) {
+		checker := health.NewChecker(logger, time.Second)
+		checker.AddReadinessCheck("database", func(ctx context.Context) error { return nil })
+		checker.SetShuttingDown()
+
+		code, report := doRequest(t, newTestMux(checker), "/readyz")
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		shutdownCheck, ok := findCheck(report, "shutdown")
+		require.True(t, ok)
+		assert.Equal(t, health.StatusDown, shutdownCheck.Status)
+	})
+}
+
+type blockingPinger struct{}
+
+func (blockingPinger) Ping(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+type fakeRow struct {
+	version int64
+	dirty   bool
+	err     error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*int64) = r.version
+	*dest[1].(*bool) = r.dirty
+	return nil
+}
+
+type fakeQuerier struct {
+	row fakeRow
+}
+
+func (q fakeQuerier) QueryRow(_ context.Context, _ string, _ ...any) pgx.Row {
+	return q.row
+}
+
+func TestMigrationVersionCheck(t *testing.T) {
+	testCases := []struct {
+		name        string
+		row         fakeRow
+		expectedErr string
+	}{
+		{name: "Expected_Version", row: fakeRow{version: 4}},
+		{name: "Outdated_Version", row: fakeRow{version: 3}, expectedErr: "migration version 3, expected 4"},
+		{name: "Dirty", row: fakeRow{version: 4, dirty: true}, expectedErr: "dirty"},
+		{name: "No_Migrations", row: fakeRow{err: pgx.ErrNoRows}, expectedErr: "no migrations applied"},
+		{name: "Query_Error", row: fakeRow{err: errors.New("relation does not exist")}, expectedErr: "failed to read migration version"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := health.MigrationVersionCheck(fakeQuerier{row: tc.row}, 4)(context.Background())
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package i18n
+
+// catalog - сообщения для клиентов по языкам. Ключи:
+//   - title.<code> и detail.<code> - заголовок и описание ошибки с кодом code (см. apperror.Code*);
+//   - validation.<tag> - сообщение об ошибке поля для правила проверки tag, параметры правила подставляются по порядку.
+//
+// Английские сообщения - язык по умолчанию, у каждого ключа должен быть английский вариант.
+var catalog = map[Locale]map[string]string{
+	LocaleEN: {
+		"title.bad_request":                 "Bad request",
+		"title.validation_failed":           "Invalid request data",
+		"title.malformed_body":              "Malformed request body",
+		"title.unauthorized":                "Unauthorized",
+		"title.forbidden":                   "Access forbidden",
+		"title.not_found":                   "Resource not found",
+		"title.conflict":                    "Resource conflict",
+		"title.gone":                        "Gone",
+		"title.precondition_failed":         "Precondition failed",
+		"title.unprocessable_entity":        "Unprocessable entity",
+		"title.too_many_requests":           "Too many requests",
+		"title.internal_error":              "Internal server error",
+		"title.database_error":              "Internal server error",
+		"title.pvz_city_not_allowed":        "City not allowed",
+		"title.pvz_full":                    "PVZ is full",
+		"title.reception_in_progress":       "Reception in progress",
+		"title.no_open_reception":           "No open reception",
+		"title.reception_closed":            "Reception closed",
+		"title.product_deletion_order":      "Wrong product deletion order",
+		"title.no_products_to_delete":       "No products to delete",
+		"title.product_not_on_hand":         "Product not on hand",
+		"title.product_reception_open":      "Product reception open",
+		"title.dispatch_already_sent":       "Dispatch already sent",
+		"title.dispatch_empty":              "Dispatch empty",
+		"title.shipment_already_attached":   "Shipment already attached",
+		"title.no_expected_shipment":        "No expected shipment",
+		"title.report_not_ready":            "Report not ready",
+		"title.report_expired":              "Report expired",
+		"title.idempotency_key_reused":      "Idempotency key reused",
+		"title.idempotency_key_in_progress": "Request in progress",
+		"title.rate_limit_exceeded":         "Rate limit exceeded",
+
+		"detail.validation_failed":           "Invalid request data",
+		"detail.malformed_body":              "Request body could not be read or is not valid JSON",
+		"detail.unauthorized":                "Unauthorized",
+		"detail.forbidden":                   "Access forbidden",
+		"detail.not_found":                   "Resource not found",
+		"detail.conflict":                    "Resource conflict",
+		"detail.precondition_failed":         "resource has been modified since it was read",
+		"detail.internal_error":              "Internal server error",
+		"detail.database_error":              "Internal server error (database operation failed)",
+		"detail.pvz_city_not_allowed":        "pvz creation is not allowed in this city",
+		"detail.pvz_full":                    "pvz has no free capacity for this product",
+		"detail.reception_in_progress":       "previous reception is still in progress",
+		"detail.no_open_reception":           "no open reception found for this pvz",
+		"detail.reception_closed":            "reception is already closed",
+		"detail.product_deletion_order":      "products can only be deleted in LIFO order from an open reception",
+		"detail.no_products_to_delete":       "no products available to delete in the current reception",
+		"detail.product_not_on_hand":         "product is not on hand at the pvz",
+		"detail.product_reception_open":      "product reception is still in progress",
+		"detail.dispatch_already_sent":       "dispatch is already sent",
+		"detail.dispatch_empty":              "dispatch has no products",
+		"detail.shipment_already_attached":   "expected shipment is already attached to a reception",
+		"detail.no_expected_shipment":        "no expected shipment attached to this reception",
+		"detail.report_not_ready":            "report is not ready yet",
+		"detail.report_expired":              "report result has expired",
+		"detail.idempotency_key_reused":      "Idempotency-Key has already been used with a different request",
+		"detail.idempotency_key_in_progress": "A request with this Idempotency-Key is still being processed",
+		"detail.rate_limit_exceeded":         "Too many requests, retry after the time in the Retry-After header",
+
+		"validation.required":          "is required",
+		"validation.email":             "must be a valid email address",
+		"validation.min":               "must be at least %s characters",
+		"validation.max":               "must be at most %s characters",
+		"validation.uuid":              "invalid UUID format",
+		"validation.integer":           "invalid integer format",
+		"validation.datetime":          "invalid date-time format (RFC3339 required)",
+		"validation.boolean":           "invalid boolean format",
+		"validation.type":              "must be of type %s",
+		"validation.oneof":             "must be one of %s",
+		"validation.nonnegative":       "must not be negative",
+		"validation.invalid":           "invalid value '%s'",
+		"validation.password_type":     "must be a string",
+		"validation.password_length":   "must be between %s and %s characters",
+		"validation.password_lower":    "must contain at least %s lowercase letter(s)",
+		"validation.password_upper":    "must contain at least %s uppercase letter(s)",
+		"validation.password_digit":    "must contain at least %s digit(s)",
+		"validation.password_symbol":   "must contain at least %s special character(s) from %s",
+		"validation.password_breached": "is a known compromised password",
+	},
+	LocaleRU: {
+		"title.bad_request":                 "Неверный запрос",
+		"title.validation_failed":           "Некорректные данные запроса",
+		"title.malformed_body":              "Некорректное тело запроса",
+		"title.unauthorized":                "Требуется авторизация",
+		"title.forbidden":                   "Доступ запрещен",
+		"title.not_found":                   "Ресурс не найден",
+		"title.conflict":                    "Конфликт ресурса",
+		"title.gone":                        "Ресурс больше недоступен",
+		"title.precondition_failed":         "Ресурс изменен",
+		"title.unprocessable_entity":        "Запрос не может быть обработан",
+		"title.too_many_requests":           "Слишком много запросов",
+		"title.internal_error":              "Внутренняя ошибка сервера",
+		"title.database_error":              "Внутренняя ошибка сервера",
+		"title.pvz_city_not_allowed":        "Город не поддерживается",
+		"title.pvz_full":                    "ПВЗ заполнен",
+		"title.reception_in_progress":       "Приемка не закрыта",
+		"title.no_open_reception":           "Нет открытой приемки",
+		"title.reception_closed":            "Приемка закрыта",
+		"title.product_deletion_order":      "Неверный порядок удаления",
+		"title.no_products_to_delete":       "Нет товаров для удаления",
+		"title.product_not_on_hand":         "Товара нет в ПВЗ",
+		"title.product_reception_open":      "Приемка товара не закрыта",
+		"title.dispatch_already_sent":       "Отправка уже отправлена",
+		"title.dispatch_empty":              "Отправка пуста",
+		"title.shipment_already_attached":   "Поставка уже привязана",
+		"title.no_expected_shipment":        "Нет ожидаемой поставки",
+		"title.report_not_ready":            "Отчет не готов",
+		"title.report_expired":              "Срок хранения отчета истек",
+		"title.idempotency_key_reused":      "Ключ идемпотентности уже использован",
+		"title.idempotency_key_in_progress": "Запрос выполняется",
+		"title.rate_limit_exceeded":         "Превышен лимит запросов",
+
+		"detail.validation_failed":           "Некорректные данные запроса",
+		"detail.malformed_body":              "Тело запроса не удалось прочитать или оно не является корректным JSON",
+		"detail.unauthorized":                "Требуется авторизация",
+		"detail.forbidden":                   "Доступ запрещен",
+		"detail.not_found":                   "Ресурс не найден",
+		"detail.conflict":                    "Конфликт ресурса",
+		"detail.precondition_failed":         "Ресурс изменен с момента чтения",
+		"detail.internal_error":              "Внутренняя ошибка сервера",
+		"detail.database_error":              "Внутренняя ошибка сервера (ошибка операции с базой данных)",
+		"detail.pvz_city_not_allowed":        "Создание ПВЗ в этом городе недоступно",
+		"detail.pvz_full":                    "В ПВЗ нет свободного места для этого товара",
+		"detail.reception_in_progress":       "Предыдущая приемка еще не закрыта",
+		"detail.no_open_reception":           "В ПВЗ нет открытой приемки",
+		"detail.reception_closed":            "Приемка уже закрыта",
+		"detail.product_deletion_order":      "Товары удаляются только из открытой приемки в обратном порядке добавления",
+		"detail.no_products_to_delete":       "В текущей приемке нет товаров для удаления",
+		"detail.product_not_on_hand":         "Товара нет в наличии в ПВЗ",
+		"detail.product_reception_open":      "Приемка товара еще не закрыта",
+		"detail.dispatch_already_sent":       "Отправка уже отправлена",
+		"detail.dispatch_empty":              "В отправке нет товаров",
+		"detail.shipment_already_attached":   "Ожидаемая поставка уже привязана к приемке",
+		"detail.no_expected_shipment":        "К приемке не привязана ожидаемая поставка",
+		"detail.report_not_ready":            "Отчет еще не готов",
+		"detail.report_expired":              "Срок хранения результата отчета истек",
+		"detail.idempotency_key_reused":      "Idempotency-Key уже использован с другим запросом",
+		"detail.idempotency_key_in_progress": "Запрос с этим Idempotency-Key еще выполняется",
+		"detail.rate_limit_exceeded":         "Слишком много запросов, повторите через время из заголовка Retry-After",
+
+		"validation.required":          "обязательное поле",
+		"validation.email":             "должно быть корректным адресом электронной почты",
+		"validation.min":               "должно содержать не менее %s символов",
+		"validation.max":               "должно содержать не более %s символов",
+		"validation.uuid":              "неверный формат UUID",
+		"validation.integer":           "должно быть целым числом",
+		"validation.datetime":          "неверный формат даты и времени (требуется RFC3339)",
+		"validation.boolean":           "должно быть true или false",
+		"validation.type":              "должно иметь тип %s",
+		"validation.oneof":             "должно быть одним из значений: %s",
+		"validation.nonnegative":       "не должно быть отрицательным",
+		"validation.invalid":           "недопустимое значение '%s'",
+		"validation.password_type":     "должно быть строкой",
+		"validation.password_length":   "должен содержать от %s до %s символов",
+		"validation.password_lower":    "должен содержать не менее %s строчных латинских букв",
+		"validation.password_upper":    "должен содержать не менее %s заглавных латинских букв",
+		"validation.password_digit":    "должен содержать не менее %s цифр",
+		"validation.password_symbol":   "должен содержать не менее %s спецсимволов (%s)",
+		"validation.password_breached": "входит в список скомпрометированных паролей",
+	},
+}
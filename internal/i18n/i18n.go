@@ -0,0 +1,86 @@
+// Package i18n содержит каталог сообщений для клиентов и выбор языка по заголовку Accept-Language.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale - язык сообщений.
+type Locale string
+
+const (
+	LocaleEN Locale = "en"
+	LocaleRU Locale = "ru"
+
+	// DefaultLocale используется, если клиент не указал поддерживаемый язык.
+	DefaultLocale = LocaleEN
+)
+
+type contextKeyLocale struct{}
+
+// WithLocale сохраняет язык запроса в контексте.
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, contextKeyLocale{}, locale)
+}
+
+// FromContext возвращает язык запроса или DefaultLocale.
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(contextKeyLocale{}).(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// MatchAcceptLanguage выбирает поддерживаемый язык из заголовка Accept-Language с учетом весов q.
+// Региональные варианты (ru-RU, en-GB) сопоставляются с основным языком.
+func MatchAcceptLanguage(header string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		locale := Locale(base)
+		if _, ok := catalog[locale]; ok {
+			candidates = append(candidates, candidate{locale: locale, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale
+}
+
+// Lookup возвращает сообщение key на языке locale, подставляя args по шаблону fmt.
+// Если в locale сообщения нет, используется DefaultLocale; ok = false, если сообщения нет совсем.
+func Lookup(locale Locale, key string, args ...any) (string, bool) {
+	message, ok := catalog[locale][key]
+	if !ok {
+		message, ok = catalog[DefaultLocale][key]
+	}
+	if !ok {
+		return "", false
+	}
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	return message, true
+}
@@ -0,0 +1,45 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"pvz-service-avito-internship/internal/i18n"
+)
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	testCases := []struct {
+		header   string
+		expected i18n.Locale
+	}{
+		{header: "", expected: i18n.DefaultLocale},
+		{header: "ru", expected: i18n.LocaleRU},
+		{header: "ru-RU,ru;q=0.9,en;q=0.8", expected: i18n.LocaleRU},
+		{header: "en-GB, ru;q=0.5", expected: i18n.LocaleEN},
+		{header: "de-DE, ru;q=0.7, en;q=0.3", expected: i18n.LocaleRU},
+		{header: "ru;q=0, en", expected: i18n.LocaleEN},
+		{header: "fr, de", expected: i18n.DefaultLocale},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			assert.Equal(t, tc.expected, i18n.MatchAcceptLanguage(tc.header))
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	message, ok := i18n.Lookup(i18n.LocaleRU, "validation.min", "8")
+	assert.True(t, ok)
+	assert.Equal(t, "должно содержать не менее 8 символов", message)
+
+	_, ok = i18n.Lookup(i18n.LocaleRU, "unknown.key")
+	assert.False(t, ok)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, i18n.DefaultLocale, i18n.FromContext(context.Background()))
+	assert.Equal(t, i18n.LocaleRU, i18n.FromContext(i18n.WithLocale(context.Background(), i18n.LocaleRU)))
+}
@@ -13,11 +13,25 @@ type collector struct {
 	requestsTotal          *prometheus.CounterVec   // Общее количество HTTP запросов
 	requestDurationSeconds *prometheus.HistogramVec // Распределение времени ответа HTTP запросов
 
+	grpcRequestsTotal          *prometheus.CounterVec   // Общее количество gRPC запросов
+	grpcRequestDurationSeconds *prometheus.HistogramVec // Распределение времени выполнения gRPC методов
+
 	pvzCreatedTotal        prometheus.Counter // Общее количество созданных ПВЗ
 	receptionsCreatedTotal prometheus.Counter // Общее количество созданных Приемок
 	productsAddedTotal     prometheus.Counter // Общее количество добавленных Товаров
+
+	staleReceptionsTotal    *prometheus.CounterVec // Количество забытых приемок, обработанных планировщиком
+	productTransitionsTotal *prometheus.CounterVec // Количество выданных, возвращенных и утерянных Товаров
+
+	pvzOccupied *prometheus.GaugeVec // Количество товаров в ПВЗ (всего и по типам)
+	pvzCapacity *prometheus.GaugeVec // Вместимость ПВЗ (только заданные ограничения)
+
+	rateLimitedTotal *prometheus.CounterVec // Количество запросов, отклоненных ограничением частоты
 }
 
+// occupancyTotalType - значение метки type для общей заполненности и вместимости ПВЗ.
+const occupancyTotalType = "all"
+
 func NewCollector() domain.MetricsCollector {
 	c := &collector{
 		requestsTotal: promauto.NewCounterVec(
@@ -35,6 +49,21 @@ func NewCollector() domain.MetricsCollector {
 			},
 			[]string{"method", "path"},
 		),
+		grpcRequestsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "pvz_grpc_requests_total",
+				Help: "Total number of processed gRPC requests.",
+			},
+			[]string{"method", "code"},
+		),
+		grpcRequestDurationSeconds: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "pvz_grpc_request_duration_seconds",
+				Help:    "Histogram of gRPC method durations in seconds.",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"method"},
+		),
 		pvzCreatedTotal: promauto.NewCounter(
 			prometheus.CounterOpts{
 				Name: "pvz_created_total",
@@ -53,6 +82,41 @@ func NewCollector() domain.MetricsCollector {
 				Help: "Total number of products added.",
 			},
 		),
+		staleReceptionsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "pvz_stale_receptions_total",
+				Help: "Total number of stale receptions closed or flagged by the scheduler.",
+			},
+			[]string{"action", "reason"},
+		),
+		productTransitionsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "pvz_product_transitions_total",
+				Help: "Total number of products issued, returned or marked lost.",
+			},
+			[]string{"status"},
+		),
+		pvzOccupied: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "pvz_occupied_products",
+				Help: "Number of products currently held at the PVZ (type=\"all\" for the total).",
+			},
+			[]string{"pvz_id", "city", "type"},
+		),
+		pvzCapacity: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "pvz_capacity_products",
+				Help: "Configured PVZ capacity (type=\"all\" for the total); absent when unlimited.",
+			},
+			[]string{"pvz_id", "city", "type"},
+		),
+		rateLimitedTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "rate_limited_requests_total",
+				Help: "Total number of requests rejected by the rate limiter.",
+			},
+			[]string{"transport", "route", "key"},
+		),
 	}
 	return c
 }
@@ -67,6 +131,16 @@ func (c *collector) ObserveRequestDuration(method, path string, duration float64
 	c.requestDurationSeconds.WithLabelValues(method, path).Observe(duration)
 }
 
+// IncGRPCRequestsTotal увеличивает счетчик общего количества gRPC запросов.
+func (c *collector) IncGRPCRequestsTotal(method, code string) {
+	c.grpcRequestsTotal.WithLabelValues(method, code).Inc()
+}
+
+// ObserveGRPCRequestDuration записывает время выполнения gRPC метода в гистограмму.
+func (c *collector) ObserveGRPCRequestDuration(method string, duration float64) {
+	c.grpcRequestDurationSeconds.WithLabelValues(method).Observe(duration)
+}
+
 // IncPVZCreated увеличивает счетчик созданных ПВЗ.
 func (c *collector) IncPVZCreated() {
 	c.pvzCreatedTotal.Inc()
@@ -82,11 +156,51 @@ func (c *collector) IncProductsAdded() {
 	c.productsAddedTotal.Inc()
 }
 
-// RunMetricsServer создает и возвращает сконфигурированный http.Server
-func RunMetricsServer(addr string) *http.Server {
+// IncStaleReceptions увеличивает счетчик забытых приемок, обработанных планировщиком.
+func (c *collector) IncStaleReceptions(action string, reason domain.StaleReason) {
+	c.staleReceptionsTotal.WithLabelValues(action, string(reason)).Inc()
+}
+
+// IncProductTransitions увеличивает счетчик товаров, переведенных в состояние status.
+func (c *collector) IncProductTransitions(status domain.ProductStatus) {
+	c.productTransitionsTotal.WithLabelValues(string(status)).Inc()
+}
+
+// SetPVZOccupancy заменяет значения метрик заполненности: ПВЗ, отсутствующие в occupancies,
+// и снятые ограничения вместимости из метрик удаляются.
+func (c *collector) SetPVZOccupancy(occupancies []domain.PVZOccupancy) {
+	c.pvzOccupied.Reset()
+	c.pvzCapacity.Reset()
+	for _, o := range occupancies {
+		pvzID, city := o.PVZID.String(), string(o.City)
+		c.pvzOccupied.WithLabelValues(pvzID, city, occupancyTotalType).Set(float64(o.Occupied))
+		if o.Capacity != nil {
+			c.pvzCapacity.WithLabelValues(pvzID, city, occupancyTotalType).Set(float64(*o.Capacity))
+		}
+		for productType, t := range o.ByType {
+			c.pvzOccupied.WithLabelValues(pvzID, city, string(productType)).Set(float64(t.Occupied))
+			if t.Capacity != nil {
+				c.pvzCapacity.WithLabelValues(pvzID, city, string(productType)).Set(float64(*t.Capacity))
+			}
+		}
+	}
+}
+
+// IncRateLimited увеличивает счетчик запросов к маршруту route (transport - http или grpc),
+// отклоненных ограничением частоты по ключу key (user, api_key или ip).
+func (c *collector) IncRateLimited(transport, route, key string) {
+	c.rateLimitedTotal.WithLabelValues(transport, route, key).Inc()
+}
+
+// RunMetricsServer создает и возвращает сконфигурированный http.Server.
+// registrars позволяют добавить служебные эндпоинты (например, /healthz и /readyz) на тот же порт.
+func RunMetricsServer(addr string, registrars ...func(mux *http.ServeMux)) *http.Server {
 	mux := http.NewServeMux()
 
 	mux.Handle("/metrics", promhttp.Handler())
+	for _, register := range registrars {
+		register(mux)
+	}
 
 	server := &http.Server{
 		Addr:    addr,
@@ -1,12 +1,14 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"pvz-service-avito-internship/internal/domain"
 	"pvz-service-avito-internship/internal/handler/http/response"
 	"pvz-service-avito-internship/pkg/jwt"
@@ -77,6 +79,7 @@ func (m *AuthMiddleware) Authorize(c *gin.Context) {
 
 	c.Set(string(UserIDKey), userID)
 	c.Set(string(UserRoleKey), userRole)
+	c.Request = c.Request.WithContext(ContextWithUser(c.Request.Context(), userID, userRole))
 
 	log = log.With(slog.String("user_id", userID.String()), slog.String("role", string(userRole)))
 	log.Debug("User authorized successfully")
@@ -130,3 +133,22 @@ func RequireRole(allowedRoles ...domain.UserRole) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// ContextWithUser сохраняет ID и роль аутентифицированного пользователя в контексте.
+// Используется как HTTP, так и gRPC транспортом, чтобы сервисы читали пользователя одинаково.
+func ContextWithUser(ctx context.Context, userID uuid.UUID, role domain.UserRole) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, userID)
+	return context.WithValue(ctx, UserRoleKey, role)
+}
+
+// GetUserIDFromContext возвращает ID пользователя, сохраненный ContextWithUser.
+func GetUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(UserIDKey).(uuid.UUID)
+	return userID, ok && userID != uuid.Nil
+}
+
+// GetUserRoleFromContext возвращает роль пользователя, сохраненную ContextWithUser.
+func GetUserRoleFromContext(ctx context.Context) (domain.UserRole, bool) {
+	role, ok := ctx.Value(UserRoleKey).(domain.UserRole)
+	return role, ok
+}
@@ -0,0 +1,236 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"pvz-service-avito-internship/internal/apperror"
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/handler/http/response"
+)
+
+const (
+	// IdempotencyKeyHeader - заголовок, которым клиент помечает повторяемый POST запрос.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader выставляется в ответе, повторенном из сохраненного.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength совпадает с размером колонки idempotency_keys.idempotency_key.
+	maxIdempotencyKeyLength = 255
+	// idempotencyPollInterval - как часто повторный запрос проверяет, завершился ли исходный.
+	idempotencyPollInterval = 100 * time.Millisecond
+)
+
+// idempotencyStoredHeaders - заголовки ответа, которые сохраняются и повторяются вместе с телом.
+var idempotencyStoredHeaders = []string{"Content-Type", "Location"}
+
+// IdempotencyMiddleware сохраняет ответы на POST запросы с заголовком Idempotency-Key и повторяет их
+// для повторных запросов с тем же ключом и тем же содержимым.
+type IdempotencyMiddleware struct {
+	log  *slog.Logger
+	repo domain.IdempotencyRepository
+	cfg  config.Idempotency
+}
+
+// NewIdempotencyMiddleware создает новый экземпляр IdempotencyMiddleware.
+func NewIdempotencyMiddleware(log *slog.Logger, repo domain.IdempotencyRepository, cfg config.Idempotency) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{log: log, repo: repo, cfg: cfg}
+}
+
+// Handle обрабатывает POST запрос с заголовком Idempotency-Key:
+//   - первый запрос захватывает ключ и выполняется, его ответ (кроме ответов 5xx) сохраняется на cfg.TTL;
+//   - повтор с тем же ключом и содержимым получает сохраненный ответ с заголовком Idempotent-Replayed;
+//   - повтор, пришедший во время выполнения первого запроса, ждет его завершения не дольше cfg.WaitTimeout,
+//     затем получает 409;
+//   - запрос с тем же ключом, но другим методом, путем или телом получает 422.
+//
+// Ключи пользователей разделены: для аутентифицированных запросов middleware должен стоять после Authorize.
+func (m *IdempotencyMiddleware) Handle(c *gin.Context) {
+	const op = "Middleware.Idempotency"
+
+	key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+	if !m.cfg.Enabled || c.Request.Method != http.MethodPost || key == "" {
+		c.Next()
+		return
+	}
+
+	reqID := GetRequestIDFromContext(c)
+	log := m.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("idempotency_key", key))
+
+	if len(key) > maxIdempotencyKeyLength {
+		log.Warn("Idempotency key is too long", slog.Int("length", len(key)))
+		response.SendProblem(c, apperror.Problem{
+			Status: http.StatusBadRequest,
+			Code:   apperror.CodeValidationFailed,
+			Errors: []domain.FieldError{{Field: IdempotencyKeyHeader, Rule: "max", Params: []string{strconv.Itoa(maxIdempotencyKeyLength)}}},
+		})
+		c.Abort()
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		log.Warn("Failed to read request body", slog.String("error", err.Error()))
+		response.SendProblem(c, apperror.Problem{Status: http.StatusBadRequest, Code: apperror.CodeMalformedBody})
+		c.Abort()
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	record := &domain.IdempotencyRecord{
+		Key:         key,
+		Method:      c.Request.Method,
+		Path:        c.Request.URL.RequestURI(),
+		RequestHash: hashIdempotentRequest(c.Request.Method, c.Request.URL.RequestURI(), body),
+		LockID:      uuid.New(),
+	}
+	if userID, ok := GetUserIDFromContext(c.Request.Context()); ok {
+		record.Scope = userID.String()
+	}
+
+	ctx := c.Request.Context()
+	waitDeadline := time.Now().Add(m.cfg.WaitTimeout)
+	for {
+		now := time.Now().UTC()
+		record.LockedAt, record.CreatedAt, record.ExpiresAt = now, now, now.Add(m.cfg.TTL)
+
+		existing, acquired, err := m.repo.Acquire(ctx, record, now.Add(-m.cfg.LockTimeout))
+		if err != nil {
+			log.Error("Failed to acquire idempotency key", slog.String("error", err.Error()))
+			response.SendProblem(c, apperror.Problem{Status: http.StatusInternalServerError, Code: apperror.CodeInternal})
+			c.Abort()
+			return
+		}
+		if acquired {
+			m.execute(c, log, record)
+			return
+		}
+
+		if existing.RequestHash != record.RequestHash {
+			log.Warn("Idempotency key reused with a different request", slog.String("original_path", existing.Path))
+			response.SendProblem(c, apperror.Problem{
+				Status: http.StatusUnprocessableEntity,
+				Code:   apperror.CodeIdempotencyKeyReused,
+			})
+			c.Abort()
+			return
+		}
+		if existing.Status == domain.IdempotencyCompleted {
+			log.Info("Replaying stored response", slog.Int("status_code", existing.ResponseStatus))
+			replayIdempotentResponse(c, existing)
+			return
+		}
+		if !time.Now().Before(waitDeadline) {
+			log.Warn("Request with the same idempotency key is still in progress")
+			c.Header("Retry-After", "1")
+			response.SendProblem(c, apperror.Problem{
+				Status: http.StatusConflict,
+				Code:   apperror.CodeIdempotencyKeyInProgress,
+			})
+			c.Abort()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			c.Abort()
+			return
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+}
+
+// execute выполняет запрос, захвативший ключ, и сохраняет его ответ. Если сохранить ответ не удалось,
+// ответ получен с ошибкой сервера или обработчик завершился паникой, ключ освобождается для повтора.
+func (m *IdempotencyMiddleware) execute(c *gin.Context, log *slog.Logger, record *domain.IdempotencyRecord) {
+	// Ответ клиенту уже отправлен, поэтому запись в БД не должна прерываться отменой запроса.
+	ctx := context.WithoutCancel(c.Request.Context())
+
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := m.repo.Release(ctx, record.Scope, record.Key, record.LockID); err != nil {
+			log.Error("Failed to release idempotency key", slog.String("error", err.Error()))
+		}
+	}()
+
+	writer := &bodyCaptureWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	c.Next()
+
+	status := writer.Status()
+	if status >= http.StatusInternalServerError {
+		log.Warn("Response is not stored for idempotency key", slog.Int("status_code", status))
+		return
+	}
+
+	record.Status = domain.IdempotencyCompleted
+	record.ResponseStatus = status
+	record.ResponseBody = writer.body.Bytes()
+	record.ResponseHeaders = make(map[string]string, len(idempotencyStoredHeaders))
+	for _, name := range idempotencyStoredHeaders {
+		if value := writer.Header().Get(name); value != "" {
+			record.ResponseHeaders[name] = value
+		}
+	}
+	record.ExpiresAt = time.Now().UTC().Add(m.cfg.TTL)
+
+	if err := m.repo.Complete(ctx, record); err != nil {
+		log.Error("Failed to store response for idempotency key", slog.String("error", err.Error()))
+		return
+	}
+	completed = true
+}
+
+// replayIdempotentResponse отправляет сохраненный ответ и прерывает обработку запроса.
+func replayIdempotentResponse(c *gin.Context, record *domain.IdempotencyRecord) {
+	for name, value := range record.ResponseHeaders {
+		c.Header(name, value)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Status(record.ResponseStatus)
+	if len(record.ResponseBody) > 0 {
+		_, _ = c.Writer.Write(record.ResponseBody)
+	}
+	c.Abort()
+}
+
+// hashIdempotentRequest возвращает SHA-256 (hex) метода, пути с параметрами и тела запроса.
+func hashIdempotentRequest(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(uri))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyCaptureWriter передает ответ клиенту и одновременно копирует тело для сохранения.
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCaptureWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
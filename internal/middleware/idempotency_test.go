@@ -0,0 +1,210 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	mw "pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.Idempotency{Enabled: true, TTL: time.Hour, LockTimeout: time.Minute, WaitTimeout: time.Second}
+	const body = `{"type":"обувь"}`
+
+	setup := func(t *testing.T, cfg config.Idempotency, status int) (*gin.Engine, *mocks.IdempotencyRepository, *atomic.Int32) {
+		repo := mocks.NewIdempotencyRepository(t)
+		calls := &atomic.Int32{}
+		router := gin.New()
+		router.Use(mw.NewIdempotencyMiddleware(log, repo, cfg).Handle)
+		router.POST("/products", func(c *gin.Context) {
+			calls.Add(1)
+			reqBody, _ := io.ReadAll(c.Request.Body)
+			c.Header("Location", "/products/1")
+			c.Data(status, "application/json", reqBody)
+		})
+		return router, repo, calls
+	}
+	newRequest := func(key, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(mw.IdempotencyKeyHeader, key)
+		}
+		return req
+	}
+	// stored возвращает запись, сохраненную первым запросом с тем же ключом и телом.
+	stored := func(t *testing.T, router *gin.Engine, repo *mocks.IdempotencyRepository, status domain.IdempotencyStatus) *domain.IdempotencyRecord {
+		var hash string
+		repo.On("Acquire", mock.Anything, mock.AnythingOfType("*domain.IdempotencyRecord"), mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { hash = args.Get(1).(*domain.IdempotencyRecord).RequestHash }).
+			Return(nil, false, assert.AnError).Once()
+		router.ServeHTTP(httptest.NewRecorder(), newRequest("key-1", body))
+		require.Len(t, hash, 64)
+		return &domain.IdempotencyRecord{
+			Key: "key-1", Path: "/products", RequestHash: hash, Status: status, LockID: uuid.New(),
+			ResponseStatus:  http.StatusCreated,
+			ResponseHeaders: map[string]string{"Content-Type": "application/json", "Location": "/products/1"},
+			ResponseBody:    []byte(body),
+		}
+	}
+	anyRecord := mock.AnythingOfType("*domain.IdempotencyRecord")
+	anyTime := mock.AnythingOfType("time.Time")
+
+	t.Run("Without_Key_Passes_Through", func(t *testing.T) {
+		router, _, calls := setup(t, cfg, http.StatusCreated)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("Disabled_Passes_Through", func(t *testing.T) {
+		disabled := cfg
+		disabled.Enabled = false
+		router, _, calls := setup(t, disabled, http.StatusCreated)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("key-1", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("Key_Too_Long", func(t *testing.T) {
+		router, _, calls := setup(t, cfg, http.StatusCreated)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest(strings.Repeat("k", 256), body))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.EqualValues(t, 0, calls.Load())
+	})
+
+	t.Run("First_Request_Stores_Response", func(t *testing.T) {
+		router, repo, calls := setup(t, cfg, http.StatusCreated)
+		var acquired *domain.IdempotencyRecord
+		repo.On("Acquire", mock.Anything, anyRecord, anyTime).
+			Run(func(args mock.Arguments) { acquired = args.Get(1).(*domain.IdempotencyRecord) }).
+			Return(&domain.IdempotencyRecord{}, true, nil).Once()
+		repo.On("Complete", mock.Anything, mock.MatchedBy(func(r *domain.IdempotencyRecord) bool {
+			return r.Key == "key-1" && r.Status == domain.IdempotencyCompleted && r.ResponseStatus == http.StatusCreated &&
+				string(r.ResponseBody) == body && r.ResponseHeaders["Location"] == "/products/1" &&
+				r.ResponseHeaders["Content-Type"] == "application/json"
+		})).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("key-1", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, body, rr.Body.String())
+		assert.Empty(t, rr.Header().Get(mw.IdempotentReplayedHeader))
+		assert.EqualValues(t, 1, calls.Load())
+		require.NotNil(t, acquired)
+		assert.Equal(t, "", acquired.Scope)
+		assert.Equal(t, http.MethodPost, acquired.Method)
+		assert.Equal(t, "/products", acquired.Path)
+		assert.NotEqual(t, uuid.Nil, acquired.LockID)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), acquired.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Completed_Key_Replays_Response", func(t *testing.T) {
+		router, repo, calls := setup(t, cfg, http.StatusCreated)
+		existing := stored(t, router, repo, domain.IdempotencyCompleted)
+		repo.On("Acquire", mock.Anything, anyRecord, anyTime).Return(existing, false, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("key-1", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, body, rr.Body.String())
+		assert.Equal(t, "true", rr.Header().Get(mw.IdempotentReplayedHeader))
+		assert.Equal(t, "/products/1", rr.Header().Get("Location"))
+		assert.EqualValues(t, 0, calls.Load())
+	})
+
+	t.Run("Key_Reused_With_Different_Payload", func(t *testing.T) {
+		router, repo, calls := setup(t, cfg, http.StatusCreated)
+		existing := stored(t, router, repo, domain.IdempotencyCompleted)
+		repo.On("Acquire", mock.Anything, anyRecord, anyTime).Return(existing, false, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("key-1", `{"type":"одежда"}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"idempotency_key_reused"`)
+		assert.EqualValues(t, 0, calls.Load())
+	})
+
+	t.Run("Concurrent_Duplicate_Waits_For_Original", func(t *testing.T) {
+		router, repo, calls := setup(t, cfg, http.StatusCreated)
+		inProgress := stored(t, router, repo, domain.IdempotencyInProgress)
+		completed := *inProgress
+		completed.Status = domain.IdempotencyCompleted
+		repo.On("Acquire", mock.Anything, anyRecord, anyTime).Return(inProgress, false, nil).Once()
+		repo.On("Acquire", mock.Anything, anyRecord, anyTime).Return(&completed, false, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("key-1", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, "true", rr.Header().Get(mw.IdempotentReplayedHeader))
+		assert.EqualValues(t, 0, calls.Load())
+	})
+
+	t.Run("Concurrent_Duplicate_Wait_Timeout", func(t *testing.T) {
+		noWait := cfg
+		noWait.WaitTimeout = 0
+		router, repo, calls := setup(t, noWait, http.StatusCreated)
+		inProgress := stored(t, router, repo, domain.IdempotencyInProgress)
+		repo.On("Acquire", mock.Anything, anyRecord, anyTime).Return(inProgress, false, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("key-1", body))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+		assert.EqualValues(t, 0, calls.Load())
+	})
+
+	t.Run("Server_Error_Releases_Key", func(t *testing.T) {
+		router, repo, calls := setup(t, cfg, http.StatusInternalServerError)
+		repo.On("Acquire", mock.Anything, anyRecord, anyTime).Return(&domain.IdempotencyRecord{}, true, nil).Once()
+		repo.On("Release", mock.Anything, "", "key-1", mock.AnythingOfType("uuid.UUID")).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("key-1", body))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.EqualValues(t, 1, calls.Load())
+		repo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Acquire_Error", func(t *testing.T) {
+		router, repo, calls := setup(t, cfg, http.StatusCreated)
+		repo.On("Acquire", mock.Anything, anyRecord, anyTime).Return(nil, false, assert.AnError).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest("key-1", body))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.EqualValues(t, 0, calls.Load())
+	})
+}
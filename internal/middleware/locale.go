@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"pvz-service-avito-internship/internal/i18n"
+)
+
+// AcceptLanguageHeader - заголовок, по которому выбирается язык сообщений об ошибках.
+const AcceptLanguageHeader = "Accept-Language"
+
+// Locale выбирает язык сообщений по заголовку Accept-Language и сохраняет его в контексте запроса.
+// Без заголовка или для неподдерживаемых языков используется i18n.DefaultLocale.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.MatchAcceptLanguage(c.GetHeader(AcceptLanguageHeader))
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), locale))
+		c.Writer.Header().Add("Vary", AcceptLanguageHeader)
+		c.Next()
+	}
+}
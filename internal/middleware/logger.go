@@ -8,11 +8,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-)
-
-type contextKeyRequestID string
 
-const RequestIDKey contextKeyRequestID = "requestID"
+	"pvz-service-avito-internship/internal/tracing"
+	"pvz-service-avito-internship/pkg/requestid"
+)
 
 type LoggingMiddleware struct {
 	log *slog.Logger
@@ -29,12 +28,18 @@ func (m *LoggingMiddleware) LogRequest(c *gin.Context) {
 	method := c.Request.Method
 	ip := c.ClientIP()
 	userAgent := c.Request.UserAgent()
-	requestID := uuid.New().String()
-
-	c.Set(string(RequestIDKey), requestID)
+	// Request ID берется из заголовка X-Request-ID (например, выданный прокси), если он корректен.
+	// Иначе он совпадает с ID трассировки запроса, чтобы по нему можно было найти трассировку.
+	requestID := c.GetHeader(requestid.Header)
+	if !requestid.Valid(requestID) {
+		requestID = tracing.TraceID(c.Request.Context())
+	}
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
 
-	ctxWithReqID := context.WithValue(c.Request.Context(), RequestIDKey, requestID)
-	c.Request = c.Request.WithContext(ctxWithReqID)
+	c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), requestID))
+	c.Header(requestid.Header, requestID)
 
 	requestLogger := m.log.With(
 		slog.String("request_id", requestID),
@@ -73,22 +78,13 @@ func (m *LoggingMiddleware) LogRequest(c *gin.Context) {
 	}
 }
 
+// GetRequestIDFromContext возвращает Request ID из ctx. Для *gin.Context он берется из контекста HTTP запроса.
 func GetRequestIDFromContext(ctx context.Context) string {
-	if reqID, ok := ctx.Value(RequestIDKey).(string); ok && reqID != "" {
-		return reqID
-	}
-
 	if gCtx, ok := ctx.(*gin.Context); ok {
-		if reqID, exists := gCtx.Get(string(RequestIDKey)); exists {
-			if idStr, ok := reqID.(string); ok && idStr != "" {
-				return idStr
-			}
-		}
-		if reqID, ok := gCtx.Request.Context().Value(RequestIDKey).(string); ok && reqID != "" {
-			return reqID
+		if gCtx.Request == nil {
+			return ""
 		}
-
+		ctx = gCtx.Request.Context()
 	}
-
-	return ""
+	return requestid.FromContext(ctx)
 }
@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	mw "pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/pkg/requestid"
+)
+
+func TestLoggingMiddleware_RequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	logging := mw.NewLoggingMiddleware(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	router := gin.New()
+	router.Use(otelgin.Middleware("pvz-test"), logging.LogRequest)
+	router.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, mw.GetRequestIDFromContext(c))
+	})
+
+	t.Run("InboundTraceparent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Body.String())
+		assert.Equal(t, w.Body.String(), w.Header().Get(requestid.Header))
+	})
+
+	t.Run("InboundRequestID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		req.Header.Set(requestid.Header, "edge-01:42")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "edge-01:42", w.Body.String())
+		assert.Equal(t, "edge-01:42", w.Header().Get(requestid.Header))
+	})
+
+	t.Run("InvalidInboundRequestID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		req.Header.Set(requestid.Header, "req 1; level=ERROR")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Body.String())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Header().Get(requestid.Header))
+	})
+
+	t.Run("NewTrace", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		assert.Len(t, w.Body.String(), 32)
+		assert.Equal(t, w.Body.String(), w.Header().Get(requestid.Header))
+	})
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvz-service-avito-internship/internal/apperror"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/handler/http/response"
+	"pvz-service-avito-internship/internal/ratelimit"
+)
+
+// Заголовки ограничения частоты запросов (draft-ietf-httpapi-ratelimit-headers).
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimitMiddleware ограничивает частоту запросов по политикам ratelimit.Limiter.
+type RateLimitMiddleware struct {
+	log     *slog.Logger
+	limiter *ratelimit.Limiter
+	metrics domain.MetricsCollector
+}
+
+// NewRateLimitMiddleware создает новый экземпляр RateLimitMiddleware. Если limiter равен nil, запросы не ограничиваются.
+func NewRateLimitMiddleware(log *slog.Logger, limiter *ratelimit.Limiter, metrics domain.MetricsCollector) *RateLimitMiddleware {
+	return &RateLimitMiddleware{log: log, limiter: limiter, metrics: metrics}
+}
+
+// Handle проверяет запрос по политике маршрута, добавляет в ответ заголовки RateLimit-* и
+// отклоняет запрос с 429 и Retry-After, если лимит исчерпан.
+// Чтобы запросы считались по пользователю, middleware должен стоять после Authorize.
+func (m *RateLimitMiddleware) Handle(c *gin.Context) {
+	const op = "Middleware.RateLimit"
+
+	if m.limiter == nil {
+		c.Next()
+		return
+	}
+
+	route := c.Request.Method + " " + c.FullPath()
+	identity := ratelimit.Identity{
+		APIKey: c.GetHeader(m.limiter.APIKeyHeader()),
+		IP:     c.ClientIP(),
+	}
+	if userID, ok := GetUserIDFromContext(c.Request.Context()); ok {
+		identity.UserID = userID.String()
+	}
+
+	result, limited := m.limiter.Allow(c.Request.Context(), route, identity)
+	if !limited {
+		c.Next()
+		return
+	}
+
+	c.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+	c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+	c.Header(RateLimitResetHeader, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	if !result.Allowed {
+		m.log.Warn("Rate limit exceeded",
+			slog.String("op", op),
+			slog.String("request_id", GetRequestIDFromContext(c)),
+			slog.String("route", route),
+			slog.String("key", result.Key),
+		)
+		m.metrics.IncRateLimited("http", route, result.Key)
+		c.Header(RetryAfterHeader, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		response.SendProblem(c, apperror.Problem{
+			Status: http.StatusTooManyRequests,
+			Code:   apperror.CodeRateLimitExceeded,
+		})
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// ceilSeconds округляет длительность вверх до целых секунд, как требуют заголовки RateLimit-Reset и Retry-After.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,120 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	mw "pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/ratelimit"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.RateLimit{
+		APIKeyHeader: "X-API-Key",
+		Policies: []config.RateLimitPolicy{
+			{Routes: []string{"POST /products"}, Rate: 0.5, Burst: 2, Key: ratelimit.KeyUser},
+		},
+	}
+
+	setup := func(t *testing.T) (*gin.Engine, *mocks.MetricsCollector) {
+		limiter, err := ratelimit.NewLimiter(log, ratelimit.NewMemoryStore(), cfg)
+		require.NoError(t, err)
+		metrics := mocks.NewMetricsCollector(t)
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if userID := c.GetHeader("X-Test-User"); userID != "" {
+				ctx := mw.ContextWithUser(c.Request.Context(), uuid.MustParse(userID), domain.RoleEmployee)
+				c.Request = c.Request.WithContext(ctx)
+			}
+		})
+		router.Use(mw.NewRateLimitMiddleware(log, limiter, metrics).Handle)
+		router.POST("/products", func(c *gin.Context) { c.Status(http.StatusCreated) })
+		router.GET("/pvz", func(c *gin.Context) { c.Status(http.StatusOK) })
+		return router, metrics
+	}
+	newRequest := func(method, path, userID string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		if userID != "" {
+			req.Header.Set("X-Test-User", userID)
+		}
+		return req
+	}
+	user := uuid.New().String()
+
+	t.Run("Throttles_After_Burst", func(t *testing.T) {
+		router, metrics := setup(t)
+		metrics.On("IncRateLimited", "http", "POST /products", ratelimit.KeyUser).Once()
+
+		for _, remaining := range []string{"1", "0"} {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, newRequest(http.MethodPost, "/products", user))
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			assert.Equal(t, "2", rr.Header().Get(mw.RateLimitLimitHeader))
+			assert.Equal(t, remaining, rr.Header().Get(mw.RateLimitRemainingHeader))
+		}
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest(http.MethodPost, "/products", user))
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "2", rr.Header().Get(mw.RetryAfterHeader))
+		assert.Equal(t, "4", rr.Header().Get(mw.RateLimitResetHeader))
+		assert.Contains(t, rr.Body.String(), `"code":"rate_limit_exceeded"`)
+
+		// Лимит другого пользователя не исчерпан.
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest(http.MethodPost, "/products", uuid.New().String()))
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("Rotating_API_Key_Is_Limited_By_IP", func(t *testing.T) {
+		router, metrics := setup(t)
+		metrics.On("IncRateLimited", "http", "POST /products", ratelimit.KeyIP).Once()
+
+		// Без пользователя и выданного API ключа запросы считаются по IP, новое значение заголовка не дает новый bucket.
+		codes := make([]int, 0, 3)
+		for i := 0; i < 3; i++ {
+			req := newRequest(http.MethodPost, "/products", "")
+			req.Header.Set("X-API-Key", uuid.New().String())
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			codes = append(codes, rr.Code)
+		}
+		assert.Equal(t, []int{http.StatusCreated, http.StatusCreated, http.StatusTooManyRequests}, codes)
+	})
+
+	t.Run("Route_Without_Policy_Is_Not_Limited", func(t *testing.T) {
+		router, _ := setup(t)
+
+		for i := 0; i < 5; i++ {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, newRequest(http.MethodGet, "/pvz", user))
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Empty(t, rr.Header().Get(mw.RateLimitLimitHeader))
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		router := gin.New()
+		router.Use(mw.NewRateLimitMiddleware(log, nil, nil).Handle)
+		router.POST("/products", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newRequest(http.MethodPost, "/products", ""))
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Empty(t, rr.Header().Get(mw.RateLimitLimitHeader))
+	})
+}
@@ -0,0 +1,170 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// Ключи, по которым считаются запросы.
+const (
+	KeyUser   = "user"
+	KeyAPIKey = "api_key"
+	KeyIP     = "ip"
+)
+
+// Identity - данные запроса, по которым выбирается ключ ограничения.
+// APIKey - значение заголовка как есть; ключ учитывается, только если он есть в конфигурации.
+type Identity struct {
+	UserID string
+	APIKey string
+	IP     string
+}
+
+// key возвращает тип и значение ключа с учетом порядка замены user -> api_key -> ip.
+// apiKeyHash - хэш проверенного API ключа (см. Limiter.verifyAPIKey) или пустая строка.
+func (id Identity) key(preferred, apiKeyHash string) (string, string) {
+	switch {
+	case preferred == KeyUser && id.UserID != "":
+		return KeyUser, id.UserID
+	case preferred != KeyIP && apiKeyHash != "":
+		return KeyAPIKey, apiKeyHash
+	default:
+		return KeyIP, id.IP
+	}
+}
+
+// hashAPIKey хэширует API ключ, чтобы он не попадал в хранилище в открытом виде.
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:16])
+}
+
+// policy - ограничение маршрута.
+type policy struct {
+	limit domain.RateLimit
+	key   string
+}
+
+// Result - решение по запросу к маршруту с ограничением.
+type Result struct {
+	domain.RateLimitDecision
+	// Key - тип ключа, по которому посчитан запрос (user, api_key или ip).
+	Key string
+}
+
+// Limiter выбирает политику маршрута и проверяет запрос по token bucket в хранилище store.
+// Используется HTTP middleware и gRPC интерцептором.
+type Limiter struct {
+	log          *slog.Logger
+	store        domain.RateLimitStore
+	routes       map[string]policy
+	defaultLimit *policy
+	apiKeyHeader string
+	// apiKeys - хэши выданных API ключей.
+	apiKeys map[string]struct{}
+}
+
+// NewLimiter создает новый экземпляр Limiter по конфигурации cfg.
+// Возвращает ошибку при некорректной политике.
+func NewLimiter(log *slog.Logger, store domain.RateLimitStore, cfg config.RateLimit) (*Limiter, error) {
+	l := &Limiter{
+		log:          log.With(slog.String("component", "RateLimiter")),
+		store:        store,
+		routes:       make(map[string]policy),
+		apiKeyHeader: cfg.APIKeyHeader,
+		apiKeys:      make(map[string]struct{}, len(cfg.APIKeys)),
+	}
+	for _, apiKey := range cfg.APIKeys {
+		if apiKey != "" {
+			l.apiKeys[hashAPIKey(apiKey)] = struct{}{}
+		}
+	}
+
+	if cfg.DefaultRate > 0 {
+		p, err := newPolicy(cfg.DefaultRate, cfg.DefaultBurst, cfg.DefaultKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default rate limit policy: %w", err)
+		}
+		l.defaultLimit = &p
+	}
+
+	for i, pc := range cfg.Policies {
+		if len(pc.Routes) == 0 {
+			return nil, fmt.Errorf("invalid rate limit policy #%d: no routes", i+1)
+		}
+		p, err := newPolicy(pc.Rate, pc.Burst, pc.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit policy #%d: %w", i+1, err)
+		}
+		for _, route := range pc.Routes {
+			if _, ok := l.routes[route]; ok {
+				return nil, fmt.Errorf("duplicate rate limit policy for route %q", route)
+			}
+			l.routes[route] = p
+		}
+	}
+
+	return l, nil
+}
+
+func newPolicy(rate float64, burst int, key string) (policy, error) {
+	if rate <= 0 || burst < 1 {
+		return policy{}, fmt.Errorf("rate must be positive and burst at least 1, got rate %v, burst %d", rate, burst)
+	}
+	switch key {
+	case "":
+		key = KeyUser
+	case KeyUser, KeyAPIKey, KeyIP:
+	default:
+		return policy{}, fmt.Errorf("unknown key %q", key)
+	}
+	return policy{limit: domain.RateLimit{Rate: rate, Burst: burst}, key: key}, nil
+}
+
+// APIKeyHeader возвращает имя заголовка с API ключом клиента.
+func (l *Limiter) APIKeyHeader() string {
+	return l.apiKeyHeader
+}
+
+// verifyAPIKey возвращает хэш API ключа, если ключ выдан клиенту (есть в конфигурации), иначе пустую строку.
+func (l *Limiter) verifyAPIKey(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	hash := hashAPIKey(apiKey)
+	if _, ok := l.apiKeys[hash]; !ok {
+		return ""
+	}
+	return hash
+}
+
+// Allow проверяет запрос к маршруту route (для HTTP - "METHOD /path", для gRPC - полное имя метода).
+// Второе значение false означает, что ограничение к запросу не применялось: у маршрута нет политики
+// или хранилище недоступно (в этом случае запрос пропускается, чтобы сбой хранилища не останавливал API).
+func (l *Limiter) Allow(ctx context.Context, route string, id Identity) (Result, bool) {
+	const op = "Limiter.Allow"
+
+	p, ok := l.routes[route]
+	if !ok {
+		if l.defaultLimit == nil {
+			return Result{}, false
+		}
+		p = *l.defaultLimit
+	}
+
+	keyType, keyValue := id.key(p.key, l.verifyAPIKey(id.APIKey))
+	decision, err := l.store.Take(ctx, route+"|"+keyType+":"+keyValue, p.limit, time.Now())
+	if err != nil {
+		l.log.Error("Failed to check rate limit, request is allowed",
+			slog.String("op", op), slog.String("route", route), slog.String("error", err.Error()))
+		return Result{}, false
+	}
+	return Result{RateLimitDecision: decision, Key: keyType}, true
+}
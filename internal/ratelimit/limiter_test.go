@@ -0,0 +1,173 @@
+package ratelimit_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/ratelimit"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	limit := domain.RateLimit{Rate: 2, Burst: 3}
+	now := time.Date(2025, 4, 20, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		decision, err := store.Take(ctx, "k", limit, now)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 3, decision.Limit)
+		assert.Equal(t, remaining, decision.Remaining)
+	}
+
+	decision, err := store.Take(ctx, "k", limit, now)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, decision.ResetAfter)
+
+	// Другой ключ не зависит от первого.
+	decision, err = store.Take(ctx, "other", limit, now)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	// Через 0.5с пополнился один токен.
+	decision, err = store.Take(ctx, "k", limit, now.Add(500*time.Millisecond))
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+
+	// Bucket не переполняется больше Burst.
+	decision, err = store.Take(ctx, "k", limit, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 2, decision.Remaining)
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.RateLimit{
+		DefaultRate:  10,
+		DefaultBurst: 20,
+		DefaultKey:   ratelimit.KeyUser,
+		APIKeys:      []string{"secret"},
+		Policies: []config.RateLimitPolicy{
+			{Routes: []string{"POST /products"}, Rate: 1, Burst: 5, Key: ratelimit.KeyUser},
+			{Routes: []string{"POST /login"}, Rate: 1, Burst: 2, Key: ratelimit.KeyIP},
+		},
+	}
+	anyTime := mock.AnythingOfType("time.Time")
+
+	t.Run("Route_Policy_Keyed_By_User", func(t *testing.T) {
+		store := mocks.NewRateLimitStore(t)
+		limiter, err := ratelimit.NewLimiter(log, store, cfg)
+		require.NoError(t, err)
+		store.On("Take", mock.Anything, "POST /products|user:u-1", domain.RateLimit{Rate: 1, Burst: 5}, anyTime).
+			Return(domain.RateLimitDecision{Allowed: true, Limit: 5, Remaining: 4}, nil).Once()
+
+		result, limited := limiter.Allow(context.Background(), "POST /products", ratelimit.Identity{UserID: "u-1", APIKey: "secret", IP: "10.0.0.1"})
+
+		assert.True(t, limited)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, ratelimit.KeyUser, result.Key)
+		assert.Equal(t, 4, result.Remaining)
+	})
+
+	t.Run("Falls_Back_To_API_Key_And_IP", func(t *testing.T) {
+		store := mocks.NewRateLimitStore(t)
+		limiter, err := ratelimit.NewLimiter(log, store, cfg)
+		require.NoError(t, err)
+		store.On("Take", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "POST /products|api_key:") && !strings.Contains(key, "secret")
+		}), mock.Anything, anyTime).Return(domain.RateLimitDecision{Allowed: true}, nil).Once()
+		store.On("Take", mock.Anything, "POST /products|ip:10.0.0.1", mock.Anything, anyTime).
+			Return(domain.RateLimitDecision{Allowed: true}, nil).Once()
+
+		result, _ := limiter.Allow(context.Background(), "POST /products", ratelimit.Identity{APIKey: "secret", IP: "10.0.0.1"})
+		assert.Equal(t, ratelimit.KeyAPIKey, result.Key)
+		result, _ = limiter.Allow(context.Background(), "POST /products", ratelimit.Identity{IP: "10.0.0.1"})
+		assert.Equal(t, ratelimit.KeyIP, result.Key)
+	})
+
+	t.Run("Unknown_API_Key_Falls_Back_To_IP", func(t *testing.T) {
+		store := mocks.NewRateLimitStore(t)
+		limiter, err := ratelimit.NewLimiter(log, store, cfg)
+		require.NoError(t, err)
+		store.On("Take", mock.Anything, "POST /products|ip:10.0.0.1", mock.Anything, anyTime).
+			Return(domain.RateLimitDecision{Allowed: true}, nil).Once()
+
+		result, _ := limiter.Allow(context.Background(), "POST /products", ratelimit.Identity{APIKey: "forged", IP: "10.0.0.1"})
+		assert.Equal(t, ratelimit.KeyIP, result.Key)
+	})
+
+	t.Run("IP_Policy_Ignores_User", func(t *testing.T) {
+		store := mocks.NewRateLimitStore(t)
+		limiter, err := ratelimit.NewLimiter(log, store, cfg)
+		require.NoError(t, err)
+		store.On("Take", mock.Anything, "POST /login|ip:10.0.0.1", domain.RateLimit{Rate: 1, Burst: 2}, anyTime).
+			Return(domain.RateLimitDecision{Allowed: true}, nil).Once()
+
+		result, limited := limiter.Allow(context.Background(), "POST /login", ratelimit.Identity{UserID: "u-1", IP: "10.0.0.1"})
+		assert.True(t, limited)
+		assert.Equal(t, ratelimit.KeyIP, result.Key)
+	})
+
+	t.Run("Default_Policy", func(t *testing.T) {
+		store := mocks.NewRateLimitStore(t)
+		limiter, err := ratelimit.NewLimiter(log, store, cfg)
+		require.NoError(t, err)
+		store.On("Take", mock.Anything, "GET /pvz|user:u-1", domain.RateLimit{Rate: 10, Burst: 20}, anyTime).
+			Return(domain.RateLimitDecision{Allowed: true}, nil).Once()
+
+		_, limited := limiter.Allow(context.Background(), "GET /pvz", ratelimit.Identity{UserID: "u-1"})
+		assert.True(t, limited)
+	})
+
+	t.Run("No_Default_Policy", func(t *testing.T) {
+		noDefault := cfg
+		noDefault.DefaultRate = 0
+		limiter, err := ratelimit.NewLimiter(log, mocks.NewRateLimitStore(t), noDefault)
+		require.NoError(t, err)
+
+		_, limited := limiter.Allow(context.Background(), "GET /pvz", ratelimit.Identity{UserID: "u-1"})
+		assert.False(t, limited)
+	})
+
+	t.Run("Store_Error_Fails_Open", func(t *testing.T) {
+		store := mocks.NewRateLimitStore(t)
+		limiter, err := ratelimit.NewLimiter(log, store, cfg)
+		require.NoError(t, err)
+		store.On("Take", mock.Anything, mock.Anything, mock.Anything, anyTime).Return(domain.RateLimitDecision{}, assert.AnError).Once()
+
+		_, limited := limiter.Allow(context.Background(), "POST /products", ratelimit.Identity{UserID: "u-1"})
+		assert.False(t, limited)
+	})
+}
+
+func TestNewLimiter_InvalidConfig(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	testCases := map[string]config.RateLimit{
+		"Zero_Burst":      {DefaultRate: 1, DefaultBurst: 0},
+		"Unknown_Key":     {Policies: []config.RateLimitPolicy{{Routes: []string{"GET /pvz"}, Rate: 1, Burst: 1, Key: "session"}}},
+		"No_Routes":       {Policies: []config.RateLimitPolicy{{Rate: 1, Burst: 1}}},
+		"Duplicate_Route": {Policies: []config.RateLimitPolicy{{Routes: []string{"GET /pvz", "GET /pvz"}, Rate: 1, Burst: 1}}},
+	}
+	for name, cfg := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ratelimit.NewLimiter(log, ratelimit.NewMemoryStore(), cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// sweepInterval - как часто MemoryStore удаляет заполненные bucket, чтобы память не росла с числом клиентов.
+const sweepInterval = time.Minute
+
+// bucket - состояние token bucket одного ключа.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time // Когда bucket заполнится, если запросов больше не будет
+}
+
+// MemoryStore реализует domain.RateLimitStore в памяти процесса. Лимиты не разделяются между экземплярами сервиса.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryStore создает новый экземпляр MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take пытается взять один токен из bucket key. Новый bucket создается заполненным.
+func (s *MemoryStore) Take(_ context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	burst := float64(limit.Burst)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, updated: now}
+		s.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed.Seconds()*limit.Rate)
+		b.updated = now
+	}
+
+	decision := domain.RateLimitDecision{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	}
+	decision.Remaining = int(b.tokens)
+	decision.ResetAfter = secondsToDuration((burst - b.tokens) / limit.Rate)
+	b.full = now.Add(decision.ResetAfter)
+
+	return decision, nil
+}
+
+// sweep удаляет bucket, которые уже заполнились: их состояние не отличается от нового bucket.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,121 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// LocalBlobStore реализует интерфейс domain.BlobStore поверх локальной файловой системы.
+// Объекты пишутся во временный файл и атомарно переименовываются, поэтому читатели не видят недописанных файлов.
+type LocalBlobStore struct {
+	dir string
+	log *slog.Logger
+}
+
+// NewLocalBlobStore создает хранилище в каталоге dir (каталог создается при необходимости).
+func NewLocalBlobStore(dir string, log *slog.Logger) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("filestore: creating directory %s: %w", dir, err)
+	}
+	return &LocalBlobStore{dir: dir, log: log}, nil
+}
+
+// Put сохраняет содержимое r в файл key.
+func (s *LocalBlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	const op = "LocalBlobStore.Put"
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%s: creating temp file: %w", op, err)
+	}
+	// При любой ошибке временный файл удаляется; после успешного переименования Remove вернет ErrNotExist.
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, &contextReader{ctx: ctx, r: r})
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("%s: writing %s: %w", op, key, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%s: syncing %s: %w", op, key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%s: closing %s: %w", op, key, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%s: renaming %s: %w", op, key, err)
+	}
+
+	s.log.Debug("Blob stored", slog.String("op", op), slog.String("key", key), slog.Int64("bytes", written))
+	return nil
+}
+
+// Open открывает файл key на чтение.
+func (s *LocalBlobStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	const op = "LocalBlobStore.Open"
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %w: blob %s", op, domain.ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("%s: opening %s: %w", op, key, err)
+	}
+	return f, nil
+}
+
+// Delete удаляет файл key. Отсутствие файла не считается ошибкой.
+func (s *LocalBlobStore) Delete(_ context.Context, key string) error {
+	const op = "LocalBlobStore.Delete"
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s: removing %s: %w", op, key, err)
+	}
+	return nil
+}
+
+// path переводит ключ в путь внутри каталога хранилища. Ключи с разделителями каталогов запрещены,
+// чтобы ключ не мог указать на файл вне хранилища.
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("%w: invalid blob key '%s'", domain.ErrValidation, key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+// contextReader прерывает копирование при отмене контекста.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+var _ domain.BlobStore = (*LocalBlobStore)(nil)
@@ -0,0 +1,64 @@
+package filestore_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/repository/filestore"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("generation failed")
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dir := t.TempDir()
+
+	store, err := filestore.NewLocalBlobStore(dir, logger)
+	require.NoError(t, err)
+
+	t.Run("Put_Open_Delete", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "report.csv", strings.NewReader("a,b\n1,2\n")))
+
+		rc, err := store.Open(ctx, "report.csv")
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		assert.Equal(t, "a,b\n1,2\n", string(content))
+
+		require.NoError(t, store.Delete(ctx, "report.csv"))
+		_, err = store.Open(ctx, "report.csv")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.NoError(t, store.Delete(ctx, "report.csv"), "deleting a missing blob is not an error")
+	})
+
+	t.Run("Failed_Put_Leaves_No_Files", func(t *testing.T) {
+		err := store.Put(ctx, "broken.csv", failingReader{})
+		require.Error(t, err)
+
+		entries, errDir := os.ReadDir(dir)
+		require.NoError(t, errDir)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Invalid_Key", func(t *testing.T) {
+		for _, key := range []string{"", "..", "../escape.csv", "nested/report.csv", ".hidden"} {
+			err := store.Put(ctx, key, strings.NewReader("x"))
+			assert.ErrorIs(t, err, domain.ErrValidation, "key %q", key)
+		}
+	})
+}
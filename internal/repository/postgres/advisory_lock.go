@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLocker реализует интерфейс domain.Locker на advisory-блокировках PostgreSQL.
+// Блокировка сессионная: она удерживается на отдельном соединении из пула и снимается
+// автоматически, если экземпляр сервиса завершился, не успев ее освободить.
+type AdvisoryLocker struct {
+	BaseRepository
+}
+
+// NewAdvisoryLocker создает новый экземпляр AdvisoryLocker.
+func NewAdvisoryLocker(db *pgxpool.Pool, log *slog.Logger) *AdvisoryLocker {
+	return &AdvisoryLocker{
+		BaseRepository: NewBaseRepository(db, log),
+	}
+}
+
+// TryWithLock выполняет fn под блокировкой pg_try_advisory_lock(key). Не ждет освобождения блокировки:
+// если ее держит другая сессия, сразу возвращает false.
+func (l *AdvisoryLocker) TryWithLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	const op = "AdvisoryLocker.TryWithLock"
+	log := l.log.With(slog.String("op", op), slog.Int64("lock_key", key))
+
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		return false, l.wrapErr(op, fmt.Errorf("acquiring connection: %w", err))
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Release()
+		return false, l.wrapErr(op, err)
+	}
+	if !acquired {
+		conn.Release()
+		return false, nil
+	}
+
+	defer func() {
+		// Снимаем блокировку даже после отмены ctx. Если это не удалось, соединение закрывается,
+		// чтобы блокировка не осталась висеть в пуле.
+		var released bool
+		if err := conn.QueryRow(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", key).Scan(&released); err != nil || !released {
+			log.Warn("Failed to release advisory lock, closing connection", slog.Any("error", err))
+			if errClose := conn.Hijack().Close(context.WithoutCancel(ctx)); errClose != nil {
+				log.Error("Failed to close connection holding advisory lock", slog.String("error", errClose.Error()))
+			}
+			return
+		}
+		conn.Release()
+	}()
+
+	return true, fn(ctx)
+}
@@ -0,0 +1,37 @@
+package postgres_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/repository/postgres"
+)
+
+func TestAdvisoryLocker_TryWithLock(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	locker := postgres.NewAdvisoryLocker(dbPool, slog.Default())
+	ctx := context.Background()
+	const key int64 = 42
+
+	t.Run("Exclusive", func(t *testing.T) {
+		var innerAcquired bool
+		acquired, err := locker.TryWithLock(ctx, key, func(ctx context.Context) error {
+			var errInner error
+			innerAcquired, errInner = locker.TryWithLock(ctx, key, func(context.Context) error { return nil })
+			return errInner
+		})
+		require.NoError(t, err)
+		assert.True(t, acquired)
+		assert.False(t, innerAcquired, "lock must not be acquired by a second session")
+	})
+
+	t.Run("Released_After_Run", func(t *testing.T) {
+		acquired, err := locker.TryWithLock(ctx, key, func(context.Context) error { return nil })
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// dispatchColumns - колонки отправки в порядке сканирования scanDispatch.
+var dispatchColumns = []string{"id", "pvz_id", "status", "created_at", "created_by", "sent_at", "sent_by"}
+
+// DispatchRepository реализует интерфейс domain.DispatchRepository для PostgreSQL.
+type DispatchRepository struct {
+	BaseRepository
+}
+
+// NewDispatchRepository создает новый экземпляр DispatchRepository.
+func NewDispatchRepository(db *pgxpool.Pool, log *slog.Logger) *DispatchRepository {
+	return &DispatchRepository{
+		BaseRepository: NewBaseRepository(db, log),
+	}
+}
+
+// Create сохраняет новую открытую отправку. Вторая открытая отправка ПВЗ отклоняется уникальным индексом.
+func (r *DispatchRepository) Create(ctx context.Context, dispatch *domain.Dispatch) error {
+	const op = "DispatchRepository.Create"
+
+	query, args, err := r.sq.Insert("dispatches").
+		Columns("id", "pvz_id", "status", "created_at", "created_by").
+		Values(dispatch.ID, dispatch.PVZID, dispatch.Status, dispatch.CreatedAt, dispatch.CreatedBy).
+		ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return r.wrapErr(op, err)
+	}
+	return nil
+}
+
+// GetByID находит отправку по ID.
+func (r *DispatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Dispatch, error) {
+	const op = "DispatchRepository.GetByID"
+	return r.getOne(ctx, op, sq.Eq{"id": id})
+}
+
+// FindOpenByPVZID находит открытую отправку ПВЗ.
+func (r *DispatchRepository) FindOpenByPVZID(ctx context.Context, pvzID uuid.UUID) (*domain.Dispatch, error) {
+	const op = "DispatchRepository.FindOpenByPVZID"
+	return r.getOne(ctx, op, sq.Eq{"pvz_id": pvzID, "status": domain.DispatchOpen})
+}
+
+// MarkSent переводит открытую отправку в состояние sent.
+func (r *DispatchRepository) MarkSent(ctx context.Context, id uuid.UUID, sentAt time.Time, sentBy *uuid.UUID) (*domain.Dispatch, error) {
+	const op = "DispatchRepository.MarkSent"
+
+	query, args, err := r.sq.Update("dispatches").
+		Set("status", domain.DispatchSent).
+		Set("sent_at", sentAt).
+		Set("sent_by", sentBy).
+		Where(sq.Eq{"id": id, "status": domain.DispatchOpen}).
+		Suffix("RETURNING " + strings.Join(dispatchColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	dispatch, err := scanDispatch(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	return dispatch, nil
+}
+
+func (r *DispatchRepository) getOne(ctx context.Context, op string, where sq.Eq) (*domain.Dispatch, error) {
+	query, args, err := r.sq.Select(dispatchColumns...).
+		From("dispatches").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	dispatch, err := scanDispatch(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	return dispatch, nil
+}
+
+func scanDispatch(row pgx.Row) (*domain.Dispatch, error) {
+	var d domain.Dispatch
+	if err := row.Scan(&d.ID, &d.PVZID, &d.Status, &d.CreatedAt, &d.CreatedBy, &d.SentAt, &d.SentBy); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,110 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+func TestDispatchRepository_ProductLifecycle(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products", "dispatches")
+	require.NoError(t, err)
+	pvz := createTestPVZ(ctx, t, testPVZRepo, domain.Moscow)
+	reception := createTestReception(ctx, t, testReceptionRepo, pvz.ID, time.Now().UTC().Add(-time.Hour))
+	shoes := createTestProduct(ctx, t, testProductRepo, reception.ID, domain.TypeShoes, time.Now().Add(-3*time.Minute))
+	clothes := createTestProduct(ctx, t, testProductRepo, reception.ID, domain.TypeClothing, time.Now().Add(-2*time.Minute))
+	electronics := createTestProduct(ctx, t, testProductRepo, reception.ID, domain.TypeElectronics, time.Now().Add(-time.Minute))
+	closeTestReception(ctx, t, testReceptionRepo, reception.ID)
+
+	t.Run("Created_Product_Is_On_Hand", func(t *testing.T) {
+		found, err := testProductRepo.GetByID(ctx, shoes.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProductReceived, found.Status)
+		assert.Nil(t, found.StatusChangedAt)
+		assert.Nil(t, found.DispatchID)
+	})
+
+	dispatch := domain.Dispatch{
+		ID: uuid.New(), PVZID: pvz.ID, Status: domain.DispatchOpen, CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	require.NoError(t, testDispatchRepo.Create(ctx, &dispatch))
+
+	t.Run("Single_Open_Dispatch_Per_PVZ", func(t *testing.T) {
+		second := domain.Dispatch{ID: uuid.New(), PVZID: pvz.ID, Status: domain.DispatchOpen, CreatedAt: time.Now().UTC()}
+		assert.ErrorIs(t, testDispatchRepo.Create(ctx, &second), domain.ErrConflict)
+
+		found, err := testDispatchRepo.FindOpenByPVZID(ctx, pvz.ID)
+		require.NoError(t, err)
+		assert.Equal(t, dispatch.ID, found.ID)
+	})
+
+	t.Run("Transitions", func(t *testing.T) {
+		now := time.Now().UTC()
+		returned, err := testProductRepo.Transition(ctx, domain.ProductTransition{
+			ProductID: shoes.ID, From: domain.ProductReceived, To: domain.ProductReturned, ChangedAt: now, DispatchID: &dispatch.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProductReturned, returned.Status)
+		require.NotNil(t, returned.DispatchID)
+		assert.Equal(t, dispatch.ID, *returned.DispatchID)
+
+		issued, err := testProductRepo.Transition(ctx, domain.ProductTransition{
+			ProductID: clothes.ID, From: domain.ProductReceived, To: domain.ProductIssued, ChangedAt: now,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProductIssued, issued.Status)
+		assert.NotNil(t, issued.StatusChangedAt)
+
+		_, err = testProductRepo.Transition(ctx, domain.ProductTransition{
+			ProductID: clothes.ID, From: domain.ProductReceived, To: domain.ProductLost, ChangedAt: now,
+		})
+		assert.ErrorIs(t, err, domain.ErrNotFound, "product is no longer on hand")
+	})
+
+	t.Run("Inventory_Counts_On_Hand_Only", func(t *testing.T) {
+		inventories, err := testProductRepo.InventoryByPVZIDs(ctx, []uuid.UUID{pvz.ID})
+		require.NoError(t, err)
+		inventory := inventories[pvz.ID]
+		assert.Equal(t, 1, inventory.OnHand)
+		assert.Equal(t, map[domain.ProductType]int{domain.TypeElectronics: 1}, inventory.ByType)
+	})
+
+	t.Run("MarkSent", func(t *testing.T) {
+		products, err := testProductRepo.ListByDispatchID(ctx, dispatch.ID)
+		require.NoError(t, err)
+		require.Len(t, products, 1)
+		assert.Equal(t, shoes.ID, products[0].ID)
+
+		sent, err := testDispatchRepo.MarkSent(ctx, dispatch.ID, time.Now().UTC(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DispatchSent, sent.Status)
+		require.NotNil(t, sent.SentAt)
+
+		_, err = testDispatchRepo.MarkSent(ctx, dispatch.ID, time.Now().UTC(), nil)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		_, err = testDispatchRepo.FindOpenByPVZID(ctx, pvz.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Return_Into_Sent_Dispatch_Rejected", func(t *testing.T) {
+		_, err := testProductRepo.Transition(ctx, domain.ProductTransition{
+			ProductID: electronics.ID, From: domain.ProductReceived, To: domain.ProductReturned,
+			ChangedAt: time.Now().UTC(), DispatchID: &dispatch.ID,
+		})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		found, err := testProductRepo.GetByID(ctx, electronics.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProductReceived, found.Status)
+	})
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// ExpectedShipmentRepository реализует интерфейс domain.ExpectedShipmentRepository для PostgreSQL.
+type ExpectedShipmentRepository struct {
+	BaseRepository
+}
+
+// NewExpectedShipmentRepository создает новый экземпляр ExpectedShipmentRepository.
+func NewExpectedShipmentRepository(db *pgxpool.Pool, log *slog.Logger) *ExpectedShipmentRepository {
+	return &ExpectedShipmentRepository{
+		BaseRepository: NewBaseRepository(db, log),
+	}
+}
+
+// Create сохраняет новую поставку. Строки поставки хранятся в JSONB.
+func (r *ExpectedShipmentRepository) Create(ctx context.Context, shipment *domain.ExpectedShipment) error {
+	const op = "ExpectedShipmentRepository.Create"
+
+	query, args, err := r.sq.Insert("expected_shipments").
+		Columns("id", "pvz_id", "reference", "lines", "created_by", "created_at").
+		Values(shipment.ID, shipment.PVZID, shipment.Reference, shipment.Lines, shipment.CreatedBy, shipment.CreatedAt).
+		ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return r.wrapErr(op, err)
+	}
+	return nil
+}
+
+// GetByID находит поставку по ID. ID приемки берется из receptions.expected_shipment_id.
+func (r *ExpectedShipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ExpectedShipment, error) {
+	const op = "ExpectedShipmentRepository.GetByID"
+
+	query, args, err := r.sq.Select(
+		"s.id", "s.pvz_id", "s.reference", "s.lines", "s.created_by", "s.created_at", "r.id", "s.reconciliation",
+	).
+		From("expected_shipments s").
+		LeftJoin("receptions r ON r.expected_shipment_id = s.id").
+		Where(sq.Eq{"s.id": id}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	var shipment domain.ExpectedShipment
+	err = r.db.QueryRow(ctx, query, args...).Scan(
+		&shipment.ID, &shipment.PVZID, &shipment.Reference, &shipment.Lines, &shipment.CreatedBy,
+		&shipment.CreatedAt, &shipment.ReceptionID, &shipment.Reconciliation,
+	)
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	return &shipment, nil
+}
+
+// SaveReconciliation сохраняет итог сверки. Статус дублируется в отдельную колонку для выборок по расхождениям.
+func (r *ExpectedShipmentRepository) SaveReconciliation(ctx context.Context, id uuid.UUID, reconciliation domain.Reconciliation) error {
+	const op = "ExpectedShipmentRepository.SaveReconciliation"
+
+	query, args, err := r.sq.Update("expected_shipments").
+		Set("reconciliation_status", reconciliation.Status).
+		Set("reconciliation", reconciliation).
+		Set("reconciled_at", reconciliation.ReconciledAt).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	cmdTag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return r.wrapErr(op, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return r.wrapErr(op, domain.ErrNotFound)
+	}
+	return nil
+}
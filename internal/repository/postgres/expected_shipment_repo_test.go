@@ -0,0 +1,117 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+func TestExpectedShipmentRepository_Lifecycle(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	repo := testShipmentRepo
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products", "expected_shipments")
+	require.NoError(t, err)
+	pvz := createTestPVZ(ctx, t, testPVZRepo, domain.Moscow)
+
+	reference := "ASN-1"
+	shipment := domain.ExpectedShipment{
+		ID:        uuid.New(),
+		PVZID:     pvz.ID,
+		Reference: &reference,
+		Lines: []domain.ExpectedShipmentLine{
+			{Type: domain.TypeShoes, Quantity: 2, ItemIDs: []string{"S-1", "S-2"}},
+			{Type: domain.TypeClothing, Quantity: 1},
+		},
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	require.NoError(t, repo.Create(ctx, &shipment))
+
+	t.Run("GetByID_Not_Attached", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, shipment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, shipment.Lines, found.Lines)
+		assert.Equal(t, &reference, found.Reference)
+		assert.Nil(t, found.ReceptionID)
+		assert.Nil(t, found.Reconciliation)
+	})
+
+	reception := domain.Reception{
+		ID:                 uuid.New(),
+		DateTime:           time.Now().UTC().Truncate(time.Microsecond),
+		PVZID:              pvz.ID,
+		Status:             domain.StatusInProgress,
+		ExpectedShipmentID: &shipment.ID,
+	}
+	require.NoError(t, testReceptionRepo.Create(ctx, &reception))
+
+	t.Run("Attached_To_Reception", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, shipment.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.ReceptionID)
+		assert.Equal(t, reception.ID, *found.ReceptionID)
+
+		rec, err := testReceptionRepo.GetByID(ctx, reception.ID)
+		require.NoError(t, err)
+		require.NotNil(t, rec.ExpectedShipmentID)
+		assert.Equal(t, shipment.ID, *rec.ExpectedShipmentID)
+	})
+
+	t.Run("Second_Reception_Conflict", func(t *testing.T) {
+		closeTestReception(ctx, t, testReceptionRepo, reception.ID)
+		second := domain.Reception{
+			ID: uuid.New(), DateTime: time.Now().UTC(), PVZID: pvz.ID, Status: domain.StatusInProgress, ExpectedShipmentID: &shipment.ID,
+		}
+		err := testReceptionRepo.Create(ctx, &second)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("Product_Item_ID_Unique_Per_Reception", func(t *testing.T) {
+		itemID := "S-1"
+		product := domain.Product{ID: uuid.New(), DateTime: time.Now().UTC(), Type: domain.TypeShoes, ReceptionID: reception.ID, ItemID: &itemID}
+		require.NoError(t, testProductRepo.Create(ctx, &product))
+
+		duplicate := domain.Product{ID: uuid.New(), DateTime: time.Now().UTC(), Type: domain.TypeShoes, ReceptionID: reception.ID, ItemID: &itemID}
+		assert.ErrorIs(t, testProductRepo.Create(ctx, &duplicate), domain.ErrConflict)
+
+		rwp, err := testReceptionRepo.GetWithProducts(ctx, reception.ID)
+		require.NoError(t, err)
+		require.Len(t, rwp.Products, 1)
+		require.NotNil(t, rwp.Products[0].ItemID)
+		assert.Equal(t, itemID, *rwp.Products[0].ItemID)
+	})
+
+	t.Run("SaveReconciliation", func(t *testing.T) {
+		reconciliation := domain.Reconciliation{
+			ShipmentID:   shipment.ID,
+			ReceptionID:  reception.ID,
+			Status:       domain.ReconciliationDiscrepancy,
+			Final:        true,
+			ReconciledAt: time.Now().UTC().Truncate(time.Microsecond),
+			Lines:        []domain.ReconciliationLine{{Type: domain.TypeShoes, Expected: 2, Received: 1, Missing: 1}},
+			MissingItems: []domain.ReconciliationItem{{ItemID: "S-2", Type: domain.TypeShoes}},
+		}
+		require.NoError(t, repo.SaveReconciliation(ctx, shipment.ID, reconciliation))
+
+		found, err := repo.GetByID(ctx, shipment.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.Reconciliation)
+		assert.Equal(t, domain.ReconciliationDiscrepancy, found.Reconciliation.Status)
+		assert.Equal(t, reconciliation.Lines, found.Reconciliation.Lines)
+		assert.True(t, found.Reconciliation.ReconciledAt.Equal(reconciliation.ReconciledAt))
+
+		assert.ErrorIs(t, repo.SaveReconciliation(ctx, uuid.New(), reconciliation), domain.ErrNotFound)
+	})
+
+	t.Run("GetByID_NotFound", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// ExportRepository реализует интерфейс domain.ExportRepository для PostgreSQL.
+type ExportRepository struct {
+	BaseRepository
+}
+
+// NewExportRepository создает новый экземпляр ExportRepository.
+func NewExportRepository(db *pgxpool.Pool, log *slog.Logger) *ExportRepository {
+	return &ExportRepository{
+		BaseRepository: NewBaseRepository(db, log),
+	}
+}
+
+// StreamReceptionRows выполняет один запрос и передает строки в fn по мере чтения из соединения.
+// pgx не буферизует результат целиком, поэтому объем памяти не зависит от размера выгрузки.
+// Приемки без товаров попадают в выгрузку одной строкой с пустыми полями товара.
+func (r *ExportRepository) StreamReceptionRows(ctx context.Context, filter domain.ReceptionExportFilter, fn domain.ReceptionExportRowFunc) error {
+	const op = "ExportRepository.StreamReceptionRows"
+	log := r.log.With(slog.String("op", op))
+
+	queryBuilder := r.sq.Select(
+		"p.id", "p.city",
+		"r.id", "r.status", "r.date_time", "r.closed_at",
+		"pr.id", "pr.type", "pr.date_time",
+	).
+		From("receptions r").
+		Join("pvz p ON p.id = r.pvz_id").
+		LeftJoin("products pr ON pr.reception_id = r.id").
+		OrderBy("p.city", "p.id", "r.date_time", "r.id", "pr.date_time", "pr.id")
+
+	if filter.StartDate != nil {
+		queryBuilder = queryBuilder.Where(sq.GtOrEq{"r.date_time": *filter.StartDate})
+	}
+	if filter.EndDate != nil {
+		queryBuilder = queryBuilder.Where(sq.Lt{"r.date_time": *filter.EndDate})
+	}
+	if filter.City != nil {
+		queryBuilder = queryBuilder.Where(sq.Eq{"p.city": *filter.City})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build export query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("querying export rows: %w", err))
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var row domain.ReceptionExportRow
+		if err := rows.Scan(
+			&row.PVZID, &row.City,
+			&row.ReceptionID, &row.ReceptionStatus, &row.OpenedAt, &row.ClosedAt,
+			&row.ProductID, &row.ProductType, &row.ProductDateTime,
+		); err != nil {
+			return r.wrapErr(op, fmt.Errorf("scanning export row: %w", err))
+		}
+		// Ошибка колбэка (например, клиент закрыл соединение) не является ошибкой БД и возвращается как есть.
+		if err := fn(row); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return r.wrapErr(op, fmt.Errorf("iterating export rows: %w", err))
+	}
+
+	log.Debug("Export rows streamed", slog.Int("rows", count))
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+func TestExportRepository_StreamReceptionRows(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	require.NotNil(t, testExportRepo, "Test Export repo should be initialized")
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
+	require.NoError(t, err)
+
+	day := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	pvzMoscow := createTestPVZ(ctx, t, testPVZRepo, domain.Moscow)
+	pvzKazan := createTestPVZ(ctx, t, testPVZRepo, domain.Kazan)
+
+	recMoscow := createTestReception(ctx, t, testReceptionRepo, pvzMoscow.ID, day.Add(9*time.Hour))
+	createTestProduct(ctx, t, testProductRepo, recMoscow.ID, domain.TypeShoes, day.Add(9*time.Hour+time.Minute))
+	createTestProduct(ctx, t, testProductRepo, recMoscow.ID, domain.TypeClothing, day.Add(9*time.Hour+2*time.Minute))
+	closeTestReception(ctx, t, testReceptionRepo, recMoscow.ID)
+
+	recKazan := createTestReception(ctx, t, testReceptionRepo, pvzKazan.ID, day.Add(12*time.Hour))
+
+	t.Run("All rows", func(t *testing.T) {
+		var rows []domain.ReceptionExportRow
+		errStream := testExportRepo.StreamReceptionRows(ctx, domain.ReceptionExportFilter{}, func(row domain.ReceptionExportRow) error {
+			rows = append(rows, row)
+			return nil
+		})
+		require.NoError(t, errStream)
+		require.Len(t, rows, 3)
+
+		for _, row := range rows {
+			switch row.ReceptionID {
+			case recMoscow.ID:
+				assert.Equal(t, domain.Moscow, row.City)
+				assert.Equal(t, domain.StatusClosed, row.ReceptionStatus)
+				assert.NotNil(t, row.ClosedAt)
+				require.NotNil(t, row.ProductID)
+				require.NotNil(t, row.ProductType)
+			case recKazan.ID:
+				assert.Equal(t, pvzKazan.ID, row.PVZID)
+				assert.Nil(t, row.ClosedAt)
+				assert.Nil(t, row.ProductID, "reception without products should produce a row with empty product fields")
+			default:
+				t.Fatalf("unexpected reception in export: %s", row.ReceptionID)
+			}
+		}
+	})
+
+	t.Run("Filter by city and period", func(t *testing.T) {
+		city := domain.Kazan
+		startDate := day
+		endDate := day.AddDate(0, 0, 1)
+		var rows []domain.ReceptionExportRow
+		errStream := testExportRepo.StreamReceptionRows(ctx, domain.ReceptionExportFilter{StartDate: &startDate, EndDate: &endDate, City: &city},
+			func(row domain.ReceptionExportRow) error {
+				rows = append(rows, row)
+				return nil
+			})
+		require.NoError(t, errStream)
+		require.Len(t, rows, 1)
+		assert.Equal(t, recKazan.ID, rows[0].ReceptionID)
+	})
+
+	t.Run("Callback error stops streaming", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		errStream := testExportRepo.StreamReceptionRows(ctx, domain.ReceptionExportFilter{}, func(domain.ReceptionExportRow) error {
+			calls++
+			return errStop
+		})
+		require.ErrorIs(t, errStream, errStop)
+		assert.Equal(t, 1, calls)
+	})
+}
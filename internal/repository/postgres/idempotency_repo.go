@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// idempotencyColumns - колонки ключа идемпотентности в порядке сканирования scanIdempotencyRecord.
+var idempotencyColumns = []string{
+	"scope", "idempotency_key", "method", "path", "request_hash", "status", "lock_id", "locked_at",
+	"response_status", "response_headers", "response_body", "created_at", "expires_at",
+}
+
+// maxAcquireAttempts - сколько раз Acquire повторяет попытку, если запись удалили между вставкой и чтением.
+const maxAcquireAttempts = 3
+
+// IdempotencyRepository реализует интерфейс domain.IdempotencyRepository для PostgreSQL.
+// Запись в состоянии in_progress служит блокировкой ключа: вставка с ON CONFLICT гарантирует,
+// что из конкурентных запросов с одним ключом (в том числе в разных экземплярах сервиса) выполняется только один.
+type IdempotencyRepository struct {
+	BaseRepository
+}
+
+// NewIdempotencyRepository создает новый экземпляр IdempotencyRepository.
+func NewIdempotencyRepository(db *pgxpool.Pool, log *slog.Logger) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		BaseRepository: NewBaseRepository(db, log),
+	}
+}
+
+// Acquire захватывает ключ для текущего запроса или возвращает существующую запись.
+func (r *IdempotencyRepository) Acquire(ctx context.Context, record *domain.IdempotencyRecord, staleBefore time.Time) (*domain.IdempotencyRecord, bool, error) {
+	const op = "IdempotencyRepository.Acquire"
+
+	// Существующая запись перезаписывается, только если ее срок хранения истек или ее владелец
+	// не завершил тот же запрос за отведенное время (например, экземпляр сервиса был остановлен).
+	query, args, err := r.sq.Insert("idempotency_keys").
+		Columns("scope", "idempotency_key", "method", "path", "request_hash", "status", "lock_id", "locked_at", "created_at", "expires_at").
+		Values(record.Scope, record.Key, record.Method, record.Path, record.RequestHash, domain.IdempotencyInProgress,
+			record.LockID, record.LockedAt, record.CreatedAt, record.ExpiresAt).
+		Suffix(`ON CONFLICT (scope, idempotency_key) DO UPDATE SET
+			method = EXCLUDED.method, path = EXCLUDED.path, request_hash = EXCLUDED.request_hash,
+			status = EXCLUDED.status, lock_id = EXCLUDED.lock_id, locked_at = EXCLUDED.locked_at,
+			response_status = NULL, response_headers = NULL, response_body = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.locked_at
+			OR (idempotency_keys.status = ? AND idempotency_keys.locked_at < ?
+				AND idempotency_keys.request_hash = EXCLUDED.request_hash)`, domain.IdempotencyInProgress, staleBefore).
+		Suffix("RETURNING " + strings.Join(idempotencyColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, false, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	for attempt := 1; ; attempt++ {
+		acquired, err := scanIdempotencyRecord(r.db.QueryRow(ctx, query, args...))
+		if err == nil {
+			return acquired, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, r.wrapErr(op, err)
+		}
+
+		// Ключ занят действующей записью.
+		existing, err := r.get(ctx, op, record.Scope, record.Key)
+		if err == nil {
+			return existing, false, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) || attempt >= maxAcquireAttempts {
+			return nil, false, err
+		}
+		// Запись удалили между вставкой и чтением (владелец снял блокировку или ключ истек): пробуем снова.
+	}
+}
+
+// Complete сохраняет ответ для ключа, захваченного запросом record.LockID.
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	const op = "IdempotencyRepository.Complete"
+
+	query, args, err := r.sq.Update("idempotency_keys").
+		Set("status", domain.IdempotencyCompleted).
+		Set("response_status", record.ResponseStatus).
+		Set("response_headers", record.ResponseHeaders).
+		Set("response_body", record.ResponseBody).
+		Set("expires_at", record.ExpiresAt).
+		Where(sq.Eq{"scope": record.Scope, "idempotency_key": record.Key, "lock_id": record.LockID}).
+		ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	cmdTag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return r.wrapErr(op, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return r.wrapErr(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// Release удаляет незавершенную запись запроса lockID. Отсутствие записи не является ошибкой.
+func (r *IdempotencyRepository) Release(ctx context.Context, scope, key string, lockID uuid.UUID) error {
+	const op = "IdempotencyRepository.Release"
+
+	query, args, err := r.sq.Delete("idempotency_keys").
+		Where(sq.Eq{"scope": scope, "idempotency_key": key, "lock_id": lockID, "status": domain.IdempotencyInProgress}).
+		ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return r.wrapErr(op, err)
+	}
+	return nil
+}
+
+// DeleteExpired удаляет записи с истекшим сроком хранения и возвращает их количество.
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	const op = "IdempotencyRepository.DeleteExpired"
+
+	query, args, err := r.sq.Delete("idempotency_keys").
+		Where(sq.LtOrEq{"expires_at": now}).
+		ToSql()
+	if err != nil {
+		return 0, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	cmdTag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, r.wrapErr(op, err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+func (r *IdempotencyRepository) get(ctx context.Context, op, scope, key string) (*domain.IdempotencyRecord, error) {
+	query, args, err := r.sq.Select(idempotencyColumns...).
+		From("idempotency_keys").
+		Where(sq.Eq{"scope": scope, "idempotency_key": key}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	record, err := scanIdempotencyRecord(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	return record, nil
+}
+
+func scanIdempotencyRecord(row pgx.Row) (*domain.IdempotencyRecord, error) {
+	var (
+		record         domain.IdempotencyRecord
+		responseStatus *int
+	)
+	err := row.Scan(
+		&record.Scope, &record.Key, &record.Method, &record.Path, &record.RequestHash, &record.Status,
+		&record.LockID, &record.LockedAt, &responseStatus, &record.ResponseHeaders, &record.ResponseBody,
+		&record.CreatedAt, &record.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if responseStatus != nil {
+		record.ResponseStatus = *responseStatus
+	}
+	return &record, nil
+}
@@ -0,0 +1,136 @@
+package postgres_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+func newTestIdempotencyRecord(scope, key, hash string, now time.Time) *domain.IdempotencyRecord {
+	return &domain.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		Method:      http.MethodPost,
+		Path:        "/products",
+		RequestHash: hash,
+		LockID:      uuid.New(),
+		LockedAt:    now,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+}
+
+func TestIdempotencyRepository_Lifecycle(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	require.NotNil(t, testIdemRepo, "Test Idempotency repo should be initialized")
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "idempotency_keys")
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	scope := uuid.New().String()
+	hashA := "a000000000000000000000000000000000000000000000000000000000000000"
+	hashB := "b000000000000000000000000000000000000000000000000000000000000000"
+
+	first := newTestIdempotencyRecord(scope, "key-1", hashA, now)
+
+	t.Run("Acquire new key", func(t *testing.T) {
+		got, acquired, errAcquire := testIdemRepo.Acquire(ctx, first, now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		assert.True(t, acquired)
+		assert.Equal(t, domain.IdempotencyInProgress, got.Status)
+		assert.Equal(t, first.LockID, got.LockID)
+	})
+
+	t.Run("Concurrent duplicate sees lock", func(t *testing.T) {
+		duplicate := newTestIdempotencyRecord(scope, "key-1", hashA, now)
+		got, acquired, errAcquire := testIdemRepo.Acquire(ctx, duplicate, now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		assert.False(t, acquired)
+		assert.Equal(t, domain.IdempotencyInProgress, got.Status)
+		assert.Equal(t, first.LockID, got.LockID)
+	})
+
+	t.Run("Same key in another scope is independent", func(t *testing.T) {
+		other := newTestIdempotencyRecord(uuid.New().String(), "key-1", hashB, now)
+		_, acquired, errAcquire := testIdemRepo.Acquire(ctx, other, now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		assert.True(t, acquired)
+	})
+
+	t.Run("Complete stores response", func(t *testing.T) {
+		first.Status = domain.IdempotencyCompleted
+		first.ResponseStatus = http.StatusCreated
+		first.ResponseHeaders = map[string]string{"Content-Type": "application/json"}
+		first.ResponseBody = []byte(`{"id":"1"}`)
+		require.NoError(t, testIdemRepo.Complete(ctx, first))
+
+		got, acquired, errAcquire := testIdemRepo.Acquire(ctx, newTestIdempotencyRecord(scope, "key-1", hashA, now), now)
+		require.NoError(t, errAcquire)
+		assert.False(t, acquired, "completed key must not be taken over before expiry")
+		assert.Equal(t, domain.IdempotencyCompleted, got.Status)
+		assert.Equal(t, http.StatusCreated, got.ResponseStatus)
+		assert.Equal(t, "application/json", got.ResponseHeaders["Content-Type"])
+		assert.Equal(t, `{"id":"1"}`, string(got.ResponseBody))
+	})
+
+	t.Run("Complete by foreign lock fails", func(t *testing.T) {
+		foreign := *first
+		foreign.LockID = uuid.New()
+		assert.ErrorIs(t, testIdemRepo.Complete(ctx, &foreign), domain.ErrNotFound)
+	})
+
+	t.Run("Stale lock is taken over only by the same request", func(t *testing.T) {
+		stale := newTestIdempotencyRecord(scope, "key-2", hashA, now.Add(-2*time.Minute))
+		_, acquired, errAcquire := testIdemRepo.Acquire(ctx, stale, now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		require.True(t, acquired)
+
+		_, acquired, errAcquire = testIdemRepo.Acquire(ctx, newTestIdempotencyRecord(scope, "key-2", hashB, now), now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		assert.False(t, acquired)
+
+		retry := newTestIdempotencyRecord(scope, "key-2", hashA, now)
+		got, acquired, errAcquire := testIdemRepo.Acquire(ctx, retry, now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		assert.True(t, acquired)
+		assert.Equal(t, retry.LockID, got.LockID)
+
+		// Брошенный запрос больше не владеет ключом.
+		assert.NoError(t, testIdemRepo.Release(ctx, scope, "key-2", stale.LockID))
+		_, acquired, errAcquire = testIdemRepo.Acquire(ctx, newTestIdempotencyRecord(scope, "key-2", hashA, now), now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		assert.False(t, acquired)
+	})
+
+	t.Run("Release frees the key", func(t *testing.T) {
+		rec := newTestIdempotencyRecord(scope, "key-3", hashA, now)
+		_, acquired, errAcquire := testIdemRepo.Acquire(ctx, rec, now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		require.True(t, acquired)
+		require.NoError(t, testIdemRepo.Release(ctx, scope, "key-3", rec.LockID))
+
+		_, acquired, errAcquire = testIdemRepo.Acquire(ctx, newTestIdempotencyRecord(scope, "key-3", hashB, now), now.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		assert.True(t, acquired)
+	})
+
+	t.Run("Expired key is reused and deleted", func(t *testing.T) {
+		later := now.Add(2 * time.Hour)
+		_, acquired, errAcquire := testIdemRepo.Acquire(ctx, newTestIdempotencyRecord(scope, "key-1", hashB, later), later.Add(-time.Minute))
+		require.NoError(t, errAcquire)
+		assert.True(t, acquired)
+
+		deleted, errDelete := testIdemRepo.DeleteExpired(ctx, later.Add(2*time.Hour))
+		require.NoError(t, errDelete)
+		assert.GreaterOrEqual(t, deleted, int64(4))
+	})
+}
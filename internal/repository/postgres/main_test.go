@@ -33,6 +33,12 @@ var (
 	testPVZRepo       *postgres.PVZRepository
 	testReceptionRepo *postgres.ReceptionRepository
 	testProductRepo   *postgres.ProductRepository
+	testStatsRepo     *postgres.StatsRepository
+	testExportRepo    *postgres.ExportRepository
+	testReportRepo    *postgres.ReportRepository
+	testShipmentRepo  *postgres.ExpectedShipmentRepository
+	testDispatchRepo  *postgres.DispatchRepository
+	testIdemRepo      *postgres.IdempotencyRepository
 )
 
 func TestMain(m *testing.M) {
@@ -71,6 +77,12 @@ func TestMain(m *testing.M) {
 	testPVZRepo = postgres.NewPVZRepository(dbPool, testLogger)
 	testReceptionRepo = postgres.NewReceptionRepository(dbPool, testLogger)
 	testProductRepo = postgres.NewProductRepository(dbPool, testLogger)
+	testStatsRepo = postgres.NewStatsRepository(dbPool, testLogger)
+	testExportRepo = postgres.NewExportRepository(dbPool, testLogger)
+	testReportRepo = postgres.NewReportRepository(dbPool, testLogger)
+	testShipmentRepo = postgres.NewExpectedShipmentRepository(dbPool, testLogger)
+	testDispatchRepo = postgres.NewDispatchRepository(dbPool, testLogger)
+	testIdemRepo = postgres.NewIdempotencyRepository(dbPool, testLogger)
 
 	exitCode := m.Run()
 
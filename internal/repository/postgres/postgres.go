@@ -1,7 +1,6 @@
 package postgres
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
@@ -26,22 +25,6 @@ func NewBaseRepository(db *pgxpool.Pool, log *slog.Logger) BaseRepository {
 	}
 }
 
-func (r *BaseRepository) logQuery(ctx context.Context, op, query string, args ...interface{}) {
-	reqID := ""
-	if idVal := ctx.Value(contextKeyRequestID("requestID")); idVal != nil {
-		if idStr, ok := idVal.(string); ok {
-			reqID = idStr
-		}
-	}
-
-	r.log.DebugContext(ctx, "Executing SQL query",
-		slog.String("operation", op),
-		slog.String("request_id", reqID),
-		slog.String("query", query),
-		slog.Any("args", args),
-	)
-}
-
 func (r *BaseRepository) wrapErr(op string, err error) error {
 	if err == nil {
 		return nil
@@ -60,6 +43,11 @@ func (r *BaseRepository) wrapErr(op string, err error) error {
 				return fmt.Errorf("%w (constraint: %s): %w", domain.ErrConflict, constraintName, wrappedErr)
 			}
 
+			// Триггер учета заполненности отклоняет товар, для которого в ПВЗ нет места.
+			if pgErr.Code == "23514" && (pgErr.ConstraintName == "pvz_capacity" || pgErr.ConstraintName == "pvz_type_capacity") {
+				return fmt.Errorf("%w: %s: %w", domain.ErrPVZFull, pgErr.Message, wrappedErr)
+			}
+
 			if pgErr.Code == "23503" {
 				constraintName := pgErr.ConstraintName
 				return fmt.Errorf("%w (foreign key constraint: %s): %w", domain.ErrValidation, constraintName, wrappedErr)
@@ -73,5 +61,3 @@ func (r *BaseRepository) wrapErr(op string, err error) error {
 func isErrNoRows(err error) bool {
 	return errors.Is(err, pgx.ErrNoRows)
 }
-
-type contextKeyRequestID string
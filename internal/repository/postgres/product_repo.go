@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"pvz-service-avito-internship/internal/domain"
 )
 
+// productColumns - колонки товара в порядке сканирования scanProduct.
+var productColumns = []string{
+	"id", "date_time", "type", "reception_id", "item_id", "status", "status_changed_at", "status_changed_by", "dispatch_id",
+}
+
 // ProductRepository реализует интерфейс domain.ProductRepository для PostgreSQL.
 type ProductRepository struct {
 	BaseRepository
@@ -29,14 +36,13 @@ func (r *ProductRepository) Create(ctx context.Context, product *domain.Product)
 	const op = "ProductRepository.Create"
 
 	query, args, err := r.sq.Insert("products").
-		Columns("id", "date_time", "type", "reception_id").
-		Values(product.ID, product.DateTime, product.Type, product.ReceptionID).
+		Columns("id", "date_time", "type", "reception_id", "item_id", "status").
+		Values(product.ID, product.DateTime, product.Type, product.ReceptionID, product.ItemID, product.Status).
 		ToSql()
 	if err != nil {
 		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	_, err = r.db.Exec(ctx, query, args...)
 	if err != nil {
 		return r.wrapErr(op, err)
@@ -48,7 +54,7 @@ func (r *ProductRepository) Create(ctx context.Context, product *domain.Product)
 func (r *ProductRepository) FindLastByReceptionID(ctx context.Context, receptionID uuid.UUID) (*domain.Product, error) {
 	const op = "ProductRepository.FindLastByReceptionID"
 
-	query, args, err := r.sq.Select("id", "date_time", "type", "reception_id").
+	query, args, err := r.sq.Select(productColumns...).
 		From("products").
 		Where(sq.Eq{"reception_id": receptionID}).
 		OrderBy("date_time DESC").
@@ -58,18 +64,16 @@ func (r *ProductRepository) FindLastByReceptionID(ctx context.Context, reception
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	row := r.db.QueryRow(ctx, query, args...)
 
-	var prod domain.Product
-	err = row.Scan(&prod.ID, &prod.DateTime, &prod.Type, &prod.ReceptionID)
+	prod, err := scanProduct(row)
 	if err != nil {
 		if isErrNoRows(err) {
 			return nil, fmt.Errorf("repository.%s: %w", op, domain.ErrNoProductsToDelete)
 		}
 		return nil, r.wrapErr(op, err)
 	}
-	return &prod, nil
+	return prod, nil
 }
 
 // DeleteByID удаляет товар по его ID.
@@ -83,7 +87,6 @@ func (r *ProductRepository) DeleteByID(ctx context.Context, id uuid.UUID) error
 		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	cmdTag, err := r.db.Exec(ctx, query, args...)
 	if err != nil {
 		return r.wrapErr(op, err)
@@ -107,7 +110,7 @@ func (r *ProductRepository) ListByReceptionIDs(ctx context.Context, receptionIDs
 	}
 	log.Debug("Fetching products for reception IDs", slog.Any("reception_ids", receptionIDs))
 
-	query, args, err := r.sq.Select("id", "date_time", "type", "reception_id").
+	query, args, err := r.sq.Select(productColumns...).
 		From("products").
 		Where(sq.Eq{"reception_id": receptionIDs}).
 		OrderBy("reception_id", "date_time ASC").
@@ -116,7 +119,6 @@ func (r *ProductRepository) ListByReceptionIDs(ctx context.Context, receptionIDs
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		if isErrNoRows(err) {
@@ -130,12 +132,12 @@ func (r *ProductRepository) ListByReceptionIDs(ctx context.Context, receptionIDs
 
 	productsByReception := make(map[uuid.UUID][]domain.Product)
 	for rows.Next() {
-		var p domain.Product
-		if err := rows.Scan(&p.ID, &p.DateTime, &p.Type, &p.ReceptionID); err != nil {
+		p, err := scanProduct(rows)
+		if err != nil {
 			log.Error("Failed to scan product", slog.String("error", err.Error()))
 			return nil, r.wrapErr(op, fmt.Errorf("scanning product: %w", err))
 		}
-		productsByReception[p.ReceptionID] = append(productsByReception[p.ReceptionID], p)
+		productsByReception[p.ReceptionID] = append(productsByReception[p.ReceptionID], *p)
 	}
 	if err = rows.Err(); err != nil {
 		log.Error("Error iterating product rows", slog.String("error", err.Error()))
@@ -145,3 +147,208 @@ func (r *ProductRepository) ListByReceptionIDs(ctx context.Context, receptionIDs
 	log.Info("Successfully listed products by reception IDs", slog.Int("reception_count_with_products", len(productsByReception)))
 	return productsByReception, nil
 }
+
+// ListByReceptionID возвращает страницу товаров приемки в порядке добавления и их общее количество.
+func (r *ProductRepository) ListByReceptionID(ctx context.Context, receptionID uuid.UUID, limit, offset int) ([]domain.Product, int, error) {
+	const op = "ProductRepository.ListByReceptionID"
+	log := r.log.With(slog.String("op", op))
+
+	countQuery, countArgs, err := r.sq.Select("count(*)").
+		From("products").
+		Where(sq.Eq{"reception_id": receptionID}).
+		ToSql()
+	if err != nil {
+		return nil, 0, r.wrapErr(op, fmt.Errorf("failed to build count query: %w", err))
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, r.wrapErr(op, err)
+	}
+
+	products := make([]domain.Product, 0, limit)
+	if total == 0 || offset >= total {
+		return products, total, nil
+	}
+
+	query, args, err := r.sq.Select(productColumns...).
+		From("products").
+		Where(sq.Eq{"reception_id": receptionID}).
+		OrderBy("date_time ASC", "id ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, 0, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to query products", slog.String("error", err.Error()))
+		return nil, 0, r.wrapErr(op, fmt.Errorf("querying products: %w", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			log.Error("Failed to scan product", slog.String("error", err.Error()))
+			return nil, 0, r.wrapErr(op, fmt.Errorf("scanning product: %w", err))
+		}
+		products = append(products, *p)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error("Error iterating product rows", slog.String("error", err.Error()))
+		return nil, 0, r.wrapErr(op, fmt.Errorf("iterating products: %w", err))
+	}
+
+	return products, total, nil
+}
+
+// GetByID находит товар по ID.
+func (r *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	const op = "ProductRepository.GetByID"
+
+	query, args, err := r.sq.Select(productColumns...).
+		From("products").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	prod, err := scanProduct(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	return prod, nil
+}
+
+// Transition атомарно переводит товар в новое состояние, если он все еще находится в состоянии From.
+// Для возврата условие дополнительно проверяет, что отправка не успела уйти на склад.
+func (r *ProductRepository) Transition(ctx context.Context, t domain.ProductTransition) (*domain.Product, error) {
+	const op = "ProductRepository.Transition"
+
+	builder := r.sq.Update("products").
+		Set("status", t.To).
+		Set("status_changed_at", t.ChangedAt).
+		Set("status_changed_by", t.ChangedBy).
+		Where(sq.Eq{"id": t.ProductID, "status": t.From})
+	if t.DispatchID != nil {
+		builder = builder.
+			Set("dispatch_id", *t.DispatchID).
+			Where("EXISTS (SELECT 1 FROM dispatches d WHERE d.id = ? AND d.status = ?)", *t.DispatchID, domain.DispatchOpen)
+	}
+
+	query, args, err := builder.Suffix("RETURNING " + strings.Join(productColumns, ", ")).ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	prod, err := scanProduct(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	return prod, nil
+}
+
+// ListByDispatchID возвращает товары отправки в порядке возврата.
+func (r *ProductRepository) ListByDispatchID(ctx context.Context, dispatchID uuid.UUID) ([]domain.Product, error) {
+	const op = "ProductRepository.ListByDispatchID"
+	log := r.log.With(slog.String("op", op))
+
+	query, args, err := r.sq.Select(productColumns...).
+		From("products").
+		Where(sq.Eq{"dispatch_id": dispatchID}).
+		OrderBy("status_changed_at ASC", "id ASC").
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to query products", slog.String("error", err.Error()))
+		return nil, r.wrapErr(op, fmt.Errorf("querying products: %w", err))
+	}
+	defer rows.Close()
+
+	products := make([]domain.Product, 0)
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			log.Error("Failed to scan product", slog.String("error", err.Error()))
+			return nil, r.wrapErr(op, fmt.Errorf("scanning product: %w", err))
+		}
+		products = append(products, *p)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error("Error iterating product rows", slog.String("error", err.Error()))
+		return nil, r.wrapErr(op, fmt.Errorf("iterating products: %w", err))
+	}
+
+	return products, nil
+}
+
+// InventoryByPVZIDs считает товары в состоянии received по типам для каждого ПВЗ.
+func (r *ProductRepository) InventoryByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID) (map[uuid.UUID]domain.PVZInventory, error) {
+	const op = "ProductRepository.InventoryByPVZIDs"
+	log := r.log.With(slog.String("op", op))
+
+	result := make(map[uuid.UUID]domain.PVZInventory, len(pvzIDs))
+	if len(pvzIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := r.sq.Select("r.pvz_id", "pr.type", "count(*)").
+		From("products pr").
+		Join("receptions r ON r.id = pr.reception_id").
+		Where(sq.Eq{"r.pvz_id": pvzIDs, "pr.status": domain.ProductReceived}).
+		GroupBy("r.pvz_id", "pr.type").
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to query inventory", slog.String("error", err.Error()))
+		return nil, r.wrapErr(op, fmt.Errorf("querying inventory: %w", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			pvzID       uuid.UUID
+			productType domain.ProductType
+			count       int
+		)
+		if err := rows.Scan(&pvzID, &productType, &count); err != nil {
+			log.Error("Failed to scan inventory", slog.String("error", err.Error()))
+			return nil, r.wrapErr(op, fmt.Errorf("scanning inventory: %w", err))
+		}
+		inventory, ok := result[pvzID]
+		if !ok {
+			inventory.ByType = make(map[domain.ProductType]int)
+		}
+		inventory.OnHand += count
+		inventory.ByType[productType] = count
+		result[pvzID] = inventory
+	}
+	if err = rows.Err(); err != nil {
+		log.Error("Error iterating inventory rows", slog.String("error", err.Error()))
+		return nil, r.wrapErr(op, fmt.Errorf("iterating inventory: %w", err))
+	}
+
+	return result, nil
+}
+
+func scanProduct(row pgx.Row) (*domain.Product, error) {
+	var p domain.Product
+	if err := row.Scan(
+		&p.ID, &p.DateTime, &p.Type, &p.ReceptionID, &p.ItemID, &p.Status, &p.StatusChangedAt, &p.StatusChangedBy, &p.DispatchID,
+	); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -141,3 +141,46 @@ func TestProductRepository_DeleteByID(t *testing.T) {
 		assert.ErrorIs(t, errDelete, domain.ErrNotFound)
 	})
 }
+
+func TestProductRepository_ListByReceptionID(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	pvzRepo := testPVZRepo
+	receptionRepo := testReceptionRepo
+	repo := testProductRepo
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
+	require.NoError(t, err)
+	pvz := createTestPVZ(ctx, t, pvzRepo, domain.Moscow)
+	reception := createTestReception(ctx, t, receptionRepo, pvz.ID, time.Now())
+	emptyReception := createTestReception(ctx, t, receptionRepo, pvz.ID, time.Now().Add(-time.Hour))
+
+	base := time.Now().Add(-time.Minute)
+	products := make([]domain.Product, 0, 3)
+	for i := 0; i < 3; i++ {
+		products = append(products, createTestProduct(ctx, t, repo, reception.ID, domain.TypeElectronics, base.Add(time.Duration(i)*time.Second)))
+	}
+
+	t.Run("Page In Insertion Order", func(t *testing.T) {
+		page, total, err := repo.ListByReceptionID(ctx, reception.ID, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, page, 2)
+		assert.Equal(t, products[1].ID, page[0].ID)
+		assert.Equal(t, products[2].ID, page[1].ID)
+	})
+
+	t.Run("Offset Past End", func(t *testing.T) {
+		page, total, err := repo.ListByReceptionID(ctx, reception.ID, 2, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Empty(t, page)
+	})
+
+	t.Run("Empty Reception", func(t *testing.T) {
+		page, total, err := repo.ListByReceptionID(ctx, emptyReception.ID, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, page)
+	})
+}
@@ -2,12 +2,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"pvz-service-avito-internship/internal/domain"
@@ -30,14 +31,13 @@ func (r *PVZRepository) Create(ctx context.Context, pvz *domain.PVZ) error {
 	const op = "PVZRepository.Create"
 
 	query, args, err := r.sq.Insert("pvz").
-		Columns("id", "registration_date", "city").
-		Values(pvz.ID, pvz.RegistrationDate, pvz.City).
+		Columns("id", "registration_date", "city", "status").
+		Values(pvz.ID, pvz.RegistrationDate, pvz.City, pvz.Status).
 		ToSql()
 	if err != nil {
 		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	_, err = r.db.Exec(ctx, query, args...)
 	if err != nil {
 		return r.wrapErr(op, err)
@@ -49,7 +49,7 @@ func (r *PVZRepository) Create(ctx context.Context, pvz *domain.PVZ) error {
 func (r *PVZRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PVZ, error) {
 	const op = "PVZRepository.GetByID"
 
-	query, args, err := r.sq.Select("id", "registration_date", "city").
+	query, args, err := r.sq.Select("id", "registration_date", "city", "status", "version").
 		From("pvz").
 		Where(sq.Eq{"id": id}).
 		Limit(1).
@@ -58,102 +58,162 @@ func (r *PVZRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PVZ,
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	row := r.db.QueryRow(ctx, query, args...)
 
 	var pvz domain.PVZ
-	err = row.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City)
+	err = row.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City, &pvz.Status, &pvz.Version)
 	if err != nil {
 		return nil, r.wrapErr(op, err)
 	}
 	return &pvz, nil
 }
 
-// ListIDsAndTotal возвращает слайс ID ПВЗ для текущей страницы с учетом фильтров
-// и общее количество ПВЗ, удовлетворяющих фильтрам.
-func (r *PVZRepository) ListIDsAndTotal(ctx context.Context, limit, offset int, startDate, endDate *time.Time) ([]uuid.UUID, int, error) {
-	const op = "PVZRepository.ListIDsAndTotal"
+// List возвращает страницу ПВЗ с учетом фильтров и сортировки.
+// Сортировка всегда дополняется p.id, поэтому порядок стабилен. При сортировке по дате регистрации
+// курсор filter.After не пропускает и не дублирует строки при добавлении новых ПВЗ.
+func (r *PVZRepository) List(ctx context.Context, filter domain.PVZListFilter) ([]domain.PVZ, error) {
+	const op = "PVZRepository.List"
 	log := r.log.With(slog.String("op", op))
 
-	countQueryBuilder := r.sq.Select("count(DISTINCT p.id)").From("pvz p")
-	if startDate != nil || endDate != nil {
-		countQueryBuilder = countQueryBuilder.Join("receptions r ON p.id = r.pvz_id")
-		if startDate != nil {
-			countQueryBuilder = countQueryBuilder.Where(sq.GtOrEq{"r.date_time": startDate})
-		}
-		if endDate != nil {
-			countQueryBuilder = countQueryBuilder.Where(sq.LtOrEq{"r.date_time": endDate})
-		}
+	sortBy, desc := pvzSort(filter.PVZQuery)
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
 	}
 
-	countSql, countArgs, err := countQueryBuilder.ToSql()
-	if err != nil {
-		return nil, 0, r.wrapErr(op, fmt.Errorf("failed to build count query: %w", err))
+	queryBuilder := r.sq.Select("p.id", "p.registration_date", "p.city", "p.status").
+		From("pvz p").
+		Limit(uint64(filter.Limit))
+	queryBuilder = applyPVZQuery(queryBuilder, filter.PVZQuery, sortBy != domain.PVZSortRegistrationDate)
+
+	switch sortBy {
+	case domain.PVZSortLastReception:
+		queryBuilder = queryBuilder.OrderBy("stats.last_reception_at " + direction + " NULLS LAST")
+	case domain.PVZSortProductCount:
+		queryBuilder = queryBuilder.OrderBy("stats.products_count " + direction)
+	default:
+		queryBuilder = queryBuilder.OrderBy("p.registration_date " + direction)
 	}
+	queryBuilder = queryBuilder.OrderBy("p.id " + direction)
 
-	r.logQuery(ctx, op+"_count", countSql, countArgs...)
-	var total int
-	err = r.db.QueryRow(ctx, countSql, countArgs...).Scan(&total)
+	if filter.After != nil {
+		comparison := ">"
+		if desc {
+			comparison = "<"
+		}
+		queryBuilder = queryBuilder.Where(sq.Expr("(p.registration_date, p.id) "+comparison+" (?, ?)", filter.After.RegistrationDate, filter.After.ID))
+	} else if filter.Offset > 0 {
+		queryBuilder = queryBuilder.Offset(uint64(filter.Offset))
+	}
+
+	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		log.Error("Failed to count PVZs", slog.String("error", err.Error()))
-		return nil, 0, r.wrapErr(op, fmt.Errorf("counting pvz: %w", err))
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	if total == 0 {
-		log.Debug("No PVZs found matching criteria (for ListIDsAndTotal)")
-		return []uuid.UUID{}, 0, nil
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to list PVZs", slog.String("error", err.Error()))
+		return nil, r.wrapErr(op, fmt.Errorf("listing pvz: %w", err))
 	}
-	log.Debug("Total PVZs matching criteria", slog.Int("total", total))
+	defer rows.Close()
 
-	idQueryBuilder := r.sq.Select("DISTINCT p.id", "p.registration_date").From("pvz p")
-	if startDate != nil || endDate != nil {
-		idQueryBuilder = idQueryBuilder.Join("receptions r ON p.id = r.pvz_id")
-		if startDate != nil {
-			idQueryBuilder = idQueryBuilder.Where(sq.GtOrEq{"r.date_time": startDate})
-		}
-		if endDate != nil {
-			idQueryBuilder = idQueryBuilder.Where(sq.LtOrEq{"r.date_time": endDate})
+	pvzs := make([]domain.PVZ, 0, filter.Limit)
+	for rows.Next() {
+		var p domain.PVZ
+		if err := rows.Scan(&p.ID, &p.RegistrationDate, &p.City, &p.Status); err != nil {
+			log.Error("Failed to scan PVZ data", slog.String("error", err.Error()))
+			return nil, r.wrapErr(op, fmt.Errorf("scanning pvz data: %w", err))
 		}
+		pvzs = append(pvzs, p)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error("Error iterating PVZ data", slog.String("error", err.Error()))
+		return nil, r.wrapErr(op, fmt.Errorf("iterating pvz data: %w", err))
 	}
-	idQueryBuilder = idQueryBuilder.OrderBy("p.registration_date DESC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset))
 
-	idsSql, idsArgs, err := idQueryBuilder.ToSql()
+	log.Debug("Fetched PVZ page", slog.Int("count", len(pvzs)))
+	return pvzs, nil
+}
+
+// Count возвращает количество ПВЗ, удовлетворяющих фильтрам (пагинация и сортировка игнорируются).
+func (r *PVZRepository) Count(ctx context.Context, filter domain.PVZListFilter) (int, error) {
+	const op = "PVZRepository.Count"
+
+	queryBuilder := applyPVZQuery(r.sq.Select("count(*)").From("pvz p"), filter.PVZQuery, false)
+
+	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		return nil, 0, r.wrapErr(op, fmt.Errorf("failed to build paginated IDs query: %w", err))
+		return 0, r.wrapErr(op, fmt.Errorf("failed to build count query: %w", err))
 	}
 
-	r.logQuery(ctx, op+"_ids", idsSql, idsArgs...)
-	rows, err := r.db.Query(ctx, idsSql, idsArgs...)
-	if err != nil {
-		log.Error("Failed to get PVZ IDs for page", slog.String("error", err.Error()))
-		return nil, 0, r.wrapErr(op, fmt.Errorf("getting pvz ids: %w", err))
+	var total int
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, r.wrapErr(op, fmt.Errorf("counting pvz: %w", err))
 	}
-	defer rows.Close()
+	return total, nil
+}
 
-	pvzIDs := make([]uuid.UUID, 0, limit)
+// pvzSort возвращает поле и направление сортировки с учетом значений по умолчанию.
+func pvzSort(q domain.PVZQuery) (domain.PVZSortField, bool) {
+	sortBy := q.SortBy
+	if sortBy == "" {
+		sortBy = domain.PVZSortRegistrationDate
+	}
+	return sortBy, q.SortOrder != domain.SortAsc
+}
 
-	addedIds := make(map[uuid.UUID]bool)
-	for rows.Next() {
-		var id uuid.UUID
-		var regDate time.Time
-		if err := rows.Scan(&id, &regDate); err != nil {
-			log.Error("Failed to scan PVZ ID", slog.String("error", err.Error()))
-			return nil, 0, r.wrapErr(op, fmt.Errorf("scanning pvz id: %w", err))
-		}
-		if !addedIds[id] {
-			pvzIDs = append(pvzIDs, id)
-			addedIds[id] = true
+// applyPVZQuery добавляет к выборке из "pvz p" условия фильтра.
+// Агрегаты по приемкам за период подключаются как LATERAL подзапрос stats (last_reception_at, products_count),
+// только если они нужны для фильтра MinProducts или если withStats (сортировка по агрегатам).
+func applyPVZQuery(builder sq.SelectBuilder, q domain.PVZQuery, withStats bool) sq.SelectBuilder {
+	if len(q.Cities) > 0 {
+		builder = builder.Where(sq.Eq{"p.city": q.Cities})
+	}
+	if q.Status != nil {
+		builder = builder.Where(sq.Eq{"p.status": *q.Status})
+	}
+	if q.StartDate != nil || q.EndDate != nil {
+		builder = builder.Where(sq.Expr("EXISTS (?)", receptionsInPeriod(q, "1")))
+	}
+	if q.HasOpenReception != nil {
+		openReception := sq.Select("1").From("receptions ro").
+			Where("ro.pvz_id = p.id").
+			Where(sq.Eq{"ro.status": domain.StatusInProgress})
+		if *q.HasOpenReception {
+			builder = builder.Where(sq.Expr("EXISTS (?)", openReception))
+		} else {
+			builder = builder.Where(sq.Expr("NOT EXISTS (?)", openReception))
 		}
 	}
-	if err = rows.Err(); err != nil {
-		log.Error("Error iterating PVZ IDs", slog.String("error", err.Error()))
-		return nil, 0, r.wrapErr(op, fmt.Errorf("iterating pvz ids: %w", err))
+	if q.ProductType != nil {
+		productOfType := receptionsInPeriod(q, "1").
+			Join("products pr ON pr.reception_id = r.id").
+			Where(sq.Eq{"pr.type": *q.ProductType})
+		builder = builder.Where(sq.Expr("EXISTS (?)", productOfType))
 	}
+	if withStats || q.MinProducts != nil {
+		stats := receptionsInPeriod(q, "max(r.date_time) AS last_reception_at", "count(pr.id) AS products_count").
+			LeftJoin("products pr ON pr.reception_id = r.id")
+		builder = builder.JoinClause(sq.Expr("LEFT JOIN LATERAL (?) stats ON true", stats))
+	}
+	if q.MinProducts != nil {
+		builder = builder.Where(sq.GtOrEq{"stats.products_count": *q.MinProducts})
+	}
+	return builder
+}
 
-	log.Debug("Fetched PVZ IDs for page", slog.Any("pvz_ids", pvzIDs))
-	return pvzIDs, total, nil
+// receptionsInPeriod возвращает подзапрос по приемкам текущего ПВЗ (p.id) за период фильтра.
+// Используется формат плейсхолдеров по умолчанию - внешний запрос пронумерует их сам.
+func receptionsInPeriod(q domain.PVZQuery, columns ...string) sq.SelectBuilder {
+	subQuery := sq.Select(columns...).From("receptions r").Where("r.pvz_id = p.id")
+	if q.StartDate != nil {
+		subQuery = subQuery.Where(sq.GtOrEq{"r.date_time": q.StartDate})
+	}
+	if q.EndDate != nil {
+		subQuery = subQuery.Where(sq.LtOrEq{"r.date_time": q.EndDate})
+	}
+	return subQuery
 }
 
 // GetByIDs находит и возвращает список ПВЗ по списку их ID.
@@ -167,7 +227,7 @@ func (r *PVZRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain
 	}
 	log.Debug("Fetching PVZ data for IDs", slog.Any("pvz_ids", ids))
 
-	query, args, err := r.sq.Select("id", "registration_date", "city").
+	query, args, err := r.sq.Select("id", "registration_date", "city", "status").
 		From("pvz").
 		Where(sq.Eq{"id": ids}).
 		OrderBy("registration_date DESC").
@@ -176,7 +236,6 @@ func (r *PVZRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build pvz data query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		if isErrNoRows(err) {
@@ -191,7 +250,7 @@ func (r *PVZRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain
 	pvzs := make([]domain.PVZ, 0, len(ids))
 	for rows.Next() {
 		var p domain.PVZ
-		if err := rows.Scan(&p.ID, &p.RegistrationDate, &p.City); err != nil {
+		if err := rows.Scan(&p.ID, &p.RegistrationDate, &p.City, &p.Status); err != nil {
 			log.Error("Failed to scan PVZ data", slog.String("error", err.Error()))
 			return nil, r.wrapErr(op, fmt.Errorf("scanning pvz data: %w", err))
 		}
@@ -210,7 +269,7 @@ func (r *PVZRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain
 func (r *PVZRepository) ListAll(ctx context.Context) ([]domain.PVZ, error) {
 	const op = "PVZRepository.ListAll"
 
-	query, args, err := r.sq.Select("id", "registration_date", "city").
+	query, args, err := r.sq.Select("id", "registration_date", "city", "status").
 		From("pvz").
 		OrderBy("registration_date DESC").
 		ToSql()
@@ -218,7 +277,6 @@ func (r *PVZRepository) ListAll(ctx context.Context) ([]domain.PVZ, error) {
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		if isErrNoRows(err) {
@@ -231,7 +289,7 @@ func (r *PVZRepository) ListAll(ctx context.Context) ([]domain.PVZ, error) {
 	pvzs := make([]domain.PVZ, 0)
 	for rows.Next() {
 		var p domain.PVZ
-		if err := rows.Scan(&p.ID, &p.RegistrationDate, &p.City); err != nil {
+		if err := rows.Scan(&p.ID, &p.RegistrationDate, &p.City, &p.Status); err != nil {
 			return nil, r.wrapErr(op, fmt.Errorf("scanning pvz data: %w", err))
 		}
 		pvzs = append(pvzs, p)
@@ -242,3 +300,172 @@ func (r *PVZRepository) ListAll(ctx context.Context) ([]domain.PVZ, error) {
 
 	return pvzs, nil
 }
+
+// SetCapacity заменяет вместимость ПВЗ в одной транзакции: общую вместимость в pvz и ограничения по типам
+// в pvz_occupancy. Ограничения типов, отсутствующих в capacity.ByType, снимаются; счетчики не меняются.
+// Версия сравнивается и увеличивается тем же UPDATE, поэтому из конкурентных изменений с одной версией проходит одно.
+func (r *PVZRepository) SetCapacity(ctx context.Context, pvzID uuid.UUID, capacity domain.PVZCapacity, expectedVersion *int64) error {
+	const op = "PVZRepository.SetCapacity"
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		where := sq.Eq{"id": pvzID}
+		if expectedVersion != nil {
+			where["version"] = *expectedVersion
+		}
+		query, args, err := r.sq.Update("pvz").
+			Set("capacity", capacity.Total).
+			Set("version", sq.Expr("version + 1")).
+			Where(where).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+		cmdTag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if cmdTag.RowsAffected() == 0 {
+			if expectedVersion == nil {
+				return domain.ErrNotFound
+			}
+			return r.versionMismatchErr(ctx, tx, op, pvzID)
+		}
+
+		query, args, err = r.sq.Update("pvz_occupancy").
+			Set("capacity", nil).
+			Where(sq.Eq{"pvz_id": pvzID}).
+			Where(sq.NotEq{"capacity": nil}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+
+		if len(capacity.ByType) == 0 {
+			return nil
+		}
+		insert := r.sq.Insert("pvz_occupancy").Columns("pvz_id", "product_type", "capacity")
+		for productType, limit := range capacity.ByType {
+			insert = insert.Values(pvzID, productType, limit)
+		}
+		query, args, err = insert.
+			Suffix("ON CONFLICT (pvz_id, product_type) DO UPDATE SET capacity = EXCLUDED.capacity").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+		_, err = tx.Exec(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		return r.wrapErr(op, err)
+	}
+	return nil
+}
+
+// versionMismatchErr определяет причину неудачного условного UPDATE: ErrPreconditionFailed, если ПВЗ существует
+// (значит, не совпала версия), иначе ErrNotFound.
+func (r *PVZRepository) versionMismatchErr(ctx context.Context, tx pgx.Tx, op string, pvzID uuid.UUID) error {
+	query, args, err := r.sq.Select("1").From("pvz").Where(sq.Eq{"id": pvzID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+	var exists int
+	if err := tx.QueryRow(ctx, query, args...).Scan(&exists); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	return domain.ErrPreconditionFailed
+}
+
+// GetOccupancy возвращает заполненность ПВЗ по счетчикам, которые поддерживает триггер на products.
+func (r *PVZRepository) GetOccupancy(ctx context.Context, pvzID uuid.UUID) (*domain.PVZOccupancy, error) {
+	const op = "PVZRepository.GetOccupancy"
+
+	occupancies, err := r.listOccupancy(ctx, op, &pvzID)
+	if err != nil {
+		return nil, err
+	}
+	if len(occupancies) == 0 {
+		return nil, r.wrapErr(op, domain.ErrNotFound)
+	}
+	return &occupancies[0], nil
+}
+
+// ListOccupancy возвращает заполненность всех ПВЗ.
+func (r *PVZRepository) ListOccupancy(ctx context.Context) ([]domain.PVZOccupancy, error) {
+	return r.listOccupancy(ctx, "PVZRepository.ListOccupancy", nil)
+}
+
+// listOccupancy читает заполненность одного ПВЗ (pvzID != nil) или всех ПВЗ.
+func (r *PVZRepository) listOccupancy(ctx context.Context, op string, pvzID *uuid.UUID) ([]domain.PVZOccupancy, error) {
+	builder := r.sq.Select("id", "city", "occupied", "capacity", "version").From("pvz").OrderBy("id")
+	if pvzID != nil {
+		builder = builder.Where(sq.Eq{"id": *pvzID})
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	defer rows.Close()
+
+	occupancies := make([]domain.PVZOccupancy, 0)
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		o := domain.PVZOccupancy{ByType: make(map[domain.ProductType]domain.TypeOccupancy)}
+		if err := rows.Scan(&o.PVZID, &o.City, &o.Occupied, &o.Capacity, &o.Version); err != nil {
+			return nil, r.wrapErr(op, fmt.Errorf("scanning pvz occupancy: %w", err))
+		}
+		index[o.PVZID] = len(occupancies)
+		occupancies = append(occupancies, o)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("iterating pvz occupancy: %w", err))
+	}
+	if len(occupancies) == 0 {
+		return occupancies, nil
+	}
+
+	typeBuilder := r.sq.Select("pvz_id", "product_type", "occupied", "capacity").From("pvz_occupancy")
+	if pvzID != nil {
+		typeBuilder = typeBuilder.Where(sq.Eq{"pvz_id": *pvzID})
+	}
+	query, args, err = typeBuilder.ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	typeRows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	defer typeRows.Close()
+
+	for typeRows.Next() {
+		var (
+			id          uuid.UUID
+			productType domain.ProductType
+			occupancy   domain.TypeOccupancy
+		)
+		if err := typeRows.Scan(&id, &productType, &occupancy.Occupied, &occupancy.Capacity); err != nil {
+			return nil, r.wrapErr(op, fmt.Errorf("scanning type occupancy: %w", err))
+		}
+		if i, ok := index[id]; ok {
+			occupancies[i].ByType[productType] = occupancy
+		}
+	}
+	if err = typeRows.Err(); err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("iterating type occupancy: %w", err))
+	}
+
+	return occupancies, nil
+}
@@ -96,7 +96,7 @@ func runTestInTransaction(t *testing.T, dbPool *pgxpool.Pool, testFunc func(ctx
 	testFunc(ctx)
 }
 
-func TestPVZRepository_ListIDsAndTotal(t *testing.T) {
+func TestPVZRepository_List_Count(t *testing.T) {
 	require.NotNil(t, dbPool, "Test DB pool should be initialized")
 	repo := testPVZRepo
 	receptionRepo := testReceptionRepo
@@ -106,78 +106,120 @@ func TestPVZRepository_ListIDsAndTotal(t *testing.T) {
 	require.NoError(t, err)
 
 	pvzM := createTestPVZ(ctx, t, repo, domain.Moscow)
-	require.NotNil(t, pvzM)
 	pvzK := createTestPVZ(ctx, t, repo, domain.Kazan)
-	require.NotNil(t, pvzK)
 	pvzS := createTestPVZ(ctx, t, repo, domain.SaintPetersburg)
-	require.NotNil(t, pvzS)
-
-	checkPVZExists := func(pvzID uuid.UUID) {
-		var exists bool
-		err := dbPool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM pvz WHERE id = $1)", pvzID).Scan(&exists)
-		require.NoError(t, err, "Failed to check if PVZ exists")
-		require.True(t, exists, "PVZ should exist in the database")
-	}
-
-	checkPVZExists(pvzM.ID)
-	checkPVZExists(pvzK.ID)
-	checkPVZExists(pvzS.ID)
 
 	now := time.Now().UTC()
 	createTestReception(ctx, t, receptionRepo, pvzM.ID, now.Add(-2*time.Hour))
 	createTestReception(ctx, t, receptionRepo, pvzK.ID, now.Add(-1*time.Hour))
 	recMS := createTestReception(ctx, t, receptionRepo, pvzM.ID, now.Add(-30*time.Minute))
-	require.NotNil(t, recMS)
+	closeTestReception(ctx, t, receptionRepo, recMS.ID)
+	createTestReception(ctx, t, receptionRepo, pvzS.ID, now.Add(time.Hour))
 
-	errUpdate := receptionRepo.UpdateStatus(ctx, recMS.ID, domain.StatusClosed)
-	require.NoError(t, errUpdate)
+	ids := func(pvzs []domain.PVZ) []uuid.UUID {
+		result := make([]uuid.UUID, 0, len(pvzs))
+		for _, p := range pvzs {
+			result = append(result, p.ID)
+		}
+		return result
+	}
 
-	createTestReception(ctx, t, receptionRepo, pvzS.ID, now.Add(time.Hour))
+	t.Run("No Filters, Offset Pages", func(t *testing.T) {
+		first, err := repo.List(ctx, domain.PVZListFilter{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{pvzS.ID, pvzK.ID}, ids(first))
+
+		second, err := repo.List(ctx, domain.PVZListFilter{Limit: 2, Offset: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{pvzM.ID}, ids(second))
 
-	t.Run("No Filters, First Page", func(t *testing.T) {
-		ids, total, err := repo.ListIDsAndTotal(ctx, 2, 0, nil, nil)
+		total, err := repo.Count(ctx, domain.PVZListFilter{Limit: 2, Offset: 2})
 		require.NoError(t, err)
 		assert.Equal(t, 3, total)
-		require.Len(t, ids, 2)
-		assert.Contains(t, ids, pvzS.ID)
-		assert.Contains(t, ids, pvzK.ID)
 	})
 
-	t.Run("No Filters, Second Page", func(t *testing.T) {
-		ids, total, err := repo.ListIDsAndTotal(ctx, 2, 2, nil, nil)
+	t.Run("Cursor Pages Are Stable When New PVZ Is Created", func(t *testing.T) {
+		first, err := repo.List(ctx, domain.PVZListFilter{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		last := first[len(first)-1]
+
+		newest := createTestPVZ(ctx, t, repo, domain.Moscow)
+
+		second, err := repo.List(ctx, domain.PVZListFilter{
+			Limit: 2,
+			After: &domain.PVZListCursor{RegistrationDate: last.RegistrationDate, ID: last.ID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{pvzM.ID}, ids(second), "new PVZ must not shift the next page")
+		assert.NotContains(t, ids(second), newest.ID)
+
+		_, err = dbPool.Exec(ctx, "DELETE FROM pvz WHERE id = $1", newest.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Cursor Breaks Ties By ID", func(t *testing.T) {
+		sameDate := now.Add(24 * time.Hour).Truncate(time.Microsecond)
+		twinA := domain.PVZ{ID: uuid.New(), RegistrationDate: sameDate, City: domain.Kazan, Status: domain.PVZStatusActive}
+		twinB := domain.PVZ{ID: uuid.New(), RegistrationDate: sameDate, City: domain.Kazan, Status: domain.PVZStatusActive}
+		require.NoError(t, repo.Create(ctx, &twinA))
+		require.NoError(t, repo.Create(ctx, &twinB))
+
+		first, err := repo.List(ctx, domain.PVZListFilter{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+
+		second, err := repo.List(ctx, domain.PVZListFilter{
+			Limit: 1,
+			After: &domain.PVZListCursor{RegistrationDate: first[0].RegistrationDate, ID: first[0].ID},
+		})
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.ElementsMatch(t, []uuid.UUID{twinA.ID, twinB.ID}, []uuid.UUID{first[0].ID, second[0].ID})
+
+		_, err = dbPool.Exec(ctx, "DELETE FROM pvz WHERE id = ANY($1)", []uuid.UUID{twinA.ID, twinB.ID})
 		require.NoError(t, err)
-		assert.Equal(t, 3, total)
-		require.Len(t, ids, 1)
-		if len(ids) > 0 {
-			assert.Equal(t, pvzM.ID, ids[0])
-		}
 	})
 
 	t.Run("Date Filter - Past Hour", func(t *testing.T) {
 		startTime := now.Add(-65 * time.Minute)
 		endTime := now.Add(-25 * time.Minute)
-		ids, total, err := repo.ListIDsAndTotal(ctx, 10, 0, &startTime, &endTime)
+		filter := domain.PVZListFilter{Limit: 10, PVZQuery: domain.PVZQuery{StartDate: &startTime, EndDate: &endTime}}
+
+		pvzs, err := repo.List(ctx, filter)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{pvzK.ID, pvzM.ID}, ids(pvzs))
+
+		total, err := repo.Count(ctx, filter)
 		require.NoError(t, err)
 		assert.Equal(t, 2, total)
-		require.Len(t, ids, 2)
-		assert.Contains(t, ids, pvzK.ID)
-		assert.Contains(t, ids, pvzM.ID)
 	})
+
 	t.Run("Date Filter - No Match", func(t *testing.T) {
 		startTime := now.Add(-10 * time.Minute)
 		endTime := now.Add(10 * time.Minute)
-		ids, total, err := repo.ListIDsAndTotal(ctx, 10, 0, &startTime, &endTime)
+		filter := domain.PVZListFilter{Limit: 10, PVZQuery: domain.PVZQuery{StartDate: &startTime, EndDate: &endTime}}
+
+		pvzs, err := repo.List(ctx, filter)
+		require.NoError(t, err)
+		assert.Empty(t, pvzs)
+
+		total, err := repo.Count(ctx, filter)
 		require.NoError(t, err)
 		assert.Equal(t, 0, total)
-		assert.Empty(t, ids)
 	})
+
 	t.Run("Empty Result", func(t *testing.T) {
 		err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
 		require.NoError(t, err)
-		ids, total, err := repo.ListIDsAndTotal(ctx, 10, 0, nil, nil)
+
+		pvzs, err := repo.List(ctx, domain.PVZListFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, pvzs)
+
+		total, err := repo.Count(ctx, domain.PVZListFilter{})
 		require.NoError(t, err)
 		assert.Equal(t, 0, total)
-		assert.Empty(t, ids)
 	})
 }
 
@@ -209,3 +251,259 @@ func TestPVZRepository_ListAll(t *testing.T) {
 		assert.Empty(t, pvzs)
 	})
 }
+
+func TestPVZRepository_List_Filters(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	repo := testPVZRepo
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	// Москва: закрытая приемка (обувь, одежда) 2 часа назад и открытая (электроника) 30 минут назад - 3 товара.
+	pvzM := createTestPVZ(ctx, t, repo, domain.Moscow)
+	recM1 := createTestReception(ctx, t, testReceptionRepo, pvzM.ID, now.Add(-2*time.Hour))
+	createTestProduct(ctx, t, testProductRepo, recM1.ID, domain.TypeShoes, now.Add(-2*time.Hour))
+	createTestProduct(ctx, t, testProductRepo, recM1.ID, domain.TypeClothing, now.Add(-2*time.Hour))
+	closeTestReception(ctx, t, testReceptionRepo, recM1.ID)
+	recM2 := createTestReception(ctx, t, testReceptionRepo, pvzM.ID, now.Add(-30*time.Minute))
+	createTestProduct(ctx, t, testProductRepo, recM2.ID, domain.TypeElectronics, now.Add(-30*time.Minute))
+
+	// Казань: закрытая приемка час назад - 4 товара одежды.
+	pvzK := createTestPVZ(ctx, t, repo, domain.Kazan)
+	recK := createTestReception(ctx, t, testReceptionRepo, pvzK.ID, now.Add(-time.Hour))
+	for i := 0; i < 4; i++ {
+		createTestProduct(ctx, t, testProductRepo, recK.ID, domain.TypeClothing, now.Add(-time.Hour))
+	}
+	closeTestReception(ctx, t, testReceptionRepo, recK.ID)
+
+	// Санкт-Петербург: выведенный из работы ПВЗ без приемок.
+	pvzS := createTestPVZ(ctx, t, repo, domain.SaintPetersburg)
+	_, err = dbPool.Exec(ctx, "UPDATE pvz SET status = $1 WHERE id = $2", domain.PVZStatusInactive, pvzS.ID)
+	require.NoError(t, err)
+
+	listIDs := func(t *testing.T, q domain.PVZQuery) []uuid.UUID {
+		t.Helper()
+		pvzs, err := repo.List(ctx, domain.PVZListFilter{PVZQuery: q, Limit: 10})
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, 0, len(pvzs))
+		for _, p := range pvzs {
+			ids = append(ids, p.ID)
+		}
+		total, err := repo.Count(ctx, domain.PVZListFilter{PVZQuery: q})
+		require.NoError(t, err)
+		assert.Equal(t, len(ids), total, "Count must agree with List for the same filter")
+		return ids
+	}
+	boolPtr := func(v bool) *bool { return &v }
+	intPtr := func(v int) *int { return &v }
+	productTypePtr := func(v domain.ProductType) *domain.ProductType { return &v }
+	statusPtr := func(v domain.PVZStatus) *domain.PVZStatus { return &v }
+
+	testCases := []struct {
+		name     string
+		query    domain.PVZQuery
+		expected []uuid.UUID
+		ordered  bool
+	}{
+		{name: "Cities", query: domain.PVZQuery{Cities: []domain.City{domain.Moscow, domain.Kazan}}, expected: []uuid.UUID{pvzM.ID, pvzK.ID}},
+		{name: "Status", query: domain.PVZQuery{Status: statusPtr(domain.PVZStatusInactive)}, expected: []uuid.UUID{pvzS.ID}},
+		{name: "Has open reception", query: domain.PVZQuery{HasOpenReception: boolPtr(true)}, expected: []uuid.UUID{pvzM.ID}},
+		{name: "No open reception", query: domain.PVZQuery{HasOpenReception: boolPtr(false)}, expected: []uuid.UUID{pvzK.ID, pvzS.ID}},
+		{name: "Product type", query: domain.PVZQuery{ProductType: productTypePtr(domain.TypeClothing)}, expected: []uuid.UUID{pvzM.ID, pvzK.ID}},
+		{
+			name: "Product type within period",
+			query: func() domain.PVZQuery {
+				start := now.Add(-90 * time.Minute)
+				return domain.PVZQuery{StartDate: &start, ProductType: productTypePtr(domain.TypeClothing)}
+			}(),
+			expected: []uuid.UUID{pvzK.ID},
+		},
+		{name: "Min products", query: domain.PVZQuery{MinProducts: intPtr(3)}, expected: []uuid.UUID{pvzM.ID, pvzK.ID}},
+		{name: "Min products strict", query: domain.PVZQuery{MinProducts: intPtr(4)}, expected: []uuid.UUID{pvzK.ID}},
+		{
+			name: "Min products within period",
+			query: func() domain.PVZQuery {
+				start := now.Add(-45 * time.Minute)
+				return domain.PVZQuery{StartDate: &start, MinProducts: intPtr(1)}
+			}(),
+			expected: []uuid.UUID{pvzM.ID},
+		},
+		{
+			name: "Combination match",
+			query: domain.PVZQuery{
+				Cities: []domain.City{domain.Moscow}, Status: statusPtr(domain.PVZStatusActive), HasOpenReception: boolPtr(true),
+				ProductType: productTypePtr(domain.TypeElectronics), MinProducts: intPtr(1),
+			},
+			expected: []uuid.UUID{pvzM.ID},
+		},
+		{
+			name:     "Combination no match",
+			query:    domain.PVZQuery{Cities: []domain.City{domain.Kazan}, HasOpenReception: boolPtr(true)},
+			expected: []uuid.UUID{},
+		},
+		{
+			name:     "Sort by product count desc",
+			query:    domain.PVZQuery{SortBy: domain.PVZSortProductCount, SortOrder: domain.SortDesc},
+			expected: []uuid.UUID{pvzK.ID, pvzM.ID, pvzS.ID},
+			ordered:  true,
+		},
+		{
+			name:     "Sort by product count asc",
+			query:    domain.PVZQuery{SortBy: domain.PVZSortProductCount, SortOrder: domain.SortAsc},
+			expected: []uuid.UUID{pvzS.ID, pvzM.ID, pvzK.ID},
+			ordered:  true,
+		},
+		{
+			name:     "Sort by last reception desc, PVZ without receptions last",
+			query:    domain.PVZQuery{SortBy: domain.PVZSortLastReception, SortOrder: domain.SortDesc},
+			expected: []uuid.UUID{pvzM.ID, pvzK.ID, pvzS.ID},
+			ordered:  true,
+		},
+		{
+			name:     "Sort by last reception asc, PVZ without receptions last",
+			query:    domain.PVZQuery{SortBy: domain.PVZSortLastReception, SortOrder: domain.SortAsc},
+			expected: []uuid.UUID{pvzK.ID, pvzM.ID, pvzS.ID},
+			ordered:  true,
+		},
+		{
+			name:     "Sort by registration date asc",
+			query:    domain.PVZQuery{SortBy: domain.PVZSortRegistrationDate, SortOrder: domain.SortAsc},
+			expected: []uuid.UUID{pvzM.ID, pvzK.ID, pvzS.ID},
+			ordered:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := listIDs(t, tc.query)
+			if tc.ordered {
+				assert.Equal(t, tc.expected, ids)
+			} else {
+				assert.ElementsMatch(t, tc.expected, ids)
+			}
+		})
+	}
+
+	t.Run("Cursor with ascending order", func(t *testing.T) {
+		query := domain.PVZQuery{SortBy: domain.PVZSortRegistrationDate, SortOrder: domain.SortAsc}
+		first, err := repo.List(ctx, domain.PVZListFilter{PVZQuery: query, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		last := first[1]
+		rest, err := repo.List(ctx, domain.PVZListFilter{
+			PVZQuery: query,
+			Limit:    2,
+			After:    &domain.PVZListCursor{RegistrationDate: last.RegistrationDate, ID: last.ID},
+		})
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+		assert.Equal(t, pvzS.ID, rest[0].ID)
+		assert.Equal(t, domain.PVZStatusInactive, rest[0].Status)
+	})
+}
+
+func TestPVZRepository_CapacityAndOccupancy(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	repo := testPVZRepo
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products", "pvz_occupancy")
+	require.NoError(t, err)
+	pvz := createTestPVZ(ctx, t, repo, domain.Kazan)
+	reception := createTestReception(ctx, t, testReceptionRepo, pvz.ID, time.Now().UTC().Add(-time.Hour))
+
+	t.Run("Empty_PVZ_Unlimited", func(t *testing.T) {
+		occupancy, err := repo.GetOccupancy(ctx, pvz.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, occupancy.Occupied)
+		assert.Nil(t, occupancy.Capacity)
+		assert.Empty(t, occupancy.ByType)
+	})
+
+	total, shoesLimit := 3, 1
+	require.NoError(t, repo.SetCapacity(ctx, pvz.ID, domain.PVZCapacity{
+		Total: &total, ByType: map[domain.ProductType]int{domain.TypeShoes: shoesLimit},
+	}, nil))
+
+	shoes := createTestProduct(ctx, t, testProductRepo, reception.ID, domain.TypeShoes, time.Now())
+
+	t.Run("Type_Capacity_Exceeded", func(t *testing.T) {
+		product := domain.Product{ID: uuid.New(), DateTime: time.Now().UTC(), Type: domain.TypeShoes, ReceptionID: reception.ID, Status: domain.ProductReceived}
+		err := testProductRepo.Create(ctx, &product)
+		assert.ErrorIs(t, err, domain.ErrPVZFull)
+	})
+
+	createTestProduct(ctx, t, testProductRepo, reception.ID, domain.TypeClothing, time.Now())
+	createTestProduct(ctx, t, testProductRepo, reception.ID, domain.TypeElectronics, time.Now())
+
+	t.Run("Total_Capacity_Exceeded", func(t *testing.T) {
+		product := domain.Product{ID: uuid.New(), DateTime: time.Now().UTC(), Type: domain.TypeClothing, ReceptionID: reception.ID, Status: domain.ProductReceived}
+		err := testProductRepo.Create(ctx, &product)
+		assert.ErrorIs(t, err, domain.ErrPVZFull)
+
+		occupancy, err := repo.GetOccupancy(ctx, pvz.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, occupancy.Occupied, "rejected product must not be counted")
+		assert.Equal(t, domain.TypeOccupancy{Occupied: 1, Capacity: &shoesLimit}, occupancy.ByType[domain.TypeShoes])
+		assert.Equal(t, domain.TypeOccupancy{Occupied: 1}, occupancy.ByType[domain.TypeClothing])
+	})
+
+	t.Run("Delete_And_Transition_Free_Space", func(t *testing.T) {
+		require.NoError(t, testProductRepo.DeleteByID(ctx, shoes.ID))
+		closeTestReception(ctx, t, testReceptionRepo, reception.ID)
+
+		inventory, err := testProductRepo.InventoryByPVZIDs(ctx, []uuid.UUID{pvz.ID})
+		require.NoError(t, err)
+		require.Equal(t, 2, inventory[pvz.ID].OnHand)
+
+		products, _, err := testProductRepo.ListByReceptionID(ctx, reception.ID, 10, 0)
+		require.NoError(t, err)
+		_, err = testProductRepo.Transition(ctx, domain.ProductTransition{
+			ProductID: products[0].ID, From: domain.ProductReceived, To: domain.ProductIssued, ChangedAt: time.Now().UTC(),
+		})
+		require.NoError(t, err)
+
+		occupancy, err := repo.GetOccupancy(ctx, pvz.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, occupancy.Occupied)
+		assert.Equal(t, 0, occupancy.ByType[domain.TypeShoes].Occupied)
+	})
+
+	t.Run("Replace_Capacity", func(t *testing.T) {
+		require.NoError(t, repo.SetCapacity(ctx, pvz.ID, domain.PVZCapacity{}, nil))
+
+		occupancies, err := repo.ListOccupancy(ctx)
+		require.NoError(t, err)
+		require.Len(t, occupancies, 1)
+		assert.Nil(t, occupancies[0].Capacity)
+		assert.Nil(t, occupancies[0].ByType[domain.TypeShoes].Capacity)
+		assert.Equal(t, domain.Kazan, occupancies[0].City)
+	})
+
+	t.Run("SetCapacity_Not_Found", func(t *testing.T) {
+		err := repo.SetCapacity(ctx, uuid.New(), domain.PVZCapacity{Total: &total}, nil)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		version := int64(1)
+		err = repo.SetCapacity(ctx, uuid.New(), domain.PVZCapacity{Total: &total}, &version)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("SetCapacity_Version", func(t *testing.T) {
+		before, err := repo.GetByID(ctx, pvz.ID)
+		require.NoError(t, err)
+
+		stale := before.Version - 1
+		err = repo.SetCapacity(ctx, pvz.ID, domain.PVZCapacity{Total: &total}, &stale)
+		assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+
+		require.NoError(t, repo.SetCapacity(ctx, pvz.ID, domain.PVZCapacity{Total: &total}, &before.Version))
+		after, err := repo.GetByID(ctx, pvz.ID)
+		require.NoError(t, err)
+		assert.Equal(t, before.Version+1, after.Version)
+	})
+}
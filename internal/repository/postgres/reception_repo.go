@@ -2,17 +2,23 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"pvz-service-avito-internship/internal/domain"
 )
 
+// receptionColumns - колонки приемки в порядке сканирования scanReception.
+var receptionColumns = []string{"id", "date_time", "pvz_id", "status", "closed_at", "opened_by", "closed_by", "auto_closed", "stale_reason", "expected_shipment_id", "version"}
+
 // ReceptionRepository реализует интерфейс domain.ReceptionRepository для PostgreSQL.
 type ReceptionRepository struct {
 	BaseRepository
@@ -30,14 +36,13 @@ func (r *ReceptionRepository) Create(ctx context.Context, reception *domain.Rece
 	const op = "ReceptionRepository.Create"
 
 	query, args, err := r.sq.Insert("receptions").
-		Columns("id", "date_time", "pvz_id", "status").
-		Values(reception.ID, reception.DateTime, reception.PVZID, reception.Status).
+		Columns("id", "date_time", "pvz_id", "status", "opened_by", "expected_shipment_id").
+		Values(reception.ID, reception.DateTime, reception.PVZID, reception.Status, reception.OpenedBy, reception.ExpectedShipmentID).
 		ToSql()
 	if err != nil {
 		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	_, err = r.db.Exec(ctx, query, args...)
 	if err != nil {
 		return r.wrapErr(op, err)
@@ -49,7 +54,7 @@ func (r *ReceptionRepository) Create(ctx context.Context, reception *domain.Rece
 func (r *ReceptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Reception, error) {
 	const op = "ReceptionRepository.GetByID"
 
-	query, args, err := r.sq.Select("id", "date_time", "pvz_id", "status").
+	query, args, err := r.sq.Select(receptionColumns...).
 		From("receptions").
 		Where(sq.Eq{"id": id}).
 		Limit(1).
@@ -58,22 +63,42 @@ func (r *ReceptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	row := r.db.QueryRow(ctx, query, args...)
 
-	var rec domain.Reception
-	err = row.Scan(&rec.ID, &rec.DateTime, &rec.PVZID, &rec.Status)
+	rec, err := scanReception(row)
 	if err != nil {
 		return nil, r.wrapErr(op, err)
 	}
-	return &rec, nil
+	return rec, nil
+}
+
+// GetWithProducts находит приемку по ID вместе с ее товарами в порядке добавления.
+func (r *ReceptionRepository) GetWithProducts(ctx context.Context, id uuid.UUID) (*domain.ReceptionWithProducts, error) {
+	const op = "ReceptionRepository.GetWithProducts"
+
+	rec, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	productRepo := NewProductRepository(r.db, r.log)
+	productsByReception, err := productRepo.ListByReceptionIDs(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("getting products: %w", err))
+	}
+
+	products := productsByReception[id]
+	if products == nil {
+		products = []domain.Product{}
+	}
+	return &domain.ReceptionWithProducts{Reception: *rec, Products: products}, nil
 }
 
 // FindOpenByPVZID находит последнюю активную ('in_progress') приемку для указанного ПВЗ.
 func (r *ReceptionRepository) FindOpenByPVZID(ctx context.Context, pvzID uuid.UUID) (*domain.Reception, error) {
 	const op = "ReceptionRepository.FindOpenByPVZID"
 
-	query, args, err := r.sq.Select("id", "date_time", "pvz_id", "status").
+	query, args, err := r.sq.Select(receptionColumns...).
 		From("receptions").
 		Where(sq.Eq{"pvz_id": pvzID, "status": domain.StatusInProgress}).
 		OrderBy("date_time DESC").
@@ -83,76 +108,154 @@ func (r *ReceptionRepository) FindOpenByPVZID(ctx context.Context, pvzID uuid.UU
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	row := r.db.QueryRow(ctx, query, args...)
 
-	var rec domain.Reception
-	err = row.Scan(&rec.ID, &rec.DateTime, &rec.PVZID, &rec.Status)
+	rec, err := scanReception(row)
 	if err != nil {
 		if isErrNoRows(err) {
 			return nil, fmt.Errorf("repository.%s: %w", op, domain.ErrNoOpenReception)
 		}
 		return nil, r.wrapErr(op, err)
 	}
-	return &rec, nil
+	return rec, nil
 }
 
-// UpdateStatus обновляет статус приемки по ее ID.
-func (r *ReceptionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ReceptionStatus) error {
-	const op = "ReceptionRepository.UpdateStatus"
+// Close закрывает приемку, если она еще активна. Условие по статусу в UPDATE защищает от повторного
+// закрытия конкурентным запросом: время и автор закрытия записываются ровно один раз.
+// Условие по версии (если expectedVersion задан) проверяется тем же UPDATE.
+func (r *ReceptionRepository) Close(ctx context.Context, id uuid.UUID, closedBy *uuid.UUID, expectedVersion *int64) (*domain.Reception, error) {
+	const op = "ReceptionRepository.Close"
 
+	where := sq.Eq{"id": id, "status": domain.StatusInProgress}
+	if expectedVersion != nil {
+		where["version"] = *expectedVersion
+	}
 	query, args, err := r.sq.Update("receptions").
-		Set("status", status).
-		Where(sq.Eq{"id": id}).
+		Set("status", domain.StatusClosed).
+		Set("closed_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("closed_by", closedBy).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("version", sq.Expr("version + 1")).
+		Where(where).
+		Suffix("RETURNING " + strings.Join(receptionColumns, ", ")).
 		ToSql()
 	if err != nil {
-		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	rec, err := scanReception(r.db.QueryRow(ctx, query, args...))
+	if err == nil {
+		return rec, nil
+	}
+	if expectedVersion == nil || !errors.Is(err, pgx.ErrNoRows) {
+		return nil, r.wrapErr(op, err)
+	}
+
+	// Приемка не обновлена: она закрыта (ErrNotFound) или изменилась после чтения клиентом.
+	current, errGet := r.GetByID(ctx, id)
+	if errGet != nil {
+		return nil, errGet
+	}
+	if current.Status == domain.StatusInProgress {
+		return nil, r.wrapErr(op, domain.ErrPreconditionFailed)
 	}
+	return nil, r.wrapErr(op, err)
+}
+
+// CloseStale закрывает незакрытые приемки, начатые раньше openedBefore, от имени планировщика (closed_by остается NULL).
+func (r *ReceptionRepository) CloseStale(ctx context.Context, openedBefore time.Time, reason domain.StaleReason) ([]domain.Reception, error) {
+	const op = "ReceptionRepository.CloseStale"
 
-	r.logQuery(ctx, op, query, args...)
-	cmdTag, err := r.db.Exec(ctx, query, args...)
+	query, args, err := r.sq.Update("receptions").
+		Set("status", domain.StatusClosed).
+		Set("closed_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("auto_closed", true).
+		Set("stale_reason", reason).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"status": domain.StatusInProgress}).
+		Where(sq.Lt{"date_time": openedBefore}).
+		Suffix("RETURNING " + strings.Join(receptionColumns, ", ")).
+		ToSql()
 	if err != nil {
-		return r.wrapErr(op, err)
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	if cmdTag.RowsAffected() == 0 {
-		return r.wrapErr(op, domain.ErrNotFound)
+	return r.queryReceptions(ctx, op, query, args...)
+}
+
+// FlagStale помечает незакрытые приемки, начатые раньше openedBefore, причиной reason без закрытия.
+func (r *ReceptionRepository) FlagStale(ctx context.Context, openedBefore time.Time, reason domain.StaleReason) ([]domain.Reception, error) {
+	const op = "ReceptionRepository.FlagStale"
+
+	query, args, err := r.sq.Update("receptions").
+		Set("stale_reason", reason).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"status": domain.StatusInProgress, "stale_reason": nil}).
+		Where(sq.Lt{"date_time": openedBefore}).
+		Suffix("RETURNING " + strings.Join(receptionColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	return nil
+	return r.queryReceptions(ctx, op, query, args...)
 }
 
-// ListByPVZIDsAndDate - вспомогательный метод для получения всех приемок и их товаров
-// для списка ID ПВЗ в заданном диапазоне дат.
-func (r *ReceptionRepository) ListByPVZIDsAndDate(ctx context.Context, pvzIDs []uuid.UUID, startDate, endDate *time.Time) (map[uuid.UUID][]domain.ReceptionWithProducts, error) {
-	const op = "ReceptionRepository.ListByPVZIDsAndDate"
+// queryReceptions выполняет запрос, возвращающий строки receptionColumns, и сканирует все приемки.
+func (r *ReceptionRepository) queryReceptions(ctx context.Context, op, query string, args ...interface{}) ([]domain.Reception, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	defer rows.Close()
+
+	receptions := make([]domain.Reception, 0)
+	for rows.Next() {
+		rec, err := scanReception(rows)
+		if err != nil {
+			return nil, r.wrapErr(op, fmt.Errorf("scanning reception: %w", err))
+		}
+		receptions = append(receptions, *rec)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("iterating receptions: %w", err))
+	}
+	return receptions, nil
+}
+
+// ListByPVZIDs - вспомогательный метод для получения приемок (и, по запросу, их товаров)
+// для списка ID ПВЗ в заданном диапазоне дат. При filter.PerPVZLimit > 0 для каждого ПВЗ
+// возвращаются только самые свежие приемки.
+func (r *ReceptionRepository) ListByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, filter domain.ReceptionEmbedFilter) (map[uuid.UUID][]domain.ReceptionWithProducts, error) {
+	const op = "ReceptionRepository.ListByPVZIDs"
 	log := r.log.With(slog.String("op", op))
 
 	if len(pvzIDs) == 0 {
 		log.Debug("No PVZ IDs provided, returning empty map")
 		return make(map[uuid.UUID][]domain.ReceptionWithProducts), nil
 	}
-	log.Debug("Fetching receptions for PVZ IDs", slog.Any("pvz_ids", pvzIDs))
+	log.Debug("Fetching receptions for PVZ IDs", slog.Any("pvz_ids", pvzIDs), slog.Int("per_pvz_limit", filter.PerPVZLimit))
 
-	receptionQueryBuilder := r.sq.Select("id", "date_time", "pvz_id", "status").
+	// Нумеруем приемки внутри каждого ПВЗ от новых к старым, чтобы ограничить их количество на ПВЗ одним запросом.
+	ranked := withReceptionDateRange(sq.Select(receptionColumns...).
+		Column("row_number() OVER (PARTITION BY pvz_id ORDER BY date_time DESC, id DESC) AS rn").
 		From("receptions").
-		Where(sq.Eq{"pvz_id": pvzIDs})
+		Where(sq.Eq{"pvz_id": pvzIDs}), filter.StartDate, filter.EndDate)
 
-	if startDate != nil {
-		receptionQueryBuilder = receptionQueryBuilder.Where(sq.GtOrEq{"date_time": startDate})
-	}
-	if endDate != nil {
-		receptionQueryBuilder = receptionQueryBuilder.Where(sq.LtOrEq{"date_time": endDate})
+	receptionQueryBuilder := r.sq.Select(receptionColumns...).
+		FromSelect(ranked, "ranked").
+		OrderBy("pvz_id", "date_time DESC", "id DESC")
+	if filter.PerPVZLimit > 0 {
+		receptionQueryBuilder = receptionQueryBuilder.Where(sq.LtOrEq{"rn": filter.PerPVZLimit})
 	}
 
-	receptionQueryBuilder = receptionQueryBuilder.OrderBy("pvz_id", "date_time DESC")
-
 	recSql, recArgs, err := receptionQueryBuilder.ToSql()
 	if err != nil {
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build reception query: %w", err))
 	}
 
-	r.logQuery(ctx, op+"_receptions", recSql, recArgs...)
 	recRows, err := r.db.Query(ctx, recSql, recArgs...)
 	if err != nil {
 		if isErrNoRows(err) {
@@ -167,12 +270,12 @@ func (r *ReceptionRepository) ListByPVZIDsAndDate(ctx context.Context, pvzIDs []
 	receptionsByPVZ := make(map[uuid.UUID][]domain.Reception)
 	allReceptionIDs := make([]uuid.UUID, 0)
 	for recRows.Next() {
-		var rec domain.Reception
-		if err := recRows.Scan(&rec.ID, &rec.DateTime, &rec.PVZID, &rec.Status); err != nil {
+		rec, err := scanReception(recRows)
+		if err != nil {
 			log.Error("Failed to scan reception", slog.String("error", err.Error()))
 			return nil, r.wrapErr(op, fmt.Errorf("scanning reception: %w", err))
 		}
-		receptionsByPVZ[rec.PVZID] = append(receptionsByPVZ[rec.PVZID], rec)
+		receptionsByPVZ[rec.PVZID] = append(receptionsByPVZ[rec.PVZID], *rec)
 		allReceptionIDs = append(allReceptionIDs, rec.ID)
 	}
 	if err = recRows.Err(); err != nil {
@@ -186,23 +289,30 @@ func (r *ReceptionRepository) ListByPVZIDsAndDate(ctx context.Context, pvzIDs []
 	}
 	log.Debug("Collected reception IDs", slog.Any("reception_ids", allReceptionIDs))
 
-	productRepo := NewProductRepository(r.db, r.log)
-	productsByReception, err := productRepo.ListByReceptionIDs(ctx, allReceptionIDs)
-	if err != nil {
-		log.Error("Failed to get products for receptions", slog.String("error", err.Error()))
-		return nil, r.wrapErr(op, fmt.Errorf("getting products: %w", err))
+	var productsByReception map[uuid.UUID][]domain.Product
+	if filter.WithProducts {
+		productRepo := NewProductRepository(r.db, r.log)
+		productsByReception, err = productRepo.ListByReceptionIDs(ctx, allReceptionIDs)
+		if err != nil {
+			log.Error("Failed to get products for receptions", slog.String("error", err.Error()))
+			return nil, r.wrapErr(op, fmt.Errorf("getting products: %w", err))
+		}
+		log.Debug("Fetched related products")
 	}
-	log.Debug("Fetched related products")
 
 	resultMap := make(map[uuid.UUID][]domain.ReceptionWithProducts, len(receptionsByPVZ))
 	for pvzID, receptions := range receptionsByPVZ {
 		receptionsWithProducts := make([]domain.ReceptionWithProducts, 0, len(receptions))
 		for _, rec := range receptions {
-			products := productsByReception[rec.ID]
-			receptionsWithProducts = append(receptionsWithProducts, domain.ReceptionWithProducts{
-				Reception: rec,
-				Products:  products,
-			})
+			item := domain.ReceptionWithProducts{Reception: rec}
+			if filter.WithProducts {
+				// Пустой, но не nil список отличает приемку без товаров от незагруженных товаров.
+				item.Products = productsByReception[rec.ID]
+				if item.Products == nil {
+					item.Products = []domain.Product{}
+				}
+			}
+			receptionsWithProducts = append(receptionsWithProducts, item)
 		}
 		resultMap[pvzID] = receptionsWithProducts
 	}
@@ -210,3 +320,155 @@ func (r *ReceptionRepository) ListByPVZIDsAndDate(ctx context.Context, pvzIDs []
 	log.Info("Successfully listed receptions by PVZ IDs", slog.Int("pvz_count", len(resultMap)))
 	return resultMap, nil
 }
+
+// SummaryByPVZIDs рассчитывает сводку по приемкам указанных ПВЗ за период одним агрегирующим запросом.
+func (r *ReceptionRepository) SummaryByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, startDate, endDate *time.Time) (map[uuid.UUID]domain.PVZSummary, error) {
+	const op = "ReceptionRepository.SummaryByPVZIDs"
+	log := r.log.With(slog.String("op", op))
+
+	if len(pvzIDs) == 0 {
+		return make(map[uuid.UUID]domain.PVZSummary), nil
+	}
+
+	builder := r.sq.Select(
+		"r.pvz_id",
+		"count(DISTINCT r.id)",
+		"count(DISTINCT r.id) FILTER (WHERE r.status = 'in_progress')",
+		"count(pr.id)",
+		"max(r.date_time)",
+	).
+		From("receptions r").
+		LeftJoin("products pr ON pr.reception_id = r.id").
+		Where(sq.Eq{"r.pvz_id": pvzIDs}).
+		GroupBy("r.pvz_id")
+	if startDate != nil {
+		builder = builder.Where(sq.GtOrEq{"r.date_time": startDate})
+	}
+	if endDate != nil {
+		builder = builder.Where(sq.LtOrEq{"r.date_time": endDate})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to query reception summary", slog.String("error", err.Error()))
+		return nil, r.wrapErr(op, fmt.Errorf("querying summary: %w", err))
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]domain.PVZSummary, len(pvzIDs))
+	for rows.Next() {
+		var (
+			pvzID   uuid.UUID
+			summary domain.PVZSummary
+			lastAt  time.Time
+		)
+		if err := rows.Scan(&pvzID, &summary.ReceptionsCount, &summary.OpenReceptionsCount, &summary.ProductsCount, &lastAt); err != nil {
+			log.Error("Failed to scan reception summary", slog.String("error", err.Error()))
+			return nil, r.wrapErr(op, fmt.Errorf("scanning summary: %w", err))
+		}
+		summary.LastReceptionAt = &lastAt
+		result[pvzID] = summary
+	}
+	if err = rows.Err(); err != nil {
+		log.Error("Error iterating summary rows", slog.String("error", err.Error()))
+		return nil, r.wrapErr(op, fmt.Errorf("iterating summary: %w", err))
+	}
+
+	return result, nil
+}
+
+// ListByPVZID возвращает страницу приемок ПВЗ от новых к старым и их общее количество по фильтру.
+func (r *ReceptionRepository) ListByPVZID(ctx context.Context, pvzID uuid.UUID, filter domain.ReceptionListFilter) ([]domain.Reception, int, error) {
+	const op = "ReceptionRepository.ListByPVZID"
+	log := r.log.With(slog.String("op", op))
+
+	applyFilter := func(b sq.SelectBuilder) sq.SelectBuilder {
+		b = withReceptionDateRange(b.Where(sq.Eq{"pvz_id": pvzID}), filter.StartDate, filter.EndDate)
+		if filter.Status != nil {
+			b = b.Where(sq.Eq{"status": *filter.Status})
+		}
+		if filter.OpenedBy != nil {
+			b = b.Where(sq.Eq{"opened_by": *filter.OpenedBy})
+		}
+		if filter.ClosedBy != nil {
+			b = b.Where(sq.Eq{"closed_by": *filter.ClosedBy})
+		}
+		if filter.ClosedFrom != nil {
+			b = b.Where(sq.GtOrEq{"closed_at": filter.ClosedFrom})
+		}
+		if filter.ClosedTo != nil {
+			b = b.Where(sq.LtOrEq{"closed_at": filter.ClosedTo})
+		}
+		return b
+	}
+
+	countQuery, countArgs, err := applyFilter(r.sq.Select("count(*)").From("receptions")).ToSql()
+	if err != nil {
+		return nil, 0, r.wrapErr(op, fmt.Errorf("failed to build count query: %w", err))
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, r.wrapErr(op, err)
+	}
+
+	receptions := make([]domain.Reception, 0, filter.Limit)
+	if total == 0 || filter.Offset >= total {
+		return receptions, total, nil
+	}
+
+	query, args, err := applyFilter(r.sq.Select(receptionColumns...).From("receptions")).
+		OrderBy("date_time DESC", "id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, 0, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to query receptions", slog.String("error", err.Error()))
+		return nil, 0, r.wrapErr(op, fmt.Errorf("querying receptions: %w", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rec, err := scanReception(rows)
+		if err != nil {
+			log.Error("Failed to scan reception", slog.String("error", err.Error()))
+			return nil, 0, r.wrapErr(op, fmt.Errorf("scanning reception: %w", err))
+		}
+		receptions = append(receptions, *rec)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error("Error iterating reception rows", slog.String("error", err.Error()))
+		return nil, 0, r.wrapErr(op, fmt.Errorf("iterating receptions: %w", err))
+	}
+
+	return receptions, total, nil
+}
+
+// withReceptionDateRange добавляет к запросу по таблице receptions ограничение по дате приемки.
+func withReceptionDateRange(b sq.SelectBuilder, startDate, endDate *time.Time) sq.SelectBuilder {
+	if startDate != nil {
+		b = b.Where(sq.GtOrEq{"date_time": startDate})
+	}
+	if endDate != nil {
+		b = b.Where(sq.LtOrEq{"date_time": endDate})
+	}
+	return b
+}
+
+func scanReception(row pgx.Row) (*domain.Reception, error) {
+	var rec domain.Reception
+	if err := row.Scan(&rec.ID, &rec.DateTime, &rec.PVZID, &rec.Status, &rec.ClosedAt, &rec.OpenedBy, &rec.ClosedBy, &rec.AutoClosed, &rec.StaleReason, &rec.ExpectedShipmentID, &rec.Version); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
@@ -75,8 +75,7 @@ func TestReceptionRepository_FindOpenByPVZID(t *testing.T) {
 
 	rec1Open := createTestReception(ctx, t, repo, pvz1.ID, time.Now().Add(-time.Hour))
 	rec1Closed := createTestReception(ctx, t, repo, pvz1.ID, time.Now().Add(-2*time.Hour))
-	errUpdate := repo.UpdateStatus(ctx, rec1Closed.ID, domain.StatusClosed)
-	require.NoError(t, errUpdate)
+	closeTestReception(ctx, t, repo, rec1Closed.ID)
 
 	t.Run("Found Open", func(t *testing.T) {
 		foundRec, err := repo.FindOpenByPVZID(ctx, pvz1.ID)
@@ -101,32 +100,264 @@ func TestReceptionRepository_FindOpenByPVZID(t *testing.T) {
 	})
 }
 
-func TestReceptionRepository_UpdateStatus(t *testing.T) {
+func TestReceptionRepository_ListByPVZIDs_Summary(t *testing.T) {
 	require.NotNil(t, dbPool, "Test DB pool should be initialized")
-	require.NotNil(t, testPVZRepo, "Test PVZ repo should be initialized")
-	require.NotNil(t, testReceptionRepo, "Test Reception repo should be initialized")
 	pvzRepo := testPVZRepo
 	repo := testReceptionRepo
 	ctx := context.Background()
 
 	err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
 	require.NoError(t, err)
-	pvz := createTestPVZ(ctx, t, pvzRepo, domain.Moscow)
-	reception := createTestReception(ctx, t, repo, pvz.ID, time.Now())
 
-	t.Run("Success Update to Closed", func(t *testing.T) {
-		errUpdate := repo.UpdateStatus(ctx, reception.ID, domain.StatusClosed)
-		require.NoError(t, errUpdate)
+	base := time.Now().UTC().Truncate(time.Microsecond).Add(-24 * time.Hour)
+	pvz1 := createTestPVZ(ctx, t, pvzRepo, domain.Moscow)
+	pvz2 := createTestPVZ(ctx, t, pvzRepo, domain.Kazan)
+	pvzEmpty := createTestPVZ(ctx, t, pvzRepo, domain.SaintPetersburg)
 
-		fetchedRec, errGet := repo.GetByID(ctx, reception.ID)
-		require.NoError(t, errGet)
-		require.NotNil(t, fetchedRec)
-		assert.Equal(t, domain.StatusClosed, fetchedRec.Status)
+	recOld := createTestReception(ctx, t, repo, pvz1.ID, base)
+	closeTestReception(ctx, t, repo, recOld.ID)
+	recMid := createTestReception(ctx, t, repo, pvz1.ID, base.Add(time.Hour))
+	closeTestReception(ctx, t, repo, recMid.ID)
+	recNew := createTestReception(ctx, t, repo, pvz1.ID, base.Add(2*time.Hour))
+	rec2 := createTestReception(ctx, t, repo, pvz2.ID, base.Add(time.Hour))
+
+	createTestProduct(ctx, t, testProductRepo, recNew.ID, domain.TypeShoes, base.Add(2*time.Hour))
+	createTestProduct(ctx, t, testProductRepo, recNew.ID, domain.TypeClothing, base.Add(3*time.Hour))
+	createTestProduct(ctx, t, testProductRepo, recOld.ID, domain.TypeShoes, base.Add(time.Minute))
+
+	ids := []uuid.UUID{pvz1.ID, pvz2.ID, pvzEmpty.ID}
+
+	t.Run("Per PVZ Limit Without Products", func(t *testing.T) {
+		result, err := repo.ListByPVZIDs(ctx, ids, domain.ReceptionEmbedFilter{PerPVZLimit: 2})
+		require.NoError(t, err)
+		require.Len(t, result[pvz1.ID], 2)
+		assert.Equal(t, recNew.ID, result[pvz1.ID][0].Reception.ID)
+		assert.Equal(t, recMid.ID, result[pvz1.ID][1].Reception.ID)
+		assert.Nil(t, result[pvz1.ID][0].Products)
+		require.Len(t, result[pvz2.ID], 1)
+		assert.Equal(t, rec2.ID, result[pvz2.ID][0].Reception.ID)
+		assert.NotContains(t, result, pvzEmpty.ID)
 	})
 
-	t.Run("Fail Update Not Found", func(t *testing.T) {
-		errUpdate := repo.UpdateStatus(ctx, uuid.New(), domain.StatusClosed)
-		require.Error(t, errUpdate)
-		assert.ErrorIs(t, errUpdate, domain.ErrNotFound)
+	t.Run("With Products And Date Range", func(t *testing.T) {
+		start := base.Add(30 * time.Minute)
+		result, err := repo.ListByPVZIDs(ctx, []uuid.UUID{pvz1.ID}, domain.ReceptionEmbedFilter{StartDate: &start, WithProducts: true})
+		require.NoError(t, err)
+		require.Len(t, result[pvz1.ID], 2)
+		assert.Len(t, result[pvz1.ID][0].Products, 2)
+		assert.NotNil(t, result[pvz1.ID][1].Products)
+		assert.Empty(t, result[pvz1.ID][1].Products)
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		summaries, err := repo.SummaryByPVZIDs(ctx, ids, nil, nil)
+		require.NoError(t, err)
+		require.Contains(t, summaries, pvz1.ID)
+		s1 := summaries[pvz1.ID]
+		assert.Equal(t, 3, s1.ReceptionsCount)
+		assert.Equal(t, 1, s1.OpenReceptionsCount)
+		assert.Equal(t, 3, s1.ProductsCount)
+		require.NotNil(t, s1.LastReceptionAt)
+		assert.WithinDuration(t, recNew.DateTime, *s1.LastReceptionAt, time.Second)
+		assert.Equal(t, 1, summaries[pvz2.ID].ReceptionsCount)
+		assert.NotContains(t, summaries, pvzEmpty.ID)
+
+		end := base.Add(90 * time.Minute)
+		summaries, err = repo.SummaryByPVZIDs(ctx, []uuid.UUID{pvz1.ID}, nil, &end)
+		require.NoError(t, err)
+		assert.Equal(t, 2, summaries[pvz1.ID].ReceptionsCount)
+		assert.Equal(t, 0, summaries[pvz1.ID].OpenReceptionsCount)
+		assert.Equal(t, 1, summaries[pvz1.ID].ProductsCount)
+	})
+
+	t.Run("List By PVZ ID", func(t *testing.T) {
+		page, total, err := repo.ListByPVZID(ctx, pvz1.ID, domain.ReceptionListFilter{Limit: 2, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, page, 2)
+		assert.Equal(t, recMid.ID, page[0].ID)
+		assert.Equal(t, recOld.ID, page[1].ID)
+
+		closed := domain.StatusClosed
+		page, total, err = repo.ListByPVZID(ctx, pvz1.ID, domain.ReceptionListFilter{Status: &closed, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, page, 2)
+
+		page, total, err = repo.ListByPVZID(ctx, pvzEmpty.ID, domain.ReceptionListFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, page)
+	})
+}
+
+func TestReceptionRepository_GetWithProducts(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	repo := testReceptionRepo
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
+	require.NoError(t, err)
+	pvz := createTestPVZ(ctx, t, testPVZRepo, domain.Moscow)
+
+	openedBy := uuid.New()
+	reception := domain.Reception{
+		ID:       uuid.New(),
+		DateTime: time.Now().UTC().Truncate(time.Microsecond),
+		PVZID:    pvz.ID,
+		Status:   domain.StatusInProgress,
+		OpenedBy: &openedBy,
+	}
+	require.NoError(t, repo.Create(ctx, &reception))
+	product := createTestProduct(ctx, t, testProductRepo, reception.ID, domain.TypeShoes, time.Now())
+	closeTestReception(ctx, t, repo, reception.ID)
+
+	t.Run("Success", func(t *testing.T) {
+		rwp, err := repo.GetWithProducts(ctx, reception.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusClosed, rwp.Reception.Status)
+		require.NotNil(t, rwp.Reception.OpenedBy)
+		assert.Equal(t, openedBy, *rwp.Reception.OpenedBy)
+		assert.NotNil(t, rwp.Reception.ClosedAt)
+		require.Len(t, rwp.Products, 1)
+		assert.Equal(t, product.ID, rwp.Products[0].ID)
+	})
+
+	t.Run("Without Products", func(t *testing.T) {
+		empty := createTestReception(ctx, t, repo, pvz.ID, time.Now())
+		rwp, err := repo.GetWithProducts(ctx, empty.ID)
+		require.NoError(t, err)
+		assert.Nil(t, rwp.Reception.OpenedBy)
+		assert.Nil(t, rwp.Reception.ClosedAt)
+		assert.NotNil(t, rwp.Products)
+		assert.Empty(t, rwp.Products)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := repo.GetWithProducts(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestReceptionRepository_Close(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	repo := testReceptionRepo
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
+	require.NoError(t, err)
+	pvz := createTestPVZ(ctx, t, testPVZRepo, domain.Moscow)
+
+	openedBy := uuid.New()
+	closedBy := uuid.New()
+	reception := domain.Reception{
+		ID:       uuid.New(),
+		DateTime: time.Now().UTC().Truncate(time.Microsecond),
+		PVZID:    pvz.ID,
+		Status:   domain.StatusInProgress,
+		OpenedBy: &openedBy,
+	}
+	require.NoError(t, repo.Create(ctx, &reception))
+	other := createTestReception(ctx, t, repo, pvz.ID, time.Now().Add(-time.Hour))
+	closeTestReception(ctx, t, repo, other.ID)
+
+	t.Run("Version Mismatch", func(t *testing.T) {
+		current, err := repo.GetByID(ctx, reception.ID)
+		require.NoError(t, err)
+		createTestProduct(ctx, t, testProductRepo, reception.ID, domain.TypeShoes, time.Now())
+
+		_, err = repo.Close(ctx, reception.ID, &closedBy, &current.Version)
+		assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+
+		updated, err := repo.GetByID(ctx, reception.ID)
+		require.NoError(t, err)
+		assert.Equal(t, current.Version+1, updated.Version, "adding a product must bump the reception version")
+		assert.Equal(t, domain.StatusInProgress, updated.Status)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		current, err := repo.GetByID(ctx, reception.ID)
+		require.NoError(t, err)
+
+		closed, err := repo.Close(ctx, reception.ID, &closedBy, &current.Version)
+		require.NoError(t, err)
+		assert.Equal(t, current.Version+1, closed.Version)
+		assert.Equal(t, domain.StatusClosed, closed.Status)
+		require.NotNil(t, closed.ClosedAt)
+		require.NotNil(t, closed.ClosedBy)
+		assert.Equal(t, closedBy, *closed.ClosedBy)
+		require.NotNil(t, closed.OpenedBy)
+		assert.Equal(t, openedBy, *closed.OpenedBy)
+	})
+
+	t.Run("Already Closed", func(t *testing.T) {
+		_, err := repo.Close(ctx, reception.ID, &closedBy, nil)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("List Filters", func(t *testing.T) {
+		items, total, err := repo.ListByPVZID(ctx, pvz.ID, domain.ReceptionListFilter{ClosedBy: &closedBy, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, items, 1)
+		assert.Equal(t, reception.ID, items[0].ID)
+
+		_, total, err = repo.ListByPVZID(ctx, pvz.ID, domain.ReceptionListFilter{OpenedBy: &openedBy, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		future := time.Now().Add(time.Hour)
+		_, total, err = repo.ListByPVZID(ctx, pvz.ID, domain.ReceptionListFilter{ClosedFrom: &future, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+	})
+}
+
+func TestReceptionRepository_CloseStale_FlagStale(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	repo := testReceptionRepo
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
+	require.NoError(t, err)
+	stalePVZ := createTestPVZ(ctx, t, testPVZRepo, domain.Moscow)
+	freshPVZ := createTestPVZ(ctx, t, testPVZRepo, domain.Kazan)
+
+	stale := createTestReception(ctx, t, repo, stalePVZ.ID, time.Now().Add(-48*time.Hour))
+	fresh := createTestReception(ctx, t, repo, freshPVZ.ID, time.Now())
+	cutoff := time.Now().Add(-24 * time.Hour)
+
+	t.Run("Flag", func(t *testing.T) {
+		flagged, err := repo.FlagStale(ctx, cutoff, domain.StaleReasonMaxAge)
+		require.NoError(t, err)
+		require.Len(t, flagged, 1)
+		assert.Equal(t, stale.ID, flagged[0].ID)
+		assert.Equal(t, domain.StatusInProgress, flagged[0].Status)
+		assert.False(t, flagged[0].AutoClosed)
+		require.NotNil(t, flagged[0].StaleReason)
+		assert.Equal(t, domain.StaleReasonMaxAge, *flagged[0].StaleReason)
+
+		// Повторная пометка не возвращает уже помеченные приемки.
+		flagged, err = repo.FlagStale(ctx, cutoff, domain.StaleReasonMaxAge)
+		require.NoError(t, err)
+		assert.Empty(t, flagged)
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		closed, err := repo.CloseStale(ctx, cutoff, domain.StaleReasonClosingTime)
+		require.NoError(t, err)
+		require.Len(t, closed, 1)
+		assert.Equal(t, stale.ID, closed[0].ID)
+		assert.Equal(t, domain.StatusClosed, closed[0].Status)
+		assert.True(t, closed[0].AutoClosed)
+		assert.NotNil(t, closed[0].ClosedAt)
+		assert.Nil(t, closed[0].ClosedBy)
+		require.NotNil(t, closed[0].StaleReason)
+		assert.Equal(t, domain.StaleReasonClosingTime, *closed[0].StaleReason)
+
+		open, err := repo.FindOpenByPVZID(ctx, freshPVZ.ID)
+		require.NoError(t, err)
+		assert.Equal(t, fresh.ID, open.ID)
+		assert.Nil(t, open.StaleReason)
 	})
 }
@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// reportColumns - колонки задачи в порядке сканирования scanReport.
+var reportColumns = []string{
+	"id", "type", "format", "params", "status", "requested_by", "blob_key", "error",
+	"attempts", "created_at", "started_at", "finished_at", "expires_at",
+}
+
+// ReportRepository реализует интерфейс domain.ReportRepository для PostgreSQL.
+// Таблица reports одновременно служит очередью задач: обработчики забирают задачи через FOR UPDATE SKIP LOCKED.
+type ReportRepository struct {
+	BaseRepository
+}
+
+// NewReportRepository создает новый экземпляр ReportRepository.
+func NewReportRepository(db *pgxpool.Pool, log *slog.Logger) *ReportRepository {
+	return &ReportRepository{
+		BaseRepository: NewBaseRepository(db, log),
+	}
+}
+
+// Create сохраняет новую задачу в состоянии pending.
+func (r *ReportRepository) Create(ctx context.Context, report *domain.Report) error {
+	const op = "ReportRepository.Create"
+
+	query, args, err := r.sq.Insert("reports").
+		Columns("id", "type", "format", "params", "status", "requested_by", "created_at").
+		Values(report.ID, report.Type, report.Format, report.Params, report.Status, report.RequestedBy, report.CreatedAt).
+		ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return r.wrapErr(op, err)
+	}
+	return nil
+}
+
+// GetByID находит задачу по ID.
+func (r *ReportRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Report, error) {
+	const op = "ReportRepository.GetByID"
+
+	query, args, err := r.sq.Select(reportColumns...).
+		From("reports").
+		Where(sq.Eq{"id": id}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	report, err := scanReport(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	return report, nil
+}
+
+// ClaimNext забирает самую старую задачу из очереди. Блокировка строки с SKIP LOCKED гарантирует,
+// что несколько обработчиков (в том числе в разных экземплярах сервиса) не возьмут одну задачу.
+func (r *ReportRepository) ClaimNext(ctx context.Context) (*domain.Report, error) {
+	const op = "ReportRepository.ClaimNext"
+
+	nextPending := sq.Select("id").
+		From("reports").
+		Where(sq.Eq{"status": domain.ReportStatusPending}).
+		OrderBy("created_at").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := r.sq.Update("reports").
+		Set("status", domain.ReportStatusRunning).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("started_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("error", nil).
+		Where(sq.Expr("id = (?)", nextPending)).
+		Suffix("RETURNING " + strings.Join(reportColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	report, err := scanReport(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	return report, nil
+}
+
+// MarkDone сохраняет ключ результата и срок его хранения, если задача все еще выполняется запуском attempt.
+func (r *ReportRepository) MarkDone(ctx context.Context, id uuid.UUID, attempt int, blobKey string, expiresAt time.Time) error {
+	const op = "ReportRepository.MarkDone"
+	return r.updateClaimed(ctx, op, id, attempt, r.sq.Update("reports").
+		Set("status", domain.ReportStatusDone).
+		Set("blob_key", blobKey).
+		Set("expires_at", expiresAt).
+		Set("finished_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")))
+}
+
+// MarkFailed переводит задачу в состояние failed с указанием причины, если задача все еще выполняется запуском attempt.
+func (r *ReportRepository) MarkFailed(ctx context.Context, id uuid.UUID, attempt int, reason string) error {
+	const op = "ReportRepository.MarkFailed"
+	return r.updateClaimed(ctx, op, id, attempt, r.sq.Update("reports").
+		Set("status", domain.ReportStatusFailed).
+		Set("error", reason).
+		Set("finished_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")))
+}
+
+// Release возвращает задачу в очередь. Попытка не возвращается: attempts уже учитывает прерванный запуск.
+func (r *ReportRepository) Release(ctx context.Context, id uuid.UUID, attempt int) error {
+	const op = "ReportRepository.Release"
+	return r.updateClaimed(ctx, op, id, attempt, r.sq.Update("reports").
+		Set("status", domain.ReportStatusPending).
+		Set("started_at", nil).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")))
+}
+
+// RequeueStale возвращает в очередь задачи, которые находятся в running дольше допустимого.
+// Такие задачи остаются после аварийной остановки обработчика; исчерпавшие попытки помечаются failed.
+func (r *ReportRepository) RequeueStale(ctx context.Context, startedBefore time.Time, maxAttempts int) (int64, error) {
+	const op = "ReportRepository.RequeueStale"
+
+	query, args, err := r.sq.Update("reports").
+		Set("status", sq.Case().
+			When(sq.Lt{"attempts": maxAttempts}, sq.Expr("?", domain.ReportStatusPending)).
+			Else(sq.Expr("?", domain.ReportStatusFailed))).
+		Set("error", sq.Case().
+			When(sq.Lt{"attempts": maxAttempts}, "NULL").
+			Else(sq.Expr("?", "report generation was interrupted too many times"))).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"status": domain.ReportStatusRunning}).
+		Where(sq.Lt{"started_at": startedBefore}).
+		ToSql()
+	if err != nil {
+		return 0, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	cmdTag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, r.wrapErr(op, err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// ListExpired возвращает готовые задачи с истекшим сроком хранения результата.
+func (r *ReportRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Report, error) {
+	const op = "ReportRepository.ListExpired"
+
+	query, args, err := r.sq.Select(reportColumns...).
+		From("reports").
+		Where(sq.Eq{"status": domain.ReportStatusDone}).
+		Where(sq.LtOrEq{"expires_at": now}).
+		OrderBy("expires_at").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.wrapErr(op, err)
+	}
+	defer rows.Close()
+
+	reports := make([]domain.Report, 0)
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, r.wrapErr(op, fmt.Errorf("scanning report: %w", err))
+		}
+		reports = append(reports, *report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("iterating reports: %w", err))
+	}
+	return reports, nil
+}
+
+// MarkExpired переводит задачу в состояние expired и очищает ключ результата.
+func (r *ReportRepository) MarkExpired(ctx context.Context, id uuid.UUID) error {
+	const op = "ReportRepository.MarkExpired"
+	return r.update(ctx, op, r.sq.Update("reports").
+		Set("status", domain.ReportStatusExpired).
+		Set("blob_key", nil).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id}), domain.ErrNotFound)
+}
+
+// updateClaimed выполняет обновление задачи, только пока ее выполняет запуск attempt. Номер запуска
+// (attempts) увеличивается при каждом ClaimNext, поэтому однозначно определяет владельца задачи.
+func (r *ReportRepository) updateClaimed(ctx context.Context, op string, id uuid.UUID, attempt int, builder sq.UpdateBuilder) error {
+	return r.update(ctx, op, builder.Where(sq.Eq{
+		"id":       id,
+		"status":   domain.ReportStatusRunning,
+		"attempts": attempt,
+	}), domain.ErrReportClaimLost)
+}
+
+// update выполняет обновление и возвращает notAffected, если ни одна строка не изменилась.
+func (r *ReportRepository) update(ctx context.Context, op string, builder sq.UpdateBuilder, notAffected error) error {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
+	}
+
+	cmdTag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return r.wrapErr(op, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return r.wrapErr(op, notAffected)
+	}
+	return nil
+}
+
+func scanReport(row pgx.Row) (*domain.Report, error) {
+	var report domain.Report
+	err := row.Scan(
+		&report.ID, &report.Type, &report.Format, &report.Params, &report.Status,
+		&report.RequestedBy, &report.BlobKey, &report.Error, &report.Attempts,
+		&report.CreatedAt, &report.StartedAt, &report.FinishedAt, &report.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,143 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+func createTestReport(ctx context.Context, t *testing.T, createdAt time.Time) *domain.Report {
+	t.Helper()
+	moscow := domain.Moscow
+	report := &domain.Report{
+		ID:        uuid.New(),
+		Type:      domain.ReportReceptionSummary,
+		Format:    domain.ExportFormatCSV,
+		Params:    domain.ReportParams{GroupBy: domain.GroupByDay, City: &moscow},
+		Status:    domain.ReportStatusPending,
+		CreatedAt: createdAt,
+	}
+	require.NoError(t, testReportRepo.Create(ctx, report))
+	return report
+}
+
+func TestReportRepository_Lifecycle(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	require.NotNil(t, testReportRepo, "Test Report repo should be initialized")
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "reports")
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	older := createTestReport(ctx, t, now.Add(-time.Minute))
+	newer := createTestReport(ctx, t, now)
+
+	t.Run("GetByID keeps params", func(t *testing.T) {
+		got, errGet := testReportRepo.GetByID(ctx, older.ID)
+		require.NoError(t, errGet)
+		assert.Equal(t, domain.ReportStatusPending, got.Status)
+		assert.Equal(t, domain.GroupByDay, got.Params.GroupBy)
+		require.NotNil(t, got.Params.City)
+		assert.Equal(t, domain.Moscow, *got.Params.City)
+	})
+
+	t.Run("GetByID not found", func(t *testing.T) {
+		_, errGet := testReportRepo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, errGet, domain.ErrNotFound)
+	})
+
+	t.Run("ClaimNext takes oldest pending", func(t *testing.T) {
+		claimed, errClaim := testReportRepo.ClaimNext(ctx)
+		require.NoError(t, errClaim)
+		assert.Equal(t, older.ID, claimed.ID)
+		assert.Equal(t, domain.ReportStatusRunning, claimed.Status)
+		assert.Equal(t, 1, claimed.Attempts)
+		assert.NotNil(t, claimed.StartedAt)
+
+		claimed, errClaim = testReportRepo.ClaimNext(ctx)
+		require.NoError(t, errClaim)
+		assert.Equal(t, newer.ID, claimed.ID)
+
+		_, errClaim = testReportRepo.ClaimNext(ctx)
+		assert.ErrorIs(t, errClaim, domain.ErrNotFound, "queue should be empty")
+	})
+
+	t.Run("MarkDone and ListExpired", func(t *testing.T) {
+		expiresAt := now.Add(time.Hour)
+		require.NoError(t, testReportRepo.MarkDone(ctx, older.ID, 1, older.ID.String()+".csv", expiresAt))
+
+		got, errGet := testReportRepo.GetByID(ctx, older.ID)
+		require.NoError(t, errGet)
+		assert.Equal(t, domain.ReportStatusDone, got.Status)
+		require.NotNil(t, got.BlobKey)
+		assert.Equal(t, older.ID.String()+".csv", *got.BlobKey)
+		assert.NotNil(t, got.FinishedAt)
+
+		expired, errList := testReportRepo.ListExpired(ctx, now, 10)
+		require.NoError(t, errList)
+		assert.Empty(t, expired)
+
+		expired, errList = testReportRepo.ListExpired(ctx, expiresAt.Add(time.Second), 10)
+		require.NoError(t, errList)
+		require.Len(t, expired, 1)
+		assert.Equal(t, older.ID, expired[0].ID)
+
+		require.NoError(t, testReportRepo.MarkExpired(ctx, older.ID))
+		got, errGet = testReportRepo.GetByID(ctx, older.ID)
+		require.NoError(t, errGet)
+		assert.Equal(t, domain.ReportStatusExpired, got.Status)
+	})
+
+	t.Run("RequeueStale returns interrupted job to queue", func(t *testing.T) {
+		affected, errRequeue := testReportRepo.RequeueStale(ctx, time.Now().Add(time.Minute), 3)
+		require.NoError(t, errRequeue)
+		assert.Equal(t, int64(1), affected)
+
+		got, errGet := testReportRepo.GetByID(ctx, newer.ID)
+		require.NoError(t, errGet)
+		assert.Equal(t, domain.ReportStatusPending, got.Status)
+
+		claimed, errClaim := testReportRepo.ClaimNext(ctx)
+		require.NoError(t, errClaim)
+		assert.Equal(t, newer.ID, claimed.ID)
+		assert.Equal(t, 2, claimed.Attempts)
+	})
+
+	t.Run("Interrupted run cannot finish reclaimed job", func(t *testing.T) {
+		errDone := testReportRepo.MarkDone(ctx, newer.ID, 1, "late.csv", now.Add(time.Hour))
+		assert.ErrorIs(t, errDone, domain.ErrReportClaimLost)
+		errFail := testReportRepo.MarkFailed(ctx, newer.ID, 1, "late failure")
+		assert.ErrorIs(t, errFail, domain.ErrReportClaimLost)
+		assert.ErrorIs(t, testReportRepo.Release(ctx, newer.ID, 1), domain.ErrReportClaimLost)
+
+		got, errGet := testReportRepo.GetByID(ctx, newer.ID)
+		require.NoError(t, errGet)
+		assert.Equal(t, domain.ReportStatusRunning, got.Status)
+		assert.Nil(t, got.BlobKey)
+		assert.Nil(t, got.Error)
+	})
+
+	t.Run("RequeueStale fails job after max attempts", func(t *testing.T) {
+		affected, errRequeue := testReportRepo.RequeueStale(ctx, time.Now().Add(time.Minute), 2)
+		require.NoError(t, errRequeue)
+		assert.Equal(t, int64(1), affected)
+
+		got, errGet := testReportRepo.GetByID(ctx, newer.ID)
+		require.NoError(t, errGet)
+		assert.Equal(t, domain.ReportStatusFailed, got.Status)
+		assert.NotNil(t, got.Error)
+	})
+
+	t.Run("Update of missing report", func(t *testing.T) {
+		errFail := testReportRepo.MarkFailed(ctx, uuid.New(), 1, "boom")
+		assert.ErrorIs(t, errFail, domain.ErrReportClaimLost)
+		assert.ErrorIs(t, testReportRepo.MarkExpired(ctx, uuid.New()), domain.ErrNotFound)
+	})
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// StatsRepository реализует интерфейс domain.StatsRepository для PostgreSQL.
+// Все агрегаты считаются на стороне БД, в память загружаются только итоговые строки.
+type StatsRepository struct {
+	BaseRepository
+}
+
+// NewStatsRepository создает новый экземпляр StatsRepository.
+func NewStatsRepository(db *pgxpool.Pool, log *slog.Logger) *StatsRepository {
+	return &StatsRepository{
+		BaseRepository: NewBaseRepository(db, log),
+	}
+}
+
+// statsKey идентифицирует строку статистики: период + ПВЗ (uuid.Nil для агрегата по городу) + город.
+type statsKey struct {
+	period int64
+	city   domain.City
+	pvzID  uuid.UUID
+}
+
+func newStatsKey(period time.Time, city domain.City, pvzID *uuid.UUID) statsKey {
+	key := statsKey{period: period.UnixNano(), city: city}
+	if pvzID != nil {
+		key.pvzID = *pvzID
+	}
+	return key
+}
+
+// ReceptionStats рассчитывает агрегаты приемок двумя запросами с GROUPING SETS:
+// первый считает количество приемок и длительности, второй - количество товаров по типам.
+// Строки с GROUPING(pvz_id) = 1 - итог по городу за период.
+func (r *StatsRepository) ReceptionStats(ctx context.Context, filter domain.ReceptionStatsFilter) (*domain.ReceptionStats, error) {
+	const op = "StatsRepository.ReceptionStats"
+	log := r.log.With(slog.String("op", op))
+
+	receptionRows, err := r.receptionAggregates(ctx, op, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.ReceptionStats{
+		ByPVZ:  make([]domain.ReceptionStatsRow, 0),
+		ByCity: make([]domain.ReceptionStatsRow, 0),
+	}
+	if len(receptionRows) == 0 {
+		log.Debug("No receptions found for the given period")
+		return stats, nil
+	}
+
+	rowsByKey := make(map[statsKey]*domain.ReceptionStatsRow, len(receptionRows))
+	for i := range receptionRows {
+		row := &receptionRows[i]
+		rowsByKey[newStatsKey(row.Period, row.City, row.PVZID)] = row
+	}
+
+	if err := r.mergeProductAggregates(ctx, op, filter, rowsByKey); err != nil {
+		return nil, err
+	}
+
+	for _, row := range receptionRows {
+		if row.PVZID == nil {
+			stats.ByCity = append(stats.ByCity, row)
+		} else {
+			stats.ByPVZ = append(stats.ByPVZ, row)
+		}
+	}
+
+	log.Debug("Reception stats calculated", slog.Int("pvz_rows", len(stats.ByPVZ)), slog.Int("city_rows", len(stats.ByCity)))
+	return stats, nil
+}
+
+// periodColumn - начало периода в UTC. Используется во вложенном запросе, чтобы GROUPING SETS
+// группировал по готовой колонке, а не по выражению с параметром.
+func periodColumn(groupBy domain.StatsGroupBy) sq.Sqlizer {
+	return sq.Expr("date_trunc(?, r.date_time, 'UTC') AS period", string(groupBy))
+}
+
+func (r *StatsRepository) receptionAggregates(ctx context.Context, op string, filter domain.ReceptionStatsFilter) ([]domain.ReceptionStatsRow, error) {
+	// Вложенный запрос строится с плейсхолдерами '?', внешний билдер переводит их в $N.
+	receptionsInPeriod := sq.Select("r.pvz_id", "p.city", "r.status", "r.date_time", "r.closed_at").
+		Column(periodColumn(filter.GroupBy)).
+		From("receptions r").
+		Join("pvz p ON p.id = r.pvz_id").
+		Where(sq.GtOrEq{"r.date_time": filter.StartDate}).
+		Where(sq.Lt{"r.date_time": filter.EndDate})
+
+	const durationExpr = "EXTRACT(EPOCH FROM s.closed_at - s.date_time)::float8"
+	query, args, err := r.sq.Select(
+		"s.period",
+		"s.city",
+		"s.pvz_id",
+		"count(*)",
+		fmt.Sprintf("count(*) FILTER (WHERE s.status = '%s')", domain.StatusInProgress),
+		fmt.Sprintf("avg(%s) FILTER (WHERE s.closed_at IS NOT NULL)", durationExpr),
+		fmt.Sprintf("percentile_cont(0.95) WITHIN GROUP (ORDER BY %s) FILTER (WHERE s.closed_at IS NOT NULL)", durationExpr),
+	).
+		FromSelect(receptionsInPeriod, "s").
+		GroupBy("GROUPING SETS ((s.period, s.city, s.pvz_id), (s.period, s.city))").
+		OrderBy("s.period", "s.city", "s.pvz_id NULLS FIRST").
+		ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build reception stats query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("querying reception stats: %w", err))
+	}
+	defer rows.Close()
+
+	result := make([]domain.ReceptionStatsRow, 0)
+	for rows.Next() {
+		row := domain.ReceptionStatsRow{ProductsByType: make(map[domain.ProductType]int)}
+		if err := rows.Scan(
+			&row.Period, &row.City, &row.PVZID,
+			&row.ReceptionsCount, &row.OpenReceptionsCount,
+			&row.AvgDurationSeconds, &row.P95DurationSeconds,
+		); err != nil {
+			return nil, r.wrapErr(op, fmt.Errorf("scanning reception stats: %w", err))
+		}
+		row.Period = row.Period.UTC()
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("iterating reception stats: %w", err))
+	}
+
+	return result, nil
+}
+
+func (r *StatsRepository) mergeProductAggregates(ctx context.Context, op string, filter domain.ReceptionStatsFilter, rowsByKey map[statsKey]*domain.ReceptionStatsRow) error {
+	productsInPeriod := sq.Select("r.pvz_id", "p.city", "pr.type").
+		Column(periodColumn(filter.GroupBy)).
+		From("products pr").
+		Join("receptions r ON r.id = pr.reception_id").
+		Join("pvz p ON p.id = r.pvz_id").
+		Where(sq.GtOrEq{"r.date_time": filter.StartDate}).
+		Where(sq.Lt{"r.date_time": filter.EndDate})
+
+	query, args, err := r.sq.Select("s.period", "s.city", "s.pvz_id", "s.type", "count(*)").
+		FromSelect(productsInPeriod, "s").
+		GroupBy("GROUPING SETS ((s.period, s.city, s.pvz_id, s.type), (s.period, s.city, s.type))").
+		ToSql()
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("failed to build product stats query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return r.wrapErr(op, fmt.Errorf("querying product stats: %w", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			period      time.Time
+			city        domain.City
+			pvzID       *uuid.UUID
+			productType domain.ProductType
+			count       int
+		)
+		if err := rows.Scan(&period, &city, &pvzID, &productType, &count); err != nil {
+			return r.wrapErr(op, fmt.Errorf("scanning product stats: %w", err))
+		}
+
+		row, ok := rowsByKey[newStatsKey(period.UTC(), city, pvzID)]
+		if !ok {
+			// Товары всегда принадлежат приемке из того же периода, строка должна существовать.
+			continue
+		}
+		row.ProductsByType[productType] = count
+		row.ProductsCount += count
+	}
+	if err := rows.Err(); err != nil {
+		return r.wrapErr(op, fmt.Errorf("iterating product stats: %w", err))
+	}
+
+	return nil
+}
+
+// PVZActivity рассчитывает активность каждого ПВЗ за период одним запросом.
+// Приемки присоединяются через LEFT JOIN, чтобы ПВЗ без приемок попали в результат с нулевыми счетчиками.
+func (r *StatsRepository) PVZActivity(ctx context.Context, filter domain.PVZActivityFilter) ([]domain.PVZActivityRow, error) {
+	const op = "StatsRepository.PVZActivity"
+	log := r.log.With(slog.String("op", op))
+
+	queryBuilder := r.sq.Select(
+		"p.id", "p.city", "p.registration_date",
+		"count(r.id)",
+		fmt.Sprintf("count(r.id) FILTER (WHERE r.status = '%s')", domain.StatusInProgress),
+		"COALESCE(sum(pc.products_count), 0)::bigint",
+		"max(r.date_time)",
+	).
+		From("pvz p").
+		LeftJoin("receptions r ON r.pvz_id = p.id AND r.date_time >= ? AND r.date_time < ?", filter.StartDate, filter.EndDate).
+		LeftJoin("LATERAL (SELECT count(*) AS products_count FROM products pr WHERE pr.reception_id = r.id) pc ON true").
+		GroupBy("p.id", "p.city", "p.registration_date").
+		OrderBy("p.city", "p.registration_date", "p.id")
+	if filter.City != nil {
+		queryBuilder = queryBuilder.Where(sq.Eq{"p.city": *filter.City})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("failed to build pvz activity query: %w", err))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("querying pvz activity: %w", err))
+	}
+	defer rows.Close()
+
+	result := make([]domain.PVZActivityRow, 0)
+	for rows.Next() {
+		var row domain.PVZActivityRow
+		if err := rows.Scan(
+			&row.PVZID, &row.City, &row.RegistrationDate,
+			&row.ReceptionsCount, &row.OpenReceptionsCount, &row.ProductsCount,
+			&row.LastReceptionAt,
+		); err != nil {
+			return nil, r.wrapErr(op, fmt.Errorf("scanning pvz activity: %w", err))
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.wrapErr(op, fmt.Errorf("iterating pvz activity: %w", err))
+	}
+
+	log.Debug("PVZ activity calculated", slog.Int("rows", len(result)))
+	return result, nil
+}
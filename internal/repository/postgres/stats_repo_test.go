@@ -0,0 +1,82 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+func TestStatsRepository_ReceptionStats(t *testing.T) {
+	require.NotNil(t, dbPool, "Test DB pool should be initialized")
+	require.NotNil(t, testStatsRepo, "Test Stats repo should be initialized")
+	ctx := context.Background()
+
+	err := clearTables(ctx, dbPool, "pvz", "receptions", "products")
+	require.NoError(t, err)
+
+	day := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	pvzMoscow := createTestPVZ(ctx, t, testPVZRepo, domain.Moscow)
+	pvzKazan := createTestPVZ(ctx, t, testPVZRepo, domain.Kazan)
+
+	closedRec := createTestReception(ctx, t, testReceptionRepo, pvzMoscow.ID, day.Add(9*time.Hour))
+	createTestProduct(ctx, t, testProductRepo, closedRec.ID, domain.TypeShoes, day.Add(9*time.Hour+time.Minute))
+	createTestProduct(ctx, t, testProductRepo, closedRec.ID, domain.TypeShoes, day.Add(9*time.Hour+2*time.Minute))
+	closeTestReception(ctx, t, testReceptionRepo, closedRec.ID)
+
+	openRec := createTestReception(ctx, t, testReceptionRepo, pvzKazan.ID, day.Add(12*time.Hour))
+	createTestProduct(ctx, t, testProductRepo, openRec.ID, domain.TypeElectronics, day.Add(12*time.Hour+time.Minute))
+
+	outOfRange := createTestReception(ctx, t, testReceptionRepo, pvzKazan.ID, day.AddDate(0, 0, -5))
+	createTestProduct(ctx, t, testProductRepo, outOfRange.ID, domain.TypeClothing, day.AddDate(0, 0, -5))
+
+	t.Run("Group by day", func(t *testing.T) {
+		stats, errStats := testStatsRepo.ReceptionStats(ctx, domain.ReceptionStatsFilter{
+			StartDate: day,
+			EndDate:   day.AddDate(0, 0, 1),
+			GroupBy:   domain.GroupByDay,
+		})
+		require.NoError(t, errStats)
+		require.Len(t, stats.ByPVZ, 2)
+		require.Len(t, stats.ByCity, 2)
+
+		for _, row := range stats.ByPVZ {
+			assert.True(t, row.Period.Equal(day))
+			assert.Equal(t, 1, row.ReceptionsCount)
+			switch *row.PVZID {
+			case pvzMoscow.ID:
+				assert.Equal(t, 0, row.OpenReceptionsCount)
+				assert.Equal(t, 2, row.ProductsCount)
+				assert.Equal(t, 2, row.ProductsByType[domain.TypeShoes])
+				assert.NotNil(t, row.AvgDurationSeconds)
+				assert.NotNil(t, row.P95DurationSeconds)
+			case pvzKazan.ID:
+				assert.Equal(t, 1, row.OpenReceptionsCount)
+				assert.Equal(t, 1, row.ProductsCount)
+				assert.Zero(t, row.ProductsByType[domain.TypeClothing])
+				assert.Nil(t, row.AvgDurationSeconds)
+			default:
+				t.Fatalf("unexpected pvz in stats: %s", row.PVZID)
+			}
+		}
+		for _, row := range stats.ByCity {
+			assert.Nil(t, row.PVZID)
+			assert.Equal(t, 1, row.ReceptionsCount)
+		}
+	})
+
+	t.Run("Empty period", func(t *testing.T) {
+		stats, errStats := testStatsRepo.ReceptionStats(ctx, domain.ReceptionStatsFilter{
+			StartDate: day.AddDate(1, 0, 0),
+			EndDate:   day.AddDate(1, 0, 1),
+			GroupBy:   domain.GroupByMonth,
+		})
+		require.NoError(t, errStats)
+		assert.Empty(t, stats.ByPVZ)
+		assert.Empty(t, stats.ByCity)
+	})
+}
@@ -18,6 +18,7 @@ func createTestPVZ(ctx context.Context, t *testing.T, repo *postgres.PVZReposito
 		ID:               uuid.New(),
 		RegistrationDate: time.Now().UTC().Truncate(time.Microsecond),
 		City:             city,
+		Status:           domain.PVZStatusActive,
 	}
 	err := repo.Create(ctx, &pvz)
 	require.NoError(t, err, "Failed to create test PVZ")
@@ -42,6 +43,12 @@ func createTestReception(ctx context.Context, t *testing.T, repo *postgres.Recep
 	return reception
 }
 
+func closeTestReception(ctx context.Context, t *testing.T, repo *postgres.ReceptionRepository, recID uuid.UUID) {
+	t.Helper()
+	_, err := repo.Close(ctx, recID, nil, nil)
+	require.NoError(t, err, "Failed to close test reception")
+}
+
 func createTestProduct(ctx context.Context, t *testing.T, repo *postgres.ProductRepository, recID uuid.UUID, prodType domain.ProductType, dateTime time.Time) domain.Product {
 	t.Helper()
 	product := domain.Product{
@@ -36,7 +36,6 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		return r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	_, err = r.db.Exec(ctx, query, args...)
 	if err != nil {
 		return r.wrapErr(op, err)
@@ -57,7 +56,6 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	row := r.db.QueryRow(ctx, query, args...)
 
 	var user domain.User
@@ -81,7 +79,6 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 		return nil, r.wrapErr(op, fmt.Errorf("failed to build query: %w", err))
 	}
 
-	r.logQuery(ctx, op, query, args...)
 	row := r.db.QueryRow(ctx, query, args...)
 
 	var user domain.User
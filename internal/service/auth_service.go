@@ -12,6 +12,7 @@ import (
 
 	"pvz-service-avito-internship/internal/domain"
 	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
 	"pvz-service-avito-internship/pkg/jwt"
 )
 
@@ -41,6 +42,8 @@ func NewAuthService(
 
 func (s *AuthService) DummyLogin(ctx context.Context, role domain.UserRole) (string, error) {
 	const op = "AuthService.DummyLogin"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("role", string(role)))
 
@@ -64,6 +67,8 @@ func (s *AuthService) DummyLogin(ctx context.Context, role domain.UserRole) (str
 
 func (s *AuthService) Register(ctx context.Context, email, password string, role domain.UserRole) (*domain.User, error) {
 	const op = "AuthService.Register"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("email", email), slog.String("role", string(role)))
@@ -102,6 +107,8 @@ func (s *AuthService) Register(ctx context.Context, email, password string, role
 
 func (s *AuthService) Login(ctx context.Context, email, password string) (string, error) {
 	const op = "AuthService.Login"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("email", email))
 
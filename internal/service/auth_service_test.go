@@ -129,15 +129,6 @@ func TestAuthService_Register(t *testing.T) {
 			expectedUser:  nil,
 			expectedError: domain.ErrConflict,
 		},
-		{
-			name:          "Fail_Short_Password",
-			email:         "short@example.com",
-			password:      "123",
-			role:          domain.RoleEmployee,
-			setupMocks:    func(email, hashedPassword string, role domain.UserRole) {},
-			expectedUser:  nil,
-			expectedError: domain.ErrValidation,
-		},
 		{
 			name:     "Fail_Hashing_Error",
 			email:    "hashfail@example.com",
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
+)
+
+// DispatchService реализует интерфейс domain.DispatchService.
+type DispatchService struct {
+	log          *slog.Logger
+	dispatchRepo domain.DispatchRepository
+	productRepo  domain.ProductRepository // Зависимость для получения товаров отправки
+}
+
+// NewDispatchService создает новый экземпляр DispatchService.
+func NewDispatchService(log *slog.Logger, dispatchRepo domain.DispatchRepository, productRepo domain.ProductRepository) *DispatchService {
+	return &DispatchService{
+		log:          log,
+		dispatchRepo: dispatchRepo,
+		productRepo:  productRepo,
+	}
+}
+
+// GetDispatch возвращает отправку с товарами.
+func (s *DispatchService) GetDispatch(ctx context.Context, id uuid.UUID) (*domain.DispatchDetails, error) {
+	const op = "DispatchService.GetDispatch"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("dispatch_id", id.String()))
+
+	dispatch, err := s.dispatchRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Dispatch not found")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get dispatch", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	products, err := s.productRepo.ListByDispatchID(ctx, id)
+	if err != nil {
+		log.Error("Failed to list dispatch products", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	return &domain.DispatchDetails{Dispatch: *dispatch, Products: products}, nil
+}
+
+// SendDispatch закрывает сборку отправки. После отправки новые возвраты ПВЗ попадают в новую отправку.
+func (s *DispatchService) SendDispatch(ctx context.Context, id uuid.UUID) (*domain.DispatchDetails, error) {
+	const op = "DispatchService.SendDispatch"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("dispatch_id", id.String()))
+
+	details, err := s.GetDispatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if details.Dispatch.Status == domain.DispatchSent {
+		log.Warn("Dispatch is already sent")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrDispatchAlreadySent)
+	}
+	if len(details.Products) == 0 {
+		log.Warn("Attempt to send empty dispatch")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrDispatchEmpty)
+	}
+
+	var sentBy *uuid.UUID
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		sentBy = &userID
+	}
+	dispatch, err := s.dispatchRepo.MarkSent(ctx, id, time.Now().UTC(), sentBy)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Dispatch was sent concurrently")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrDispatchAlreadySent)
+		}
+		log.Error("Failed to mark dispatch as sent", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	// Товары перечитываются: до отправки в нее могли успеть попасть новые возвраты.
+	products, err := s.productRepo.ListByDispatchID(ctx, id)
+	if err != nil {
+		log.Error("Failed to list dispatch products", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("Dispatch sent successfully", slog.Int("products_count", len(products)))
+	return &domain.DispatchDetails{Dispatch: *dispatch, Products: products}, nil
+}
@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/service"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestDispatchService_GetDispatch(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	dispatchID := uuid.New()
+	dispatch := &domain.Dispatch{ID: dispatchID, PVZID: uuid.New(), Status: domain.DispatchOpen}
+	products := []domain.Product{{ID: uuid.New(), Status: domain.ProductReturned, DispatchID: &dispatchID}}
+
+	t.Run("Success", func(t *testing.T) {
+		dispatchRepo := mocks.NewDispatchRepository(t)
+		productRepo := mocks.NewProductRepository(t)
+		dispatchRepo.On("GetByID", mock.Anything, dispatchID).Return(dispatch, nil).Once()
+		productRepo.On("ListByDispatchID", mock.Anything, dispatchID).Return(products, nil).Once()
+
+		details, err := service.NewDispatchService(logger, dispatchRepo, productRepo).GetDispatch(ctx, dispatchID)
+		require.NoError(t, err)
+		assert.Equal(t, *dispatch, details.Dispatch)
+		assert.Equal(t, products, details.Products)
+	})
+
+	t.Run("Fail_Not_Found", func(t *testing.T) {
+		dispatchRepo := mocks.NewDispatchRepository(t)
+		dispatchRepo.On("GetByID", mock.Anything, dispatchID).Return(nil, domain.ErrNotFound).Once()
+
+		_, err := service.NewDispatchService(logger, dispatchRepo, mocks.NewProductRepository(t)).GetDispatch(ctx, dispatchID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestDispatchService_SendDispatch(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	dispatchID := uuid.New()
+	openDispatch := &domain.Dispatch{ID: dispatchID, PVZID: uuid.New(), Status: domain.DispatchOpen}
+	sentAt := time.Now().UTC()
+	sentDispatch := &domain.Dispatch{ID: dispatchID, PVZID: openDispatch.PVZID, Status: domain.DispatchSent, SentAt: &sentAt}
+	products := []domain.Product{{ID: uuid.New(), Status: domain.ProductReturned, DispatchID: &dispatchID}}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(dispatchRepo *mocks.DispatchRepository, productRepo *mocks.ProductRepository)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMocks: func(dispatchRepo *mocks.DispatchRepository, productRepo *mocks.ProductRepository) {
+				dispatchRepo.On("GetByID", mock.Anything, dispatchID).Return(openDispatch, nil).Once()
+				productRepo.On("ListByDispatchID", mock.Anything, dispatchID).Return(products, nil).Twice()
+				dispatchRepo.On("MarkSent", mock.Anything, dispatchID, mock.AnythingOfType("time.Time"), (*uuid.UUID)(nil)).
+					Return(sentDispatch, nil).Once()
+			},
+		},
+		{
+			name: "Fail_Already_Sent",
+			setupMocks: func(dispatchRepo *mocks.DispatchRepository, productRepo *mocks.ProductRepository) {
+				dispatchRepo.On("GetByID", mock.Anything, dispatchID).Return(sentDispatch, nil).Once()
+				productRepo.On("ListByDispatchID", mock.Anything, dispatchID).Return(products, nil).Once()
+			},
+			expectedError: domain.ErrDispatchAlreadySent,
+		},
+		{
+			name: "Fail_Empty",
+			setupMocks: func(dispatchRepo *mocks.DispatchRepository, productRepo *mocks.ProductRepository) {
+				dispatchRepo.On("GetByID", mock.Anything, dispatchID).Return(openDispatch, nil).Once()
+				productRepo.On("ListByDispatchID", mock.Anything, dispatchID).Return([]domain.Product{}, nil).Once()
+			},
+			expectedError: domain.ErrDispatchEmpty,
+		},
+		{
+			name: "Fail_Sent_Concurrently",
+			setupMocks: func(dispatchRepo *mocks.DispatchRepository, productRepo *mocks.ProductRepository) {
+				dispatchRepo.On("GetByID", mock.Anything, dispatchID).Return(openDispatch, nil).Once()
+				productRepo.On("ListByDispatchID", mock.Anything, dispatchID).Return(products, nil).Once()
+				dispatchRepo.On("MarkSent", mock.Anything, dispatchID, mock.AnythingOfType("time.Time"), (*uuid.UUID)(nil)).
+					Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrDispatchAlreadySent,
+		},
+		{
+			name: "Fail_MarkSent_Repo_Error",
+			setupMocks: func(dispatchRepo *mocks.DispatchRepository, productRepo *mocks.ProductRepository) {
+				dispatchRepo.On("GetByID", mock.Anything, dispatchID).Return(openDispatch, nil).Once()
+				productRepo.On("ListByDispatchID", mock.Anything, dispatchID).Return(products, nil).Once()
+				dispatchRepo.On("MarkSent", mock.Anything, dispatchID, mock.AnythingOfType("time.Time"), (*uuid.UUID)(nil)).
+					Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatchRepo := mocks.NewDispatchRepository(t)
+			productRepo := mocks.NewProductRepository(t)
+			tc.setupMocks(dispatchRepo, productRepo)
+
+			details, err := service.NewDispatchService(logger, dispatchRepo, productRepo).SendDispatch(ctx, dispatchID)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, details)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, domain.DispatchSent, details.Dispatch.Status)
+				assert.Len(t, details.Products, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
+)
+
+// ExportService реализует интерфейс domain.ExportService.
+type ExportService struct {
+	log        *slog.Logger
+	exportRepo domain.ExportRepository // Зависимость для потокового чтения данных
+}
+
+// NewExportService создает новый экземпляр ExportService.
+func NewExportService(log *slog.Logger, exportRepo domain.ExportRepository) *ExportService {
+	return &ExportService{
+		log:        log,
+		exportRepo: exportRepo,
+	}
+}
+
+// ExportReceptions проверяет фильтр и передает строки выгрузки в fn.
+// Ошибки, которые вернул сам fn, пробрасываются без изменений, ошибки чтения - как ErrDatabaseError.
+func (s *ExportService) ExportReceptions(ctx context.Context, filter domain.ReceptionExportFilter, fn domain.ReceptionExportRowFunc) error {
+	const op = "ExportService.ExportReceptions"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	if filter.City != nil && !filter.City.IsValid() {
+		log.Warn("Invalid city for export", slog.String("city", string(*filter.City)))
+		return fmt.Errorf("%s: %w", op, domain.NewRuleError("city", "invalid", string(*filter.City)))
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && !filter.StartDate.Before(*filter.EndDate) {
+		log.Warn("Invalid export period", slog.Time("start_date", *filter.StartDate), slog.Time("end_date", *filter.EndDate))
+		return fmt.Errorf("%s: %w", op, domain.NewFieldError("startDate", "must be before endDate"))
+	}
+
+	var (
+		rows        int
+		callbackErr error
+	)
+	err := s.exportRepo.StreamReceptionRows(ctx, filter, func(row domain.ReceptionExportRow) error {
+		if err := fn(row); err != nil {
+			callbackErr = err
+			return err
+		}
+		rows++
+		return nil
+	})
+	if err != nil {
+		if callbackErr != nil && errors.Is(err, callbackErr) {
+			log.Warn("Reception export interrupted", slog.Int("rows", rows), slog.String("error", err.Error()))
+			return err
+		}
+		log.Error("Failed to export receptions", slog.Int("rows", rows), slog.String("error", err.Error()))
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("Receptions exported", slog.Int("rows", rows))
+	return nil
+}
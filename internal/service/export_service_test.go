@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/service"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestExportService_ExportReceptions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	startDate := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	moscow := domain.Moscow
+	unknownCity := domain.City("Новосибирск")
+	row := domain.ReceptionExportRow{
+		PVZID: uuid.New(), City: domain.Moscow, ReceptionID: uuid.New(),
+		ReceptionStatus: domain.StatusInProgress, OpenedAt: startDate,
+	}
+	errClientGone := errors.New("client disconnected")
+
+	testCases := []struct {
+		name          string
+		filter        domain.ReceptionExportFilter
+		callbackErr   error
+		setupMocks    func(repo *mocks.ExportRepository)
+		expectedRows  int
+		expectedError error
+	}{
+		{
+			name:   "Success",
+			filter: domain.ReceptionExportFilter{StartDate: &startDate, EndDate: &endDate, City: &moscow},
+			setupMocks: func(repo *mocks.ExportRepository) {
+				repo.On("StreamReceptionRows", mock.Anything, domain.ReceptionExportFilter{StartDate: &startDate, EndDate: &endDate, City: &moscow}, mock.Anything).
+					Return(func(_ context.Context, _ domain.ReceptionExportFilter, fn domain.ReceptionExportRowFunc) error {
+						for i := 0; i < 2; i++ {
+							if err := fn(row); err != nil {
+								return err
+							}
+						}
+						return nil
+					}).Once()
+			},
+			expectedRows: 2,
+		},
+		{
+			name:          "Fail_Invalid_City",
+			filter:        domain.ReceptionExportFilter{City: &unknownCity},
+			setupMocks:    func(repo *mocks.ExportRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Start_After_End",
+			filter:        domain.ReceptionExportFilter{StartDate: &endDate, EndDate: &startDate},
+			setupMocks:    func(repo *mocks.ExportRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:   "Fail_Repo_Error",
+			filter: domain.ReceptionExportFilter{},
+			setupMocks: func(repo *mocks.ExportRepository) {
+				repo.On("StreamReceptionRows", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("some db error")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+		{
+			name:        "Fail_Callback_Error_Passed_Through",
+			filter:      domain.ReceptionExportFilter{},
+			callbackErr: errClientGone,
+			setupMocks: func(repo *mocks.ExportRepository) {
+				repo.On("StreamReceptionRows", mock.Anything, mock.Anything, mock.Anything).
+					Return(func(_ context.Context, _ domain.ReceptionExportFilter, fn domain.ReceptionExportRowFunc) error {
+						return fn(row)
+					}).Once()
+			},
+			expectedError: errClientGone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockExportRepo := mocks.NewExportRepository(t)
+			tc.setupMocks(mockExportRepo)
+			exportService := service.NewExportService(logger, mockExportRepo)
+
+			received := 0
+			err := exportService.ExportReceptions(ctx, tc.filter, func(domain.ReceptionExportRow) error {
+				if tc.callbackErr != nil {
+					return tc.callbackErr
+				}
+				received++
+				return nil
+			})
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				if tc.callbackErr != nil {
+					assert.NotErrorIs(t, err, domain.ErrDatabaseError)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRows, received)
+		})
+	}
+}
@@ -11,12 +11,14 @@ import (
 
 	"pvz-service-avito-internship/internal/domain"
 	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
 )
 
 type ProductService struct {
 	log           *slog.Logger
 	receptionRepo domain.ReceptionRepository
 	productRepo   domain.ProductRepository
+	dispatchRepo  domain.DispatchRepository
 	metrics       domain.MetricsCollector
 }
 
@@ -24,24 +26,34 @@ func NewProductService(
 	log *slog.Logger,
 	receptionRepo domain.ReceptionRepository,
 	productRepo domain.ProductRepository,
+	dispatchRepo domain.DispatchRepository,
 	metrics domain.MetricsCollector,
 ) *ProductService {
 	return &ProductService{
 		log:           log,
 		receptionRepo: receptionRepo,
 		productRepo:   productRepo,
+		dispatchRepo:  dispatchRepo,
 		metrics:       metrics,
 	}
 }
 
-func (s *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, productType domain.ProductType) (*domain.Product, error) {
+func (s *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, productType domain.ProductType, itemID *string) (*domain.Product, error) {
 	const op = "ProductService.AddProduct"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()), slog.String("type", string(productType)))
 
 	if !productType.IsValid() {
 		log.Warn("Invalid product type provided")
-		return nil, fmt.Errorf("%s: %w: invalid product type '%s'", op, domain.ErrValidation, productType)
+		return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("type", "invalid", string(productType)))
+	}
+
+	itemID, err := normalizeItemID(itemID)
+	if err != nil {
+		log.Warn("Invalid item ID provided", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	reception, err := s.receptionRepo.FindOpenByPVZID(ctx, pvzID)
@@ -61,10 +73,20 @@ func (s *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, produc
 		DateTime:    time.Now().UTC(),
 		Type:        productType,
 		ReceptionID: reception.ID,
+		ItemID:      itemID,
+		Status:      domain.ProductReceived,
 	}
 
 	err = s.productRepo.Create(ctx, product)
 	if err != nil {
+		if itemID != nil && errors.Is(err, domain.ErrConflict) {
+			log.Warn("Item is already accepted in this reception", slog.String("item_id", *itemID))
+			return nil, fmt.Errorf("%s: %w: item '%s' is already accepted in this reception", op, domain.ErrConflict, *itemID)
+		}
+		if errors.Is(err, domain.ErrPVZFull) {
+			log.Warn("PVZ has no free capacity for product", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrPVZFull)
+		}
 		log.Error("Failed to create product in repository", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("%s: %w", op, domain.ErrDatabaseError)
 	}
@@ -78,6 +100,8 @@ func (s *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, produc
 // DeleteLastProduct удаляет последний добавленный товар из открытой приемки (LIFO).
 func (s *ProductService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
 	const op = "ProductService.DeleteLastProduct"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()))
 
@@ -112,3 +136,148 @@ func (s *ProductService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID)
 	log.Info("Last product deleted successfully")
 	return nil
 }
+
+// ListReceptionProducts возвращает страницу товаров приемки в порядке добавления и их общее количество.
+func (s *ProductService) ListReceptionProducts(ctx context.Context, receptionID uuid.UUID, limit, offset int) ([]domain.Product, int, error) {
+	const op = "ProductService.ListReceptionProducts"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("reception_id", receptionID.String()))
+
+	if limit <= 0 || offset < 0 {
+		log.Error("Invalid pagination parameters received")
+		return nil, 0, fmt.Errorf("%s: %w: invalid pagination parameters", op, domain.ErrInternalServer)
+	}
+
+	if _, err := s.receptionRepo.GetByID(ctx, receptionID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Attempt to list products of non-existent reception")
+			return nil, 0, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get reception by ID before listing products", slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	products, total, err := s.productRepo.ListByReceptionID(ctx, receptionID, limit, offset)
+	if err != nil {
+		log.Error("Failed to list products from repository", slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("Reception products listed successfully", slog.Int("returned_count", len(products)), slog.Int("total", total))
+	return products, total, nil
+}
+
+// IssueProduct отмечает выдачу товара клиенту.
+func (s *ProductService) IssueProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	return s.transition(ctx, "ProductService.IssueProduct", id, domain.ProductIssued)
+}
+
+// ReturnProduct возвращает товар на склад в составе открытой отправки ПВЗ.
+func (s *ProductService) ReturnProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	return s.transition(ctx, "ProductService.ReturnProduct", id, domain.ProductReturned)
+}
+
+// MarkProductLost списывает утерянный товар.
+func (s *ProductService) MarkProductLost(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	return s.transition(ctx, "ProductService.MarkProductLost", id, domain.ProductLost)
+}
+
+// transition переводит находящийся в ПВЗ товар в состояние to. Товар должен быть принят закрытой приемкой:
+// товары открытой приемки еще можно удалить по LIFO.
+func (s *ProductService) transition(ctx context.Context, op string, id uuid.UUID, to domain.ProductStatus) (*domain.Product, error) {
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("product_id", id.String()))
+
+	product, err := s.productRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Product not found")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get product by ID", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	if product.Status != domain.ProductReceived {
+		log.Warn("Product is not on hand", slog.String("status", string(product.Status)))
+		return nil, fmt.Errorf("%s: %w: product is %s", op, domain.ErrProductNotOnHand, product.Status)
+	}
+
+	reception, err := s.receptionRepo.GetByID(ctx, product.ReceptionID)
+	if err != nil {
+		log.Error("Failed to get product reception", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	if reception.Status != domain.StatusClosed {
+		log.Warn("Product reception is still in progress", slog.String("reception_id", reception.ID.String()))
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrProductReceptionOpen)
+	}
+
+	transition := domain.ProductTransition{
+		ProductID: id,
+		From:      domain.ProductReceived,
+		To:        to,
+		ChangedAt: time.Now().UTC(),
+	}
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		transition.ChangedBy = &userID
+	}
+	if to == domain.ProductReturned {
+		dispatch, err := s.openDispatch(ctx, reception.PVZID, transition.ChangedBy)
+		if err != nil {
+			log.Error("Failed to get open dispatch", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+		}
+		transition.DispatchID = &dispatch.ID
+		log = log.With(slog.String("dispatch_id", dispatch.ID.String()))
+	}
+
+	updated, err := s.productRepo.Transition(ctx, transition)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// Товар успели выдать или вернуть конкурентно, либо отправку успели отправить до включения товара.
+			log.Warn("Product or dispatch changed concurrently")
+			if to == domain.ProductReturned {
+				return nil, fmt.Errorf("%s: %w: product or dispatch changed concurrently", op, domain.ErrConflict)
+			}
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrProductNotOnHand)
+		}
+		log.Error("Failed to update product status", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	s.metrics.IncProductTransitions(to)
+
+	log.Info("Product status changed", slog.String("status", string(to)))
+	return updated, nil
+}
+
+// openDispatch возвращает открытую отправку ПВЗ, создавая ее при первом возврате.
+func (s *ProductService) openDispatch(ctx context.Context, pvzID uuid.UUID, createdBy *uuid.UUID) (*domain.Dispatch, error) {
+	dispatch, err := s.dispatchRepo.FindOpenByPVZID(ctx, pvzID)
+	if err == nil {
+		return dispatch, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	dispatch = &domain.Dispatch{
+		ID:        uuid.New(),
+		PVZID:     pvzID,
+		Status:    domain.DispatchOpen,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: createdBy,
+	}
+	if err := s.dispatchRepo.Create(ctx, dispatch); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			// Открытую отправку успел создать конкурентный возврат (уникальный индекс по pvz_id).
+			return s.dispatchRepo.FindOpenByPVZID(ctx, pvzID)
+		}
+		return nil, err
+	}
+	return dispatch, nil
+}
@@ -3,8 +3,10 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,7 +26,7 @@ func TestProductService_AddProduct(t *testing.T) {
 	mockProductRepo := mocks.NewProductRepository(t)
 	mockMetrics := mocks.NewMetricsCollector(t)
 
-	productService := service.NewProductService(logger, mockReceptionRepo, mockProductRepo, mockMetrics)
+	productService := service.NewProductService(logger, mockReceptionRepo, mockProductRepo, mocks.NewDispatchRepository(t), mockMetrics)
 
 	ctx := context.Background()
 	testPvzID := uuid.New()
@@ -38,6 +40,7 @@ func TestProductService_AddProduct(t *testing.T) {
 		name          string
 		pvzID         uuid.UUID
 		productType   domain.ProductType
+		itemID        *string
 		setupMocks    func()
 		expectedError error
 	}{
@@ -46,8 +49,8 @@ func TestProductService_AddProduct(t *testing.T) {
 			pvzID:       testPvzID,
 			productType: validProductType,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockProductRepo.On("Create", ctx, mock.MatchedBy(func(p *domain.Product) bool {
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Product) bool {
 					return p.ReceptionID == testReceptionID && p.Type == validProductType && p.ID != uuid.Nil
 				})).Return(nil).Once()
 				mockMetrics.On("IncProductsAdded").Return().Once()
@@ -66,7 +69,7 @@ func TestProductService_AddProduct(t *testing.T) {
 			pvzID:       testPvzID,
 			productType: validProductType,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
 			},
 			expectedError: domain.ErrNoOpenReception,
 		},
@@ -75,7 +78,7 @@ func TestProductService_AddProduct(t *testing.T) {
 			pvzID:       testPvzID,
 			productType: validProductType,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, someError).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
@@ -84,18 +87,62 @@ func TestProductService_AddProduct(t *testing.T) {
 			pvzID:       testPvzID,
 			productType: validProductType,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockProductRepo.On("Create", ctx, mock.AnythingOfType("*domain.Product")).Return(someError).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
+		{
+			name:        "Success_With_Item_ID",
+			pvzID:       testPvzID,
+			productType: validProductType,
+			itemID:      func() *string { s := "  SKU-1 "; return &s }(),
+			setupMocks: func() {
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Product) bool {
+					return p.ItemID != nil && *p.ItemID == "SKU-1"
+				})).Return(nil).Once()
+				mockMetrics.On("IncProductsAdded").Return().Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Fail_Item_ID_Too_Long",
+			pvzID:         testPvzID,
+			productType:   validProductType,
+			itemID:        func() *string { s := strings.Repeat("x", 101); return &s }(),
+			setupMocks:    func() {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:        "Fail_Item_Already_Accepted",
+			pvzID:       testPvzID,
+			productType: validProductType,
+			itemID:      func() *string { s := "SKU-1"; return &s }(),
+			setupMocks: func() {
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(domain.ErrConflict).Once()
+			},
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:        "Fail_PVZ_Full",
+			pvzID:       testPvzID,
+			productType: validProductType,
+			setupMocks: func() {
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Product")).
+					Return(fmt.Errorf("%w: pvz is full", domain.ErrPVZFull)).Once()
+			},
+			expectedError: domain.ErrPVZFull,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMocks()
 
-			prod, err := productService.AddProduct(ctx, tc.pvzID, tc.productType)
+			prod, err := productService.AddProduct(ctx, tc.pvzID, tc.productType, tc.itemID)
 
 			if tc.expectedError != nil {
 				require.Error(t, err)
@@ -121,7 +168,7 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 	mockProductRepo := mocks.NewProductRepository(t)
 	mockMetrics := mocks.NewMetricsCollector(t)
 
-	productService := service.NewProductService(logger, mockReceptionRepo, mockProductRepo, mockMetrics)
+	productService := service.NewProductService(logger, mockReceptionRepo, mockProductRepo, mocks.NewDispatchRepository(t), mockMetrics)
 
 	ctx := context.Background()
 	testPvzID := uuid.New()
@@ -141,9 +188,9 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			name:  "Success",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockProductRepo.On("FindLastByReceptionID", ctx, testReceptionID).Return(lastProduct, nil).Once()
-				mockProductRepo.On("DeleteByID", ctx, testProductID).Return(nil).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("FindLastByReceptionID", mock.Anything, testReceptionID).Return(lastProduct, nil).Once()
+				mockProductRepo.On("DeleteByID", mock.Anything, testProductID).Return(nil).Once()
 			},
 			expectedError: nil,
 		},
@@ -151,7 +198,7 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			name:  "Fail_No_Open_Reception",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
 			},
 			expectedError: domain.ErrNoOpenReception,
 		},
@@ -159,8 +206,8 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			name:  "Fail_No_Products_To_Delete",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockProductRepo.On("FindLastByReceptionID", ctx, testReceptionID).Return(nil, domain.ErrNoProductsToDelete).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("FindLastByReceptionID", mock.Anything, testReceptionID).Return(nil, domain.ErrNoProductsToDelete).Once()
 			},
 			expectedError: domain.ErrNoProductsToDelete,
 		},
@@ -168,7 +215,7 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			name:  "Fail_FindOpen_Repo_Error",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, someError).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
@@ -176,8 +223,8 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			name:  "Fail_FindLast_Repo_Error",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockProductRepo.On("FindLastByReceptionID", ctx, testReceptionID).Return(nil, someError).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("FindLastByReceptionID", mock.Anything, testReceptionID).Return(nil, someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
@@ -185,9 +232,9 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			name:  "Fail_DeleteByID_Repo_Error",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockProductRepo.On("FindLastByReceptionID", ctx, testReceptionID).Return(lastProduct, nil).Once()
-				mockProductRepo.On("DeleteByID", ctx, testProductID).Return(someError).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockProductRepo.On("FindLastByReceptionID", mock.Anything, testReceptionID).Return(lastProduct, nil).Once()
+				mockProductRepo.On("DeleteByID", mock.Anything, testProductID).Return(someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
@@ -210,3 +257,266 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestProductService_ListReceptionProducts(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockReceptionRepo := mocks.NewReceptionRepository(t)
+	mockProductRepo := mocks.NewProductRepository(t)
+	mockMetrics := mocks.NewMetricsCollector(t)
+
+	productService := service.NewProductService(logger, mockReceptionRepo, mockProductRepo, mocks.NewDispatchRepository(t), mockMetrics)
+
+	ctx := context.Background()
+	testReceptionID := uuid.New()
+	products := []domain.Product{
+		{ID: uuid.New(), ReceptionID: testReceptionID, Type: domain.TypeShoes},
+	}
+
+	testCases := []struct {
+		name          string
+		limit         int
+		offset        int
+		setupMocks    func()
+		expectedTotal int
+		expectedError error
+	}{
+		{
+			name:   "Success",
+			limit:  10,
+			offset: 10,
+			setupMocks: func() {
+				mockReceptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(&domain.Reception{ID: testReceptionID}, nil).Once()
+				mockProductRepo.On("ListByReceptionID", mock.Anything, testReceptionID, 10, 10).Return(products, 11, nil).Once()
+			},
+			expectedTotal: 11,
+		},
+		{
+			name:          "Fail_Invalid_Pagination",
+			limit:         10,
+			offset:        -1,
+			setupMocks:    func() {},
+			expectedError: domain.ErrInternalServer,
+		},
+		{
+			name:  "Fail_Reception_Not_Found",
+			limit: 10,
+			setupMocks: func() {
+				mockReceptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name:  "Fail_List_Repo_Error",
+			limit: 10,
+			setupMocks: func() {
+				mockReceptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(&domain.Reception{ID: testReceptionID}, nil).Once()
+				mockProductRepo.On("ListByReceptionID", mock.Anything, testReceptionID, 10, 0).Return(nil, 0, errors.New("db error")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks()
+
+			items, total, err := productService.ListReceptionProducts(ctx, testReceptionID, tc.limit, tc.offset)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, items)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, products, items)
+				assert.Equal(t, tc.expectedTotal, total)
+			}
+
+			mockReceptionRepo.AssertExpectations(t)
+			mockProductRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProductService_Transitions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	testPvzID := uuid.New()
+	testReceptionID := uuid.New()
+	testProductID := uuid.New()
+	onHand := &domain.Product{ID: testProductID, ReceptionID: testReceptionID, Type: domain.TypeShoes, Status: domain.ProductReceived}
+	closedReception := &domain.Reception{ID: testReceptionID, PVZID: testPvzID, Status: domain.StatusClosed}
+	openDispatch := &domain.Dispatch{ID: uuid.New(), PVZID: testPvzID, Status: domain.DispatchOpen}
+
+	type deps struct {
+		receptionRepo *mocks.ReceptionRepository
+		productRepo   *mocks.ProductRepository
+		dispatchRepo  *mocks.DispatchRepository
+		metrics       *mocks.MetricsCollector
+	}
+	transitionTo := func(to domain.ProductStatus, dispatchID *uuid.UUID) interface{} {
+		return mock.MatchedBy(func(tr domain.ProductTransition) bool {
+			if tr.ProductID != testProductID || tr.From != domain.ProductReceived || tr.To != to {
+				return false
+			}
+			if dispatchID == nil {
+				return tr.DispatchID == nil
+			}
+			return tr.DispatchID != nil && *tr.DispatchID == *dispatchID
+		})
+	}
+	updated := func(status domain.ProductStatus, dispatchID *uuid.UUID) *domain.Product {
+		p := *onHand
+		p.Status = status
+		p.DispatchID = dispatchID
+		return &p
+	}
+
+	testCases := []struct {
+		name          string
+		call          func(s *service.ProductService) (*domain.Product, error)
+		setupMocks    func(d deps)
+		expectedError error
+		expected      domain.ProductStatus
+	}{
+		{
+			name: "Issue_Success",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.IssueProduct(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(onHand, nil).Once()
+				d.receptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+				d.productRepo.On("Transition", mock.Anything, transitionTo(domain.ProductIssued, nil)).
+					Return(updated(domain.ProductIssued, nil), nil).Once()
+				d.metrics.On("IncProductTransitions", domain.ProductIssued).Return().Once()
+			},
+			expected: domain.ProductIssued,
+		},
+		{
+			name: "Return_Joins_Open_Dispatch",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.ReturnProduct(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(onHand, nil).Once()
+				d.receptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+				d.dispatchRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openDispatch, nil).Once()
+				d.productRepo.On("Transition", mock.Anything, transitionTo(domain.ProductReturned, &openDispatch.ID)).
+					Return(updated(domain.ProductReturned, &openDispatch.ID), nil).Once()
+				d.metrics.On("IncProductTransitions", domain.ProductReturned).Return().Once()
+			},
+			expected: domain.ProductReturned,
+		},
+		{
+			name: "Return_Creates_Dispatch",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.ReturnProduct(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(onHand, nil).Once()
+				d.receptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+				d.dispatchRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNotFound).Once()
+				d.dispatchRepo.On("Create", mock.Anything, mock.MatchedBy(func(dispatch *domain.Dispatch) bool {
+					return dispatch.PVZID == testPvzID && dispatch.Status == domain.DispatchOpen && dispatch.ID != uuid.Nil
+				})).Return(nil).Once()
+				d.productRepo.On("Transition", mock.Anything, mock.MatchedBy(func(tr domain.ProductTransition) bool {
+					return tr.To == domain.ProductReturned && tr.DispatchID != nil
+				})).Return(updated(domain.ProductReturned, &openDispatch.ID), nil).Once()
+				d.metrics.On("IncProductTransitions", domain.ProductReturned).Return().Once()
+			},
+			expected: domain.ProductReturned,
+		},
+		{
+			name: "Return_Dispatch_Created_Concurrently",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.ReturnProduct(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(onHand, nil).Once()
+				d.receptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+				d.dispatchRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNotFound).Once()
+				d.dispatchRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Dispatch")).Return(domain.ErrConflict).Once()
+				d.dispatchRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openDispatch, nil).Once()
+				d.productRepo.On("Transition", mock.Anything, transitionTo(domain.ProductReturned, &openDispatch.ID)).
+					Return(updated(domain.ProductReturned, &openDispatch.ID), nil).Once()
+				d.metrics.On("IncProductTransitions", domain.ProductReturned).Return().Once()
+			},
+			expected: domain.ProductReturned,
+		},
+		{
+			name: "Return_Dispatch_Sent_Concurrently",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.ReturnProduct(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(onHand, nil).Once()
+				d.receptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+				d.dispatchRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openDispatch, nil).Once()
+				d.productRepo.On("Transition", mock.Anything, mock.AnythingOfType("domain.ProductTransition")).
+					Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name: "Lost_Fail_Not_Found",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.MarkProductLost(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name: "Issue_Fail_Already_Issued",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.IssueProduct(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(updated(domain.ProductIssued, nil), nil).Once()
+			},
+			expectedError: domain.ErrProductNotOnHand,
+		},
+		{
+			name: "Lost_Fail_Reception_Open",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.MarkProductLost(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(onHand, nil).Once()
+				d.receptionRepo.On("GetByID", mock.Anything, testReceptionID).
+					Return(&domain.Reception{ID: testReceptionID, PVZID: testPvzID, Status: domain.StatusInProgress}, nil).Once()
+			},
+			expectedError: domain.ErrProductReceptionOpen,
+		},
+		{
+			name: "Lost_Fail_Changed_Concurrently",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.MarkProductLost(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(onHand, nil).Once()
+				d.receptionRepo.On("GetByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+				d.productRepo.On("Transition", mock.Anything, transitionTo(domain.ProductLost, nil)).Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrProductNotOnHand,
+		},
+		{
+			name: "Issue_Fail_Repo_Error",
+			call: func(s *service.ProductService) (*domain.Product, error) { return s.IssueProduct(ctx, testProductID) },
+			setupMocks: func(d deps) {
+				d.productRepo.On("GetByID", mock.Anything, testProductID).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := deps{
+				receptionRepo: mocks.NewReceptionRepository(t),
+				productRepo:   mocks.NewProductRepository(t),
+				dispatchRepo:  mocks.NewDispatchRepository(t),
+				metrics:       mocks.NewMetricsCollector(t),
+			}
+			tc.setupMocks(d)
+			productService := service.NewProductService(logger, d.receptionRepo, d.productRepo, d.dispatchRepo, d.metrics)
+
+			prod, err := tc.call(productService)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, prod)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, prod)
+				assert.Equal(t, tc.expected, prod.Status)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -10,6 +11,7 @@ import (
 
 	"pvz-service-avito-internship/internal/domain"
 	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
 )
 
 // PVZService реализует интерфейс domain.PVZService.
@@ -17,6 +19,7 @@ type PVZService struct {
 	log           *slog.Logger
 	pvzRepo       domain.PVZRepository
 	receptionRepo domain.ReceptionRepository
+	productRepo   domain.ProductRepository
 	metrics       domain.MetricsCollector
 }
 
@@ -25,12 +28,14 @@ func NewPVZService(
 	log *slog.Logger,
 	pvzRepo domain.PVZRepository,
 	receptionRepo domain.ReceptionRepository,
+	productRepo domain.ProductRepository,
 	metrics domain.MetricsCollector,
 ) *PVZService {
 	return &PVZService{
 		log:           log,
 		pvzRepo:       pvzRepo,
 		receptionRepo: receptionRepo,
+		productRepo:   productRepo,
 		metrics:       metrics,
 	}
 }
@@ -38,6 +43,8 @@ func NewPVZService(
 // CreatePVZ создает новый ПВЗ.
 func (s *PVZService) CreatePVZ(ctx context.Context, city domain.City) (*domain.PVZ, error) {
 	const op = "PVZService.CreatePVZ"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("city", string(city)))
 
@@ -50,6 +57,7 @@ func (s *PVZService) CreatePVZ(ctx context.Context, city domain.City) (*domain.P
 		ID:               uuid.New(),
 		RegistrationDate: time.Now().UTC(),
 		City:             city,
+		Status:           domain.PVZStatusActive,
 	}
 
 	err := s.pvzRepo.Create(ctx, pvz)
@@ -64,70 +72,207 @@ func (s *PVZService) CreatePVZ(ctx context.Context, city domain.City) (*domain.P
 	return pvz, nil
 }
 
-// ListPVZs возвращает список ПВЗ с деталями приемок и товаров, с пагинацией и фильтрацией.
-func (s *PVZService) ListPVZs(ctx context.Context, limit, page int, startDate, endDate *time.Time) ([]domain.PVZWithDetails, int, error) {
+// ListPVZs возвращает страницу ПВЗ со сводкой по приемкам за период и текущими остатками.
+// Сами приемки (и их товары) загружаются только по запросу, с ограничением их количества на ПВЗ.
+// Если задан курсор, страница начинается сразу после него (номер страницы игнорируется),
+// иначе используется постраничная пагинация через OFFSET. Курсор следующей страницы возвращается в обоих режимах.
+func (s *PVZService) ListPVZs(ctx context.Context, params domain.PVZListParams) (*domain.PVZListPage, error) {
 	const op = "PVZService.ListPVZs"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("request_id", reqID),
-		slog.Int("limit", limit),
-		slog.Int("page", page),
-		slog.Any("startDate", startDate),
-		slog.Any("endDate", endDate),
+		slog.Int("limit", params.Limit),
+		slog.Int("page", params.Page),
+		slog.Bool("cursor", params.Cursor != nil),
+		slog.Any("startDate", params.StartDate),
+		slog.Any("endDate", params.EndDate),
+		slog.String("sortBy", string(params.SortBy)),
+		slog.Bool("include_receptions", params.IncludeReceptions),
 	)
 
-	if limit <= 0 || page <= 0 {
-		log.Error("Invalid pagination parameters received", slog.Int("limit", limit), slog.Int("page", page))
-		return nil, 0, fmt.Errorf("%s: %w: invalid pagination parameters (limit/page must be positive)", op, domain.ErrInternalServer)
+	if params.Limit <= 0 || (params.Cursor == nil && params.Page <= 0) {
+		log.Error("Invalid pagination parameters received")
+		return nil, fmt.Errorf("%s: %w: invalid pagination parameters (limit/page must be positive)", op, domain.ErrInternalServer)
 	}
-	offset := (page - 1) * limit
 
-	pvzIDs, total, err := s.pvzRepo.ListIDsAndTotal(ctx, limit, offset, startDate, endDate)
+	filter := domain.PVZListFilter{
+		PVZQuery: params.PVZQuery,
+		After:    params.Cursor,
+		Limit:    params.Limit + 1, // Лишняя строка показывает, есть ли следующая страница
+	}
+	if params.Cursor == nil {
+		filter.Offset = (params.Page - 1) * params.Limit
+	}
+
+	pvzs, err := s.pvzRepo.List(ctx, filter)
 	if err != nil {
-		log.Error("Failed to list PVZ IDs from repository", slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+		log.Error("Failed to list PVZs from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	page := &domain.PVZListPage{Items: []domain.PVZWithDetails{}}
+	if len(pvzs) > params.Limit {
+		pvzs = pvzs[:params.Limit]
+		last := pvzs[len(pvzs)-1]
+		sortOrder := params.SortOrder
+		if sortOrder == "" {
+			sortOrder = domain.SortDesc
+		}
+		page.NextCursor = &domain.PVZListCursor{RegistrationDate: last.RegistrationDate, ID: last.ID, SortOrder: sortOrder}
 	}
 
-	if len(pvzIDs) == 0 {
+	if params.IncludeTotal {
+		total, err := s.pvzRepo.Count(ctx, filter)
+		if err != nil {
+			log.Error("Failed to count PVZs in repository", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+		}
+		page.Total = &total
+	}
+
+	if len(pvzs) == 0 {
 		log.Info("No PVZs found for the given criteria/page")
-		return []domain.PVZWithDetails{}, total, nil
+		return page, nil
 	}
-	log.Debug("Fetched PVZ IDs for page", slog.Any("pvz_ids", pvzIDs), slog.Int("total", total))
 
-	pvzs, err := s.pvzRepo.GetByIDs(ctx, pvzIDs)
+	pvzIDs := make([]uuid.UUID, 0, len(pvzs))
+	for _, p := range pvzs {
+		pvzIDs = append(pvzIDs, p.ID)
+	}
+	log.Debug("Fetched PVZs for page", slog.Any("pvz_ids", pvzIDs))
+
+	summaries, err := s.receptionRepo.SummaryByPVZIDs(ctx, pvzIDs, params.StartDate, params.EndDate)
 	if err != nil {
-		log.Error("Failed to get PVZ data by IDs from repository", slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+		log.Error("Failed to get reception summary from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
 	}
 
-	pvzMap := make(map[uuid.UUID]domain.PVZ, len(pvzs))
+	inventories, err := s.productRepo.InventoryByPVZIDs(ctx, pvzIDs)
+	if err != nil {
+		log.Error("Failed to get PVZ inventory from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	var receptionsMap map[uuid.UUID][]domain.ReceptionWithProducts
+	if params.IncludeReceptions {
+		receptionsMap, err = s.receptionRepo.ListByPVZIDs(ctx, pvzIDs, domain.ReceptionEmbedFilter{
+			StartDate:    params.StartDate,
+			EndDate:      params.EndDate,
+			PerPVZLimit:  params.ReceptionsPerPVZ,
+			WithProducts: params.IncludeProducts,
+		})
+		if err != nil {
+			log.Error("Failed to get receptions details from repository", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+		}
+		log.Debug("Fetched related receptions", slog.Int("pvz_with_receptions_count", len(receptionsMap)), slog.Bool("with_products", params.IncludeProducts))
+	}
+
+	page.Items = make([]domain.PVZWithDetails, 0, len(pvzs))
 	for _, p := range pvzs {
-		pvzMap[p.ID] = p
+		item := domain.PVZWithDetails{
+			PVZ:       p,
+			Summary:   summaries[p.ID],
+			Inventory: inventories[p.ID],
+		}
+		if item.Inventory.ByType == nil {
+			item.Inventory.ByType = map[domain.ProductType]int{}
+		}
+		if params.IncludeReceptions {
+			item.Receptions = receptionsMap[p.ID]
+			if item.Receptions == nil {
+				item.Receptions = []domain.ReceptionWithProducts{}
+			}
+		}
+		page.Items = append(page.Items, item)
 	}
-	log.Debug("Fetched PVZ data", slog.Int("count", len(pvzs)))
 
-	receptionsMap, err := s.receptionRepo.ListByPVZIDsAndDate(ctx, pvzIDs, startDate, endDate)
+	log.Info("PVZ list with details retrieved successfully", slog.Int("returned_count", len(page.Items)), slog.Bool("has_next", page.NextCursor != nil))
+	return page, nil
+}
+
+// GetPVZ возвращает ПВЗ по ID вместе с его версией.
+func (s *PVZService) GetPVZ(ctx context.Context, id uuid.UUID) (*domain.PVZ, error) {
+	const op = "PVZService.GetPVZ"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", id.String()))
+
+	pvz, err := s.pvzRepo.GetByID(ctx, id)
 	if err != nil {
-		log.Error("Failed to get receptions and products details from repository", slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("PVZ not found")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get PVZ from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
 	}
-	log.Debug("Fetched related receptions and products", slog.Int("pvz_with_receptions_count", len(receptionsMap)))
+	return pvz, nil
+}
+
+// SetCapacity проверяет и заменяет вместимость ПВЗ. Вместимость ниже текущей заполненности допускается:
+// уже принятые товары остаются, новые не принимаются, пока заполненность не опустится ниже ограничения.
+func (s *PVZService) SetCapacity(ctx context.Context, pvzID uuid.UUID, capacity domain.PVZCapacity, expectedVersion *int64) (*domain.PVZOccupancy, error) {
+	const op = "PVZService.SetCapacity"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()))
 
-	results := make([]domain.PVZWithDetails, 0, len(pvzIDs))
-	for _, id := range pvzIDs {
-		pvzData, ok := pvzMap[id]
-		if !ok {
-			log.Error("Consistency error: PVZ data not found for fetched ID", slog.String("pvz_id", id.String()))
-			continue
+	if capacity.Total != nil && *capacity.Total < 0 {
+		return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("total", "nonnegative"))
+	}
+	for productType, limit := range capacity.ByType {
+		if !productType.IsValid() {
+			return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("byType", "invalid", string(productType)))
+		}
+		if limit < 0 {
+			return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("byType."+string(productType), "nonnegative"))
 		}
-		pvzReceptions := receptionsMap[id]
-		results = append(results, domain.PVZWithDetails{
-			PVZ:        pvzData,
-			Receptions: pvzReceptions,
-		})
 	}
 
-	log.Info("PVZ list with details retrieved successfully", slog.Int("returned_count", len(results)), slog.Int("total_count", total))
-	return results, total, nil
+	if err := s.pvzRepo.SetCapacity(ctx, pvzID, capacity, expectedVersion); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Attempt to set capacity of non-existent PVZ")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		if errors.Is(err, domain.ErrPreconditionFailed) {
+			log.Warn("PVZ version mismatch", slog.Int64("expected_version", *expectedVersion))
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrPreconditionFailed)
+		}
+		log.Error("Failed to set PVZ capacity in repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	occupancy, err := s.GetOccupancy(ctx, pvzID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("PVZ capacity updated successfully", slog.Any("total", capacity.Total), slog.Int("limited_types", len(capacity.ByType)))
+	return occupancy, nil
+}
+
+// GetOccupancy возвращает текущую заполненность ПВЗ и ее ограничения.
+func (s *PVZService) GetOccupancy(ctx context.Context, pvzID uuid.UUID) (*domain.PVZOccupancy, error) {
+	const op = "PVZService.GetOccupancy"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()))
+
+	occupancy, err := s.pvzRepo.GetOccupancy(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("PVZ not found")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get PVZ occupancy from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	return occupancy, nil
 }
@@ -22,9 +22,10 @@ func TestPVZService_CreatePVZ(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	mockPVZRepo := mocks.NewPVZRepository(t)
 	mockReceptionRepo := mocks.NewReceptionRepository(t)
+	mockProductRepo := mocks.NewProductRepository(t)
 	mockMetrics := mocks.NewMetricsCollector(t)
 
-	pvzService := service.NewPVZService(logger, mockPVZRepo, mockReceptionRepo, mockMetrics)
+	pvzService := service.NewPVZService(logger, mockPVZRepo, mockReceptionRepo, mockProductRepo, mockMetrics)
 
 	ctx := context.Background()
 	testCityAllowed := domain.Moscow
@@ -41,7 +42,7 @@ func TestPVZService_CreatePVZ(t *testing.T) {
 			inputCity: testCityAllowed,
 			setupMocks: func() {
 				mockPVZRepo.On("Create", mock.Anything, mock.MatchedBy(func(pvz *domain.PVZ) bool {
-					return pvz.City == testCityAllowed && pvz.ID != uuid.Nil
+					return pvz.City == testCityAllowed && pvz.ID != uuid.Nil && pvz.Status == domain.PVZStatusActive
 				})).Return(nil).Once()
 				mockMetrics.On("IncPVZCreated").Return().Once()
 			},
@@ -96,23 +97,27 @@ func TestPVZService_ListPVZs(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	mockPVZRepo := mocks.NewPVZRepository(t)
 	mockReceptionRepo := mocks.NewReceptionRepository(t)
+	mockProductRepo := mocks.NewProductRepository(t)
 	mockMetrics := mocks.NewMetricsCollector(t)
 
-	pvzService := service.NewPVZService(logger, mockPVZRepo, mockReceptionRepo, mockMetrics)
+	pvzService := service.NewPVZService(logger, mockPVZRepo, mockReceptionRepo, mockProductRepo, mockMetrics)
 
 	ctx := context.Background()
-	testLimit := 10
-	testPage := 1
-	testOffset := 0
+	testLimit := 2
 	testTotal := 5
 
+	now := time.Now().UTC()
 	pvzID1 := uuid.New()
 	pvzID2 := uuid.New()
+	pvzID3 := uuid.New()
 	testIDs := []uuid.UUID{pvzID1, pvzID2}
 	testPVZs := []domain.PVZ{
-		{ID: pvzID1, City: domain.Moscow, RegistrationDate: time.Now().Add(-time.Hour)},
-		{ID: pvzID2, City: domain.Kazan, RegistrationDate: time.Now()},
+		{ID: pvzID1, City: domain.Moscow, RegistrationDate: now},
+		{ID: pvzID2, City: domain.Kazan, RegistrationDate: now.Add(-time.Hour)},
 	}
+	// Лишняя строка сверх limit означает, что есть следующая страница.
+	testPVZsWithExtra := append(append([]domain.PVZ{}, testPVZs...),
+		domain.PVZ{ID: pvzID3, City: domain.Kazan, RegistrationDate: now.Add(-2 * time.Hour)})
 	testReceptions := map[uuid.UUID][]domain.ReceptionWithProducts{
 		pvzID1: {
 			{
@@ -121,103 +126,139 @@ func TestPVZService_ListPVZs(t *testing.T) {
 			},
 		},
 	}
+	lastReceptionAt := now.Add(-time.Minute)
+	testSummaries := map[uuid.UUID]domain.PVZSummary{
+		pvzID1: {ReceptionsCount: 1, ProductsCount: 1, LastReceptionAt: &lastReceptionAt},
+	}
+	testInventories := map[uuid.UUID]domain.PVZInventory{
+		pvzID1: {OnHand: 1, ByType: map[domain.ProductType]int{domain.TypeElectronics: 1}},
+	}
+	embedFilter := domain.ReceptionEmbedFilter{PerPVZLimit: 5, WithProducts: true}
+	cursor := &domain.PVZListCursor{RegistrationDate: now.Add(time.Hour), ID: uuid.New(), SortOrder: domain.SortDesc}
+	pageFilter := func(offset int) domain.PVZListFilter {
+		return domain.PVZListFilter{Limit: testLimit + 1, Offset: offset}
+	}
 
 	testCases := []struct {
 		name                string
-		limit               int
-		page                int
-		startDate           *time.Time
-		endDate             *time.Time
+		params              domain.PVZListParams
 		setupMocks          func()
 		expectedResultCount int
-		expectedTotal       int
+		expectedTotal       *int
+		expectedNext        *domain.PVZListCursor
+		expectReceptions    bool
 		expectedError       error
 	}{
 		{
-			name:  "Success_No_Filters",
-			limit: testLimit,
-			page:  testPage,
+			name:   "Success_Page_With_Total_And_Next",
+			params: domain.PVZListParams{Limit: testLimit, Page: 1, IncludeTotal: true},
 			setupMocks: func() {
-				mockPVZRepo.On("ListIDsAndTotal", ctx, testLimit, testOffset, (*time.Time)(nil), (*time.Time)(nil)).
-					Return(testIDs, testTotal, nil).Once()
-				mockPVZRepo.On("GetByIDs", ctx, testIDs).Return(testPVZs, nil).Once()
-				mockReceptionRepo.On("ListByPVZIDsAndDate", ctx, testIDs, (*time.Time)(nil), (*time.Time)(nil)).
-					Return(testReceptions, nil).Once()
+				mockPVZRepo.On("List", mock.Anything, pageFilter(0)).Return(testPVZsWithExtra, nil).Once()
+				mockPVZRepo.On("Count", mock.Anything, pageFilter(0)).Return(testTotal, nil).Once()
+				mockReceptionRepo.On("SummaryByPVZIDs", mock.Anything, testIDs, (*time.Time)(nil), (*time.Time)(nil)).
+					Return(testSummaries, nil).Once()
+				mockProductRepo.On("InventoryByPVZIDs", mock.Anything, testIDs).Return(testInventories, nil).Once()
 			},
 			expectedResultCount: len(testIDs),
-			expectedTotal:       testTotal,
-			expectedError:       nil,
+			expectedTotal:       &testTotal,
+			expectedNext:        &domain.PVZListCursor{RegistrationDate: testPVZs[1].RegistrationDate, ID: pvzID2, SortOrder: domain.SortDesc},
 		},
 		{
-			name:  "Success_Empty_Page",
-			limit: testLimit,
-			page:  2,
+			name:   "Success_Cursor_Last_Page_Without_Total",
+			params: domain.PVZListParams{Limit: testLimit, Cursor: cursor},
 			setupMocks: func() {
-				offsetPage2 := (2 - 1) * testLimit
-				mockPVZRepo.On("ListIDsAndTotal", ctx, testLimit, offsetPage2, (*time.Time)(nil), (*time.Time)(nil)).
-					Return([]uuid.UUID{}, testTotal, nil).Once()
-
+				mockPVZRepo.On("List", mock.Anything, domain.PVZListFilter{Limit: testLimit + 1, After: cursor}).Return(testPVZs, nil).Once()
+				mockReceptionRepo.On("SummaryByPVZIDs", mock.Anything, testIDs, (*time.Time)(nil), (*time.Time)(nil)).
+					Return(testSummaries, nil).Once()
+				mockProductRepo.On("InventoryByPVZIDs", mock.Anything, testIDs).Return(testInventories, nil).Once()
 			},
-			expectedResultCount: 0,
-			expectedTotal:       testTotal,
-			expectedError:       nil,
+			expectedResultCount: len(testIDs),
 		},
 		{
-			name:  "Success_No_PVZs_Found",
-			limit: testLimit,
-			page:  testPage,
+			name: "Success_Include_Receptions_And_Products",
+			params: domain.PVZListParams{
+				Limit: testLimit, Page: 1,
+				IncludeReceptions: true, IncludeProducts: true, ReceptionsPerPVZ: 5,
+			},
 			setupMocks: func() {
-				mockPVZRepo.On("ListIDsAndTotal", ctx, testLimit, testOffset, (*time.Time)(nil), (*time.Time)(nil)).
-					Return([]uuid.UUID{}, 0, nil).Once()
-
+				mockPVZRepo.On("List", mock.Anything, pageFilter(0)).Return(testPVZs, nil).Once()
+				mockReceptionRepo.On("SummaryByPVZIDs", mock.Anything, testIDs, (*time.Time)(nil), (*time.Time)(nil)).
+					Return(testSummaries, nil).Once()
+				mockProductRepo.On("InventoryByPVZIDs", mock.Anything, testIDs).Return(testInventories, nil).Once()
+				mockReceptionRepo.On("ListByPVZIDs", mock.Anything, testIDs, embedFilter).Return(testReceptions, nil).Once()
 			},
-			expectedResultCount: 0,
-			expectedTotal:       0,
-			expectedError:       nil,
+			expectedResultCount: len(testIDs),
+			expectReceptions:    true,
 		},
 		{
-			name:  "Fail_ListIDsAndTotal_Error",
-			limit: testLimit,
-			page:  testPage,
+			name:   "Success_Empty_Page",
+			params: domain.PVZListParams{Limit: testLimit, Page: 4, IncludeTotal: true},
 			setupMocks: func() {
-				dbError := errors.New("db error list ids")
-				mockPVZRepo.On("ListIDsAndTotal", ctx, testLimit, testOffset, (*time.Time)(nil), (*time.Time)(nil)).
-					Return(nil, 0, dbError).Once()
+				mockPVZRepo.On("List", mock.Anything, pageFilter(6)).Return([]domain.PVZ{}, nil).Once()
+				mockPVZRepo.On("Count", mock.Anything, pageFilter(6)).Return(testTotal, nil).Once()
 			},
 			expectedResultCount: 0,
-			expectedTotal:       0,
-			expectedError:       domain.ErrDatabaseError,
+			expectedTotal:       &testTotal,
 		},
 		{
-			name:  "Fail_GetByIDs_Error",
-			limit: testLimit,
-			page:  testPage,
+			name:          "Fail_Invalid_Pagination",
+			params:        domain.PVZListParams{Limit: 0, Page: 1},
+			setupMocks:    func() {},
+			expectedError: domain.ErrInternalServer,
+		},
+		{
+			name:   "Fail_List_Error",
+			params: domain.PVZListParams{Limit: testLimit, Page: 1},
 			setupMocks: func() {
-				dbError := errors.New("db error get by ids")
-				mockPVZRepo.On("ListIDsAndTotal", ctx, testLimit, testOffset, (*time.Time)(nil), (*time.Time)(nil)).
-					Return(testIDs, testTotal, nil).Once()
-				mockPVZRepo.On("GetByIDs", ctx, testIDs).Return(nil, dbError).Once()
-
+				mockPVZRepo.On("List", mock.Anything, pageFilter(0)).Return(nil, errors.New("db error list")).Once()
 			},
-			expectedResultCount: 0,
-			expectedTotal:       0,
-			expectedError:       domain.ErrDatabaseError,
+			expectedError: domain.ErrDatabaseError,
 		},
 		{
-			name:  "Fail_ListReceptions_Error",
-			limit: testLimit,
-			page:  testPage,
+			name:   "Fail_Count_Error",
+			params: domain.PVZListParams{Limit: testLimit, Page: 1, IncludeTotal: true},
 			setupMocks: func() {
-				dbError := errors.New("db error list receptions")
-				mockPVZRepo.On("ListIDsAndTotal", ctx, testLimit, testOffset, (*time.Time)(nil), (*time.Time)(nil)).
-					Return(testIDs, testTotal, nil).Once()
-				mockPVZRepo.On("GetByIDs", ctx, testIDs).Return(testPVZs, nil).Once()
-				mockReceptionRepo.On("ListByPVZIDsAndDate", ctx, testIDs, (*time.Time)(nil), (*time.Time)(nil)).
-					Return(nil, dbError).Once()
+				mockPVZRepo.On("List", mock.Anything, pageFilter(0)).Return(testPVZs, nil).Once()
+				mockPVZRepo.On("Count", mock.Anything, pageFilter(0)).Return(0, errors.New("db error count")).Once()
 			},
-			expectedResultCount: 0,
-			expectedTotal:       0,
-			expectedError:       domain.ErrDatabaseError,
+			expectedError: domain.ErrDatabaseError,
+		},
+		{
+			name:   "Fail_Summary_Error",
+			params: domain.PVZListParams{Limit: testLimit, Page: 1},
+			setupMocks: func() {
+				mockPVZRepo.On("List", mock.Anything, pageFilter(0)).Return(testPVZs, nil).Once()
+				mockReceptionRepo.On("SummaryByPVZIDs", mock.Anything, testIDs, (*time.Time)(nil), (*time.Time)(nil)).
+					Return(nil, errors.New("db error summary")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+		{
+			name:   "Fail_Inventory_Error",
+			params: domain.PVZListParams{Limit: testLimit, Page: 1},
+			setupMocks: func() {
+				mockPVZRepo.On("List", mock.Anything, pageFilter(0)).Return(testPVZs, nil).Once()
+				mockReceptionRepo.On("SummaryByPVZIDs", mock.Anything, testIDs, (*time.Time)(nil), (*time.Time)(nil)).
+					Return(testSummaries, nil).Once()
+				mockProductRepo.On("InventoryByPVZIDs", mock.Anything, testIDs).Return(nil, errors.New("db error inventory")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+		{
+			name: "Fail_ListReceptions_Error",
+			params: domain.PVZListParams{
+				Limit: testLimit, Page: 1,
+				IncludeReceptions: true, IncludeProducts: true, ReceptionsPerPVZ: 5,
+			},
+			setupMocks: func() {
+				mockPVZRepo.On("List", mock.Anything, pageFilter(0)).Return(testPVZs, nil).Once()
+				mockReceptionRepo.On("SummaryByPVZIDs", mock.Anything, testIDs, (*time.Time)(nil), (*time.Time)(nil)).
+					Return(testSummaries, nil).Once()
+				mockProductRepo.On("InventoryByPVZIDs", mock.Anything, testIDs).Return(testInventories, nil).Once()
+				mockReceptionRepo.On("ListByPVZIDs", mock.Anything, testIDs, embedFilter).
+					Return(nil, errors.New("db error list receptions")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
 		},
 	}
 
@@ -225,27 +266,138 @@ func TestPVZService_ListPVZs(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMocks()
 
-			results, total, err := pvzService.ListPVZs(ctx, tc.limit, tc.page, tc.startDate, tc.endDate)
+			page, err := pvzService.ListPVZs(ctx, tc.params)
 
 			if tc.expectedError != nil {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, tc.expectedError)
-				assert.Nil(t, results)
+				assert.Nil(t, page)
 			} else {
 				require.NoError(t, err)
-				assert.Equal(t, tc.expectedTotal, total)
-				require.Len(t, results, tc.expectedResultCount)
-				if len(results) > 0 {
-					assert.Equal(t, testPVZs[0].ID, results[0].PVZ.ID)
-					if tc.name == "Success_No_Filters" {
-						assert.Len(t, results[0].Receptions, 1)
-						assert.Len(t, results[1].Receptions, 0)
+				require.NotNil(t, page)
+				assert.Equal(t, tc.expectedTotal, page.Total)
+				assert.Equal(t, tc.expectedNext, page.NextCursor)
+				require.Len(t, page.Items, tc.expectedResultCount)
+				if len(page.Items) > 0 {
+					assert.Equal(t, testPVZs[0].ID, page.Items[0].PVZ.ID)
+					assert.Equal(t, testSummaries[pvzID1], page.Items[0].Summary)
+					assert.Equal(t, domain.PVZSummary{}, page.Items[1].Summary)
+					assert.Equal(t, testInventories[pvzID1], page.Items[0].Inventory)
+					assert.Equal(t, 0, page.Items[1].Inventory.OnHand)
+					assert.NotNil(t, page.Items[1].Inventory.ByType)
+					if tc.expectReceptions {
+						assert.Len(t, page.Items[0].Receptions, 1)
+						assert.NotNil(t, page.Items[1].Receptions)
+						assert.Len(t, page.Items[1].Receptions, 0)
+					} else {
+						assert.Nil(t, page.Items[0].Receptions)
 					}
 				}
 			}
 
 			mockPVZRepo.AssertExpectations(t)
 			mockReceptionRepo.AssertExpectations(t)
+			mockProductRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPVZService_SetCapacity(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	pvzID := uuid.New()
+	total := 100
+	negative := -1
+	occupancy := &domain.PVZOccupancy{PVZID: pvzID, City: domain.Moscow, Occupied: 5, Capacity: &total}
+	version := int64(4)
+
+	testCases := []struct {
+		name          string
+		capacity      domain.PVZCapacity
+		version       *int64
+		setupMocks    func(pvzRepo *mocks.PVZRepository)
+		expectedError error
+	}{
+		{
+			name:     "Success",
+			capacity: domain.PVZCapacity{Total: &total, ByType: map[domain.ProductType]int{domain.TypeShoes: 10}},
+			setupMocks: func(pvzRepo *mocks.PVZRepository) {
+				pvzRepo.On("SetCapacity", mock.Anything, pvzID, domain.PVZCapacity{Total: &total, ByType: map[domain.ProductType]int{domain.TypeShoes: 10}}, (*int64)(nil)).
+					Return(nil).Once()
+				pvzRepo.On("GetOccupancy", mock.Anything, pvzID).Return(occupancy, nil).Once()
+			},
+		},
+		{
+			name:          "Fail_Negative_Total",
+			capacity:      domain.PVZCapacity{Total: &negative},
+			setupMocks:    func(pvzRepo *mocks.PVZRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Invalid_Type",
+			capacity:      domain.PVZCapacity{ByType: map[domain.ProductType]int{"мебель": 1}},
+			setupMocks:    func(pvzRepo *mocks.PVZRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:     "Fail_PVZ_Not_Found",
+			capacity: domain.PVZCapacity{},
+			setupMocks: func(pvzRepo *mocks.PVZRepository) {
+				pvzRepo.On("SetCapacity", mock.Anything, pvzID, domain.PVZCapacity{}, (*int64)(nil)).Return(domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name:     "Fail_Repository_Error",
+			capacity: domain.PVZCapacity{},
+			setupMocks: func(pvzRepo *mocks.PVZRepository) {
+				pvzRepo.On("SetCapacity", mock.Anything, pvzID, domain.PVZCapacity{}, (*int64)(nil)).Return(errors.New("db error")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+		{
+			name:     "Fail_Version_Mismatch",
+			capacity: domain.PVZCapacity{},
+			version:  &version,
+			setupMocks: func(pvzRepo *mocks.PVZRepository) {
+				pvzRepo.On("SetCapacity", mock.Anything, pvzID, domain.PVZCapacity{}, &version).Return(domain.ErrPreconditionFailed).Once()
+			},
+			expectedError: domain.ErrPreconditionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZRepository(t)
+			tc.setupMocks(pvzRepo)
+			pvzService := service.NewPVZService(logger, pvzRepo, mocks.NewReceptionRepository(t), mocks.NewProductRepository(t), mocks.NewMetricsCollector(t))
+
+			result, err := pvzService.SetCapacity(ctx, pvzID, tc.capacity, tc.version)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, occupancy, result)
+			}
 		})
 	}
 }
+
+func TestPVZListCursor_EncodeDecode(t *testing.T) {
+	cursor := domain.PVZListCursor{RegistrationDate: time.Date(2025, 4, 10, 9, 0, 0, 123456000, time.UTC), ID: uuid.New(), SortOrder: domain.SortAsc}
+
+	decoded, err := domain.DecodePVZListCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.True(t, cursor.RegistrationDate.Equal(decoded.RegistrationDate))
+	assert.Equal(t, domain.SortAsc, decoded.SortOrder)
+
+	withoutOrder := domain.PVZListCursor{RegistrationDate: cursor.RegistrationDate, ID: cursor.ID}.Encode()
+	for _, token := range []string{"", "%%%", "bm90LWpzb24", cursor.Encode()[:10], withoutOrder} {
+		_, err := domain.DecodePVZListCursor(token)
+		assert.ErrorIs(t, err, domain.ErrValidation, "token %q", token)
+	}
+}
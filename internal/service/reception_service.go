@@ -11,14 +11,16 @@ import (
 
 	"pvz-service-avito-internship/internal/domain"
 	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
 )
 
 // ReceptionService реализует интерфейс domain.ReceptionService.
 type ReceptionService struct {
 	log           *slog.Logger
-	pvzRepo       domain.PVZRepository       // Зависимость для проверки существования ПВЗ
-	receptionRepo domain.ReceptionRepository // Зависимость для работы с приемками
-	metrics       domain.MetricsCollector    // Зависимость для сбора метрик
+	pvzRepo       domain.PVZRepository              // Зависимость для проверки существования ПВЗ
+	receptionRepo domain.ReceptionRepository        // Зависимость для работы с приемками
+	shipmentRepo  domain.ExpectedShipmentRepository // Зависимость для привязки и сверки ожидаемых поставок
+	metrics       domain.MetricsCollector           // Зависимость для сбора метрик
 }
 
 // NewReceptionService создает новый экземпляр ReceptionService.
@@ -26,19 +28,23 @@ func NewReceptionService(
 	log *slog.Logger,
 	pvzRepo domain.PVZRepository,
 	receptionRepo domain.ReceptionRepository,
+	shipmentRepo domain.ExpectedShipmentRepository,
 	metrics domain.MetricsCollector,
 ) *ReceptionService {
 	return &ReceptionService{
 		log:           log,
 		pvzRepo:       pvzRepo,
 		receptionRepo: receptionRepo,
+		shipmentRepo:  shipmentRepo,
 		metrics:       metrics,
 	}
 }
 
-// CreateReception инициирует новую приемку для ПВЗ.
-func (s *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID) (*domain.Reception, error) {
+// CreateReception инициирует новую приемку для ПВЗ и, если передан shipmentID, привязывает к ней ожидаемую поставку.
+func (s *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID, shipmentID *uuid.UUID) (*domain.Reception, error) {
 	const op = "ReceptionService.CreateReception"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()))
 
@@ -63,15 +69,32 @@ func (s *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID)
 	}
 	log.Debug("No open reception found, proceeding to create a new one")
 
+	if shipmentID != nil {
+		log = log.With(slog.String("shipment_id", shipmentID.String()))
+		if err := s.checkShipmentAttachable(ctx, pvzID, *shipmentID); err != nil {
+			log.Warn("Expected shipment cannot be attached to reception", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	reception := &domain.Reception{
-		ID:       uuid.New(),
-		DateTime: time.Now().UTC(),
-		PVZID:    pvzID,
-		Status:   domain.StatusInProgress,
+		ID:                 uuid.New(),
+		DateTime:           time.Now().UTC(),
+		PVZID:              pvzID,
+		Status:             domain.StatusInProgress,
+		ExpectedShipmentID: shipmentID,
+	}
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		reception.OpenedBy = &userID
 	}
 
 	err = s.receptionRepo.Create(ctx, reception)
 	if err != nil {
+		if shipmentID != nil && errors.Is(err, domain.ErrConflict) {
+			// Поставку успела занять конкурентно созданная приемка (уникальный индекс по expected_shipment_id).
+			log.Warn("Expected shipment was attached concurrently")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrShipmentAlreadyAttached)
+		}
 		log.Error("Failed to create reception in repository", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
 	}
@@ -83,8 +106,10 @@ func (s *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID)
 }
 
 // CloseReception закрывает последнюю активную приемку для ПВЗ.
-func (s *ReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID) (*domain.Reception, error) {
+func (s *ReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID, expectedVersion *int64) (*domain.Reception, error) {
 	const op = "ReceptionService.CloseReception"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()))
 
@@ -99,16 +124,196 @@ func (s *ReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID)
 	}
 	log = log.With(slog.String("reception_id", reception.ID.String()))
 
-	err = s.receptionRepo.UpdateStatus(ctx, reception.ID, domain.StatusClosed)
+	var closedBy *uuid.UUID
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		closedBy = &userID
+	}
+
+	if expectedVersion != nil && *expectedVersion != reception.Version {
+		log.Warn("Reception version mismatch", slog.Int64("expected_version", *expectedVersion), slog.Int64("version", reception.Version))
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrPreconditionFailed)
+	}
+
+	closed, err := s.receptionRepo.Close(ctx, reception.ID, closedBy, expectedVersion)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			return nil, domain.ErrNotFound
+			// Приемку успел закрыть конкурентный запрос.
+			log.Warn("Reception was closed concurrently")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrReceptionClosed)
+		}
+		if errors.Is(err, domain.ErrPreconditionFailed) {
+			// Приемку изменил конкурентный запрос между чтением и закрытием.
+			log.Warn("Reception was modified concurrently")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrPreconditionFailed)
 		}
-		log.Error("Failed to update reception status to closed", slog.String("error", err.Error()))
+		log.Error("Failed to close reception", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
 	}
 
-	reception.Status = domain.StatusClosed
+	// Приемка уже закрыта, поэтому ошибка сверки не возвращается клиенту: итог будет
+	// пересчитан при запросе GET /receptions/{receptionId}/reconciliation.
+	if err := s.ReconcileReception(ctx, closed); err != nil {
+		log.Error("Failed to reconcile closed reception with expected shipment", slog.String("error", err.Error()))
+	}
+
 	log.Info("Reception closed successfully")
-	return reception, nil
+	return closed, nil
+}
+
+// checkShipmentAttachable проверяет, что поставка существует, ожидается в этом ПВЗ и еще не привязана к приемке.
+func (s *ReceptionService) checkShipmentAttachable(ctx context.Context, pvzID, shipmentID uuid.UUID) error {
+	shipment, err := s.shipmentRepo.GetByID(ctx, shipmentID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%w: expected shipment with id %s not found", domain.ErrValidation, shipmentID)
+		}
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	if shipment.PVZID != pvzID {
+		return fmt.Errorf("%w: expected shipment %s belongs to another pvz", domain.ErrValidation, shipmentID)
+	}
+	if shipment.ReceptionID != nil {
+		return domain.ErrShipmentAlreadyAttached
+	}
+	return nil
+}
+
+// ReconcileReception сверяет закрытую приемку с привязанной поставкой и сохраняет итог.
+// Приемка без поставки не сверяется.
+func (s *ReceptionService) ReconcileReception(ctx context.Context, reception *domain.Reception) error {
+	const op = "ReceptionService.ReconcileReception"
+	if reception.ExpectedShipmentID == nil {
+		return nil
+	}
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+
+	shipment, err := s.shipmentRepo.GetByID(ctx, *reception.ExpectedShipmentID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rwp, err := s.receptionRepo.GetWithProducts(ctx, reception.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	reconciliation, err := reconcileReception(ctx, s.shipmentRepo, shipment, rwp)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if reconciliation.Status == domain.ReconciliationDiscrepancy {
+		s.log.Warn("Reception does not match expected shipment",
+			slog.String("reception_id", reception.ID.String()),
+			slog.String("shipment_id", shipment.ID.String()),
+			slog.Int("missing_items", len(reconciliation.MissingItems)),
+			slog.Int("extra_items", len(reconciliation.ExtraItems)),
+			slog.Int("mismatched_items", len(reconciliation.MismatchedItems)),
+		)
+	}
+	return nil
+}
+
+// ListPVZReceptions возвращает страницу приемок ПВЗ от новых к старым и их общее количество.
+func (s *ReceptionService) ListPVZReceptions(ctx context.Context, pvzID uuid.UUID, filter domain.ReceptionListFilter) ([]domain.Reception, int, error) {
+	const op = "ReceptionService.ListPVZReceptions"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()))
+
+	if filter.Limit <= 0 || filter.Offset < 0 {
+		log.Error("Invalid pagination parameters received")
+		return nil, 0, fmt.Errorf("%s: %w: invalid pagination parameters", op, domain.ErrInternalServer)
+	}
+
+	if _, err := s.pvzRepo.GetByID(ctx, pvzID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Attempt to list receptions of non-existent PVZ")
+			return nil, 0, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get PVZ by ID before listing receptions", slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	receptions, total, err := s.receptionRepo.ListByPVZID(ctx, pvzID, filter)
+	if err != nil {
+		log.Error("Failed to list receptions from repository", slog.String("error", err.Error()))
+		return nil, 0, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("PVZ receptions listed successfully", slog.Int("returned_count", len(receptions)), slog.Int("total", total))
+	return receptions, total, nil
+}
+
+// GetReception возвращает приемку с товарами и количеством товаров по типам.
+func (s *ReceptionService) GetReception(ctx context.Context, id uuid.UUID) (*domain.ReceptionDetails, error) {
+	const op = "ReceptionService.GetReception"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("reception_id", id.String()))
+
+	details, err := s.getDetails(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Reception not found")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get reception with products", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("Reception retrieved successfully", slog.Int("products_count", len(details.Products)))
+	return details, nil
+}
+
+// GetCurrentReception возвращает незакрытую приемку ПВЗ с товарами.
+func (s *ReceptionService) GetCurrentReception(ctx context.Context, pvzID uuid.UUID) (*domain.ReceptionDetails, error) {
+	const op = "ReceptionService.GetCurrentReception"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()))
+
+	reception, err := s.receptionRepo.FindOpenByPVZID(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoOpenReception) {
+			log.Info("No open reception for PVZ")
+			return nil, fmt.Errorf("%s: %w: no open reception for pvz %s", op, domain.ErrNotFound, pvzID)
+		}
+		log.Error("Failed to find open reception", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	log = log.With(slog.String("reception_id", reception.ID.String()))
+
+	details, err := s.getDetails(ctx, reception.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// Приемка удалена между запросами - для клиента это то же, что отсутствие открытой приемки.
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get reception with products", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("Current reception retrieved successfully", slog.Int("products_count", len(details.Products)))
+	return details, nil
+}
+
+// getDetails загружает приемку с товарами и считает товары по типам.
+func (s *ReceptionService) getDetails(ctx context.Context, id uuid.UUID) (*domain.ReceptionDetails, error) {
+	rwp, err := s.receptionRepo.GetWithProducts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.ProductType]int)
+	for _, p := range rwp.Products {
+		counts[p.Type]++
+	}
+	return &domain.ReceptionDetails{
+		Reception:     rwp.Reception,
+		Products:      rwp.Products,
+		ProductCounts: counts,
+	}, nil
 }
@@ -10,8 +10,10 @@ import (
 	"io"
 	"log/slog"
 	"testing"
+	"time"
 
 	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
 	"pvz-service-avito-internship/internal/service"
 	"pvz-service-avito-internship/mocks"
 )
@@ -20,11 +22,13 @@ func TestReceptionService_CreateReception(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	mockPVZRepo := mocks.NewPVZRepository(t)
 	mockReceptionRepo := mocks.NewReceptionRepository(t)
+	mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
 	mockMetrics := mocks.NewMetricsCollector(t)
 
-	receptionService := service.NewReceptionService(logger, mockPVZRepo, mockReceptionRepo, mockMetrics)
+	receptionService := service.NewReceptionService(logger, mockPVZRepo, mockReceptionRepo, mockShipmentRepo, mockMetrics)
 
-	ctx := context.Background()
+	employeeID := uuid.New()
+	ctx := middleware.ContextWithUser(context.Background(), employeeID, domain.RoleEmployee)
 	testPvzID := uuid.New()
 	existingPvz := &domain.PVZ{ID: testPvzID, City: domain.Moscow}
 	someError := errors.New("some db error")
@@ -39,10 +43,11 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name:  "Success",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockPVZRepo.On("GetByID", ctx, testPvzID).Return(existingPvz, nil).Once()
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
-				mockReceptionRepo.On("Create", ctx, mock.MatchedBy(func(rec *domain.Reception) bool {
-					return rec.PVZID == testPvzID && rec.Status == domain.StatusInProgress && rec.ID != uuid.Nil
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(existingPvz, nil).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
+				mockReceptionRepo.On("Create", mock.Anything, mock.MatchedBy(func(rec *domain.Reception) bool {
+					return rec.PVZID == testPvzID && rec.Status == domain.StatusInProgress && rec.ID != uuid.Nil &&
+						rec.OpenedBy != nil && *rec.OpenedBy == employeeID
 				})).Return(nil).Once()
 				mockMetrics.On("IncReceptionsCreated").Return().Once()
 			},
@@ -52,7 +57,7 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name:  "Fail_PVZ_NotFound",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockPVZRepo.On("GetByID", ctx, testPvzID).Return(nil, domain.ErrNotFound).Once()
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(nil, domain.ErrNotFound).Once()
 			},
 			expectedError: domain.ErrValidation,
 		},
@@ -60,7 +65,7 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name:  "Fail_PVZ_Repo_Error",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockPVZRepo.On("GetByID", ctx, testPvzID).Return(nil, someError).Once()
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(nil, someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
@@ -68,8 +73,8 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name:  "Fail_Reception_In_Progress",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockPVZRepo.On("GetByID", ctx, testPvzID).Return(existingPvz, nil).Once()
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(&domain.Reception{}, nil).Once()
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(existingPvz, nil).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(&domain.Reception{}, nil).Once()
 			},
 			expectedError: domain.ErrReceptionInProgress,
 		},
@@ -77,8 +82,8 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name:  "Fail_FindOpen_Repo_Error",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockPVZRepo.On("GetByID", ctx, testPvzID).Return(existingPvz, nil).Once()
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, someError).Once()
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(existingPvz, nil).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
@@ -86,9 +91,9 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name:  "Fail_Create_Repo_Error",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockPVZRepo.On("GetByID", ctx, testPvzID).Return(existingPvz, nil).Once()
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
-				mockReceptionRepo.On("Create", ctx, mock.AnythingOfType("*domain.Reception")).Return(someError).Once()
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(existingPvz, nil).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
+				mockReceptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Reception")).Return(someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
@@ -98,7 +103,7 @@ func TestReceptionService_CreateReception(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMocks()
 
-			rec, err := receptionService.CreateReception(ctx, tc.pvzID)
+			rec, err := receptionService.CreateReception(ctx, tc.pvzID, nil)
 
 			if tc.expectedError != nil {
 				require.Error(t, err)
@@ -110,6 +115,8 @@ func TestReceptionService_CreateReception(t *testing.T) {
 				assert.Equal(t, tc.pvzID, rec.PVZID)
 				assert.Equal(t, domain.StatusInProgress, rec.Status)
 				assert.NotEqual(t, uuid.Nil, rec.ID)
+				require.NotNil(t, rec.OpenedBy)
+				assert.Equal(t, employeeID, *rec.OpenedBy)
 			}
 			mockPVZRepo.AssertExpectations(t)
 			mockReceptionRepo.AssertExpectations(t)
@@ -122,19 +129,27 @@ func TestReceptionService_CloseReception(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	mockPVZRepo := mocks.NewPVZRepository(t)
 	mockReceptionRepo := mocks.NewReceptionRepository(t)
+	mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
 	mockMetrics := mocks.NewMetricsCollector(t)
 
-	receptionService := service.NewReceptionService(logger, mockPVZRepo, mockReceptionRepo, mockMetrics)
+	receptionService := service.NewReceptionService(logger, mockPVZRepo, mockReceptionRepo, mockShipmentRepo, mockMetrics)
 
-	ctx := context.Background()
+	employeeID := uuid.New()
+	ctx := middleware.ContextWithUser(context.Background(), employeeID, domain.RoleEmployee)
 	testPvzID := uuid.New()
 	testReceptionID := uuid.New()
-	openReception := &domain.Reception{ID: testReceptionID, PVZID: testPvzID, Status: domain.StatusInProgress}
+	openReception := &domain.Reception{ID: testReceptionID, PVZID: testPvzID, Status: domain.StatusInProgress, Version: 3}
+	currentVersion, staleVersion := int64(3), int64(2)
+	closedAt := time.Now().UTC()
+	closedReception := &domain.Reception{
+		ID: testReceptionID, PVZID: testPvzID, Status: domain.StatusClosed, ClosedAt: &closedAt, ClosedBy: &employeeID,
+	}
 	someError := errors.New("db error")
 
 	testCases := []struct {
 		name          string
 		pvzID         uuid.UUID
+		version       *int64
 		setupMocks    func()
 		expectedError error
 	}{
@@ -142,16 +157,45 @@ func TestReceptionService_CloseReception(t *testing.T) {
 			name:  "Success",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockReceptionRepo.On("UpdateStatus", ctx, testReceptionID, domain.StatusClosed).Return(nil).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockReceptionRepo.On("Close", mock.Anything, testReceptionID, &employeeID, (*int64)(nil)).Return(closedReception, nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Success_Version_Matches",
+			pvzID:   testPvzID,
+			version: &currentVersion,
+			setupMocks: func() {
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockReceptionRepo.On("Close", mock.Anything, testReceptionID, &employeeID, &currentVersion).Return(closedReception, nil).Once()
 			},
 			expectedError: nil,
 		},
+		{
+			name:    "Fail_Version_Mismatch",
+			pvzID:   testPvzID,
+			version: &staleVersion,
+			setupMocks: func() {
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+			},
+			expectedError: domain.ErrPreconditionFailed,
+		},
+		{
+			name:    "Fail_Modified_Concurrently",
+			pvzID:   testPvzID,
+			version: &currentVersion,
+			setupMocks: func() {
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockReceptionRepo.On("Close", mock.Anything, testReceptionID, &employeeID, &currentVersion).Return(nil, domain.ErrPreconditionFailed).Once()
+			},
+			expectedError: domain.ErrPreconditionFailed,
+		},
 		{
 			name:  "Fail_No_Open_Reception",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
 			},
 			expectedError: domain.ErrReceptionClosed,
 		},
@@ -159,27 +203,27 @@ func TestReceptionService_CloseReception(t *testing.T) {
 			name:  "Fail_FindOpen_Repo_Error",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(nil, someError).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
 		{
-			name:  "Fail_UpdateStatus_Repo_Error",
+			name:  "Fail_Close_Repo_Error",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockReceptionRepo.On("UpdateStatus", ctx, testReceptionID, domain.StatusClosed).Return(someError).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockReceptionRepo.On("Close", mock.Anything, testReceptionID, &employeeID, (*int64)(nil)).Return(nil, someError).Once()
 			},
 			expectedError: domain.ErrDatabaseError,
 		},
 		{
-			name:  "Fail_UpdateStatus_NotFound",
+			name:  "Fail_Closed_Concurrently",
 			pvzID: testPvzID,
 			setupMocks: func() {
-				mockReceptionRepo.On("FindOpenByPVZID", ctx, testPvzID).Return(openReception, nil).Once()
-				mockReceptionRepo.On("UpdateStatus", ctx, testReceptionID, domain.StatusClosed).Return(domain.ErrNotFound).Once()
+				mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+				mockReceptionRepo.On("Close", mock.Anything, testReceptionID, &employeeID, (*int64)(nil)).Return(nil, domain.ErrNotFound).Once()
 			},
-			expectedError: domain.ErrNotFound,
+			expectedError: domain.ErrReceptionClosed,
 		},
 	}
 
@@ -187,7 +231,7 @@ func TestReceptionService_CloseReception(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMocks()
 
-			rec, err := receptionService.CloseReception(ctx, tc.pvzID)
+			rec, err := receptionService.CloseReception(ctx, tc.pvzID, tc.version)
 
 			if tc.expectedError != nil {
 				require.Error(t, err)
@@ -198,8 +242,294 @@ func TestReceptionService_CloseReception(t *testing.T) {
 				require.NotNil(t, rec)
 				assert.Equal(t, testReceptionID, rec.ID)
 				assert.Equal(t, domain.StatusClosed, rec.Status)
+				assert.Equal(t, &closedAt, rec.ClosedAt)
+				assert.Equal(t, &employeeID, rec.ClosedBy)
+			}
+			mockReceptionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReceptionService_ListPVZReceptions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockPVZRepo := mocks.NewPVZRepository(t)
+	mockReceptionRepo := mocks.NewReceptionRepository(t)
+	mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+	mockMetrics := mocks.NewMetricsCollector(t)
+
+	receptionService := service.NewReceptionService(logger, mockPVZRepo, mockReceptionRepo, mockShipmentRepo, mockMetrics)
+
+	ctx := context.Background()
+	testPvzID := uuid.New()
+	status := domain.StatusClosed
+	filter := domain.ReceptionListFilter{Status: &status, Limit: 2, Offset: 2}
+	receptions := []domain.Reception{
+		{ID: uuid.New(), PVZID: testPvzID, Status: domain.StatusClosed},
+		{ID: uuid.New(), PVZID: testPvzID, Status: domain.StatusClosed},
+	}
+
+	testCases := []struct {
+		name          string
+		filter        domain.ReceptionListFilter
+		setupMocks    func()
+		expectedTotal int
+		expectedError error
+	}{
+		{
+			name:   "Success",
+			filter: filter,
+			setupMocks: func() {
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(&domain.PVZ{ID: testPvzID}, nil).Once()
+				mockReceptionRepo.On("ListByPVZID", mock.Anything, testPvzID, filter).Return(receptions, 5, nil).Once()
+			},
+			expectedTotal: 5,
+		},
+		{
+			name:          "Fail_Invalid_Pagination",
+			filter:        domain.ReceptionListFilter{Limit: 0},
+			setupMocks:    func() {},
+			expectedError: domain.ErrInternalServer,
+		},
+		{
+			name:   "Fail_PVZ_Not_Found",
+			filter: filter,
+			setupMocks: func() {
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name:   "Fail_List_Repo_Error",
+			filter: filter,
+			setupMocks: func() {
+				mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(&domain.PVZ{ID: testPvzID}, nil).Once()
+				mockReceptionRepo.On("ListByPVZID", mock.Anything, testPvzID, filter).Return(nil, 0, errors.New("db error")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks()
+
+			items, total, err := receptionService.ListPVZReceptions(ctx, testPvzID, tc.filter)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, items)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, receptions, items)
+				assert.Equal(t, tc.expectedTotal, total)
 			}
+
+			mockPVZRepo.AssertExpectations(t)
 			mockReceptionRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestReceptionService_GetReception(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockPVZRepo := mocks.NewPVZRepository(t)
+	mockReceptionRepo := mocks.NewReceptionRepository(t)
+	mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+	mockMetrics := mocks.NewMetricsCollector(t)
+
+	receptionService := service.NewReceptionService(logger, mockPVZRepo, mockReceptionRepo, mockShipmentRepo, mockMetrics)
+
+	ctx := context.Background()
+	testPvzID := uuid.New()
+	reception := domain.Reception{ID: uuid.New(), PVZID: testPvzID, Status: domain.StatusInProgress}
+	withProducts := &domain.ReceptionWithProducts{
+		Reception: reception,
+		Products: []domain.Product{
+			{ID: uuid.New(), Type: domain.TypeShoes},
+			{ID: uuid.New(), Type: domain.TypeElectronics},
+			{ID: uuid.New(), Type: domain.TypeShoes},
+		},
+	}
+	expectedCounts := map[domain.ProductType]int{domain.TypeShoes: 2, domain.TypeElectronics: 1}
+
+	t.Run("Success", func(t *testing.T) {
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, reception.ID).Return(withProducts, nil).Once()
+
+		details, err := receptionService.GetReception(ctx, reception.ID)
+		require.NoError(t, err)
+		assert.Equal(t, reception, details.Reception)
+		assert.Len(t, details.Products, 3)
+		assert.Equal(t, expectedCounts, details.ProductCounts)
+	})
+
+	t.Run("Fail_Not_Found", func(t *testing.T) {
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, reception.ID).Return(nil, domain.ErrNotFound).Once()
+
+		details, err := receptionService.GetReception(ctx, reception.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, details)
+	})
+
+	t.Run("Fail_Repo_Error", func(t *testing.T) {
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, reception.ID).Return(nil, errors.New("db error")).Once()
+
+		_, err := receptionService.GetReception(ctx, reception.ID)
+		assert.ErrorIs(t, err, domain.ErrDatabaseError)
+	})
+
+	t.Run("Current_Success", func(t *testing.T) {
+		mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(&reception, nil).Once()
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, reception.ID).Return(withProducts, nil).Once()
+
+		details, err := receptionService.GetCurrentReception(ctx, testPvzID)
+		require.NoError(t, err)
+		assert.Equal(t, reception.ID, details.Reception.ID)
+		assert.Equal(t, expectedCounts, details.ProductCounts)
+	})
+
+	t.Run("Current_No_Open_Reception", func(t *testing.T) {
+		mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
+
+		details, err := receptionService.GetCurrentReception(ctx, testPvzID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, details)
+	})
+
+	mockReceptionRepo.AssertExpectations(t)
+}
+
+func TestReceptionService_CreateReception_WithShipment(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	testPvzID := uuid.New()
+	shipmentID := uuid.New()
+	existingPvz := &domain.PVZ{ID: testPvzID, City: domain.Moscow}
+
+	testCases := []struct {
+		name          string
+		shipment      *domain.ExpectedShipment
+		shipmentErr   error
+		createErr     error
+		expectedError error
+	}{
+		{
+			name:     "Success",
+			shipment: &domain.ExpectedShipment{ID: shipmentID, PVZID: testPvzID},
+		},
+		{
+			name:          "Fail_Shipment_NotFound",
+			shipmentErr:   domain.ErrNotFound,
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Shipment_Of_Another_PVZ",
+			shipment:      &domain.ExpectedShipment{ID: shipmentID, PVZID: uuid.New()},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Shipment_Already_Attached",
+			shipment:      &domain.ExpectedShipment{ID: shipmentID, PVZID: testPvzID, ReceptionID: func() *uuid.UUID { id := uuid.New(); return &id }()},
+			expectedError: domain.ErrShipmentAlreadyAttached,
+		},
+		{
+			name:          "Fail_Shipment_Attached_Concurrently",
+			shipment:      &domain.ExpectedShipment{ID: shipmentID, PVZID: testPvzID},
+			createErr:     domain.ErrConflict,
+			expectedError: domain.ErrShipmentAlreadyAttached,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPVZRepo := mocks.NewPVZRepository(t)
+			mockReceptionRepo := mocks.NewReceptionRepository(t)
+			mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+			mockMetrics := mocks.NewMetricsCollector(t)
+			receptionService := service.NewReceptionService(logger, mockPVZRepo, mockReceptionRepo, mockShipmentRepo, mockMetrics)
+
+			mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(existingPvz, nil).Once()
+			mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(nil, domain.ErrNoOpenReception).Once()
+			mockShipmentRepo.On("GetByID", mock.Anything, shipmentID).Return(tc.shipment, tc.shipmentErr).Once()
+			if tc.shipment != nil && tc.shipment.PVZID == testPvzID && tc.shipment.ReceptionID == nil {
+				mockReceptionRepo.On("Create", mock.Anything, mock.MatchedBy(func(rec *domain.Reception) bool {
+					return rec.ExpectedShipmentID != nil && *rec.ExpectedShipmentID == shipmentID
+				})).Return(tc.createErr).Once()
+			}
+			if tc.expectedError == nil {
+				mockMetrics.On("IncReceptionsCreated").Return().Once()
+			}
+
+			rec, err := receptionService.CreateReception(ctx, testPvzID, &shipmentID)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, rec)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, rec.ExpectedShipmentID)
+			assert.Equal(t, shipmentID, *rec.ExpectedShipmentID)
+		})
+	}
+}
+
+func TestReceptionService_CloseReception_Reconciles(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	testPvzID := uuid.New()
+	testReceptionID := uuid.New()
+	shipmentID := uuid.New()
+	openReception := &domain.Reception{ID: testReceptionID, PVZID: testPvzID, Status: domain.StatusInProgress, ExpectedShipmentID: &shipmentID}
+	closedReception := &domain.Reception{ID: testReceptionID, PVZID: testPvzID, Status: domain.StatusClosed, ExpectedShipmentID: &shipmentID}
+	shipment := &domain.ExpectedShipment{
+		ID:    shipmentID,
+		PVZID: testPvzID,
+		Lines: []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 2}},
+	}
+	rwp := &domain.ReceptionWithProducts{
+		Reception: *closedReception,
+		Products:  []domain.Product{{ID: uuid.New(), Type: domain.TypeShoes, ReceptionID: testReceptionID}},
+	}
+
+	t.Run("Saves_Discrepancy", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+		receptionService := service.NewReceptionService(logger, mocks.NewPVZRepository(t), mockReceptionRepo, mockShipmentRepo, mocks.NewMetricsCollector(t))
+
+		mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("Close", mock.Anything, testReceptionID, (*uuid.UUID)(nil), (*int64)(nil)).Return(closedReception, nil).Once()
+		mockShipmentRepo.On("GetByID", mock.Anything, shipmentID).Return(shipment, nil).Once()
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, testReceptionID).Return(rwp, nil).Once()
+		mockShipmentRepo.On("SaveReconciliation", mock.Anything, shipmentID, mock.MatchedBy(func(r domain.Reconciliation) bool {
+			return r.Final && r.Status == domain.ReconciliationDiscrepancy && len(r.Lines) == 1 && r.Lines[0].Missing == 1
+		})).Return(nil).Once()
+
+		rec, err := receptionService.CloseReception(ctx, testPvzID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusClosed, rec.Status)
+	})
+
+	t.Run("Reconciliation_Error_Does_Not_Fail_Close", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+		receptionService := service.NewReceptionService(logger, mocks.NewPVZRepository(t), mockReceptionRepo, mockShipmentRepo, mocks.NewMetricsCollector(t))
+
+		mockReceptionRepo.On("FindOpenByPVZID", mock.Anything, testPvzID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("Close", mock.Anything, testReceptionID, (*uuid.UUID)(nil), (*int64)(nil)).Return(closedReception, nil).Once()
+		mockShipmentRepo.On("GetByID", mock.Anything, shipmentID).Return(nil, errors.New("connection refused")).Once()
+
+		rec, err := receptionService.CloseReception(ctx, testPvzID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusClosed, rec.Status)
+	})
+
+	t.Run("Reception_Without_Shipment_Is_Skipped", func(t *testing.T) {
+		receptionService := service.NewReceptionService(logger, mocks.NewPVZRepository(t), mocks.NewReceptionRepository(t),
+			mocks.NewExpectedShipmentRepository(t), mocks.NewMetricsCollector(t))
+
+		err := receptionService.ReconcileReception(ctx, &domain.Reception{ID: testReceptionID, PVZID: testPvzID, Status: domain.StatusClosed})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"pvz-service-avito-internship/internal/domain"
+)
+
+// buildReconciliation сравнивает товары приемки с ожидаемой поставкой.
+// Количество сверяется по типам. Единицы с идентификаторами сверяются поштучно: ожидаемая, но не принятая
+// единица попадает в MissingItems, принятая без ожидания - в ExtraItems, принятая с другим типом - в MismatchedItems.
+func buildReconciliation(shipment *domain.ExpectedShipment, rwp *domain.ReceptionWithProducts, now time.Time) domain.Reconciliation {
+	result := domain.Reconciliation{
+		ShipmentID:      shipment.ID,
+		ReceptionID:     rwp.Reception.ID,
+		Status:          domain.ReconciliationMatched,
+		Final:           rwp.Reception.Status == domain.StatusClosed,
+		ReconciledAt:    now,
+		Lines:           make([]domain.ReconciliationLine, 0),
+		MissingItems:    make([]domain.ReconciliationItem, 0),
+		ExtraItems:      make([]domain.ReconciliationItem, 0),
+		MismatchedItems: make([]domain.MismatchedItem, 0),
+	}
+
+	expected := make(map[domain.ProductType]int)
+	expectedItems := make(map[string]domain.ProductType)
+	for _, line := range shipment.Lines {
+		expected[line.Type] += line.Quantity
+		for _, itemID := range line.ItemIDs {
+			expectedItems[itemID] = line.Type
+		}
+	}
+
+	received := make(map[domain.ProductType]int)
+	for _, p := range rwp.Products {
+		received[p.Type]++
+		if p.ItemID == nil {
+			continue
+		}
+
+		productID := p.ID
+		expectedType, ok := expectedItems[*p.ItemID]
+		switch {
+		case !ok:
+			result.ExtraItems = append(result.ExtraItems, domain.ReconciliationItem{ItemID: *p.ItemID, Type: p.Type, ProductID: &productID})
+		case expectedType != p.Type:
+			result.MismatchedItems = append(result.MismatchedItems, domain.MismatchedItem{
+				ItemID: *p.ItemID, ProductID: productID, ExpectedType: expectedType, ActualType: p.Type,
+			})
+		}
+		delete(expectedItems, *p.ItemID)
+	}
+	for itemID, itemType := range expectedItems {
+		result.MissingItems = append(result.MissingItems, domain.ReconciliationItem{ItemID: itemID, Type: itemType})
+	}
+	sort.Slice(result.MissingItems, func(i, j int) bool { return result.MissingItems[i].ItemID < result.MissingItems[j].ItemID })
+
+	types := make([]string, 0, len(expected)+len(received))
+	for productType := range expected {
+		types = append(types, string(productType))
+	}
+	for productType := range received {
+		if _, ok := expected[productType]; !ok {
+			types = append(types, string(productType))
+		}
+	}
+	sort.Strings(types)
+
+	for _, t := range types {
+		productType := domain.ProductType(t)
+		line := domain.ReconciliationLine{Type: productType, Expected: expected[productType], Received: received[productType]}
+		if line.Expected > line.Received {
+			line.Missing = line.Expected - line.Received
+		} else {
+			line.Extra = line.Received - line.Expected
+		}
+		if line.Missing > 0 || line.Extra > 0 {
+			result.Status = domain.ReconciliationDiscrepancy
+		}
+		result.Lines = append(result.Lines, line)
+	}
+	if len(result.MissingItems) > 0 || len(result.ExtraItems) > 0 || len(result.MismatchedItems) > 0 {
+		result.Status = domain.ReconciliationDiscrepancy
+	}
+
+	return result
+}
+
+// reconcileReception сверяет приемку с поставкой. Итог по закрытой приемке сохраняется в поставке,
+// по открытой - только возвращается.
+func reconcileReception(
+	ctx context.Context,
+	shipmentRepo domain.ExpectedShipmentRepository,
+	shipment *domain.ExpectedShipment,
+	rwp *domain.ReceptionWithProducts,
+) (*domain.Reconciliation, error) {
+	reconciliation := buildReconciliation(shipment, rwp, time.Now().UTC())
+	if reconciliation.Final {
+		if err := shipmentRepo.SaveReconciliation(ctx, shipment.ID, reconciliation); err != nil {
+			return nil, fmt.Errorf("saving reconciliation: %w", err)
+		}
+	}
+	return &reconciliation, nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
+)
+
+// ReportService реализует интерфейс domain.ReportService.
+// Сервис только ставит задачи в очередь и отдает результаты, построением занимается ReportWorker.
+type ReportService struct {
+	log        *slog.Logger
+	reportRepo domain.ReportRepository // Зависимость для хранения задач
+	blobStore  domain.BlobStore        // Зависимость для чтения готовых файлов
+}
+
+// NewReportService создает новый экземпляр ReportService.
+func NewReportService(log *slog.Logger, reportRepo domain.ReportRepository, blobStore domain.BlobStore) *ReportService {
+	return &ReportService{
+		log:        log,
+		reportRepo: reportRepo,
+		blobStore:  blobStore,
+	}
+}
+
+// CreateReport проверяет параметры и сохраняет задачу в состоянии pending.
+// Пустой формат означает CSV, пустой шаг группировки - группировку по дням.
+func (s *ReportService) CreateReport(ctx context.Context, reportType domain.ReportType, format domain.ExportFormat, params domain.ReportParams) (*domain.Report, error) {
+	const op = "ReportService.CreateReport"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("report_type", string(reportType)))
+
+	if format == "" {
+		format = domain.ExportFormatCSV
+	}
+	if params.GroupBy == "" {
+		params.GroupBy = domain.GroupByDay
+	}
+
+	if !reportType.IsValid() {
+		log.Warn("Invalid report type")
+		return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("type", "oneof", "reception_summary, product_breakdown, pvz_activity"))
+	}
+	if !format.IsValid() {
+		log.Warn("Invalid report format", slog.String("format", string(format)))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("format", "oneof", "csv, xlsx"))
+	}
+	if !params.GroupBy.IsValid() {
+		log.Warn("Invalid report groupBy", slog.String("group_by", string(params.GroupBy)))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("params.groupBy", "oneof", "day, week, month"))
+	}
+	if params.StartDate != nil && params.EndDate != nil && !params.StartDate.Before(*params.EndDate) {
+		log.Warn("Invalid report period", slog.Time("start_date", *params.StartDate), slog.Time("end_date", *params.EndDate))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewFieldError("params.startDate", "must be before endDate"))
+	}
+	if params.City != nil && !params.City.IsValid() {
+		log.Warn("Invalid report city", slog.String("city", string(*params.City)))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("params.city", "invalid", string(*params.City)))
+	}
+
+	report := &domain.Report{
+		ID:        uuid.New(),
+		Type:      reportType,
+		Format:    format,
+		Params:    params,
+		Status:    domain.ReportStatusPending,
+		CreatedAt: time.Now().UTC(),
+	}
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		report.RequestedBy = &userID
+	}
+
+	if err := s.reportRepo.Create(ctx, report); err != nil {
+		log.Error("Failed to create report job", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("Report job enqueued", slog.String("report_id", report.ID.String()), slog.String("format", string(format)))
+	return report, nil
+}
+
+// GetReport возвращает задачу с текущим состоянием.
+func (s *ReportService) GetReport(ctx context.Context, id uuid.UUID) (*domain.Report, error) {
+	const op = "ReportService.GetReport"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("report_id", id.String()))
+
+	report, err := s.reportRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Report not found")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get report", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	return report, nil
+}
+
+// OpenReportResult открывает файл готового отчета.
+func (s *ReportService) OpenReportResult(ctx context.Context, id uuid.UUID) (*domain.Report, io.ReadCloser, error) {
+	const op = "ReportService.OpenReportResult"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("report_id", id.String()))
+
+	report, err := s.GetReport(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case report.Status == domain.ReportStatusExpired:
+		log.Warn("Requested result of expired report")
+		return nil, nil, fmt.Errorf("%s: %w", op, domain.ErrReportExpired)
+	case report.Status != domain.ReportStatusDone || report.BlobKey == nil:
+		log.Warn("Requested result of unfinished report", slog.String("status", string(report.Status)))
+		return nil, nil, fmt.Errorf("%s: %w: status is %s", op, domain.ErrReportNotReady, report.Status)
+	}
+
+	content, err := s.blobStore.Open(ctx, *report.BlobKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// Файл удален раньше, чем задача помечена expired (очистка еще не дошла до записи).
+			log.Warn("Report result is missing in blob store", slog.String("blob_key", *report.BlobKey))
+			return nil, nil, fmt.Errorf("%s: %w", op, domain.ErrReportExpired)
+		}
+		log.Error("Failed to open report result", slog.String("error", err.Error()))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return report, content, nil
+}
@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/service"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestReportService_CreateReport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	userID := uuid.New()
+	ctx := middleware.ContextWithUser(context.Background(), userID, domain.RoleModerator)
+
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	unknownCity := domain.City("Новосибирск")
+
+	testCases := []struct {
+		name          string
+		reportType    domain.ReportType
+		format        domain.ExportFormat
+		params        domain.ReportParams
+		setupMocks    func(repo *mocks.ReportRepository)
+		expectedError error
+	}{
+		{
+			name:       "Success_Defaults",
+			reportType: domain.ReportReceptionSummary,
+			params:     domain.ReportParams{StartDate: &startDate, EndDate: &endDate},
+			setupMocks: func(repo *mocks.ReportRepository) {
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Report) bool {
+					return r.Type == domain.ReportReceptionSummary &&
+						r.Format == domain.ExportFormatCSV &&
+						r.Params.GroupBy == domain.GroupByDay &&
+						r.Status == domain.ReportStatusPending &&
+						r.RequestedBy != nil && *r.RequestedBy == userID
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:          "Fail_Invalid_Type",
+			reportType:    "everything",
+			setupMocks:    func(repo *mocks.ReportRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Invalid_Format",
+			reportType:    domain.ReportPVZActivity,
+			format:        "pdf",
+			setupMocks:    func(repo *mocks.ReportRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Start_After_End",
+			reportType:    domain.ReportPVZActivity,
+			params:        domain.ReportParams{StartDate: &endDate, EndDate: &startDate},
+			setupMocks:    func(repo *mocks.ReportRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Invalid_City",
+			reportType:    domain.ReportProductBreakdown,
+			params:        domain.ReportParams{City: &unknownCity},
+			setupMocks:    func(repo *mocks.ReportRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:       "Fail_Repo_Error",
+			reportType: domain.ReportProductBreakdown,
+			format:     domain.ExportFormatXLSX,
+			setupMocks: func(repo *mocks.ReportRepository) {
+				repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("some db error")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockReportRepo := mocks.NewReportRepository(t)
+			mockBlobStore := mocks.NewBlobStore(t)
+			tc.setupMocks(mockReportRepo)
+			reportService := service.NewReportService(logger, mockReportRepo, mockBlobStore)
+
+			report, err := reportService.CreateReport(ctx, tc.reportType, tc.format, tc.params)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, report)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, report)
+			assert.NotEqual(t, uuid.Nil, report.ID)
+		})
+	}
+}
+
+func TestReportService_OpenReportResult(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	reportID := uuid.New()
+	blobKey := reportID.String() + ".csv"
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(repo *mocks.ReportRepository, store *mocks.BlobStore)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMocks: func(repo *mocks.ReportRepository, store *mocks.BlobStore) {
+				repo.On("GetByID", mock.Anything, reportID).Return(&domain.Report{ID: reportID, Status: domain.ReportStatusDone, BlobKey: &blobKey}, nil).Once()
+				store.On("Open", mock.Anything, blobKey).Return(io.NopCloser(strings.NewReader("data")), nil).Once()
+			},
+		},
+		{
+			name: "Fail_Not_Found",
+			setupMocks: func(repo *mocks.ReportRepository, store *mocks.BlobStore) {
+				repo.On("GetByID", mock.Anything, reportID).Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name: "Fail_Not_Ready",
+			setupMocks: func(repo *mocks.ReportRepository, store *mocks.BlobStore) {
+				repo.On("GetByID", mock.Anything, reportID).Return(&domain.Report{ID: reportID, Status: domain.ReportStatusRunning}, nil).Once()
+			},
+			expectedError: domain.ErrReportNotReady,
+		},
+		{
+			name: "Fail_Expired",
+			setupMocks: func(repo *mocks.ReportRepository, store *mocks.BlobStore) {
+				repo.On("GetByID", mock.Anything, reportID).Return(&domain.Report{ID: reportID, Status: domain.ReportStatusExpired}, nil).Once()
+			},
+			expectedError: domain.ErrReportExpired,
+		},
+		{
+			name: "Fail_Blob_Missing",
+			setupMocks: func(repo *mocks.ReportRepository, store *mocks.BlobStore) {
+				repo.On("GetByID", mock.Anything, reportID).Return(&domain.Report{ID: reportID, Status: domain.ReportStatusDone, BlobKey: &blobKey}, nil).Once()
+				store.On("Open", mock.Anything, blobKey).Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedError: domain.ErrReportExpired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockReportRepo := mocks.NewReportRepository(t)
+			mockBlobStore := mocks.NewBlobStore(t)
+			tc.setupMocks(mockReportRepo, mockBlobStore)
+			reportService := service.NewReportService(logger, mockReportRepo, mockBlobStore)
+
+			report, content, err := reportService.OpenReportResult(ctx, reportID)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, content)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, report)
+			data, err := io.ReadAll(content)
+			require.NoError(t, err)
+			assert.Equal(t, "data", string(data))
+		})
+	}
+}
@@ -0,0 +1,436 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/tracing"
+	"pvz-service-avito-internship/pkg/tabular"
+)
+
+const (
+	// expiredReportsBatch - сколько устаревших результатов удаляется за один запрос к БД.
+	expiredReportsBatch = 100
+	// releaseTimeout - время на возврат прерванной задачи в очередь при остановке.
+	releaseTimeout = 5 * time.Second
+)
+
+// ReportWorker строит отчеты из очереди reports и обслуживает хранилище результатов.
+// Обработчики забирают задачи из БД, поэтому очередь переживает перезапуск и может обслуживаться
+// несколькими экземплярами сервиса одновременно.
+type ReportWorker struct {
+	log          *slog.Logger
+	reportRepo   domain.ReportRepository // Зависимость для очереди задач
+	statsService domain.StatsService     // Зависимость для получения данных отчетов
+	blobStore    domain.BlobStore        // Зависимость для сохранения результатов
+	cfg          config.Reports
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewReportWorker создает новый экземпляр ReportWorker.
+func NewReportWorker(
+	log *slog.Logger,
+	reportRepo domain.ReportRepository,
+	statsService domain.StatsService,
+	blobStore domain.BlobStore,
+	cfg config.Reports,
+) *ReportWorker {
+	return &ReportWorker{
+		log:          log,
+		reportRepo:   reportRepo,
+		statsService: statsService,
+		blobStore:    blobStore,
+		cfg:          cfg,
+	}
+}
+
+// Start запускает обработчики очереди и цикл обслуживания. Не блокирует.
+func (w *ReportWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	workers := w.cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		w.wg.Add(1)
+		go func(id int) {
+			defer w.wg.Done()
+			w.runWorker(ctx, id)
+		}(i)
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.runMaintenance(ctx)
+	}()
+
+	w.log.Info("Report workers started", slog.Int("workers", workers))
+}
+
+// Stop останавливает обработчики и ждет их завершения, но не дольше дедлайна ctx.
+// Прерванные задачи возвращаются в очередь и будут построены заново.
+func (w *ReportWorker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.log.Info("Report workers stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("report workers did not stop in time: %w", ctx.Err())
+	}
+}
+
+func (w *ReportWorker) runWorker(ctx context.Context, id int) {
+	log := w.log.With(slog.String("op", "ReportWorker.runWorker"), slog.Int("worker", id))
+
+	for {
+		report, err := w.reportRepo.ClaimNext(ctx)
+		switch {
+		case err == nil:
+			w.process(ctx, report)
+			continue
+		case ctx.Err() != nil:
+			return
+		case !errors.Is(err, domain.ErrNotFound):
+			log.Error("Failed to claim report job", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.cfg.PollInterval):
+		}
+	}
+}
+
+func (w *ReportWorker) runMaintenance(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		w.requeueStale(ctx)
+		w.expireResults(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// process строит отчет и сохраняет его в хранилище. Данные передаются в хранилище через pipe,
+// поэтому файл не накапливается в памяти целиком.
+func (w *ReportWorker) process(ctx context.Context, report *domain.Report) {
+	const op = "ReportWorker.process"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	log := w.log.With(
+		slog.String("op", op),
+		slog.String("report_id", report.ID.String()),
+		slog.String("report_type", string(report.Type)),
+		slog.Int("attempt", report.Attempts),
+	)
+	log.Info("Report generation started")
+	started := time.Now()
+
+	jobCtx, cancel := context.WithTimeout(ctx, w.cfg.JobTimeout)
+	defer cancel()
+
+	// Ключ результата уникален для запуска: запуск, потерявший задачу, не перезапишет результат нового запуска.
+	key := fmt.Sprintf("%s-%d.%s", report.ID, report.Attempts, report.Format)
+	pr, pw := io.Pipe()
+	generated := make(chan struct{})
+	var errGenerate error
+	go func() {
+		defer close(generated)
+		errGenerate = w.generate(jobCtx, report, pw)
+		pw.CloseWithError(errGenerate)
+	}()
+
+	err := w.blobStore.Put(jobCtx, key, pr)
+	// Если хранилище завершилось раньше генератора, разблокируем его запись в pipe.
+	pr.CloseWithError(io.ErrClosedPipe)
+	<-generated
+	if errGenerate != nil {
+		// Ошибка генератора информативнее, чем ошибка чтения pipe в хранилище.
+		err = errGenerate
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			log.Warn("Report generation interrupted by shutdown, returning job to queue")
+			w.release(report)
+			return
+		}
+		log.Error("Report generation failed", slog.String("error", err.Error()))
+		errMark := w.reportRepo.MarkFailed(context.WithoutCancel(ctx), report.ID, report.Attempts, err.Error())
+		switch {
+		case errors.Is(errMark, domain.ErrReportClaimLost):
+			log.Warn("Report job was taken over by another run, failure is not recorded")
+		case errMark != nil:
+			log.Error("Failed to mark report as failed", slog.String("error", errMark.Error()))
+		}
+		return
+	}
+
+	expiresAt := time.Now().UTC().Add(w.cfg.ResultTTL)
+	if err := w.reportRepo.MarkDone(context.WithoutCancel(ctx), report.ID, report.Attempts, key, expiresAt); err != nil {
+		if errors.Is(err, domain.ErrReportClaimLost) {
+			log.Warn("Report job was taken over by another run, removing result")
+		} else {
+			log.Error("Failed to mark report as done, removing result", slog.String("error", err.Error()))
+		}
+		if errDelete := w.blobStore.Delete(context.WithoutCancel(ctx), key); errDelete != nil {
+			log.Error("Failed to remove orphaned report result", slog.String("error", errDelete.Error()))
+		}
+		return
+	}
+
+	log.Info("Report generated", slog.Duration("duration", time.Since(started)), slog.Time("expires_at", expiresAt))
+}
+
+func (w *ReportWorker) release(report *domain.Report) {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := w.reportRepo.Release(ctx, report.ID, report.Attempts); err != nil {
+		w.log.Error("Failed to return report job to queue",
+			slog.String("report_id", report.ID.String()), slog.String("error", err.Error()))
+	}
+}
+
+func (w *ReportWorker) requeueStale(ctx context.Context) {
+	const op = "ReportWorker.requeueStale"
+	log := w.log.With(slog.String("op", op))
+
+	requeued, err := w.reportRepo.RequeueStale(ctx, time.Now().UTC().Add(-w.cfg.JobTimeout), w.cfg.MaxAttempts)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Error("Failed to requeue stale report jobs", slog.String("error", err.Error()))
+		}
+		return
+	}
+	if requeued > 0 {
+		log.Warn("Stale report jobs requeued", slog.Int64("count", requeued))
+	}
+}
+
+func (w *ReportWorker) expireResults(ctx context.Context) {
+	const op = "ReportWorker.expireResults"
+	log := w.log.With(slog.String("op", op))
+
+	for {
+		reports, err := w.reportRepo.ListExpired(ctx, time.Now().UTC(), expiredReportsBatch)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error("Failed to list expired reports", slog.String("error", err.Error()))
+			}
+			return
+		}
+
+		for _, report := range reports {
+			if report.BlobKey != nil {
+				if err := w.blobStore.Delete(ctx, *report.BlobKey); err != nil {
+					log.Error("Failed to delete expired report result",
+						slog.String("report_id", report.ID.String()), slog.String("error", err.Error()))
+					continue
+				}
+			}
+			if err := w.reportRepo.MarkExpired(ctx, report.ID); err != nil {
+				log.Error("Failed to mark report as expired",
+					slog.String("report_id", report.ID.String()), slog.String("error", err.Error()))
+				return
+			}
+		}
+		if len(reports) > 0 {
+			log.Info("Expired report results removed", slog.Int("count", len(reports)))
+		}
+		if len(reports) < expiredReportsBatch {
+			return
+		}
+	}
+}
+
+// generate записывает отчет в out в формате задачи.
+func (w *ReportWorker) generate(ctx context.Context, report *domain.Report, out io.Writer) error {
+	table, err := tabular.NewWriter(string(report.Format), out, string(report.Type))
+	if err != nil {
+		return err
+	}
+
+	switch report.Type {
+	case domain.ReportReceptionSummary, domain.ReportProductBreakdown:
+		err = w.writeStatsReport(ctx, report, table)
+	case domain.ReportPVZActivity:
+		err = w.writePVZActivityReport(ctx, report, table)
+	default:
+		err = fmt.Errorf("%w: unknown report type '%s'", domain.ErrValidation, report.Type)
+	}
+	if err != nil {
+		return err
+	}
+	return table.Close()
+}
+
+func (w *ReportWorker) writeStatsReport(ctx context.Context, report *domain.Report, table tabular.Writer) error {
+	filter := domain.ReceptionStatsFilter{GroupBy: report.Params.GroupBy}
+	if report.Params.StartDate != nil {
+		filter.StartDate = *report.Params.StartDate
+	}
+	if report.Params.EndDate != nil {
+		filter.EndDate = *report.Params.EndDate
+	}
+
+	stats, err := w.statsService.ReceptionStats(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	// Строки по ПВЗ идут перед итогами по городам; у итогов pvz_id пустой.
+	rows := make([]domain.ReceptionStatsRow, 0, len(stats.ByPVZ)+len(stats.ByCity))
+	for _, group := range [][]domain.ReceptionStatsRow{stats.ByPVZ, stats.ByCity} {
+		for _, row := range group {
+			if report.Params.City == nil || row.City == *report.Params.City {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	if report.Type == domain.ReportProductBreakdown {
+		return writeProductBreakdown(table, rows)
+	}
+	return writeReceptionSummary(table, rows)
+}
+
+func writeReceptionSummary(table tabular.Writer, rows []domain.ReceptionStatsRow) error {
+	header := []string{
+		"period", "city", "pvz_id", "receptions_count", "open_receptions_count",
+		"products_count", "avg_duration_seconds", "p95_duration_seconds",
+	}
+	if err := table.WriteRow(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		cells := []string{
+			row.Period.UTC().Format(time.RFC3339),
+			string(row.City),
+			formatReportPVZID(row),
+			strconv.Itoa(row.ReceptionsCount),
+			strconv.Itoa(row.OpenReceptionsCount),
+			strconv.Itoa(row.ProductsCount),
+			formatReportFloat(row.AvgDurationSeconds),
+			formatReportFloat(row.P95DurationSeconds),
+		}
+		if err := table.WriteRow(cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeProductBreakdown(table tabular.Writer, rows []domain.ReceptionStatsRow) error {
+	if err := table.WriteRow([]string{"period", "city", "pvz_id", "product_type", "products_count"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		types := make([]string, 0, len(row.ProductsByType))
+		for productType := range row.ProductsByType {
+			types = append(types, string(productType))
+		}
+		sort.Strings(types)
+
+		for _, productType := range types {
+			cells := []string{
+				row.Period.UTC().Format(time.RFC3339),
+				string(row.City),
+				formatReportPVZID(row),
+				productType,
+				strconv.Itoa(row.ProductsByType[domain.ProductType(productType)]),
+			}
+			if err := table.WriteRow(cells); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *ReportWorker) writePVZActivityReport(ctx context.Context, report *domain.Report, table tabular.Writer) error {
+	filter := domain.PVZActivityFilter{City: report.Params.City}
+	if report.Params.StartDate != nil {
+		filter.StartDate = *report.Params.StartDate
+	}
+	if report.Params.EndDate != nil {
+		filter.EndDate = *report.Params.EndDate
+	}
+
+	rows, err := w.statsService.PVZActivity(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	header := []string{
+		"pvz_id", "city", "registration_date", "receptions_count",
+		"open_receptions_count", "products_count", "last_reception_at",
+	}
+	if err := table.WriteRow(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		lastReception := ""
+		if row.LastReceptionAt != nil {
+			lastReception = row.LastReceptionAt.UTC().Format(time.RFC3339)
+		}
+		cells := []string{
+			row.PVZID.String(),
+			string(row.City),
+			row.RegistrationDate.UTC().Format(time.RFC3339),
+			strconv.Itoa(row.ReceptionsCount),
+			strconv.Itoa(row.OpenReceptionsCount),
+			strconv.Itoa(row.ProductsCount),
+			lastReception,
+		}
+		if err := table.WriteRow(cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatReportPVZID(row domain.ReceptionStatsRow) string {
+	if row.PVZID == nil {
+		return ""
+	}
+	return row.PVZID.String()
+}
+
+func formatReportFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', 1, 64)
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/repository/filestore"
+	"pvz-service-avito-internship/internal/service"
+	"pvz-service-avito-internship/mocks"
+)
+
+func testReportsConfig() config.Reports {
+	return config.Reports{
+		Workers:         1,
+		PollInterval:    10 * time.Millisecond,
+		JobTimeout:      time.Minute,
+		MaxAttempts:     3,
+		ResultTTL:       time.Hour,
+		CleanupInterval: time.Hour,
+	}
+}
+
+// runWorkerUntil запускает обработчик и ждет сигнала от мока о завершении задачи.
+func runWorkerUntil(t *testing.T, worker *service.ReportWorker, done <-chan struct{}) {
+	t.Helper()
+	worker.Start()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("report job was not processed in time")
+	}
+	stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, worker.Stop(stopCtx))
+}
+
+func TestReportWorker(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	setupMaintenance := func(repo *mocks.ReportRepository) {
+		repo.On("RequeueStale", mock.Anything, mock.Anything, 3).Return(int64(0), nil).Maybe()
+		repo.On("ListExpired", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Report{}, nil).Maybe()
+	}
+
+	t.Run("Generates_Report_Into_Store", func(t *testing.T) {
+		mockReportRepo := mocks.NewReportRepository(t)
+		mockStatsService := mocks.NewStatsService(t)
+		store, err := filestore.NewLocalBlobStore(t.TempDir(), logger)
+		require.NoError(t, err)
+
+		pvzID := uuid.New()
+		lastReception := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+		report := &domain.Report{
+			ID: uuid.New(), Type: domain.ReportPVZActivity, Format: domain.ExportFormatCSV,
+			Status: domain.ReportStatusRunning, Attempts: 1,
+		}
+		expectedKey := report.ID.String() + "-1.csv"
+
+		setupMaintenance(mockReportRepo)
+		mockReportRepo.On("ClaimNext", mock.Anything).Return(report, nil).Once()
+		mockReportRepo.On("ClaimNext", mock.Anything).Return(nil, domain.ErrNotFound)
+		mockStatsService.On("PVZActivity", mock.Anything, domain.PVZActivityFilter{}).Return([]domain.PVZActivityRow{{
+			PVZID: pvzID, City: domain.Kazan, RegistrationDate: lastReception.AddDate(0, -1, 0),
+			ReceptionsCount: 2, OpenReceptionsCount: 1, ProductsCount: 7, LastReceptionAt: &lastReception,
+		}}, nil).Once()
+
+		done := make(chan struct{})
+		mockReportRepo.On("MarkDone", mock.Anything, report.ID, 1, expectedKey, mock.AnythingOfType("time.Time")).
+			Run(func(mock.Arguments) { close(done) }).Return(nil).Once()
+
+		worker := service.NewReportWorker(logger, mockReportRepo, mockStatsService, store, testReportsConfig())
+		runWorkerUntil(t, worker, done)
+
+		content, err := store.Open(context.Background(), expectedKey)
+		require.NoError(t, err)
+		defer content.Close()
+		data, err := io.ReadAll(content)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\xEF\xBB\xBF"))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "pvz_id", records[0][0])
+		assert.Equal(t, []string{
+			pvzID.String(), string(domain.Kazan), "2025-03-10T09:00:00Z", "2", "1", "7", "2025-04-10T09:00:00Z",
+		}, records[1])
+	})
+
+	t.Run("Marks_Failed_On_Generation_Error", func(t *testing.T) {
+		mockReportRepo := mocks.NewReportRepository(t)
+		mockStatsService := mocks.NewStatsService(t)
+		store, err := filestore.NewLocalBlobStore(t.TempDir(), logger)
+		require.NoError(t, err)
+
+		report := &domain.Report{
+			ID: uuid.New(), Type: domain.ReportReceptionSummary, Format: domain.ExportFormatXLSX,
+			Params: domain.ReportParams{GroupBy: domain.GroupByDay}, Status: domain.ReportStatusRunning, Attempts: 1,
+		}
+
+		setupMaintenance(mockReportRepo)
+		mockReportRepo.On("ClaimNext", mock.Anything).Return(report, nil).Once()
+		mockReportRepo.On("ClaimNext", mock.Anything).Return(nil, domain.ErrNotFound)
+		mockStatsService.On("ReceptionStats", mock.Anything, mock.Anything).
+			Return(nil, errors.New("validation failed: period too long")).Once()
+
+		done := make(chan struct{})
+		mockReportRepo.On("MarkFailed", mock.Anything, report.ID, 1, mock.MatchedBy(func(reason string) bool {
+			return strings.Contains(reason, "period too long")
+		})).Run(func(mock.Arguments) { close(done) }).Return(nil).Once()
+
+		worker := service.NewReportWorker(logger, mockReportRepo, mockStatsService, store, testReportsConfig())
+		runWorkerUntil(t, worker, done)
+
+		_, err = store.Open(context.Background(), report.ID.String()+"-1.xlsx")
+		assert.ErrorIs(t, err, domain.ErrNotFound, "failed report must not leave a result")
+	})
+
+	t.Run("Discards_Result_When_Claim_Is_Lost", func(t *testing.T) {
+		mockReportRepo := mocks.NewReportRepository(t)
+		mockStatsService := mocks.NewStatsService(t)
+		store, err := filestore.NewLocalBlobStore(t.TempDir(), logger)
+		require.NoError(t, err)
+
+		report := &domain.Report{
+			ID: uuid.New(), Type: domain.ReportPVZActivity, Format: domain.ExportFormatCSV,
+			Status: domain.ReportStatusRunning, Attempts: 1,
+		}
+		// Результат нового запуска той же задачи, который не должен пострадать.
+		newerKey := report.ID.String() + "-2.csv"
+		require.NoError(t, store.Put(context.Background(), newerKey, strings.NewReader("newer")))
+
+		setupMaintenance(mockReportRepo)
+		mockReportRepo.On("ClaimNext", mock.Anything).Return(report, nil).Once()
+		mockReportRepo.On("ClaimNext", mock.Anything).Return(nil, domain.ErrNotFound)
+		mockStatsService.On("PVZActivity", mock.Anything, domain.PVZActivityFilter{}).Return([]domain.PVZActivityRow{}, nil).Once()
+
+		done := make(chan struct{})
+		mockReportRepo.On("MarkDone", mock.Anything, report.ID, 1, report.ID.String()+"-1.csv", mock.AnythingOfType("time.Time")).
+			Run(func(mock.Arguments) { close(done) }).Return(domain.ErrReportClaimLost).Once()
+
+		worker := service.NewReportWorker(logger, mockReportRepo, mockStatsService, store, testReportsConfig())
+		runWorkerUntil(t, worker, done)
+
+		_, err = store.Open(context.Background(), report.ID.String()+"-1.csv")
+		assert.ErrorIs(t, err, domain.ErrNotFound, "result of the lost run must be removed")
+		content, err := store.Open(context.Background(), newerKey)
+		require.NoError(t, err)
+		defer content.Close()
+		data, err := io.ReadAll(content)
+		require.NoError(t, err)
+		assert.Equal(t, "newer", string(data))
+	})
+
+	t.Run("Removes_Expired_Results", func(t *testing.T) {
+		mockReportRepo := mocks.NewReportRepository(t)
+		mockStatsService := mocks.NewStatsService(t)
+		store, err := filestore.NewLocalBlobStore(t.TempDir(), logger)
+		require.NoError(t, err)
+
+		expiredKey := "expired.csv"
+		require.NoError(t, store.Put(context.Background(), expiredKey, strings.NewReader("old")))
+		expired := domain.Report{ID: uuid.New(), Status: domain.ReportStatusDone, BlobKey: &expiredKey}
+
+		mockReportRepo.On("ClaimNext", mock.Anything).Return(nil, domain.ErrNotFound).Maybe()
+		mockReportRepo.On("RequeueStale", mock.Anything, mock.Anything, 3).Return(int64(1), nil).Once()
+		mockReportRepo.On("ListExpired", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Report{expired}, nil).Once()
+
+		done := make(chan struct{})
+		mockReportRepo.On("MarkExpired", mock.Anything, expired.ID).Run(func(mock.Arguments) { close(done) }).Return(nil).Once()
+
+		worker := service.NewReportWorker(logger, mockReportRepo, mockStatsService, store, testReportsConfig())
+		runWorkerUntil(t, worker, done)
+
+		_, err = store.Open(context.Background(), expiredKey)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
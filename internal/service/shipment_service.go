@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
+)
+
+const (
+	// maxItemIDLength - максимальная длина идентификатора единицы (совпадает с products.item_id).
+	maxItemIDLength = 100
+	// maxShipmentReferenceLength - максимальная длина номера поставки у логистики.
+	maxShipmentReferenceLength = 100
+	// maxShipmentLineQuantity - верхняя граница количества товаров одного типа в поставке.
+	maxShipmentLineQuantity = 10000
+)
+
+// ShipmentService реализует интерфейс domain.ShipmentService.
+type ShipmentService struct {
+	log           *slog.Logger
+	pvzRepo       domain.PVZRepository              // Зависимость для проверки существования ПВЗ
+	receptionRepo domain.ReceptionRepository        // Зависимость для получения товаров приемки
+	shipmentRepo  domain.ExpectedShipmentRepository // Зависимость для хранения поставок
+}
+
+// NewShipmentService создает новый экземпляр ShipmentService.
+func NewShipmentService(
+	log *slog.Logger,
+	pvzRepo domain.PVZRepository,
+	receptionRepo domain.ReceptionRepository,
+	shipmentRepo domain.ExpectedShipmentRepository,
+) *ShipmentService {
+	return &ShipmentService{
+		log:           log,
+		pvzRepo:       pvzRepo,
+		receptionRepo: receptionRepo,
+		shipmentRepo:  shipmentRepo,
+	}
+}
+
+// CreateShipment проверяет строки поставки и регистрирует ее для ПВЗ.
+func (s *ShipmentService) CreateShipment(ctx context.Context, pvzID uuid.UUID, reference *string, lines []domain.ExpectedShipmentLine) (*domain.ExpectedShipment, error) {
+	const op = "ShipmentService.CreateShipment"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("pvz_id", pvzID.String()))
+
+	if err := validateShipmentLines(lines); err != nil {
+		log.Warn("Invalid expected shipment lines", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if reference != nil {
+		trimmed := strings.TrimSpace(*reference)
+		if utf8.RuneCountInString(trimmed) > maxShipmentReferenceLength {
+			return nil, fmt.Errorf("%s: %w: reference must not exceed %d characters", op, domain.ErrValidation, maxShipmentReferenceLength)
+		}
+		reference = &trimmed
+		if trimmed == "" {
+			reference = nil
+		}
+	}
+
+	if _, err := s.pvzRepo.GetByID(ctx, pvzID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Attempt to create expected shipment for non-existent PVZ")
+			return nil, fmt.Errorf("%s: %w: pvz with id %s not found", op, domain.ErrValidation, pvzID)
+		}
+		log.Error("Failed to get PVZ by ID before creating expected shipment", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	shipment := &domain.ExpectedShipment{
+		ID:        uuid.New(),
+		PVZID:     pvzID,
+		Reference: reference,
+		Lines:     lines,
+		CreatedAt: time.Now().UTC(),
+	}
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		shipment.CreatedBy = &userID
+	}
+
+	if err := s.shipmentRepo.Create(ctx, shipment); err != nil {
+		log.Error("Failed to create expected shipment in repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("Expected shipment created successfully", slog.String("shipment_id", shipment.ID.String()))
+	return shipment, nil
+}
+
+// GetShipment возвращает поставку по ID.
+func (s *ShipmentService) GetShipment(ctx context.Context, id uuid.UUID) (*domain.ExpectedShipment, error) {
+	const op = "ShipmentService.GetShipment"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("shipment_id", id.String()))
+
+	shipment, err := s.shipmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Expected shipment not found")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get expected shipment", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	return shipment, nil
+}
+
+// GetReconciliation возвращает расхождения приемки с поставкой. Если приемка закрыта, но итог еще не
+// сохранен (например, приемку закрыл планировщик), сверка выполняется и сохраняется при первом запросе.
+func (s *ShipmentService) GetReconciliation(ctx context.Context, receptionID uuid.UUID) (*domain.Reconciliation, error) {
+	const op = "ShipmentService.GetReconciliation"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("reception_id", receptionID.String()))
+
+	rwp, err := s.receptionRepo.GetWithProducts(ctx, receptionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Reception not found")
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		log.Error("Failed to get reception with products", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	if rwp.Reception.ExpectedShipmentID == nil {
+		log.Warn("Reception has no expected shipment")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrNoExpectedShipment)
+	}
+
+	shipment, err := s.shipmentRepo.GetByID(ctx, *rwp.Reception.ExpectedShipmentID)
+	if err != nil {
+		log.Error("Failed to get expected shipment", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	if rwp.Reception.Status == domain.StatusClosed && shipment.Reconciliation != nil {
+		return shipment.Reconciliation, nil
+	}
+
+	reconciliation, err := reconcileReception(ctx, s.shipmentRepo, shipment, rwp)
+	if err != nil {
+		log.Error("Failed to reconcile reception", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+	return reconciliation, nil
+}
+
+// validateShipmentLines проверяет строки поставки: допустимые типы без повторов, положительное количество
+// и уникальные идентификаторы единиц, которых не больше количества.
+func validateShipmentLines(lines []domain.ExpectedShipmentLine) error {
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: expected shipment must contain at least one line", domain.ErrValidation)
+	}
+
+	seenTypes := make(map[domain.ProductType]struct{}, len(lines))
+	seenItems := make(map[string]struct{})
+	for i := range lines {
+		line := &lines[i]
+		if !line.Type.IsValid() {
+			return fmt.Errorf("%w: invalid product type '%s'", domain.ErrValidation, line.Type)
+		}
+		if _, ok := seenTypes[line.Type]; ok {
+			return fmt.Errorf("%w: duplicate line for product type '%s'", domain.ErrValidation, line.Type)
+		}
+		seenTypes[line.Type] = struct{}{}
+
+		if line.Quantity < 1 || line.Quantity > maxShipmentLineQuantity {
+			return fmt.Errorf("%w: quantity for '%s' must be between 1 and %d", domain.ErrValidation, line.Type, maxShipmentLineQuantity)
+		}
+		if len(line.ItemIDs) > line.Quantity {
+			return fmt.Errorf("%w: more item ids than quantity for '%s'", domain.ErrValidation, line.Type)
+		}
+		for j, itemID := range line.ItemIDs {
+			normalized, err := normalizeItemID(&itemID)
+			if err != nil {
+				return err
+			}
+			if normalized == nil {
+				return fmt.Errorf("%w: item id must not be empty", domain.ErrValidation)
+			}
+			if _, ok := seenItems[*normalized]; ok {
+				return fmt.Errorf("%w: duplicate item id '%s'", domain.ErrValidation, *normalized)
+			}
+			seenItems[*normalized] = struct{}{}
+			line.ItemIDs[j] = *normalized
+		}
+	}
+	return nil
+}
+
+// normalizeItemID обрезает пробелы вокруг идентификатора единицы. Пустой идентификатор считается отсутствующим.
+func normalizeItemID(itemID *string) (*string, error) {
+	if itemID == nil {
+		return nil, nil
+	}
+	trimmed := strings.TrimSpace(*itemID)
+	if trimmed == "" {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(trimmed) > maxItemIDLength {
+		return nil, fmt.Errorf("%w: item id must not exceed %d characters", domain.ErrValidation, maxItemIDLength)
+	}
+	return &trimmed, nil
+}
@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/service"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestShipmentService_CreateShipment(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	moderatorID := uuid.New()
+	ctx := middleware.ContextWithUser(context.Background(), moderatorID, domain.RoleModerator)
+	testPvzID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockPVZRepo := mocks.NewPVZRepository(t)
+		mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+		shipmentService := service.NewShipmentService(logger, mockPVZRepo, mocks.NewReceptionRepository(t), mockShipmentRepo)
+
+		mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(&domain.PVZ{ID: testPvzID}, nil).Once()
+		mockShipmentRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.ExpectedShipment) bool {
+			return s.PVZID == testPvzID && s.CreatedBy != nil && *s.CreatedBy == moderatorID &&
+				s.Reference != nil && *s.Reference == "ASN-1" && s.Lines[0].ItemIDs[0] == "SKU-1"
+		})).Return(nil).Once()
+
+		reference := "  ASN-1 "
+		lines := []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 2, ItemIDs: []string{" SKU-1 "}}}
+		shipment, err := shipmentService.CreateShipment(ctx, testPvzID, &reference, lines)
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, shipment.ID)
+	})
+
+	t.Run("Fail_PVZ_NotFound", func(t *testing.T) {
+		mockPVZRepo := mocks.NewPVZRepository(t)
+		shipmentService := service.NewShipmentService(logger, mockPVZRepo, mocks.NewReceptionRepository(t), mocks.NewExpectedShipmentRepository(t))
+		mockPVZRepo.On("GetByID", mock.Anything, testPvzID).Return(nil, domain.ErrNotFound).Once()
+
+		_, err := shipmentService.CreateShipment(ctx, testPvzID, nil, []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 1}})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+
+	invalid := []struct {
+		name  string
+		lines []domain.ExpectedShipmentLine
+	}{
+		{name: "No_Lines", lines: nil},
+		{name: "Invalid_Type", lines: []domain.ExpectedShipmentLine{{Type: "мебель", Quantity: 1}}},
+		{name: "Duplicate_Type", lines: []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 1}, {Type: domain.TypeShoes, Quantity: 1}}},
+		{name: "Zero_Quantity", lines: []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 0}}},
+		{name: "More_Items_Than_Quantity", lines: []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 1, ItemIDs: []string{"a", "b"}}}},
+		{name: "Duplicate_Item", lines: []domain.ExpectedShipmentLine{
+			{Type: domain.TypeShoes, Quantity: 1, ItemIDs: []string{"a"}},
+			{Type: domain.TypeClothing, Quantity: 1, ItemIDs: []string{"a"}},
+		}},
+		{name: "Empty_Item", lines: []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 1, ItemIDs: []string{" "}}}},
+		{name: "Too_Long_Item", lines: []domain.ExpectedShipmentLine{{Type: domain.TypeShoes, Quantity: 1, ItemIDs: []string{strings.Repeat("x", 101)}}}},
+	}
+	for _, tc := range invalid {
+		t.Run("Fail_"+tc.name, func(t *testing.T) {
+			shipmentService := service.NewShipmentService(logger, mocks.NewPVZRepository(t), mocks.NewReceptionRepository(t), mocks.NewExpectedShipmentRepository(t))
+
+			_, err := shipmentService.CreateShipment(ctx, testPvzID, nil, tc.lines)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+		})
+	}
+}
+
+func TestShipmentService_GetReconciliation(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	receptionID := uuid.New()
+	shipmentID := uuid.New()
+	itemID := func(s string) *string { return &s }
+
+	shipment := &domain.ExpectedShipment{
+		ID: shipmentID,
+		Lines: []domain.ExpectedShipmentLine{
+			{Type: domain.TypeShoes, Quantity: 2, ItemIDs: []string{"S-1", "S-2"}},
+			{Type: domain.TypeClothing, Quantity: 1, ItemIDs: []string{"C-1"}},
+		},
+	}
+	products := []domain.Product{
+		{ID: uuid.New(), Type: domain.TypeShoes, ItemID: itemID("S-1")},
+		{ID: uuid.New(), Type: domain.TypeElectronics, ItemID: itemID("C-1")},
+		{ID: uuid.New(), Type: domain.TypeElectronics, ItemID: itemID("E-9")},
+	}
+
+	t.Run("Computes_For_Open_Reception", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+		shipmentService := service.NewShipmentService(logger, mocks.NewPVZRepository(t), mockReceptionRepo, mockShipmentRepo)
+
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, receptionID).Return(&domain.ReceptionWithProducts{
+			Reception: domain.Reception{ID: receptionID, Status: domain.StatusInProgress, ExpectedShipmentID: &shipmentID},
+			Products:  products,
+		}, nil).Once()
+		mockShipmentRepo.On("GetByID", mock.Anything, shipmentID).Return(shipment, nil).Once()
+
+		rec, err := shipmentService.GetReconciliation(ctx, receptionID)
+		require.NoError(t, err)
+		assert.False(t, rec.Final)
+		assert.Equal(t, domain.ReconciliationDiscrepancy, rec.Status)
+		assert.Equal(t, []domain.ReconciliationLine{
+			{Type: domain.TypeShoes, Expected: 2, Received: 1, Missing: 1},
+			{Type: domain.TypeClothing, Expected: 1, Received: 0, Missing: 1},
+			{Type: domain.TypeElectronics, Expected: 0, Received: 2, Extra: 2},
+		}, rec.Lines)
+		require.Len(t, rec.MissingItems, 1)
+		assert.Equal(t, "S-2", rec.MissingItems[0].ItemID)
+		require.Len(t, rec.ExtraItems, 1)
+		assert.Equal(t, "E-9", rec.ExtraItems[0].ItemID)
+		require.Len(t, rec.MismatchedItems, 1)
+		assert.Equal(t, domain.MismatchedItem{
+			ItemID: "C-1", ProductID: products[1].ID, ExpectedType: domain.TypeClothing, ActualType: domain.TypeElectronics,
+		}, rec.MismatchedItems[0])
+		mockShipmentRepo.AssertNotCalled(t, "SaveReconciliation", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Computes_And_Saves_For_Closed_Reception", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+		shipmentService := service.NewShipmentService(logger, mocks.NewPVZRepository(t), mockReceptionRepo, mockShipmentRepo)
+
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, receptionID).Return(&domain.ReceptionWithProducts{
+			Reception: domain.Reception{ID: receptionID, Status: domain.StatusClosed, ExpectedShipmentID: &shipmentID},
+			Products: []domain.Product{
+				{ID: uuid.New(), Type: domain.TypeShoes, ItemID: itemID("S-1")},
+				{ID: uuid.New(), Type: domain.TypeShoes, ItemID: itemID("S-2")},
+				{ID: uuid.New(), Type: domain.TypeClothing},
+			},
+		}, nil).Once()
+		mockShipmentRepo.On("GetByID", mock.Anything, shipmentID).Return(shipment, nil).Once()
+		mockShipmentRepo.On("SaveReconciliation", mock.Anything, shipmentID, mock.AnythingOfType("domain.Reconciliation")).Return(nil).Once()
+
+		rec, err := shipmentService.GetReconciliation(ctx, receptionID)
+		require.NoError(t, err)
+		assert.True(t, rec.Final)
+		// C-1 принят без идентификатора: по количеству совпадает, но единица числится недостающей.
+		assert.Equal(t, domain.ReconciliationDiscrepancy, rec.Status)
+		require.Len(t, rec.MissingItems, 1)
+		assert.Equal(t, "C-1", rec.MissingItems[0].ItemID)
+		for _, line := range rec.Lines {
+			assert.Zero(t, line.Missing)
+			assert.Zero(t, line.Extra)
+		}
+	})
+
+	t.Run("Returns_Stored_For_Closed_Reception", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+		shipmentService := service.NewShipmentService(logger, mocks.NewPVZRepository(t), mockReceptionRepo, mockShipmentRepo)
+
+		stored := &domain.Reconciliation{ShipmentID: shipmentID, ReceptionID: receptionID, Status: domain.ReconciliationMatched, Final: true}
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, receptionID).Return(&domain.ReceptionWithProducts{
+			Reception: domain.Reception{ID: receptionID, Status: domain.StatusClosed, ExpectedShipmentID: &shipmentID},
+		}, nil).Once()
+		mockShipmentRepo.On("GetByID", mock.Anything, shipmentID).Return(&domain.ExpectedShipment{ID: shipmentID, Reconciliation: stored}, nil).Once()
+
+		rec, err := shipmentService.GetReconciliation(ctx, receptionID)
+		require.NoError(t, err)
+		assert.Equal(t, stored, rec)
+	})
+
+	t.Run("Fail_No_Expected_Shipment", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		shipmentService := service.NewShipmentService(logger, mocks.NewPVZRepository(t), mockReceptionRepo, mocks.NewExpectedShipmentRepository(t))
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, receptionID).Return(&domain.ReceptionWithProducts{
+			Reception: domain.Reception{ID: receptionID, Status: domain.StatusInProgress},
+		}, nil).Once()
+
+		_, err := shipmentService.GetReconciliation(ctx, receptionID)
+		assert.ErrorIs(t, err, domain.ErrNoExpectedShipment)
+	})
+
+	t.Run("Fail_Reception_NotFound", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		shipmentService := service.NewShipmentService(logger, mocks.NewPVZRepository(t), mockReceptionRepo, mocks.NewExpectedShipmentRepository(t))
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, receptionID).Return(nil, domain.ErrNotFound).Once()
+
+		_, err := shipmentService.GetReconciliation(ctx, receptionID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Fail_Save_Error", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		mockShipmentRepo := mocks.NewExpectedShipmentRepository(t)
+		shipmentService := service.NewShipmentService(logger, mocks.NewPVZRepository(t), mockReceptionRepo, mockShipmentRepo)
+
+		mockReceptionRepo.On("GetWithProducts", mock.Anything, receptionID).Return(&domain.ReceptionWithProducts{
+			Reception: domain.Reception{ID: receptionID, Status: domain.StatusClosed, ExpectedShipmentID: &shipmentID},
+		}, nil).Once()
+		mockShipmentRepo.On("GetByID", mock.Anything, shipmentID).Return(shipment, nil).Once()
+		mockShipmentRepo.On("SaveReconciliation", mock.Anything, shipmentID, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		_, err := shipmentService.GetReconciliation(ctx, receptionID)
+		assert.ErrorIs(t, err, domain.ErrDatabaseError)
+	})
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/tracing"
+)
+
+const (
+	// defaultStatsPeriod - период статистики, если начальная дата не указана.
+	defaultStatsPeriod = 30 * 24 * time.Hour
+	// maxDailyStatsRange - максимальный диапазон при группировке по дням, чтобы ответ оставался обозримым.
+	maxDailyStatsRange = 366 * 24 * time.Hour
+)
+
+// StatsService реализует интерфейс domain.StatsService.
+type StatsService struct {
+	log       *slog.Logger
+	statsRepo domain.StatsRepository // Зависимость для расчета агрегатов
+}
+
+// NewStatsService создает новый экземпляр StatsService.
+func NewStatsService(log *slog.Logger, statsRepo domain.StatsRepository) *StatsService {
+	return &StatsService{
+		log:       log,
+		statsRepo: statsRepo,
+	}
+}
+
+// ReceptionStats проверяет параметры периода и возвращает агрегаты приемок.
+// Пустая конечная дата означает "сейчас", пустая начальная - 30 дней до конечной, пустой шаг - группировку по дням.
+func (s *StatsService) ReceptionStats(ctx context.Context, filter domain.ReceptionStatsFilter) (*domain.ReceptionStats, error) {
+	const op = "StatsService.ReceptionStats"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	if filter.EndDate.IsZero() {
+		filter.EndDate = time.Now().UTC()
+	}
+	if filter.StartDate.IsZero() {
+		filter.StartDate = filter.EndDate.Add(-defaultStatsPeriod)
+	}
+	if filter.GroupBy == "" {
+		filter.GroupBy = domain.GroupByDay
+	}
+
+	if !filter.GroupBy.IsValid() {
+		log.Warn("Invalid groupBy value", slog.String("group_by", string(filter.GroupBy)))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("groupBy", "oneof", "day, week, month"))
+	}
+	if !filter.StartDate.Before(filter.EndDate) {
+		log.Warn("Invalid stats period", slog.Time("start_date", filter.StartDate), slog.Time("end_date", filter.EndDate))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewFieldError("startDate", "must be before endDate"))
+	}
+	if filter.GroupBy == domain.GroupByDay && filter.EndDate.Sub(filter.StartDate) > maxDailyStatsRange {
+		log.Warn("Stats period too long for daily grouping", slog.Time("start_date", filter.StartDate), slog.Time("end_date", filter.EndDate))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewFieldError("groupBy", "period longer than a year requires week or month"))
+	}
+
+	log = log.With(
+		slog.Time("start_date", filter.StartDate),
+		slog.Time("end_date", filter.EndDate),
+		slog.String("group_by", string(filter.GroupBy)),
+	)
+
+	stats, err := s.statsRepo.ReceptionStats(ctx, filter)
+	if err != nil {
+		log.Error("Failed to calculate reception stats", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	stats.StartDate = filter.StartDate
+	stats.EndDate = filter.EndDate
+	stats.GroupBy = filter.GroupBy
+
+	log.Info("Reception stats calculated", slog.Int("pvz_rows", len(stats.ByPVZ)), slog.Int("city_rows", len(stats.ByCity)))
+	return stats, nil
+}
+
+// PVZActivity проверяет период и возвращает активность ПВЗ.
+// Значения периода по умолчанию такие же, как у ReceptionStats.
+func (s *StatsService) PVZActivity(ctx context.Context, filter domain.PVZActivityFilter) ([]domain.PVZActivityRow, error) {
+	const op = "StatsService.PVZActivity"
+	ctx, span := tracing.StartSpan(ctx, op)
+	defer span.End()
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	if filter.EndDate.IsZero() {
+		filter.EndDate = time.Now().UTC()
+	}
+	if filter.StartDate.IsZero() {
+		filter.StartDate = filter.EndDate.Add(-defaultStatsPeriod)
+	}
+
+	if !filter.StartDate.Before(filter.EndDate) {
+		log.Warn("Invalid activity period", slog.Time("start_date", filter.StartDate), slog.Time("end_date", filter.EndDate))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewFieldError("startDate", "must be before endDate"))
+	}
+	if filter.City != nil && !filter.City.IsValid() {
+		log.Warn("Invalid city for activity", slog.String("city", string(*filter.City)))
+		return nil, fmt.Errorf("%s: %w", op, domain.NewRuleError("city", "invalid", string(*filter.City)))
+	}
+
+	rows, err := s.statsRepo.PVZActivity(ctx, filter)
+	if err != nil {
+		log.Error("Failed to calculate pvz activity", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseError, err)
+	}
+
+	log.Info("PVZ activity calculated", slog.Int("rows", len(rows)))
+	return rows, nil
+}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/service"
+	"pvz-service-avito-internship/mocks"
+)
+
+func TestStatsService_ReceptionStats(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	startDate := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	pvzID := uuid.New()
+	repoStats := &domain.ReceptionStats{
+		ByPVZ: []domain.ReceptionStatsRow{{
+			Period: startDate, City: domain.Moscow, PVZID: &pvzID, ReceptionsCount: 2,
+			ProductsCount: 3, ProductsByType: map[domain.ProductType]int{domain.TypeShoes: 3},
+		}},
+		ByCity: []domain.ReceptionStatsRow{{
+			Period: startDate, City: domain.Moscow, ReceptionsCount: 2,
+			ProductsCount: 3, ProductsByType: map[domain.ProductType]int{domain.TypeShoes: 3},
+		}},
+	}
+
+	testCases := []struct {
+		name          string
+		filter        domain.ReceptionStatsFilter
+		setupMocks    func(repo *mocks.StatsRepository)
+		expectedError error
+	}{
+		{
+			name:   "Success",
+			filter: domain.ReceptionStatsFilter{StartDate: startDate, EndDate: endDate, GroupBy: domain.GroupByWeek},
+			setupMocks: func(repo *mocks.StatsRepository) {
+				repo.On("ReceptionStats", mock.Anything, domain.ReceptionStatsFilter{StartDate: startDate, EndDate: endDate, GroupBy: domain.GroupByWeek}).
+					Return(repoStats, nil).Once()
+			},
+		},
+		{
+			name:   "Success_Defaults",
+			filter: domain.ReceptionStatsFilter{EndDate: endDate},
+			setupMocks: func(repo *mocks.StatsRepository) {
+				repo.On("ReceptionStats", mock.Anything, domain.ReceptionStatsFilter{StartDate: endDate.Add(-30 * 24 * time.Hour), EndDate: endDate, GroupBy: domain.GroupByDay}).
+					Return(&domain.ReceptionStats{}, nil).Once()
+			},
+		},
+		{
+			name:          "Fail_Invalid_GroupBy",
+			filter:        domain.ReceptionStatsFilter{StartDate: startDate, EndDate: endDate, GroupBy: "year"},
+			setupMocks:    func(repo *mocks.StatsRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Start_After_End",
+			filter:        domain.ReceptionStatsFilter{StartDate: endDate, EndDate: startDate},
+			setupMocks:    func(repo *mocks.StatsRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:          "Fail_Daily_Range_Too_Long",
+			filter:        domain.ReceptionStatsFilter{StartDate: startDate.AddDate(-2, 0, 0), EndDate: endDate, GroupBy: domain.GroupByDay},
+			setupMocks:    func(repo *mocks.StatsRepository) {},
+			expectedError: domain.ErrValidation,
+		},
+		{
+			name:   "Fail_Repo_Error",
+			filter: domain.ReceptionStatsFilter{StartDate: startDate, EndDate: endDate, GroupBy: domain.GroupByMonth},
+			setupMocks: func(repo *mocks.StatsRepository) {
+				repo.On("ReceptionStats", mock.Anything, mock.Anything).Return(nil, errors.New("some db error")).Once()
+			},
+			expectedError: domain.ErrDatabaseError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStatsRepo := mocks.NewStatsRepository(t)
+			tc.setupMocks(mockStatsRepo)
+			statsService := service.NewStatsService(logger, mockStatsRepo)
+
+			stats, err := statsService.ReceptionStats(ctx, tc.filter)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, stats)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, stats)
+			assert.False(t, stats.StartDate.IsZero())
+			assert.Equal(t, tc.filter.EndDate, stats.EndDate)
+			assert.True(t, stats.GroupBy.IsValid())
+		})
+	}
+}
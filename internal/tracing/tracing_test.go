@@ -0,0 +1,49 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/tracing"
+)
+
+func TestSetup(t *testing.T) {
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	t.Run("UnknownExporter", func(t *testing.T) {
+		_, err := tracing.Setup(context.Background(), config.Tracing{Exporter: "jaeger", SampleRatio: 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidSampleRatio", func(t *testing.T) {
+		_, err := tracing.Setup(context.Background(), config.Tracing{Exporter: tracing.ExporterNone, SampleRatio: 1.5})
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		shutdown, err := tracing.Setup(context.Background(), config.Tracing{Exporter: tracing.ExporterNone, ServiceName: "pvz-test", SampleRatio: 1})
+		require.NoError(t, err)
+
+		ctx, span := tracing.StartSpan(context.Background(), "op")
+		traceID := tracing.TraceID(ctx)
+		span.End()
+
+		assert.Len(t, traceID, 32)
+		assert.Equal(t, span.SpanContext().TraceID().String(), traceID)
+		assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+		assert.NoError(t, shutdown(context.Background()))
+	})
+}
+
+func TestTraceID_NoSpan(t *testing.T) {
+	assert.Empty(t, tracing.TraceID(context.Background()))
+}
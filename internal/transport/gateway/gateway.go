@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"pvz-service-avito-internship/internal/middleware"
+	pb "pvz-service-avito-internship/pkg/grpc/pvz/v1"
+)
+
+// PathPrefix - префикс, под которым HTTP/JSON шлюз монтируется в основной роутер.
+const PathPrefix = "/v2"
+
+// Gateway транслирует HTTP/JSON запросы в вызовы gRPC сервера.
+// Вызовы идут через полноценное gRPC соединение, поэтому к ним применяется та же цепочка
+// интерцепторов (авторизация, логирование, метрики), что и к прямым gRPC клиентам.
+type Gateway struct {
+	log  *slog.Logger
+	mux  *runtime.ServeMux
+	conn *grpc.ClientConn
+}
+
+// New создает шлюз, проксирующий запросы на gRPC сервер по адресу grpcAddr.
+// Соединение устанавливается лениво при первом запросе.
+func New(ctx context.Context, log *slog.Logger, grpcAddr string, dialOpts ...grpc.DialOption) (*Gateway, error) {
+	const op = "gateway.New"
+
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithMetadata(requestIDMetadata),
+	)
+
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	// Клиентский span передает контекст трассировки HTTP запроса в gRPC сервер (traceparent в метаданных).
+	dialOpts = append(dialOpts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+	conn, err := grpc.NewClient(grpcAddr, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create gRPC client: %w", op, err)
+	}
+
+	if err := pb.RegisterPVZServiceHandler(ctx, mux, conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("%s: failed to register PVZService handler: %w", op, err)
+	}
+
+	log.Info("HTTP/JSON gateway configured", slog.String("op", op), slog.String("grpc_addr", grpcAddr), slog.String("prefix", PathPrefix))
+
+	return &Gateway{
+		log:  log,
+		mux:  mux,
+		conn: conn,
+	}, nil
+}
+
+// ServeHTTP реализует http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// Close закрывает соединение с gRPC сервером.
+func (g *Gateway) Close() error {
+	return g.conn.Close()
+}
+
+// requestIDMetadata передает Request ID, выданный HTTP middleware, в gRPC вызов,
+// чтобы логи шлюза и gRPC сервера были связаны одним идентификатором.
+func requestIDMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	if requestID := middleware.GetRequestIDFromContext(r.Context()); requestID != "" {
+		return metadata.Pairs("x-request-id", requestID)
+	}
+	return nil
+}
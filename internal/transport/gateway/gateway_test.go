@@ -0,0 +1,119 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/transport/gateway"
+	grpcTransport "pvz-service-avito-internship/internal/transport/grpc"
+	"pvz-service-avito-internship/mocks"
+	"pvz-service-avito-internship/pkg/requestid"
+)
+
+type okPinger struct{}
+
+func (okPinger) Ping(_ context.Context) error { return nil }
+
+func setupGateway(t *testing.T, pvzRepo domain.PVZRepository) *gateway.Gateway {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	metricsCollector := mocks.NewMetricsCollector(t)
+	metricsCollector.On("IncGRPCRequestsTotal", mock.Anything, mock.Anything).Maybe()
+	metricsCollector.On("ObserveGRPCRequestDuration", mock.Anything, mock.Anything).Maybe()
+
+	server := grpcTransport.NewServer(logger, pvzRepo, mocks.NewReceptionService(t), mocks.NewProductService(t),
+		metricsCollector, nil, okPinger{}, "test-secret", config.GRPCServer{
+			Port:                "0",
+			MaxRecvMsgSize:      4 << 20,
+			MaxSendMsgSize:      4 << 20,
+			HealthCheckInterval: time.Second,
+			HealthCheckTimeout:  time.Second,
+			ShutdownTimeout:     time.Second,
+		})
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	gw, err := gateway.New(context.Background(), logger, "passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = gw.Close() })
+
+	return gw
+}
+
+func TestGateway_GetPVZList(t *testing.T) {
+	mockPVZRepo := mocks.NewPVZRepository(t)
+	gw := setupGateway(t, mockPVZRepo)
+
+	pvzID := uuid.New()
+	registeredAt := time.Date(2025, 4, 18, 10, 0, 0, 0, time.UTC)
+	const requestID = "gateway-req-1"
+
+	mockPVZRepo.On("ListAll", mock.MatchedBy(func(ctx context.Context) bool {
+		return middleware.GetRequestIDFromContext(ctx) == requestID
+	})).Return([]domain.PVZ{{ID: pvzID, RegistrationDate: registeredAt, City: domain.Moscow}}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, gateway.PathPrefix+"/pvz", nil)
+	req = req.WithContext(requestid.NewContext(req.Context(), requestID))
+	rec := httptest.NewRecorder()
+
+	gw.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Pvzs []struct {
+			ID               string    `json:"id"`
+			RegistrationDate time.Time `json:"registrationDate"`
+			City             string    `json:"city"`
+		} `json:"pvzs"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Pvzs, 1)
+	assert.Equal(t, pvzID.String(), body.Pvzs[0].ID)
+	assert.True(t, registeredAt.Equal(body.Pvzs[0].RegistrationDate))
+	assert.Equal(t, string(domain.Moscow), body.Pvzs[0].City)
+}
+
+func TestGateway_MapsGRPCErrors(t *testing.T) {
+	mockPVZRepo := mocks.NewPVZRepository(t)
+	gw := setupGateway(t, mockPVZRepo)
+
+	mockPVZRepo.On("ListAll", mock.Anything).Return(nil, domain.ErrDatabaseError).Once()
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, gateway.PathPrefix+"/pvz", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestGateway_UnknownRoute(t *testing.T) {
+	gw := setupGateway(t, mocks.NewPVZRepository(t))
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, gateway.PathPrefix+"/unknown", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "pvz-service-avito-internship/pkg/grpc/pvz/v1"
+)
+
+// DBPinger - зависимость, по доступности которой определяется статус gRPC health сервиса.
+// Реализуется *pgxpool.Pool.
+type DBPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// healthChecker периодически пингует БД и обновляет статус стандартного сервиса grpc.health.v1.
+type healthChecker struct {
+	log      *slog.Logger
+	server   *health.Server
+	pinger   DBPinger
+	interval time.Duration
+	timeout  time.Duration
+	stopCh   chan struct{}
+}
+
+func newHealthChecker(log *slog.Logger, pinger DBPinger, interval, timeout time.Duration) *healthChecker {
+	hs := health.NewServer()
+	// До первой проверки считаем сервер не готовым.
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(pb.PVZService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return &healthChecker{
+		log:      log,
+		server:   hs,
+		pinger:   pinger,
+		interval: interval,
+		timeout:  timeout,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// run выполняет проверки до вызова shutdown. Блокирующий.
+func (h *healthChecker) run() {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	h.check()
+	for {
+		select {
+		case <-h.stopCh:
+			return
+		case <-ticker.C:
+			h.check()
+		}
+	}
+}
+
+func (h *healthChecker) check() {
+	const op = "GRPCHealthChecker.Check"
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := h.pinger.Ping(ctx); err != nil {
+		h.log.Warn("Database ping failed, reporting NOT_SERVING", slog.String("op", op), slog.String("error", err.Error()))
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	h.server.SetServingStatus("", status)
+	h.server.SetServingStatus(pb.PVZService_ServiceDesc.ServiceName, status)
+}
+
+// shutdown переводит все сервисы в NOT_SERVING и останавливает проверки.
+// После вызова статус больше не меняется.
+func (h *healthChecker) shutdown() {
+	select {
+	case <-h.stopCh:
+	default:
+		close(h.stopCh)
+	}
+	h.server.Shutdown()
+}
@@ -0,0 +1,101 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	grpcTransport "pvz-service-avito-internship/internal/transport/grpc"
+	"pvz-service-avito-internship/mocks"
+)
+
+func setupHealthServer(t *testing.T, pinger *stubPinger) (*grpcTransport.Server, healthpb.HealthClient) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cfg := testGRPCConfig()
+	cfg.ShutdownTimeout = 100 * time.Millisecond
+	server := grpcTransport.NewServer(logger, mocks.NewPVZRepository(t), mocks.NewReceptionService(t), mocks.NewProductService(t),
+		newMetricsCollector(t), nil, pinger, testJWTSecret, cfg)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return server, healthpb.NewHealthClient(conn)
+}
+
+func servingStatus(client healthpb.HealthClient, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN
+	}
+	return resp.GetStatus()
+}
+
+func TestHealth_FollowsDatabase(t *testing.T) {
+	pinger := &stubPinger{}
+	server, client := setupHealthServer(t, pinger)
+	t.Cleanup(server.Stop)
+
+	assert.Eventually(t, func() bool {
+		return servingStatus(client, "pvz.v1.PVZService") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	pinger.fail.Store(true)
+	assert.Eventually(t, func() bool {
+		return servingStatus(client, "") == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	pinger.fail.Store(false)
+	assert.Eventually(t, func() bool {
+		return servingStatus(client, "") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHealth_StopReportsNotServingBeforeDrain(t *testing.T) {
+	server, client := setupHealthServer(t, &stubPinger{})
+
+	require.Eventually(t, func() bool {
+		return servingStatus(client, "") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	// Открытый Watch стрим не дает GracefulStop завершиться, поэтому Stop должен сработать по таймауту.
+	watch, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{Service: ""})
+	require.NoError(t, err)
+	resp, err := watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	stopped := make(chan struct{})
+	go func() {
+		server.Stop()
+		close(stopped)
+	}()
+
+	resp, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not fall back to forced stop after shutdown timeout")
+	}
+}
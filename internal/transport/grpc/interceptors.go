@@ -0,0 +1,395 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"pvz-service-avito-internship/internal/apperror"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/ratelimit"
+	"pvz-service-avito-internship/internal/tracing"
+	"pvz-service-avito-internship/pkg/jwt"
+	"pvz-service-avito-internship/pkg/requestid"
+)
+
+const (
+	// authorizationMetadataKey - ключ метаданных с JWT токеном (аналог HTTP заголовка Authorization).
+	authorizationMetadataKey = "authorization"
+	// requestIDMetadataKey - ключ метаданных с Request ID (аналог HTTP заголовка X-Request-ID).
+	requestIDMetadataKey = "x-request-id"
+)
+
+// wrappedStream подменяет контекст серверного стрима, чтобы интерцепторы могли передать
+// обогащенный контекст (Request ID, пользователь) в обработчик.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
+
+// --- Request ID ---
+
+// RequestIDUnaryInterceptor берет Request ID из метаданных x-request-id, если он корректен (см. requestid.Valid),
+// иначе использует ID трассировки вызова. Request ID кладется в контекст и возвращается клиенту в заголовках ответа.
+func RequestIDUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(contextWithRequestID(ctx), req)
+	}
+}
+
+// RequestIDStreamInterceptor - stream-версия RequestIDUnaryInterceptor.
+func RequestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: contextWithRequestID(ss.Context())})
+	}
+}
+
+func contextWithRequestID(ctx context.Context) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 && requestid.Valid(values[0]) {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = tracing.TraceID(ctx)
+	}
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+	return requestid.NewContext(ctx, requestID)
+}
+
+// --- Logging ---
+
+// LoggingUnaryInterceptor пишет access-лог для каждого unary вызова, аналогично LoggingMiddleware.
+func LoggingUnaryInterceptor(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, log, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// LoggingStreamInterceptor - stream-версия LoggingUnaryInterceptor.
+func LoggingStreamInterceptor(log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), log, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(ctx context.Context, log *slog.Logger, fullMethod string, start time.Time, err error) {
+	code := status.Code(err)
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	requestLogger := log.With(
+		slog.String("request_id", middleware.GetRequestIDFromContext(ctx)),
+		slog.String("method", fullMethod),
+		slog.String("remote_ip", remoteAddr),
+	)
+	logArgs := []any{
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if err != nil {
+		logArgs = append(logArgs, slog.String("error", err.Error()))
+	}
+
+	switch code {
+	case codes.OK:
+		requestLogger.Info("gRPC call completed successfully", logArgs...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		requestLogger.Error("gRPC call completed with server error", logArgs...)
+	default:
+		requestLogger.Warn("gRPC call completed with client error", logArgs...)
+	}
+}
+
+// --- Metrics ---
+
+// MetricsUnaryInterceptor собирает метрики количества вызовов и времени выполнения по каждому методу.
+func MetricsUnaryInterceptor(collector domain.MetricsCollector) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeCall(collector, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor - stream-версия MetricsUnaryInterceptor.
+func MetricsStreamInterceptor(collector domain.MetricsCollector) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeCall(collector, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeCall(collector domain.MetricsCollector, fullMethod string, start time.Time, err error) {
+	collector.IncGRPCRequestsTotal(fullMethod, status.Code(err).String())
+	collector.ObserveGRPCRequestDuration(fullMethod, time.Since(start).Seconds())
+}
+
+// --- Recovery ---
+
+// RecoveryUnaryInterceptor перехватывает панику в обработчике, логирует ее со стектрейсом
+// и возвращает клиенту codes.Internal. Аналог middleware.Recovery для gRPC.
+func RecoveryUnaryInterceptor(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(ctx, log, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor - stream-версия RecoveryUnaryInterceptor.
+func RecoveryStreamInterceptor(log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(ss.Context(), log, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recoverPanic(ctx context.Context, log *slog.Logger, fullMethod string, r any) error {
+	log.Error("Panic recovered",
+		slog.String("request_id", middleware.GetRequestIDFromContext(ctx)),
+		slog.String("method", fullMethod),
+		slog.Any("error", r),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return apperror.NewGRPCError(codes.Internal, apperror.CodeInternal, "internal server error")
+}
+
+// --- Rate Limit ---
+
+// Ключи метаданных ответа с состоянием ограничения частоты (аналоги HTTP заголовков RateLimit-* и Retry-After).
+const (
+	rateLimitLimitMetadataKey     = "ratelimit-limit"
+	rateLimitRemainingMetadataKey = "ratelimit-remaining"
+	rateLimitResetMetadataKey     = "ratelimit-reset"
+	retryAfterMetadataKey         = "retry-after"
+	// forwardedForMetadataKey - адрес клиента, который добавляет HTTP/JSON шлюз.
+	forwardedForMetadataKey = "x-forwarded-for"
+)
+
+// RateLimitInterceptor ограничивает частоту вызовов gRPC методов по политикам ratelimit.Limiter.
+// Должен стоять после AuthInterceptor, чтобы вызовы считались по пользователю.
+type RateLimitInterceptor struct {
+	log     *slog.Logger
+	limiter *ratelimit.Limiter
+	metrics domain.MetricsCollector
+}
+
+// NewRateLimitInterceptor создает новый экземпляр RateLimitInterceptor. Если limiter равен nil, вызовы не ограничиваются.
+func NewRateLimitInterceptor(log *slog.Logger, limiter *ratelimit.Limiter, metrics domain.MetricsCollector) *RateLimitInterceptor {
+	return &RateLimitInterceptor{log: log, limiter: limiter, metrics: metrics}
+}
+
+// Unary возвращает unary интерцептор, ограничивающий частоту вызовов.
+func (i *RateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := i.allow(ctx, info.FullMethod, func(md metadata.MD) { _ = grpc.SetHeader(ctx, md) }); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream возвращает stream интерцептор, ограничивающий частоту вызовов.
+func (i *RateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.allow(ss.Context(), info.FullMethod, func(md metadata.MD) { _ = ss.SetHeader(md) }); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (i *RateLimitInterceptor) allow(ctx context.Context, fullMethod string, setHeader func(metadata.MD)) error {
+	const op = "GRPCInterceptor.RateLimit"
+
+	if i.limiter == nil {
+		return nil
+	}
+
+	identity := ratelimit.Identity{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(strings.ToLower(i.limiter.APIKeyHeader())); len(values) > 0 {
+		identity.APIKey = values[0]
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		identity.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(identity.IP); err == nil {
+			identity.IP = host
+		}
+	}
+	// HTTP/JSON шлюз вызывает сервер через loopback и передает адрес клиента в x-forwarded-for.
+	// Метаданным от других клиентов не доверяем, чтобы адрес нельзя было подменить.
+	if ip := net.ParseIP(identity.IP); ip != nil && ip.IsLoopback() {
+		if values := md.Get(forwardedForMetadataKey); len(values) > 0 {
+			if client := strings.TrimSpace(strings.Split(values[0], ",")[0]); client != "" {
+				identity.IP = client
+			}
+		}
+	}
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		identity.UserID = userID.String()
+	}
+
+	result, limited := i.limiter.Allow(ctx, fullMethod, identity)
+	if !limited {
+		return nil
+	}
+
+	header := metadata.Pairs(
+		rateLimitLimitMetadataKey, strconv.Itoa(result.Limit),
+		rateLimitRemainingMetadataKey, strconv.Itoa(result.Remaining),
+		rateLimitResetMetadataKey, strconv.Itoa(int(math.Ceil(result.ResetAfter.Seconds()))),
+	)
+	if result.Allowed {
+		setHeader(header)
+		return nil
+	}
+
+	i.log.Warn("Rate limit exceeded",
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetRequestIDFromContext(ctx)),
+		slog.String("method", fullMethod),
+		slog.String("key", result.Key),
+	)
+	i.metrics.IncRateLimited("grpc", fullMethod, result.Key)
+	header.Set(retryAfterMetadataKey, strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+	setHeader(header)
+	return apperror.NewGRPCError(codes.ResourceExhausted, apperror.CodeRateLimitExceeded, "rate limit exceeded")
+}
+
+// --- Auth ---
+
+// AuthInterceptor проверяет JWT токен и роль пользователя для защищенных gRPC методов.
+// Методы, отсутствующие в methodRoles, считаются публичными.
+type AuthInterceptor struct {
+	log         *slog.Logger
+	jwtSecret   string
+	methodRoles map[string][]domain.UserRole
+}
+
+// NewAuthInterceptor создает новый экземпляр AuthInterceptor.
+func NewAuthInterceptor(log *slog.Logger, jwtSecret string, methodRoles map[string][]domain.UserRole) *AuthInterceptor {
+	return &AuthInterceptor{
+		log:         log,
+		jwtSecret:   jwtSecret,
+		methodRoles: methodRoles,
+	}
+}
+
+// Unary возвращает unary интерцептор, выполняющий аутентификацию и проверку роли.
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream возвращает stream интерцептор, выполняющий аутентификацию и проверку роли.
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *AuthInterceptor) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	const op = "GRPCInterceptor.Authorize"
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := i.log.With(slog.String("op", op), slog.String("request_id", reqID), slog.String("method", fullMethod))
+
+	allowedRoles, protected := i.methodRoles[fullMethod]
+	if !protected {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		log.Warn("Authorization metadata is missing")
+		return nil, apperror.NewGRPCError(codes.Unauthenticated, apperror.CodeUnauthorized, "authorization metadata required")
+	}
+
+	headerParts := strings.Split(values[0], " ")
+	if len(headerParts) != 2 || !strings.EqualFold(headerParts[0], "Bearer") || headerParts[1] == "" {
+		log.Warn("Invalid authorization metadata format")
+		return nil, apperror.NewGRPCError(codes.Unauthenticated, apperror.CodeUnauthorized, "invalid authorization metadata format (Bearer token expected)")
+	}
+
+	claims, err := jwt.ValidateToken(headerParts[1], i.jwtSecret)
+	if err != nil {
+		log.Warn("Invalid or expired token", slog.String("error", err.Error()))
+		return nil, apperror.NewGRPCError(codes.Unauthenticated, apperror.CodeUnauthorized, "invalid or expired token")
+	}
+
+	if !claims.Role.IsValid() {
+		log.Error("Invalid user role found in token claims", slog.String("role", string(claims.Role)), slog.String("user_id", claims.UserID.String()))
+		return nil, apperror.NewGRPCError(codes.Unauthenticated, apperror.CodeUnauthorized, "invalid token claims (role)")
+	}
+
+	if !roleAllowed(claims.Role, allowedRoles) {
+		log.Warn("User role not allowed for this method",
+			slog.String("user_role", string(claims.Role)),
+			slog.String("user_id", claims.UserID.String()),
+		)
+		return nil, apperror.NewGRPCError(codes.PermissionDenied, apperror.CodeForbidden, "access forbidden: required role not met")
+	}
+
+	log.Debug("User authorized successfully", slog.String("user_id", claims.UserID.String()), slog.String("role", string(claims.Role)))
+	return middleware.ContextWithUser(ctx, claims.UserID, claims.Role), nil
+}
+
+func roleAllowed(role domain.UserRole, allowedRoles []domain.UserRole) bool {
+	for _, allowedRole := range allowedRoles {
+		if role == allowedRole {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/ratelimit"
+	grpcTransport "pvz-service-avito-internship/internal/transport/grpc"
+	"pvz-service-avito-internship/mocks"
+	pb "pvz-service-avito-internship/pkg/grpc/pvz/v1"
+)
+
+func TestInterceptors_RequestID(t *testing.T) {
+	mockPVZRepo := mocks.NewPVZRepository(t)
+	client := setupServerWithDeps(t, mockPVZRepo, mocks.NewReceptionService(t), mocks.NewProductService(t))
+
+	t.Run("Propagates_Incoming_ID", func(t *testing.T) {
+		const requestID = "scanner-42-req"
+		mockPVZRepo.On("ListAll", mock.MatchedBy(func(ctx context.Context) bool {
+			return middleware.GetRequestIDFromContext(ctx) == requestID
+		})).Return([]domain.PVZ{}, nil).Once()
+
+		var header metadata.MD
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", requestID)
+		_, err := client.GetPVZList(ctx, &pb.GetPVZListRequest{}, grpc.Header(&header))
+		require.NoError(t, err)
+		assert.Equal(t, []string{requestID}, header.Get("x-request-id"))
+	})
+
+	t.Run("Generates_ID_When_Missing", func(t *testing.T) {
+		mockPVZRepo.On("ListAll", mock.MatchedBy(func(ctx context.Context) bool {
+			return middleware.GetRequestIDFromContext(ctx) != ""
+		})).Return([]domain.PVZ{}, nil).Once()
+
+		var header metadata.MD
+		_, err := client.GetPVZList(context.Background(), &pb.GetPVZListRequest{}, grpc.Header(&header))
+		require.NoError(t, err)
+		require.Len(t, header.Get("x-request-id"), 1)
+		assert.NotEmpty(t, header.Get("x-request-id")[0])
+	})
+
+	t.Run("Replaces_Invalid_ID", func(t *testing.T) {
+		const invalidID = "req 1; level=ERROR"
+		mockPVZRepo.On("ListAll", mock.MatchedBy(func(ctx context.Context) bool {
+			reqID := middleware.GetRequestIDFromContext(ctx)
+			return reqID != "" && reqID != invalidID
+		})).Return([]domain.PVZ{}, nil).Once()
+
+		var header metadata.MD
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", invalidID)
+		_, err := client.GetPVZList(ctx, &pb.GetPVZListRequest{}, grpc.Header(&header))
+		require.NoError(t, err)
+		require.Len(t, header.Get("x-request-id"), 1)
+		assert.NotEqual(t, invalidID, header.Get("x-request-id")[0])
+	})
+}
+
+func TestInterceptors_Recovery(t *testing.T) {
+	mockPVZRepo := mocks.NewPVZRepository(t)
+	client := setupServerWithDeps(t, mockPVZRepo, mocks.NewReceptionService(t), mocks.NewProductService(t))
+
+	mockPVZRepo.On("ListAll", mock.Anything).Run(func(args mock.Arguments) {
+		panic("unexpected nil pointer")
+	}).Once()
+
+	_, err := client.GetPVZList(context.Background(), &pb.GetPVZListRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	mockPVZRepo.On("ListAll", mock.Anything).Return([]domain.PVZ{}, nil).Once()
+	_, err = client.GetPVZList(context.Background(), &pb.GetPVZListRequest{})
+	assert.NoError(t, err, "server must keep serving after a recovered panic")
+}
+
+func TestInterceptors_RateLimit(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockPVZRepo := mocks.NewPVZRepository(t)
+	metricsCollector := newMetricsCollector(t)
+	limiter, err := ratelimit.NewLimiter(logger, ratelimit.NewMemoryStore(), config.RateLimit{
+		APIKeyHeader: "X-API-Key",
+		APIKeys:      []string{"client-1", "client-2"},
+		Policies: []config.RateLimitPolicy{
+			{Routes: []string{pb.PVZService_GetPVZList_FullMethodName}, Rate: 0.001, Burst: 2, Key: ratelimit.KeyAPIKey},
+		},
+	})
+	require.NoError(t, err)
+	server := grpcTransport.NewServer(logger, mockPVZRepo, mocks.NewReceptionService(t), mocks.NewProductService(t),
+		metricsCollector, limiter, &stubPinger{}, testJWTSecret, testGRPCConfig())
+	client := serveBufconn(t, server)
+
+	mockPVZRepo.On("ListAll", mock.Anything).Return([]domain.PVZ{}, nil).Times(3)
+	metricsCollector.On("IncRateLimited", "grpc", pb.PVZService_GetPVZList_FullMethodName, ratelimit.KeyAPIKey).Once()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "client-1")
+
+	for remaining := 1; remaining >= 0; remaining-- {
+		var header metadata.MD
+		_, err := client.GetPVZList(ctx, &pb.GetPVZListRequest{}, grpc.Header(&header))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2"}, header.Get("ratelimit-limit"))
+		assert.Equal(t, []string{strconv.Itoa(remaining)}, header.Get("ratelimit-remaining"))
+	}
+
+	var header metadata.MD
+	_, err = client.GetPVZList(ctx, &pb.GetPVZListRequest{}, grpc.Header(&header))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NotEmpty(t, header.Get("retry-after"))
+
+	// Другой API ключ считается отдельно.
+	otherCtx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "client-2")
+	_, err = client.GetPVZList(otherCtx, &pb.GetPVZListRequest{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,92 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/mocks"
+	pb "pvz-service-avito-internship/pkg/grpc/pvz/v1"
+)
+
+func TestGetPVZList_Pagination(t *testing.T) {
+	registeredAt := time.Date(2025, 4, 18, 10, 0, 0, 0, time.UTC)
+	pvzs := []domain.PVZ{
+		{ID: uuid.New(), RegistrationDate: registeredAt, City: domain.Moscow},
+		{ID: uuid.New(), RegistrationDate: registeredAt.Add(-time.Hour), City: domain.Kazan},
+		{ID: uuid.New(), RegistrationDate: registeredAt.Add(-2 * time.Hour), City: domain.Kazan},
+	}
+
+	t.Run("Empty request returns full list", func(t *testing.T) {
+		mockPVZRepo := mocks.NewPVZRepository(t)
+		client := setupServerWithDeps(t, mockPVZRepo, nil, nil)
+		mockPVZRepo.On("ListAll", mock.Anything).Return(pvzs, nil).Once()
+
+		resp, err := client.GetPVZList(context.Background(), &pb.GetPVZListRequest{})
+		require.NoError(t, err)
+		assert.Len(t, resp.GetPvzs(), 3)
+		assert.Empty(t, resp.GetNextCursor())
+	})
+
+	t.Run("Pages follow next_cursor", func(t *testing.T) {
+		mockPVZRepo := mocks.NewPVZRepository(t)
+		client := setupServerWithDeps(t, mockPVZRepo, nil, nil)
+
+		mockPVZRepo.On("List", mock.Anything, domain.PVZListFilter{Limit: 3}).Return(pvzs, nil).Once()
+		resp, err := client.GetPVZList(context.Background(), &pb.GetPVZListRequest{PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, resp.GetPvzs(), 2)
+		assert.Equal(t, pvzs[1].ID.String(), resp.GetPvzs()[1].GetId())
+		require.NotEmpty(t, resp.GetNextCursor())
+
+		mockPVZRepo.On("List", mock.Anything, mock.MatchedBy(func(filter domain.PVZListFilter) bool {
+			return filter.Limit == 3 && filter.After != nil && filter.After.ID == pvzs[1].ID &&
+				filter.After.RegistrationDate.Equal(pvzs[1].RegistrationDate)
+		})).Return(pvzs[2:], nil).Once()
+		resp, err = client.GetPVZList(context.Background(), &pb.GetPVZListRequest{PageSize: 2, Cursor: resp.GetNextCursor()})
+		require.NoError(t, err)
+		require.Len(t, resp.GetPvzs(), 1)
+		assert.Equal(t, pvzs[2].ID.String(), resp.GetPvzs()[0].GetId())
+		assert.Empty(t, resp.GetNextCursor())
+	})
+
+	t.Run("Page size is capped", func(t *testing.T) {
+		mockPVZRepo := mocks.NewPVZRepository(t)
+		client := setupServerWithDeps(t, mockPVZRepo, nil, nil)
+		mockPVZRepo.On("List", mock.Anything, domain.PVZListFilter{Limit: 1001}).Return(pvzs, nil).Once()
+
+		resp, err := client.GetPVZList(context.Background(), &pb.GetPVZListRequest{PageSize: 5000})
+		require.NoError(t, err)
+		assert.Len(t, resp.GetPvzs(), 3)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		client := setupServerWithDeps(t, mocks.NewPVZRepository(t), nil, nil)
+
+		_, err := client.GetPVZList(context.Background(), &pb.GetPVZListRequest{Cursor: "garbage"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Ascending cursor is rejected", func(t *testing.T) {
+		client := setupServerWithDeps(t, mocks.NewPVZRepository(t), nil, nil)
+		cursor := domain.PVZListCursor{RegistrationDate: registeredAt, ID: pvzs[0].ID, SortOrder: domain.SortAsc}
+
+		_, err := client.GetPVZList(context.Background(), &pb.GetPVZListRequest{Cursor: cursor.Encode()})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Negative page size", func(t *testing.T) {
+		client := setupServerWithDeps(t, mocks.NewPVZRepository(t), nil, nil)
+
+		_, err := client.GetPVZList(context.Background(), &pb.GetPVZListRequest{PageSize: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -2,57 +2,157 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
+	"time"
 
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"pvz-service-avito-internship/internal/apperror"
+	"pvz-service-avito-internship/internal/config"
 	"pvz-service-avito-internship/internal/domain"
 	"pvz-service-avito-internship/internal/middleware"
+	"pvz-service-avito-internship/internal/ratelimit"
 	pb "pvz-service-avito-internship/pkg/grpc/pvz/v1"
 )
 
+// methodRoles задает роли, которым разрешен вызов защищенных методов.
+// Совпадает с ограничениями RequireRole для аналогичных HTTP маршрутов.
+var methodRoles = map[string][]domain.UserRole{
+	pb.PVZService_CreateReception_FullMethodName:   {domain.RoleEmployee},
+	pb.PVZService_AddProduct_FullMethodName:        {domain.RoleEmployee},
+	pb.PVZService_DeleteLastProduct_FullMethodName: {domain.RoleEmployee},
+	pb.PVZService_CloseReception_FullMethodName:    {domain.RoleEmployee},
+}
+
+// Размер страницы GetPVZList при keyset-пагинации.
+const (
+	defaultPVZPageSize = 100
+	maxPVZPageSize     = 1000
+)
+
 type Server struct {
 	pb.UnimplementedPVZServiceServer
 
-	log      *slog.Logger
-	pvzRepo  domain.PVZRepository
-	grpcServ *grpc.Server
-	port     string
-	lis      net.Listener
+	log              *slog.Logger
+	pvzRepo          domain.PVZRepository
+	receptionService domain.ReceptionService
+	productService   domain.ProductService
+	grpcServ         *grpc.Server
+	health           *healthChecker
+	port             string
+	shutdownTimeout  time.Duration
+	lis              net.Listener
 }
 
-func NewServer(log *slog.Logger, pvzRepo domain.PVZRepository, port string) *Server {
-	grpcServerInstance := grpc.NewServer()
+func NewServer(
+	log *slog.Logger,
+	pvzRepo domain.PVZRepository,
+	receptionService domain.ReceptionService,
+	productService domain.ProductService,
+	metrics domain.MetricsCollector,
+	limiter *ratelimit.Limiter,
+	dbPinger DBPinger,
+	jwtSecret string,
+	cfg config.GRPCServer,
+) *Server {
+	authInterceptor := NewAuthInterceptor(log, jwtSecret, methodRoles)
+	rateLimitInterceptor := NewRateLimitInterceptor(log, limiter, metrics)
+	// Recovery стоит после логирования и метрик, чтобы паника попадала в access-лог и метрики как codes.Internal.
+	grpcServerInstance := grpc.NewServer(
+		// Stats handler начинает span вызова (с родителем из traceparent) до интерцепторов,
+		// поэтому Request ID и логи интерцепторов уже связаны с трассировкой.
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			RequestIDUnaryInterceptor(),
+			LoggingUnaryInterceptor(log),
+			MetricsUnaryInterceptor(metrics),
+			RecoveryUnaryInterceptor(log),
+			authInterceptor.Unary(),
+			rateLimitInterceptor.Unary(),
+		),
+		grpc.ChainStreamInterceptor(
+			RequestIDStreamInterceptor(),
+			LoggingStreamInterceptor(log),
+			MetricsStreamInterceptor(metrics),
+			RecoveryStreamInterceptor(log),
+			authInterceptor.Stream(),
+			rateLimitInterceptor.Stream(),
+		),
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(cfg.MaxSendMsgSize),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              cfg.Keepalive.Time,
+			Timeout:           cfg.Keepalive.Timeout,
+			MaxConnectionIdle: cfg.Keepalive.MaxConnectionIdle,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.Keepalive.MinClientPingInterval,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}),
+	)
 
 	s := &Server{
-		log:      log,
-		pvzRepo:  pvzRepo,
-		grpcServ: grpcServerInstance,
-		port:     port,
+		log:              log,
+		pvzRepo:          pvzRepo,
+		receptionService: receptionService,
+		productService:   productService,
+		grpcServ:         grpcServerInstance,
+		health:           newHealthChecker(log, dbPinger, cfg.HealthCheckInterval, cfg.HealthCheckTimeout),
+		port:             cfg.Port,
+		shutdownTimeout:  cfg.ShutdownTimeout,
 	}
 
 	pb.RegisterPVZServiceServer(grpcServerInstance, s)
+	healthpb.RegisterHealthServer(grpcServerInstance, s.health.server)
+	if cfg.Reflection {
+		reflection.Register(grpcServerInstance)
+		log.Info("gRPC server reflection enabled")
+	}
 
 	return s
 }
 
+// GetPVZList возвращает список ПВЗ. Пустой запрос сохраняет прежнее поведение (все ПВЗ одним ответом),
+// page_size или cursor включают keyset-пагинацию по (registration_date, id).
 func (s *Server) GetPVZList(ctx context.Context, req *pb.GetPVZListRequest) (*pb.GetPVZListResponse, error) {
 	const op = "GRPCServer.GetPVZList"
 
 	reqID := middleware.GetRequestIDFromContext(ctx)
 	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID))
 
-	log.Info("Received GetPVZList request")
+	log.Info("Received GetPVZList request", slog.Int("page_size", int(req.GetPageSize())), slog.Bool("cursor", req.GetCursor() != ""))
+
+	if req.GetPageSize() < 0 {
+		return nil, apperror.GRPCError(domain.NewFieldError("page_size", "must not be negative"))
+	}
 
-	pvzs, err := s.pvzRepo.ListAll(ctx)
+	var (
+		pvzs       []domain.PVZ
+		nextCursor string
+		err        error
+	)
+	if req.GetPageSize() == 0 && req.GetCursor() == "" {
+		pvzs, err = s.pvzRepo.ListAll(ctx)
+	} else {
+		pvzs, nextCursor, err = s.listPVZPage(ctx, int(req.GetPageSize()), req.GetCursor())
+	}
 	if err != nil {
-		log.Error("Failed to list all PVZs from repository", slog.String("error", err.Error()))
-		return nil, status.Errorf(codes.Internal, "failed to retrieve PVZ list")
+		if errors.Is(err, domain.ErrValidation) {
+			log.Warn("Invalid GetPVZList request", slog.String("error", err.Error()))
+			return nil, apperror.GRPCError(err)
+		}
+		log.Error("Failed to list PVZs from repository", slog.String("error", err.Error()))
+		return nil, apperror.NewGRPCError(codes.Internal, apperror.CodeInternal, "failed to retrieve PVZ list")
 	}
 
 	log.Info("PVZ list retrieved successfully from repository", slog.Int("count", len(pvzs)))
@@ -67,13 +167,203 @@ func (s *Server) GetPVZList(ctx context.Context, req *pb.GetPVZListRequest) (*pb
 	}
 
 	response := &pb.GetPVZListResponse{
-		Pvzs: respPVZs,
+		Pvzs:       respPVZs,
+		NextCursor: nextCursor,
 	}
 
 	log.Info("Successfully prepared GetPVZList response", slog.Int("count", len(response.Pvzs)))
 	return response, nil
 }
 
+// listPVZPage читает одну страницу списка ПВЗ после курсора и возвращает токен следующей страницы.
+func (s *Server) listPVZPage(ctx context.Context, pageSize int, cursorToken string) ([]domain.PVZ, string, error) {
+	switch {
+	case pageSize == 0:
+		pageSize = defaultPVZPageSize
+	case pageSize > maxPVZPageSize:
+		pageSize = maxPVZPageSize
+	}
+
+	filter := domain.PVZListFilter{Limit: pageSize + 1}
+	if cursorToken != "" {
+		cursor, err := domain.DecodePVZListCursor(cursorToken)
+		if err != nil {
+			return nil, "", err
+		}
+		// Список в gRPC всегда отсортирован по убыванию, курсор из asc-выдачи HTTP API здесь не подходит
+		if cursor.SortOrder != domain.SortDesc {
+			return nil, "", domain.NewFieldError("cursor", fmt.Sprintf("was issued for sortOrder=%s", cursor.SortOrder))
+		}
+		filter.After = cursor
+	}
+
+	pvzs, err := s.pvzRepo.List(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(pvzs) <= pageSize {
+		return pvzs, "", nil
+	}
+
+	pvzs = pvzs[:pageSize]
+	last := pvzs[len(pvzs)-1]
+	return pvzs, domain.PVZListCursor{RegistrationDate: last.RegistrationDate, ID: last.ID, SortOrder: domain.SortDesc}.Encode(), nil
+}
+
+func (s *Server) CreateReception(ctx context.Context, req *pb.CreateReceptionRequest) (*pb.CreateReceptionResponse, error) {
+	const op = "GRPCServer.CreateReception"
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	pvzID, err := parseUUIDField(req.GetPvzId(), "pvz_id")
+	if err != nil {
+		log.Warn("Invalid pvz_id in request", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	var shipmentID *uuid.UUID
+	if req.GetExpectedShipmentId() != "" {
+		id, err := parseUUIDField(req.GetExpectedShipmentId(), "expected_shipment_id")
+		if err != nil {
+			log.Warn("Invalid expected_shipment_id in request", slog.String("error", err.Error()))
+			return nil, err
+		}
+		shipmentID = &id
+	}
+
+	reception, err := s.receptionService.CreateReception(ctx, pvzID, shipmentID)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	log.Info("Reception created successfully", slog.String("reception_id", reception.ID.String()))
+	return &pb.CreateReceptionResponse{Reception: toPBReception(reception)}, nil
+}
+
+func (s *Server) AddProduct(ctx context.Context, req *pb.AddProductRequest) (*pb.AddProductResponse, error) {
+	const op = "GRPCServer.AddProduct"
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	pvzID, err := parseUUIDField(req.GetPvzId(), "pvz_id")
+	if err != nil {
+		log.Warn("Invalid pvz_id in request", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	var itemID *string
+	if req.GetItemId() != "" {
+		id := req.GetItemId()
+		itemID = &id
+	}
+
+	product, err := s.productService.AddProduct(ctx, pvzID, domain.ProductType(req.GetType()), itemID)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	log.Info("Product added successfully", slog.String("product_id", product.ID.String()))
+	return &pb.AddProductResponse{Product: toPBProduct(product)}, nil
+}
+
+func (s *Server) DeleteLastProduct(ctx context.Context, req *pb.DeleteLastProductRequest) (*pb.DeleteLastProductResponse, error) {
+	const op = "GRPCServer.DeleteLastProduct"
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	pvzID, err := parseUUIDField(req.GetPvzId(), "pvz_id")
+	if err != nil {
+		log.Warn("Invalid pvz_id in request", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.productService.DeleteLastProduct(ctx, pvzID); err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	log.Info("Last product deleted successfully", slog.String("pvz_id", pvzID.String()))
+	return &pb.DeleteLastProductResponse{}, nil
+}
+
+func (s *Server) CloseReception(ctx context.Context, req *pb.CloseReceptionRequest) (*pb.CloseReceptionResponse, error) {
+	const op = "GRPCServer.CloseReception"
+	reqID := middleware.GetRequestIDFromContext(ctx)
+	log := s.log.With(slog.String("op", op), slog.String("request_id", reqID))
+
+	pvzID, err := parseUUIDField(req.GetPvzId(), "pvz_id")
+	if err != nil {
+		log.Warn("Invalid pvz_id in request", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	reception, err := s.receptionService.CloseReception(ctx, pvzID, nil)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	log.Info("Reception closed successfully", slog.String("reception_id", reception.ID.String()))
+	return &pb.CloseReceptionResponse{Reception: toPBReception(reception)}, nil
+}
+
+func parseUUIDField(value, field string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, apperror.GRPCError(domain.NewFieldError(field, "invalid UUID format"))
+	}
+	return id, nil
+}
+
+func toPBReception(reception *domain.Reception) *pb.Reception {
+	resp := &pb.Reception{
+		Id:         reception.ID.String(),
+		DateTime:   timestamppb.New(reception.DateTime.UTC()),
+		PvzId:      reception.PVZID.String(),
+		Status:     toPBReceptionStatus(reception.Status),
+		AutoClosed: reception.AutoClosed,
+	}
+	if reception.ClosedAt != nil {
+		resp.ClosedAt = timestamppb.New(reception.ClosedAt.UTC())
+	}
+	if reception.OpenedBy != nil {
+		resp.OpenedBy = reception.OpenedBy.String()
+	}
+	if reception.ClosedBy != nil {
+		resp.ClosedBy = reception.ClosedBy.String()
+	}
+	if reception.StaleReason != nil {
+		resp.StaleReason = string(*reception.StaleReason)
+	}
+	if reception.ExpectedShipmentID != nil {
+		resp.ExpectedShipmentId = reception.ExpectedShipmentID.String()
+	}
+	return resp
+}
+
+func toPBReceptionStatus(s domain.ReceptionStatus) pb.ReceptionStatus {
+	switch s {
+	case domain.StatusInProgress:
+		return pb.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS
+	case domain.StatusClosed:
+		return pb.ReceptionStatus_RECEPTION_STATUS_CLOSED
+	default:
+		return pb.ReceptionStatus_RECEPTION_STATUS_UNSPECIFIED
+	}
+}
+
+func toPBProduct(product *domain.Product) *pb.Product {
+	resp := &pb.Product{
+		Id:          product.ID.String(),
+		DateTime:    timestamppb.New(product.DateTime.UTC()),
+		Type:        string(product.Type),
+		ReceptionId: product.ReceptionID.String(),
+		Status:      string(product.Status),
+	}
+	if product.ItemID != nil {
+		resp.ItemId = *product.ItemID
+	}
+	return resp
+}
+
 func (s *Server) Start() error {
 	const op = "GRPCServer.Start"
 	log := s.log.With(slog.String("op", op))
@@ -84,8 +374,18 @@ func (s *Server) Start() error {
 		log.Error("Failed to listen on gRPC port", slog.String("address", address), slog.String("error", err.Error()))
 		return fmt.Errorf("failed to listen on gRPC port %s: %w", s.port, err)
 	}
+
+	return s.Serve(lis)
+}
+
+// Serve запускает gRPC сервер на переданном listener. Блокирует до остановки сервера.
+func (s *Server) Serve(lis net.Listener) error {
+	const op = "GRPCServer.Serve"
+	log := s.log.With(slog.String("op", op))
 	s.lis = lis
 
+	go s.health.run()
+
 	log.Info("Starting gRPC server listener", slog.String("address", lis.Addr().String()))
 
 	if err := s.grpcServ.Serve(lis); err != nil {
@@ -97,12 +397,32 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// Stop переводит health статус в NOT_SERVING, чтобы балансировщик перестал направлять новые вызовы,
+// и ожидает завершения активных вызовов. Если они не успели завершиться за shutdownTimeout,
+// соединения закрываются принудительно.
 func (s *Server) Stop() {
 	const op = "GRPCServer.Stop"
 	log := s.log.With(slog.String("op", op))
 	log.Info("Stopping gRPC server gracefully...")
 
-	s.grpcServ.GracefulStop()
+	s.health.shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServ.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(s.shutdownTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+	case <-timer.C:
+		log.Warn("Graceful stop timed out, forcing gRPC server stop", slog.Duration("timeout", s.shutdownTimeout))
+		s.grpcServ.Stop()
+		<-stopped
+	}
 
 	if s.lis != nil {
 		_ = s.lis.Close()
@@ -0,0 +1,226 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"pvz-service-avito-internship/internal/config"
+	"pvz-service-avito-internship/internal/domain"
+	"pvz-service-avito-internship/internal/middleware"
+	grpcTransport "pvz-service-avito-internship/internal/transport/grpc"
+	"pvz-service-avito-internship/mocks"
+	pb "pvz-service-avito-internship/pkg/grpc/pvz/v1"
+	"pvz-service-avito-internship/pkg/jwt"
+)
+
+const testJWTSecret = "test-secret"
+
+// stubPinger имитирует пул соединений с БД для health сервиса.
+type stubPinger struct {
+	fail atomic.Bool
+}
+
+func (p *stubPinger) Ping(_ context.Context) error {
+	if p.fail.Load() {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func newMetricsCollector(t *testing.T) *mocks.MetricsCollector {
+	metricsCollector := mocks.NewMetricsCollector(t)
+	metricsCollector.On("IncGRPCRequestsTotal", mock.Anything, mock.Anything).Maybe()
+	metricsCollector.On("ObserveGRPCRequestDuration", mock.Anything, mock.Anything).Maybe()
+	return metricsCollector
+}
+
+func testGRPCConfig() config.GRPCServer {
+	return config.GRPCServer{
+		Port:                "0",
+		MaxRecvMsgSize:      4 << 20,
+		MaxSendMsgSize:      4 << 20,
+		HealthCheckInterval: 10 * time.Millisecond,
+		HealthCheckTimeout:  time.Second,
+		ShutdownTimeout:     time.Second,
+	}
+}
+
+func setupWriteAPIServer(t *testing.T, receptionService domain.ReceptionService, productService domain.ProductService) pb.PVZServiceClient {
+	t.Helper()
+	return setupServerWithDeps(t, mocks.NewPVZRepository(t), receptionService, productService)
+}
+
+func setupServerWithDeps(t *testing.T, pvzRepo domain.PVZRepository, receptionService domain.ReceptionService, productService domain.ProductService) pb.PVZServiceClient {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	server := grpcTransport.NewServer(logger, pvzRepo, receptionService, productService, newMetricsCollector(t), nil, &stubPinger{}, testJWTSecret, testGRPCConfig())
+	return serveBufconn(t, server)
+}
+
+// serveBufconn запускает server на bufconn и возвращает подключенного к нему клиента.
+func serveBufconn(t *testing.T, server *grpcTransport.Server) pb.PVZServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewPVZServiceClient(conn)
+}
+
+func withToken(t *testing.T, role domain.UserRole) context.Context {
+	t.Helper()
+	token, err := jwt.GenerateToken(uuid.New(), role, testJWTSecret, time.Hour)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestWriteAPI_CreateReception(t *testing.T) {
+	mockReceptionService := mocks.NewReceptionService(t)
+	client := setupWriteAPIServer(t, mockReceptionService, mocks.NewProductService(t))
+	pvzID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		reception := &domain.Reception{ID: uuid.New(), DateTime: time.Now().UTC(), PVZID: pvzID, Status: domain.StatusInProgress}
+		mockReceptionService.On("CreateReception", mock.MatchedBy(func(ctx context.Context) bool {
+			role, ok := middleware.GetUserRoleFromContext(ctx)
+			return ok && role == domain.RoleEmployee
+		}), pvzID, (*uuid.UUID)(nil)).Return(reception, nil).Once()
+
+		resp, err := client.CreateReception(withToken(t, domain.RoleEmployee), &pb.CreateReceptionRequest{PvzId: pvzID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, reception.ID.String(), resp.GetReception().GetId())
+		assert.Equal(t, pb.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS, resp.GetReception().GetStatus())
+	})
+
+	t.Run("Reception_In_Progress", func(t *testing.T) {
+		mockReceptionService.On("CreateReception", mock.Anything, pvzID, (*uuid.UUID)(nil)).Return(nil, domain.ErrReceptionInProgress).Once()
+
+		_, err := client.CreateReception(withToken(t, domain.RoleEmployee), &pb.CreateReceptionRequest{PvzId: pvzID.String()})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("Missing_Token", func(t *testing.T) {
+		_, err := client.CreateReception(context.Background(), &pb.CreateReceptionRequest{PvzId: pvzID.String()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Moderator_Forbidden", func(t *testing.T) {
+		_, err := client.CreateReception(withToken(t, domain.RoleModerator), &pb.CreateReceptionRequest{PvzId: pvzID.String()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Invalid_PVZ_ID", func(t *testing.T) {
+		_, err := client.CreateReception(withToken(t, domain.RoleEmployee), &pb.CreateReceptionRequest{PvzId: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("With_Expected_Shipment", func(t *testing.T) {
+		shipmentID := uuid.New()
+		reception := &domain.Reception{ID: uuid.New(), PVZID: pvzID, Status: domain.StatusInProgress, ExpectedShipmentID: &shipmentID}
+		mockReceptionService.On("CreateReception", mock.Anything, pvzID, &shipmentID).Return(reception, nil).Once()
+
+		resp, err := client.CreateReception(withToken(t, domain.RoleEmployee),
+			&pb.CreateReceptionRequest{PvzId: pvzID.String(), ExpectedShipmentId: shipmentID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, shipmentID.String(), resp.GetReception().GetExpectedShipmentId())
+	})
+
+	t.Run("Invalid_Expected_Shipment_ID", func(t *testing.T) {
+		_, err := client.CreateReception(withToken(t, domain.RoleEmployee),
+			&pb.CreateReceptionRequest{PvzId: pvzID.String(), ExpectedShipmentId: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestWriteAPI_Products(t *testing.T) {
+	mockProductService := mocks.NewProductService(t)
+	client := setupWriteAPIServer(t, mocks.NewReceptionService(t), mockProductService)
+	pvzID := uuid.New()
+
+	t.Run("AddProduct_Success", func(t *testing.T) {
+		product := &domain.Product{ID: uuid.New(), DateTime: time.Now().UTC(), Type: domain.TypeShoes, ReceptionID: uuid.New()}
+		mockProductService.On("AddProduct", mock.Anything, pvzID, domain.TypeShoes, (*string)(nil)).Return(product, nil).Once()
+
+		resp, err := client.AddProduct(withToken(t, domain.RoleEmployee), &pb.AddProductRequest{PvzId: pvzID.String(), Type: string(domain.TypeShoes)})
+		require.NoError(t, err)
+		assert.Equal(t, product.ID.String(), resp.GetProduct().GetId())
+		assert.Equal(t, product.ReceptionID.String(), resp.GetProduct().GetReceptionId())
+	})
+
+	t.Run("AddProduct_No_Open_Reception", func(t *testing.T) {
+		mockProductService.On("AddProduct", mock.Anything, pvzID, domain.TypeClothing, (*string)(nil)).Return(nil, domain.ErrNoOpenReception).Once()
+
+		_, err := client.AddProduct(withToken(t, domain.RoleEmployee), &pb.AddProductRequest{PvzId: pvzID.String(), Type: string(domain.TypeClothing)})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("DeleteLastProduct_Success", func(t *testing.T) {
+		mockProductService.On("DeleteLastProduct", mock.Anything, pvzID).Return(nil).Once()
+
+		_, err := client.DeleteLastProduct(withToken(t, domain.RoleEmployee), &pb.DeleteLastProductRequest{PvzId: pvzID.String()})
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteLastProduct_Database_Error", func(t *testing.T) {
+		mockProductService.On("DeleteLastProduct", mock.Anything, pvzID).Return(domain.ErrDatabaseError).Once()
+
+		_, err := client.DeleteLastProduct(withToken(t, domain.RoleEmployee), &pb.DeleteLastProductRequest{PvzId: pvzID.String()})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestWriteAPI_CloseReception(t *testing.T) {
+	mockReceptionService := mocks.NewReceptionService(t)
+	client := setupWriteAPIServer(t, mockReceptionService, mocks.NewProductService(t))
+	pvzID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		closedAt := time.Now().UTC()
+		closedBy := uuid.New()
+		reception := &domain.Reception{
+			ID: uuid.New(), DateTime: closedAt.Add(-time.Hour), PVZID: pvzID, Status: domain.StatusClosed,
+			ClosedAt: &closedAt, ClosedBy: &closedBy,
+		}
+		mockReceptionService.On("CloseReception", mock.Anything, pvzID, (*int64)(nil)).Return(reception, nil).Once()
+
+		resp, err := client.CloseReception(withToken(t, domain.RoleEmployee), &pb.CloseReceptionRequest{PvzId: pvzID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, pb.ReceptionStatus_RECEPTION_STATUS_CLOSED, resp.GetReception().GetStatus())
+		assert.True(t, closedAt.Equal(resp.GetReception().GetClosedAt().AsTime()))
+		assert.Equal(t, closedBy.String(), resp.GetReception().GetClosedBy())
+		assert.Empty(t, resp.GetReception().GetOpenedBy())
+	})
+
+	t.Run("Already_Closed", func(t *testing.T) {
+		mockReceptionService.On("CloseReception", mock.Anything, pvzID, (*int64)(nil)).Return(nil, domain.ErrReceptionClosed).Once()
+
+		_, err := client.CloseReception(withToken(t, domain.RoleEmployee), &pb.CloseReceptionRequest{PvzId: pvzID.String()})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
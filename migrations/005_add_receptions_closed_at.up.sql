@@ -0,0 +1,16 @@
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- Для уже закрытых приемок точное время закрытия неизвестно, используем время последнего обновления записи.
+UPDATE receptions
+SET closed_at = updated_at
+WHERE status = 'close'
+  AND closed_at IS NULL;
+
+-- Индексы для агрегирующих запросов статистики (GET /stats/receptions):
+-- диапазон по дате приемки с доступом к pvz_id без обращения к таблице,
+-- подсчет товаров по типу внутри приемки.
+CREATE INDEX IF NOT EXISTS idx_receptions_date_time_pvz_id ON receptions (date_time, pvz_id);
+CREATE INDEX IF NOT EXISTS idx_products_reception_id_type ON products (reception_id, type);
+
+COMMENT ON COLUMN receptions.closed_at IS 'Дата и время закрытия приемки (NULL, пока приемка активна)';
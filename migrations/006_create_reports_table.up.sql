@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS reports
+(
+    id           UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    type         VARCHAR(50)              NOT NULL CHECK (type IN ('reception_summary', 'product_breakdown', 'pvz_activity')),
+    format       VARCHAR(10)              NOT NULL CHECK (format IN ('csv', 'xlsx')),
+    params       JSONB                    NOT NULL DEFAULT '{}'::jsonb,
+    status       VARCHAR(20)              NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'done', 'failed', 'expired')),
+    requested_by UUID                     NULL,
+    blob_key     TEXT                     NULL,
+    error        TEXT                     NULL,
+    attempts     INTEGER                  NOT NULL DEFAULT 0,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at   TIMESTAMP WITH TIME ZONE NULL,
+    finished_at  TIMESTAMP WITH TIME ZONE NULL,
+    expires_at   TIMESTAMP WITH TIME ZONE NULL
+);
+
+-- Выборка очереди обработчиками (FOR UPDATE SKIP LOCKED) и поиск зависших задач.
+CREATE INDEX IF NOT EXISTS idx_reports_status_created_at ON reports (status, created_at);
+-- Поиск результатов с истекшим сроком хранения.
+CREATE INDEX IF NOT EXISTS idx_reports_expires_at ON reports (expires_at) WHERE status = 'done';
+
+COMMENT ON TABLE reports IS 'Задачи асинхронного построения отчетов и их результаты';
+COMMENT ON COLUMN reports.id IS 'Уникальный идентификатор задачи (UUID)';
+COMMENT ON COLUMN reports.type IS 'Вид отчета (reception_summary, product_breakdown, pvz_activity)';
+COMMENT ON COLUMN reports.format IS 'Формат файла результата (csv, xlsx)';
+COMMENT ON COLUMN reports.params IS 'Параметры отчета (период, шаг группировки, город)';
+COMMENT ON COLUMN reports.status IS 'Состояние задачи (pending, running, done, failed, expired)';
+COMMENT ON COLUMN reports.requested_by IS 'Пользователь, создавший задачу';
+COMMENT ON COLUMN reports.blob_key IS 'Ключ файла результата в хранилище';
+COMMENT ON COLUMN reports.error IS 'Причина ошибки построения';
+COMMENT ON COLUMN reports.attempts IS 'Количество запусков построения';
+COMMENT ON COLUMN reports.started_at IS 'Время начала последнего построения';
+COMMENT ON COLUMN reports.finished_at IS 'Время завершения построения';
+COMMENT ON COLUMN reports.expires_at IS 'Время, после которого результат удаляется';
@@ -0,0 +1,7 @@
+-- Keyset-пагинация списка ПВЗ (GET /pvz?cursor=...) идет по (registration_date, id) по убыванию.
+-- Индекс позволяет читать страницу с позиции курсора без сортировки и без OFFSET.
+CREATE INDEX IF NOT EXISTS idx_pvz_registration_date_id ON pvz (registration_date DESC, id DESC);
+DROP INDEX IF EXISTS idx_pvz_registration_date;
+
+-- Проверка EXISTS (приемки ПВЗ в диапазоне дат) для фильтра по датам.
+CREATE INDEX IF NOT EXISTS idx_receptions_pvz_id_date_time ON receptions (pvz_id, date_time);
@@ -0,0 +1,8 @@
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive'));
+
+-- Фильтры списка ПВЗ (GET /pvz): статус и город, наличие незакрытой приемки.
+CREATE INDEX IF NOT EXISTS idx_pvz_status_city ON pvz (status, city);
+CREATE INDEX IF NOT EXISTS idx_receptions_pvz_id_open ON receptions (pvz_id) WHERE status = 'in_progress';
+
+COMMENT ON COLUMN pvz.status IS 'Состояние ПВЗ (active, inactive)';
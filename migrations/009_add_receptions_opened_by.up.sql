@@ -0,0 +1,5 @@
+-- Сотрудник, открывший приемку (ID из JWT). Внешнего ключа нет: токены /dummyLogin выдаются без записи в users.
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS opened_by UUID NULL;
+
+COMMENT ON COLUMN receptions.opened_by IS 'ID пользователя, открывшего приемку (NULL для приемок, созданных до учета)';
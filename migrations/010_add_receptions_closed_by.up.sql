@@ -0,0 +1,9 @@
+-- Сотрудник, закрывший приемку (ID из JWT). Как и opened_by, без внешнего ключа на users.
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS closed_by UUID NULL;
+
+-- Фильтры списка приемок ПВЗ (GET /pvz/{pvzId}/receptions) по сотруднику.
+CREATE INDEX IF NOT EXISTS idx_receptions_opened_by ON receptions (opened_by) WHERE opened_by IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_receptions_closed_by ON receptions (closed_by) WHERE closed_by IS NOT NULL;
+
+COMMENT ON COLUMN receptions.closed_by IS 'ID пользователя, закрывшего приемку (NULL, пока приемка активна или если закрыта автоматически)';
@@ -0,0 +1,10 @@
+-- Признаки забытых приемок, которые обрабатывает фоновый планировщик (internal/app).
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS auto_closed  BOOLEAN     NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS stale_reason VARCHAR(20) NULL CHECK (stale_reason IN ('max_age', 'closing_time'));
+
+-- Поиск приемок, открытых дольше допустимого.
+CREATE INDEX IF NOT EXISTS idx_receptions_in_progress_date_time ON receptions (date_time) WHERE status = 'in_progress';
+
+COMMENT ON COLUMN receptions.auto_closed IS 'Приемка закрыта планировщиком, а не сотрудником';
+COMMENT ON COLUMN receptions.stale_reason IS 'Причина, по которой приемка признана забытой (max_age, closing_time); NULL для обычных приемок';
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS expected_shipments
+(
+    id                    UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    pvz_id                UUID                     NOT NULL,
+    reference             VARCHAR(100)             NULL,
+    lines                 JSONB                    NOT NULL,
+    created_by            UUID                     NULL,
+    created_at            TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reconciliation_status VARCHAR(20)              NULL CHECK (reconciliation_status IN ('matched', 'discrepancy')),
+    reconciliation        JSONB                    NULL,
+    reconciled_at         TIMESTAMP WITH TIME ZONE NULL,
+    CONSTRAINT fk_expected_shipment_pvz FOREIGN KEY (pvz_id) REFERENCES pvz (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_expected_shipments_pvz_id ON expected_shipments (pvz_id, created_at);
+-- Поставки с расхождениями для разбора логистикой.
+CREATE INDEX IF NOT EXISTS idx_expected_shipments_discrepancy ON expected_shipments (reconciled_at)
+    WHERE reconciliation_status = 'discrepancy';
+
+-- Приемка, в которую пришла поставка. Уникальность гарантирует, что поставка привязана не более чем к одной приемке.
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS expected_shipment_id UUID NULL
+        CONSTRAINT fk_reception_expected_shipment REFERENCES expected_shipments (id) ON DELETE SET NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receptions_expected_shipment_id ON receptions (expected_shipment_id)
+    WHERE expected_shipment_id IS NOT NULL;
+
+-- Идентификатор единицы (штрихкод) для сверки с поставкой. Одна единица не может быть принята дважды в одной приемке.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS item_id VARCHAR(100) NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_products_reception_item_id ON products (reception_id, item_id)
+    WHERE item_id IS NOT NULL;
+
+COMMENT ON TABLE expected_shipments IS 'Ожидаемые поставки (ASN) от логистики';
+COMMENT ON COLUMN expected_shipments.pvz_id IS 'ПВЗ, в который ожидается поставка';
+COMMENT ON COLUMN expected_shipments.reference IS 'Номер уведомления об отгрузке у логистики';
+COMMENT ON COLUMN expected_shipments.lines IS 'Ожидаемое количество товаров по типам и идентификаторы единиц';
+COMMENT ON COLUMN expected_shipments.created_by IS 'Пользователь, зарегистрировавший поставку';
+COMMENT ON COLUMN expected_shipments.reconciliation_status IS 'Итог сверки с закрытой приемкой (matched, discrepancy)';
+COMMENT ON COLUMN expected_shipments.reconciliation IS 'Расхождения: недостающие, лишние и принятые с другим типом единицы';
+COMMENT ON COLUMN expected_shipments.reconciled_at IS 'Время сверки';
+COMMENT ON COLUMN receptions.expected_shipment_id IS 'Ожидаемая поставка, привязанная при открытии приемки';
+COMMENT ON COLUMN products.item_id IS 'Идентификатор единицы из поставки (штрихкод)';
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS dispatches
+(
+    id         UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    pvz_id     UUID                     NOT NULL,
+    status     VARCHAR(20)              NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'sent')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by UUID                     NULL,
+    sent_at    TIMESTAMP WITH TIME ZONE NULL,
+    sent_by    UUID                     NULL,
+    CONSTRAINT fk_dispatch_pvz FOREIGN KEY (pvz_id) REFERENCES pvz (id) ON DELETE CASCADE
+);
+
+-- В ПВЗ собирается не более одной открытой отправки одновременно.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dispatches_open_pvz_id ON dispatches (pvz_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_dispatches_pvz_id ON dispatches (pvz_id, created_at);
+
+-- Жизненный цикл товара: received -> issued / returned / lost. Существующие товары считаются находящимися в ПВЗ.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS status            VARCHAR(20)              NOT NULL DEFAULT 'received'
+        CHECK (status IN ('received', 'issued', 'returned', 'lost')),
+    ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP WITH TIME ZONE NULL,
+    ADD COLUMN IF NOT EXISTS status_changed_by UUID                     NULL,
+    ADD COLUMN IF NOT EXISTS dispatch_id       UUID                     NULL
+        CONSTRAINT fk_product_dispatch REFERENCES dispatches (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_dispatch_id ON products (dispatch_id) WHERE dispatch_id IS NOT NULL;
+-- Подсчет остатков ПВЗ.
+CREATE INDEX IF NOT EXISTS idx_products_received ON products (reception_id, type) WHERE status = 'received';
+
+COMMENT ON TABLE dispatches IS 'Исходящие отправки возвратов на склад';
+COMMENT ON COLUMN dispatches.status IS 'Состояние отправки (open - собирается, sent - отправлена)';
+COMMENT ON COLUMN dispatches.created_by IS 'Сотрудник, вернувший первый товар отправки';
+COMMENT ON COLUMN dispatches.sent_by IS 'Сотрудник, отправивший партию';
+COMMENT ON COLUMN products.status IS 'Состояние товара (received, issued, returned, lost)';
+COMMENT ON COLUMN products.status_changed_at IS 'Время выдачи, возврата или списания товара';
+COMMENT ON COLUMN products.status_changed_by IS 'Пользователь, изменивший состояние товара';
+COMMENT ON COLUMN products.dispatch_id IS 'Отправка, в которую включен возвращенный товар';
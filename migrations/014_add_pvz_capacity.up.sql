@@ -0,0 +1,119 @@
+-- Общая вместимость и заполненность ПВЗ. NULL в capacity означает отсутствие ограничения.
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS capacity INTEGER NULL CHECK (capacity IS NULL OR capacity >= 0),
+    ADD COLUMN IF NOT EXISTS occupied INTEGER NOT NULL DEFAULT 0;
+
+-- Заполненность и необязательная вместимость ПВЗ по типам товаров.
+CREATE TABLE IF NOT EXISTS pvz_occupancy
+(
+    pvz_id       UUID        NOT NULL,
+    product_type VARCHAR(50) NOT NULL CHECK (product_type IN ('электроника', 'одежда', 'обувь')),
+    occupied     INTEGER     NOT NULL DEFAULT 0,
+    capacity     INTEGER     NULL CHECK (capacity IS NULL OR capacity >= 0),
+    PRIMARY KEY (pvz_id, product_type),
+    CONSTRAINT fk_pvz_occupancy_pvz FOREIGN KEY (pvz_id) REFERENCES pvz (id) ON DELETE CASCADE
+);
+
+-- Счетчики учитывают товары в статусе received и обновляются в той же транзакции, что и товар.
+-- Строка pvz блокируется на время изменения, поэтому конкурентные добавления в один ПВЗ не превышают вместимость.
+-- Превышение вместимости отклоняет вставку с check_violation и именем ограничения pvz_capacity / pvz_type_capacity.
+-- Уменьшение вместимости ниже текущей заполненности допускается: новые товары не принимаются, пока место не освободится.
+-- Результат AFTER-триггера игнорируется, поэтому функция всегда возвращает NULL.
+CREATE OR REPLACE FUNCTION products_track_occupancy() RETURNS TRIGGER AS
+$$
+DECLARE
+    delta          INTEGER;
+    target_pvz     UUID;
+    target_type    VARCHAR(50);
+    total_occupied INTEGER;
+    total_capacity INTEGER;
+    type_occupied  INTEGER;
+    type_capacity  INTEGER;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        IF NEW.status <> 'received' THEN
+            RETURN NULL;
+        END IF;
+        delta := 1;
+        target_type := NEW.type;
+        SELECT pvz_id INTO target_pvz FROM receptions WHERE id = NEW.reception_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        IF OLD.status <> 'received' THEN
+            RETURN NULL;
+        END IF;
+        delta := -1;
+        target_type := OLD.type;
+        SELECT pvz_id INTO target_pvz FROM receptions WHERE id = OLD.reception_id;
+    ELSE
+        IF OLD.status = NEW.status THEN
+            RETURN NULL;
+        ELSIF OLD.status = 'received' THEN
+            delta := -1;
+        ELSIF NEW.status = 'received' THEN
+            delta := 1;
+        ELSE
+            RETURN NULL;
+        END IF;
+        target_type := NEW.type;
+        SELECT pvz_id INTO target_pvz FROM receptions WHERE id = NEW.reception_id;
+    END IF;
+
+    -- Приемка уже удалена каскадно вместе с ПВЗ: считать нечего.
+    IF target_pvz IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    UPDATE pvz
+    SET occupied = GREATEST(occupied + delta, 0)
+    WHERE id = target_pvz
+    RETURNING occupied, capacity INTO total_occupied, total_capacity;
+
+    IF delta > 0 AND total_capacity IS NOT NULL AND total_occupied > total_capacity THEN
+        RAISE EXCEPTION 'pvz % is full: capacity %', target_pvz, total_capacity
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'pvz_capacity';
+    END IF;
+
+    INSERT INTO pvz_occupancy (pvz_id, product_type, occupied)
+    VALUES (target_pvz, target_type, GREATEST(delta, 0))
+    ON CONFLICT (pvz_id, product_type) DO UPDATE SET occupied = GREATEST(pvz_occupancy.occupied + delta, 0)
+    RETURNING occupied, capacity INTO type_occupied, type_capacity;
+
+    IF delta > 0 AND type_capacity IS NOT NULL AND type_occupied > type_capacity THEN
+        RAISE EXCEPTION 'pvz % is full for product type %: capacity %', target_pvz, target_type, type_capacity
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'pvz_type_capacity';
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_products_track_occupancy ON products;
+CREATE TRIGGER trg_products_track_occupancy
+    AFTER INSERT OR DELETE OR UPDATE OF status
+    ON products
+    FOR EACH ROW
+EXECUTE FUNCTION products_track_occupancy();
+
+-- Начальные значения счетчиков для уже принятых товаров.
+UPDATE pvz p
+SET occupied = s.cnt
+FROM (SELECT r.pvz_id, count(*) AS cnt
+      FROM products pr
+               JOIN receptions r ON r.id = pr.reception_id
+      WHERE pr.status = 'received'
+      GROUP BY r.pvz_id) s
+WHERE p.id = s.pvz_id;
+
+INSERT INTO pvz_occupancy (pvz_id, product_type, occupied)
+SELECT r.pvz_id, pr.type, count(*)
+FROM products pr
+         JOIN receptions r ON r.id = pr.reception_id
+WHERE pr.status = 'received'
+GROUP BY r.pvz_id, pr.type
+ON CONFLICT (pvz_id, product_type) DO UPDATE SET occupied = EXCLUDED.occupied;
+
+COMMENT ON COLUMN pvz.capacity IS 'Общая вместимость ПВЗ (NULL - без ограничения)';
+COMMENT ON COLUMN pvz.occupied IS 'Количество товаров в ПВЗ (status = received), поддерживается триггером';
+COMMENT ON TABLE pvz_occupancy IS 'Заполненность и вместимость ПВЗ по типам товаров';
+COMMENT ON COLUMN pvz_occupancy.occupied IS 'Количество товаров типа в ПВЗ, поддерживается триггером';
+COMMENT ON COLUMN pvz_occupancy.capacity IS 'Вместимость ПВЗ для типа товара (NULL - без отдельного ограничения)';
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    scope            VARCHAR(64)              NOT NULL,
+    idempotency_key  VARCHAR(255)             NOT NULL,
+    method           VARCHAR(10)              NOT NULL,
+    path             TEXT                     NOT NULL,
+    request_hash     CHAR(64)                 NOT NULL,
+    status           VARCHAR(20)              NOT NULL CHECK (status IN ('in_progress', 'completed')),
+    lock_id          UUID                     NOT NULL,
+    locked_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+    response_status  INTEGER                  NULL,
+    response_headers JSONB                    NULL,
+    response_body    BYTEA                    NULL,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (scope, idempotency_key)
+);
+
+-- Удаление ключей с истекшим сроком хранения.
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+COMMENT ON TABLE idempotency_keys IS 'Ключи идемпотентности POST запросов и сохраненные ответы на них';
+COMMENT ON COLUMN idempotency_keys.scope IS 'Владелец ключа: ID пользователя или пустая строка для запросов без аутентификации';
+COMMENT ON COLUMN idempotency_keys.idempotency_key IS 'Значение заголовка Idempotency-Key';
+COMMENT ON COLUMN idempotency_keys.request_hash IS 'SHA-256 метода, пути и тела первого запроса (hex)';
+COMMENT ON COLUMN idempotency_keys.status IS 'Состояние ключа (in_progress - запрос выполняется, completed - ответ сохранен)';
+COMMENT ON COLUMN idempotency_keys.lock_id IS 'Идентификатор запроса, который держит ключ';
+COMMENT ON COLUMN idempotency_keys.locked_at IS 'Время захвата ключа; незавершенный запрос старше таймаута считается брошенным';
+COMMENT ON COLUMN idempotency_keys.response_headers IS 'Сохраненные заголовки ответа';
+COMMENT ON COLUMN idempotency_keys.expires_at IS 'Время, после которого ключ удаляется и может быть использован заново';
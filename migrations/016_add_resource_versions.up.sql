@@ -0,0 +1,36 @@
+-- Версии для оптимистичной блокировки (ETag / If-Match). Версия ПВЗ увеличивается при изменении ПВЗ
+-- (счетчик заполненности occupied ее не меняет), версия приемки - при изменении приемки и ее товаров.
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+-- Товары входят в представление приемки (GET /receptions/{receptionId}), поэтому добавление, удаление и
+-- изменение товара увеличивают версию приемки в той же транзакции.
+CREATE OR REPLACE FUNCTION products_bump_reception_version() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF OLD IS NOT DISTINCT FROM NEW THEN
+            RETURN NULL;
+        END IF;
+        UPDATE receptions SET version = version + 1 WHERE id IN (OLD.reception_id, NEW.reception_id);
+    ELSIF TG_OP = 'DELETE' THEN
+        -- При каскадном удалении приемки строка уже удалена, и UPDATE ничего не меняет.
+        UPDATE receptions SET version = version + 1 WHERE id = OLD.reception_id;
+    ELSE
+        UPDATE receptions SET version = version + 1 WHERE id = NEW.reception_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_products_bump_reception_version ON products;
+CREATE TRIGGER trg_products_bump_reception_version
+    AFTER INSERT OR DELETE OR UPDATE
+    ON products
+    FOR EACH ROW
+EXECUTE FUNCTION products_bump_reception_version();
+
+COMMENT ON COLUMN pvz.version IS 'Версия ПВЗ, увеличивается при каждом изменении (ETag)';
+COMMENT ON COLUMN receptions.version IS 'Версия приемки, увеличивается при изменении приемки или ее товаров (ETag)';
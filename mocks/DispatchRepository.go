@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// DispatchRepository is an autogenerated mock type for the DispatchRepository type
+type DispatchRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, dispatch
+func (_m *DispatchRepository) Create(ctx context.Context, dispatch *domain.Dispatch) error {
+	ret := _m.Called(ctx, dispatch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Dispatch) error); ok {
+		r0 = rf(ctx, dispatch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindOpenByPVZID provides a mock function with given fields: ctx, pvzID
+func (_m *DispatchRepository) FindOpenByPVZID(ctx context.Context, pvzID uuid.UUID) (*domain.Dispatch, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOpenByPVZID")
+	}
+
+	var r0 *domain.Dispatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Dispatch, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Dispatch); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Dispatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *DispatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Dispatch, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Dispatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Dispatch, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Dispatch); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Dispatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkSent provides a mock function with given fields: ctx, id, sentAt, sentBy
+func (_m *DispatchRepository) MarkSent(ctx context.Context, id uuid.UUID, sentAt time.Time, sentBy *uuid.UUID) (*domain.Dispatch, error) {
+	ret := _m.Called(ctx, id, sentAt, sentBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSent")
+	}
+
+	var r0 *domain.Dispatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *uuid.UUID) (*domain.Dispatch, error)); ok {
+		return rf(ctx, id, sentAt, sentBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *uuid.UUID) *domain.Dispatch); ok {
+		r0 = rf(ctx, id, sentAt, sentBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Dispatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id, sentAt, sentBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDispatchRepository creates a new instance of DispatchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDispatchRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DispatchRepository {
+	mock := &DispatchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ExpectedShipmentRepository is an autogenerated mock type for the ExpectedShipmentRepository type
+type ExpectedShipmentRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, shipment
+func (_m *ExpectedShipmentRepository) Create(ctx context.Context, shipment *domain.ExpectedShipment) error {
+	ret := _m.Called(ctx, shipment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExpectedShipment) error); ok {
+		r0 = rf(ctx, shipment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ExpectedShipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ExpectedShipment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.ExpectedShipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.ExpectedShipment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.ExpectedShipment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpectedShipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveReconciliation provides a mock function with given fields: ctx, id, reconciliation
+func (_m *ExpectedShipmentRepository) SaveReconciliation(ctx context.Context, id uuid.UUID, reconciliation domain.Reconciliation) error {
+	ret := _m.Called(ctx, id, reconciliation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveReconciliation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Reconciliation) error); ok {
+		r0 = rf(ctx, id, reconciliation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExpectedShipmentRepository creates a new instance of ExpectedShipmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExpectedShipmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExpectedShipmentRepository {
+	mock := &ExpectedShipmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
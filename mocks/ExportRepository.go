@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportRepository is an autogenerated mock type for the ExportRepository type
+type ExportRepository struct {
+	mock.Mock
+}
+
+// StreamReceptionRows provides a mock function with given fields: ctx, filter, fn
+func (_m *ExportRepository) StreamReceptionRows(ctx context.Context, filter domain.ReceptionExportFilter, fn domain.ReceptionExportRowFunc) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamReceptionRows")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReceptionExportFilter, domain.ReceptionExportRowFunc) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExportRepository creates a new instance of ExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportRepository {
+	mock := &ExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
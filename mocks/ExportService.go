@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportService is an autogenerated mock type for the ExportService type
+type ExportService struct {
+	mock.Mock
+}
+
+// ExportReceptions provides a mock function with given fields: ctx, filter, fn
+func (_m *ExportService) ExportReceptions(ctx context.Context, filter domain.ReceptionExportFilter, fn domain.ReceptionExportRowFunc) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportReceptions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReceptionExportFilter, domain.ReceptionExportRowFunc) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExportService creates a new instance of ExportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportService {
+	mock := &ExportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
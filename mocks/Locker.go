@@ -0,0 +1,56 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Locker is an autogenerated mock type for the Locker type
+type Locker struct {
+	mock.Mock
+}
+
+// TryWithLock provides a mock function with given fields: ctx, key, fn
+func (_m *Locker) TryWithLock(ctx context.Context, key int64, fn func(context.Context) error) (bool, error) {
+	ret := _m.Called(ctx, key, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryWithLock")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, func(context.Context) error) (bool, error)); ok {
+		return rf(ctx, key, fn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, func(context.Context) error) bool); ok {
+		r0 = rf(ctx, key, fn)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, func(context.Context) error) error); ok {
+		r1 = rf(ctx, key, fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLocker creates a new instance of Locker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLocker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Locker {
+	mock := &Locker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,23 +2,42 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MetricsCollector is an autogenerated mock type for the MetricsCollector type
 type MetricsCollector struct {
 	mock.Mock
 }
 
+// IncGRPCRequestsTotal provides a mock function with given fields: method, code
+func (_m *MetricsCollector) IncGRPCRequestsTotal(method string, code string) {
+	_m.Called(method, code)
+}
+
 // IncPVZCreated provides a mock function with no fields
 func (_m *MetricsCollector) IncPVZCreated() {
 	_m.Called()
 }
 
+// IncProductTransitions provides a mock function with given fields: status
+func (_m *MetricsCollector) IncProductTransitions(status domain.ProductStatus) {
+	_m.Called(status)
+}
+
 // IncProductsAdded provides a mock function with no fields
 func (_m *MetricsCollector) IncProductsAdded() {
 	_m.Called()
 }
 
+// IncRateLimited provides a mock function with given fields: transport, route, key
+func (_m *MetricsCollector) IncRateLimited(transport string, route string, key string) {
+	_m.Called(transport, route, key)
+}
+
 // IncReceptionsCreated provides a mock function with no fields
 func (_m *MetricsCollector) IncReceptionsCreated() {
 	_m.Called()
@@ -29,11 +48,26 @@ func (_m *MetricsCollector) IncRequestsTotal(method string, path string, statusC
 	_m.Called(method, path, statusCode)
 }
 
+// IncStaleReceptions provides a mock function with given fields: action, reason
+func (_m *MetricsCollector) IncStaleReceptions(action string, reason domain.StaleReason) {
+	_m.Called(action, reason)
+}
+
+// ObserveGRPCRequestDuration provides a mock function with given fields: method, duration
+func (_m *MetricsCollector) ObserveGRPCRequestDuration(method string, duration float64) {
+	_m.Called(method, duration)
+}
+
 // ObserveRequestDuration provides a mock function with given fields: method, path, duration
 func (_m *MetricsCollector) ObserveRequestDuration(method string, path string, duration float64) {
 	_m.Called(method, path, duration)
 }
 
+// SetPVZOccupancy provides a mock function with given fields: occupancies
+func (_m *MetricsCollector) SetPVZOccupancy(occupancies []domain.PVZOccupancy) {
+	_m.Called(occupancies)
+}
+
 // NewMetricsCollector creates a new instance of MetricsCollector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMetricsCollector(t interface {
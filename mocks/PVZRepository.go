@@ -8,8 +8,6 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
-	time "time"
-
 	uuid "github.com/google/uuid"
 )
 
@@ -18,6 +16,34 @@ type PVZRepository struct {
 	mock.Mock
 }
 
+// Count provides a mock function with given fields: ctx, filter
+func (_m *PVZRepository) Count(ctx context.Context, filter domain.PVZListFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZListFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZListFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PVZListFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, pvz
 func (_m *PVZRepository) Create(ctx context.Context, pvz *domain.PVZ) error {
 	ret := _m.Called(ctx, pvz)
@@ -96,6 +122,66 @@ func (_m *PVZRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domai
 	return r0, r1
 }
 
+// GetOccupancy provides a mock function with given fields: ctx, pvzID
+func (_m *PVZRepository) GetOccupancy(ctx context.Context, pvzID uuid.UUID) (*domain.PVZOccupancy, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOccupancy")
+	}
+
+	var r0 *domain.PVZOccupancy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.PVZOccupancy, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.PVZOccupancy); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PVZOccupancy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *PVZRepository) List(ctx context.Context, filter domain.PVZListFilter) ([]domain.PVZ, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZListFilter) ([]domain.PVZ, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZListFilter) []domain.PVZ); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PVZ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PVZListFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListAll provides a mock function with given fields: ctx
 func (_m *PVZRepository) ListAll(ctx context.Context) ([]domain.PVZ, error) {
 	ret := _m.Called(ctx)
@@ -126,41 +212,52 @@ func (_m *PVZRepository) ListAll(ctx context.Context) ([]domain.PVZ, error) {
 	return r0, r1
 }
 
-// ListIDsAndTotal provides a mock function with given fields: ctx, limit, offset, startDate, endDate
-func (_m *PVZRepository) ListIDsAndTotal(ctx context.Context, limit int, offset int, startDate *time.Time, endDate *time.Time) ([]uuid.UUID, int, error) {
-	ret := _m.Called(ctx, limit, offset, startDate, endDate)
+// ListOccupancy provides a mock function with given fields: ctx
+func (_m *PVZRepository) ListOccupancy(ctx context.Context) ([]domain.PVZOccupancy, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListIDsAndTotal")
+		panic("no return value specified for ListOccupancy")
 	}
 
-	var r0 []uuid.UUID
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *time.Time, *time.Time) ([]uuid.UUID, int, error)); ok {
-		return rf(ctx, limit, offset, startDate, endDate)
+	var r0 []domain.PVZOccupancy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PVZOccupancy, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *time.Time, *time.Time) []uuid.UUID); ok {
-		r0 = rf(ctx, limit, offset, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.PVZOccupancy); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]uuid.UUID)
+			r0 = ret.Get(0).([]domain.PVZOccupancy)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, *time.Time, *time.Time) int); ok {
-		r1 = rf(ctx, limit, offset, startDate, endDate)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
-		r1 = ret.Get(1).(int)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCapacity provides a mock function with given fields: ctx, pvzID, capacity, expectedVersion
+func (_m *PVZRepository) SetCapacity(ctx context.Context, pvzID uuid.UUID, capacity domain.PVZCapacity, expectedVersion *int64) error {
+	ret := _m.Called(ctx, pvzID, capacity, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCapacity")
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, int, int, *time.Time, *time.Time) error); ok {
-		r2 = rf(ctx, limit, offset, startDate, endDate)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.PVZCapacity, *int64) error); ok {
+		r0 = rf(ctx, pvzID, capacity, expectedVersion)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
 // NewPVZRepository creates a new instance of PVZRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
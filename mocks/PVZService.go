@@ -8,7 +8,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
-	time "time"
+	uuid "github.com/google/uuid"
 )
 
 // PVZService is an autogenerated mock type for the PVZService type
@@ -46,41 +46,124 @@ func (_m *PVZService) CreatePVZ(ctx context.Context, city domain.City) (*domain.
 	return r0, r1
 }
 
-// ListPVZs provides a mock function with given fields: ctx, limit, page, startDate, endDate
-func (_m *PVZService) ListPVZs(ctx context.Context, limit int, page int, startDate *time.Time, endDate *time.Time) ([]domain.PVZWithDetails, int, error) {
-	ret := _m.Called(ctx, limit, page, startDate, endDate)
+// GetOccupancy provides a mock function with given fields: ctx, pvzID
+func (_m *PVZService) GetOccupancy(ctx context.Context, pvzID uuid.UUID) (*domain.PVZOccupancy, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOccupancy")
+	}
+
+	var r0 *domain.PVZOccupancy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.PVZOccupancy, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.PVZOccupancy); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PVZOccupancy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPVZ provides a mock function with given fields: ctx, id
+func (_m *PVZService) GetPVZ(ctx context.Context, id uuid.UUID) (*domain.PVZ, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZ")
+	}
+
+	var r0 *domain.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.PVZ, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.PVZ); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PVZ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPVZs provides a mock function with given fields: ctx, params
+func (_m *PVZService) ListPVZs(ctx context.Context, params domain.PVZListParams) (*domain.PVZListPage, error) {
+	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListPVZs")
 	}
 
-	var r0 []domain.PVZWithDetails
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *time.Time, *time.Time) ([]domain.PVZWithDetails, int, error)); ok {
-		return rf(ctx, limit, page, startDate, endDate)
+	var r0 *domain.PVZListPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZListParams) (*domain.PVZListPage, error)); ok {
+		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *time.Time, *time.Time) []domain.PVZWithDetails); ok {
-		r0 = rf(ctx, limit, page, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZListParams) *domain.PVZListPage); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.PVZWithDetails)
+			r0 = ret.Get(0).(*domain.PVZListPage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, *time.Time, *time.Time) int); ok {
-		r1 = rf(ctx, limit, page, startDate, endDate)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PVZListParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCapacity provides a mock function with given fields: ctx, pvzID, capacity, expectedVersion
+func (_m *PVZService) SetCapacity(ctx context.Context, pvzID uuid.UUID, capacity domain.PVZCapacity, expectedVersion *int64) (*domain.PVZOccupancy, error) {
+	ret := _m.Called(ctx, pvzID, capacity, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCapacity")
+	}
+
+	var r0 *domain.PVZOccupancy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.PVZCapacity, *int64) (*domain.PVZOccupancy, error)); ok {
+		return rf(ctx, pvzID, capacity, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.PVZCapacity, *int64) *domain.PVZOccupancy); ok {
+		r0 = rf(ctx, pvzID, capacity, expectedVersion)
 	} else {
-		r1 = ret.Get(1).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PVZOccupancy)
+		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, int, int, *time.Time, *time.Time) error); ok {
-		r2 = rf(ctx, limit, page, startDate, endDate)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.PVZCapacity, *int64) error); ok {
+		r1 = rf(ctx, pvzID, capacity, expectedVersion)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
 // NewPVZService creates a new instance of PVZService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
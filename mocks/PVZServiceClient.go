@@ -17,6 +17,154 @@ type PVZServiceClient struct {
 	mock.Mock
 }
 
+// AddProduct provides a mock function with given fields: ctx, in, opts
+func (_m *PVZServiceClient) AddProduct(ctx context.Context, in *pvz_v1.AddProductRequest, opts ...grpc.CallOption) (*pvz_v1.AddProductResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProduct")
+	}
+
+	var r0 *pvz_v1.AddProductResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.AddProductRequest, ...grpc.CallOption) (*pvz_v1.AddProductResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.AddProductRequest, ...grpc.CallOption) *pvz_v1.AddProductResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pvz_v1.AddProductResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pvz_v1.AddProductRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseReception provides a mock function with given fields: ctx, in, opts
+func (_m *PVZServiceClient) CloseReception(ctx context.Context, in *pvz_v1.CloseReceptionRequest, opts ...grpc.CallOption) (*pvz_v1.CloseReceptionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseReception")
+	}
+
+	var r0 *pvz_v1.CloseReceptionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.CloseReceptionRequest, ...grpc.CallOption) (*pvz_v1.CloseReceptionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.CloseReceptionRequest, ...grpc.CallOption) *pvz_v1.CloseReceptionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pvz_v1.CloseReceptionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pvz_v1.CloseReceptionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReception provides a mock function with given fields: ctx, in, opts
+func (_m *PVZServiceClient) CreateReception(ctx context.Context, in *pvz_v1.CreateReceptionRequest, opts ...grpc.CallOption) (*pvz_v1.CreateReceptionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReception")
+	}
+
+	var r0 *pvz_v1.CreateReceptionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.CreateReceptionRequest, ...grpc.CallOption) (*pvz_v1.CreateReceptionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.CreateReceptionRequest, ...grpc.CallOption) *pvz_v1.CreateReceptionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pvz_v1.CreateReceptionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pvz_v1.CreateReceptionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLastProduct provides a mock function with given fields: ctx, in, opts
+func (_m *PVZServiceClient) DeleteLastProduct(ctx context.Context, in *pvz_v1.DeleteLastProductRequest, opts ...grpc.CallOption) (*pvz_v1.DeleteLastProductResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLastProduct")
+	}
+
+	var r0 *pvz_v1.DeleteLastProductResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.DeleteLastProductRequest, ...grpc.CallOption) (*pvz_v1.DeleteLastProductResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.DeleteLastProductRequest, ...grpc.CallOption) *pvz_v1.DeleteLastProductResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pvz_v1.DeleteLastProductResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pvz_v1.DeleteLastProductRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPVZList provides a mock function with given fields: ctx, in, opts
 func (_m *PVZServiceClient) GetPVZList(ctx context.Context, in *pvz_v1.GetPVZListRequest, opts ...grpc.CallOption) (*pvz_v1.GetPVZListResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -14,6 +14,126 @@ type PVZServiceServer struct {
 	mock.Mock
 }
 
+// AddProduct provides a mock function with given fields: _a0, _a1
+func (_m *PVZServiceServer) AddProduct(_a0 context.Context, _a1 *pvz_v1.AddProductRequest) (*pvz_v1.AddProductResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProduct")
+	}
+
+	var r0 *pvz_v1.AddProductResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.AddProductRequest) (*pvz_v1.AddProductResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.AddProductRequest) *pvz_v1.AddProductResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pvz_v1.AddProductResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pvz_v1.AddProductRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseReception provides a mock function with given fields: _a0, _a1
+func (_m *PVZServiceServer) CloseReception(_a0 context.Context, _a1 *pvz_v1.CloseReceptionRequest) (*pvz_v1.CloseReceptionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseReception")
+	}
+
+	var r0 *pvz_v1.CloseReceptionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.CloseReceptionRequest) (*pvz_v1.CloseReceptionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.CloseReceptionRequest) *pvz_v1.CloseReceptionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pvz_v1.CloseReceptionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pvz_v1.CloseReceptionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReception provides a mock function with given fields: _a0, _a1
+func (_m *PVZServiceServer) CreateReception(_a0 context.Context, _a1 *pvz_v1.CreateReceptionRequest) (*pvz_v1.CreateReceptionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReception")
+	}
+
+	var r0 *pvz_v1.CreateReceptionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.CreateReceptionRequest) (*pvz_v1.CreateReceptionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.CreateReceptionRequest) *pvz_v1.CreateReceptionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pvz_v1.CreateReceptionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pvz_v1.CreateReceptionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLastProduct provides a mock function with given fields: _a0, _a1
+func (_m *PVZServiceServer) DeleteLastProduct(_a0 context.Context, _a1 *pvz_v1.DeleteLastProductRequest) (*pvz_v1.DeleteLastProductResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLastProduct")
+	}
+
+	var r0 *pvz_v1.DeleteLastProductResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.DeleteLastProductRequest) (*pvz_v1.DeleteLastProductResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pvz_v1.DeleteLastProductRequest) *pvz_v1.DeleteLastProductResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pvz_v1.DeleteLastProductResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pvz_v1.DeleteLastProductRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPVZList provides a mock function with given fields: _a0, _a1
 func (_m *PVZServiceServer) GetPVZList(_a0 context.Context, _a1 *pvz_v1.GetPVZListRequest) (*pvz_v1.GetPVZListResponse, error) {
 	ret := _m.Called(_a0, _a1)
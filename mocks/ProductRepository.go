@@ -82,6 +82,133 @@ func (_m *ProductRepository) FindLastByReceptionID(ctx context.Context, receptio
 	return r0, r1
 }
 
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Product, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InventoryByPVZIDs provides a mock function with given fields: ctx, pvzIDs
+func (_m *ProductRepository) InventoryByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID) (map[uuid.UUID]domain.PVZInventory, error) {
+	ret := _m.Called(ctx, pvzIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InventoryByPVZIDs")
+	}
+
+	var r0 map[uuid.UUID]domain.PVZInventory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) (map[uuid.UUID]domain.PVZInventory, error)); ok {
+		return rf(ctx, pvzIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) map[uuid.UUID]domain.PVZInventory); ok {
+		r0 = rf(ctx, pvzIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]domain.PVZInventory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, pvzIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByDispatchID provides a mock function with given fields: ctx, dispatchID
+func (_m *ProductRepository) ListByDispatchID(ctx context.Context, dispatchID uuid.UUID) ([]domain.Product, error) {
+	ret := _m.Called(ctx, dispatchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByDispatchID")
+	}
+
+	var r0 []domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Product, error)); ok {
+		return rf(ctx, dispatchID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Product); ok {
+		r0 = rf(ctx, dispatchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, dispatchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByReceptionID provides a mock function with given fields: ctx, receptionID, limit, offset
+func (_m *ProductRepository) ListByReceptionID(ctx context.Context, receptionID uuid.UUID, limit int, offset int) ([]domain.Product, int, error) {
+	ret := _m.Called(ctx, receptionID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByReceptionID")
+	}
+
+	var r0 []domain.Product
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) ([]domain.Product, int, error)); ok {
+		return rf(ctx, receptionID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) []domain.Product); ok {
+		r0 = rf(ctx, receptionID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) int); ok {
+		r1 = rf(ctx, receptionID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r2 = rf(ctx, receptionID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListByReceptionIDs provides a mock function with given fields: ctx, receptionIDs
 func (_m *ProductRepository) ListByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) (map[uuid.UUID][]domain.Product, error) {
 	ret := _m.Called(ctx, receptionIDs)
@@ -112,6 +239,36 @@ func (_m *ProductRepository) ListByReceptionIDs(ctx context.Context, receptionID
 	return r0, r1
 }
 
+// Transition provides a mock function with given fields: ctx, transition
+func (_m *ProductRepository) Transition(ctx context.Context, transition domain.ProductTransition) (*domain.Product, error) {
+	ret := _m.Called(ctx, transition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transition")
+	}
+
+	var r0 *domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProductTransition) (*domain.Product, error)); ok {
+		return rf(ctx, transition)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProductTransition) *domain.Product); ok {
+		r0 = rf(ctx, transition)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ProductTransition) error); ok {
+		r1 = rf(ctx, transition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewProductRepository creates a new instance of ProductRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProductRepository(t interface {
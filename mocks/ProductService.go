@@ -16,9 +16,9 @@ type ProductService struct {
 	mock.Mock
 }
 
-// AddProduct provides a mock function with given fields: ctx, pvzID, productType
-func (_m *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, productType domain.ProductType) (*domain.Product, error) {
-	ret := _m.Called(ctx, pvzID, productType)
+// AddProduct provides a mock function with given fields: ctx, pvzID, productType, itemID
+func (_m *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, productType domain.ProductType, itemID *string) (*domain.Product, error) {
+	ret := _m.Called(ctx, pvzID, productType, itemID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddProduct")
@@ -26,19 +26,19 @@ func (_m *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, produ
 
 	var r0 *domain.Product
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ProductType) (*domain.Product, error)); ok {
-		return rf(ctx, pvzID, productType)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ProductType, *string) (*domain.Product, error)); ok {
+		return rf(ctx, pvzID, productType, itemID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ProductType) *domain.Product); ok {
-		r0 = rf(ctx, pvzID, productType)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ProductType, *string) *domain.Product); ok {
+		r0 = rf(ctx, pvzID, productType, itemID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Product)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.ProductType) error); ok {
-		r1 = rf(ctx, pvzID, productType)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.ProductType, *string) error); ok {
+		r1 = rf(ctx, pvzID, productType, itemID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,6 +64,133 @@ func (_m *ProductService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID
 	return r0
 }
 
+// IssueProduct provides a mock function with given fields: ctx, id
+func (_m *ProductService) IssueProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueProduct")
+	}
+
+	var r0 *domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Product, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReceptionProducts provides a mock function with given fields: ctx, receptionID, limit, offset
+func (_m *ProductService) ListReceptionProducts(ctx context.Context, receptionID uuid.UUID, limit int, offset int) ([]domain.Product, int, error) {
+	ret := _m.Called(ctx, receptionID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReceptionProducts")
+	}
+
+	var r0 []domain.Product
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) ([]domain.Product, int, error)); ok {
+		return rf(ctx, receptionID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) []domain.Product); ok {
+		r0 = rf(ctx, receptionID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) int); ok {
+		r1 = rf(ctx, receptionID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r2 = rf(ctx, receptionID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MarkProductLost provides a mock function with given fields: ctx, id
+func (_m *ProductService) MarkProductLost(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkProductLost")
+	}
+
+	var r0 *domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Product, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReturnProduct provides a mock function with given fields: ctx, id
+func (_m *ProductService) ReturnProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnProduct")
+	}
+
+	var r0 *domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Product, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewProductService creates a new instance of ProductService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProductService(t interface {
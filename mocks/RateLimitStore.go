@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RateLimitStore is an autogenerated mock type for the RateLimitStore type
+type RateLimitStore struct {
+	mock.Mock
+}
+
+// Take provides a mock function with given fields: ctx, key, limit, now
+func (_m *RateLimitStore) Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error) {
+	ret := _m.Called(ctx, key, limit, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 domain.RateLimitDecision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.RateLimit, time.Time) (domain.RateLimitDecision, error)); ok {
+		return rf(ctx, key, limit, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.RateLimit, time.Time) domain.RateLimitDecision); ok {
+		r0 = rf(ctx, key, limit, now)
+	} else {
+		r0 = ret.Get(0).(domain.RateLimitDecision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.RateLimit, time.Time) error); ok {
+		r1 = rf(ctx, key, limit, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRateLimitStore creates a new instance of RateLimitStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimitStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimitStore {
+	mock := &RateLimitStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReceptionExportRowFunc is an autogenerated mock type for the ReceptionExportRowFunc type
+type ReceptionExportRowFunc struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: row
+func (_m *ReceptionExportRowFunc) Execute(row domain.ReceptionExportRow) error {
+	ret := _m.Called(row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.ReceptionExportRow) error); ok {
+		r0 = rf(row)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewReceptionExportRowFunc creates a new instance of ReceptionExportRowFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceptionExportRowFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceptionExportRowFunc {
+	mock := &ReceptionExportRowFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -18,6 +18,66 @@ type ReceptionRepository struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields: ctx, id, closedBy, expectedVersion
+func (_m *ReceptionRepository) Close(ctx context.Context, id uuid.UUID, closedBy *uuid.UUID, expectedVersion *int64) (*domain.Reception, error) {
+	ret := _m.Called(ctx, id, closedBy, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 *domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, *int64) (*domain.Reception, error)); ok {
+		return rf(ctx, id, closedBy, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, *int64) *domain.Reception); ok {
+		r0 = rf(ctx, id, closedBy, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *uuid.UUID, *int64) error); ok {
+		r1 = rf(ctx, id, closedBy, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseStale provides a mock function with given fields: ctx, openedBefore, reason
+func (_m *ReceptionRepository) CloseStale(ctx context.Context, openedBefore time.Time, reason domain.StaleReason) ([]domain.Reception, error) {
+	ret := _m.Called(ctx, openedBefore, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseStale")
+	}
+
+	var r0 []domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, domain.StaleReason) ([]domain.Reception, error)); ok {
+		return rf(ctx, openedBefore, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, domain.StaleReason) []domain.Reception); ok {
+		r0 = rf(ctx, openedBefore, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, domain.StaleReason) error); ok {
+		r1 = rf(ctx, openedBefore, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, reception
 func (_m *ReceptionRepository) Create(ctx context.Context, reception *domain.Reception) error {
 	ret := _m.Called(ctx, reception)
@@ -66,6 +126,36 @@ func (_m *ReceptionRepository) FindOpenByPVZID(ctx context.Context, pvzID uuid.U
 	return r0, r1
 }
 
+// FlagStale provides a mock function with given fields: ctx, openedBefore, reason
+func (_m *ReceptionRepository) FlagStale(ctx context.Context, openedBefore time.Time, reason domain.StaleReason) ([]domain.Reception, error) {
+	ret := _m.Called(ctx, openedBefore, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagStale")
+	}
+
+	var r0 []domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, domain.StaleReason) ([]domain.Reception, error)); ok {
+		return rf(ctx, openedBefore, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, domain.StaleReason) []domain.Reception); ok {
+		r0 = rf(ctx, openedBefore, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, domain.StaleReason) error); ok {
+		r1 = rf(ctx, openedBefore, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *ReceptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Reception, error) {
 	ret := _m.Called(ctx, id)
@@ -96,29 +186,96 @@ func (_m *ReceptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	return r0, r1
 }
 
-// ListByPVZIDsAndDate provides a mock function with given fields: ctx, pvzIDs, startDate, endDate
-func (_m *ReceptionRepository) ListByPVZIDsAndDate(ctx context.Context, pvzIDs []uuid.UUID, startDate *time.Time, endDate *time.Time) (map[uuid.UUID][]domain.ReceptionWithProducts, error) {
-	ret := _m.Called(ctx, pvzIDs, startDate, endDate)
+// GetWithProducts provides a mock function with given fields: ctx, id
+func (_m *ReceptionRepository) GetWithProducts(ctx context.Context, id uuid.UUID) (*domain.ReceptionWithProducts, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListByPVZIDsAndDate")
+		panic("no return value specified for GetWithProducts")
+	}
+
+	var r0 *domain.ReceptionWithProducts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.ReceptionWithProducts, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.ReceptionWithProducts); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ReceptionWithProducts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByPVZID provides a mock function with given fields: ctx, pvzID, filter
+func (_m *ReceptionRepository) ListByPVZID(ctx context.Context, pvzID uuid.UUID, filter domain.ReceptionListFilter) ([]domain.Reception, int, error) {
+	ret := _m.Called(ctx, pvzID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByPVZID")
+	}
+
+	var r0 []domain.Reception
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReceptionListFilter) ([]domain.Reception, int, error)); ok {
+		return rf(ctx, pvzID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReceptionListFilter) []domain.Reception); ok {
+		r0 = rf(ctx, pvzID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.ReceptionListFilter) int); ok {
+		r1 = rf(ctx, pvzID, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, domain.ReceptionListFilter) error); ok {
+		r2 = rf(ctx, pvzID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListByPVZIDs provides a mock function with given fields: ctx, pvzIDs, filter
+func (_m *ReceptionRepository) ListByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, filter domain.ReceptionEmbedFilter) (map[uuid.UUID][]domain.ReceptionWithProducts, error) {
+	ret := _m.Called(ctx, pvzIDs, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByPVZIDs")
 	}
 
 	var r0 map[uuid.UUID][]domain.ReceptionWithProducts
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) (map[uuid.UUID][]domain.ReceptionWithProducts, error)); ok {
-		return rf(ctx, pvzIDs, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, domain.ReceptionEmbedFilter) (map[uuid.UUID][]domain.ReceptionWithProducts, error)); ok {
+		return rf(ctx, pvzIDs, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) map[uuid.UUID][]domain.ReceptionWithProducts); ok {
-		r0 = rf(ctx, pvzIDs, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, domain.ReceptionEmbedFilter) map[uuid.UUID][]domain.ReceptionWithProducts); ok {
+		r0 = rf(ctx, pvzIDs, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[uuid.UUID][]domain.ReceptionWithProducts)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) error); ok {
-		r1 = rf(ctx, pvzIDs, startDate, endDate)
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, domain.ReceptionEmbedFilter) error); ok {
+		r1 = rf(ctx, pvzIDs, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -126,22 +283,34 @@ func (_m *ReceptionRepository) ListByPVZIDsAndDate(ctx context.Context, pvzIDs [
 	return r0, r1
 }
 
-// UpdateStatus provides a mock function with given fields: ctx, id, status
-func (_m *ReceptionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ReceptionStatus) error {
-	ret := _m.Called(ctx, id, status)
+// SummaryByPVZIDs provides a mock function with given fields: ctx, pvzIDs, startDate, endDate
+func (_m *ReceptionRepository) SummaryByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, startDate *time.Time, endDate *time.Time) (map[uuid.UUID]domain.PVZSummary, error) {
+	ret := _m.Called(ctx, pvzIDs, startDate, endDate)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateStatus")
+		panic("no return value specified for SummaryByPVZIDs")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReceptionStatus) error); ok {
-		r0 = rf(ctx, id, status)
+	var r0 map[uuid.UUID]domain.PVZSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) (map[uuid.UUID]domain.PVZSummary, error)); ok {
+		return rf(ctx, pvzIDs, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) map[uuid.UUID]domain.PVZSummary); ok {
+		r0 = rf(ctx, pvzIDs, startDate, endDate)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]domain.PVZSummary)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, pvzIDs, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewReceptionRepository creates a new instance of ReceptionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -16,9 +16,9 @@ type ReceptionService struct {
 	mock.Mock
 }
 
-// CloseReception provides a mock function with given fields: ctx, pvzID
-func (_m *ReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID) (*domain.Reception, error) {
-	ret := _m.Called(ctx, pvzID)
+// CloseReception provides a mock function with given fields: ctx, pvzID, expectedVersion
+func (_m *ReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID, expectedVersion *int64) (*domain.Reception, error) {
+	ret := _m.Called(ctx, pvzID, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CloseReception")
@@ -26,19 +26,19 @@ func (_m *ReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID)
 
 	var r0 *domain.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Reception, error)); ok {
-		return rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *int64) (*domain.Reception, error)); ok {
+		return rf(ctx, pvzID, expectedVersion)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Reception); ok {
-		r0 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *int64) *domain.Reception); ok {
+		r0 = rf(ctx, pvzID, expectedVersion)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Reception)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *int64) error); ok {
+		r1 = rf(ctx, pvzID, expectedVersion)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,9 +46,9 @@ func (_m *ReceptionService) CloseReception(ctx context.Context, pvzID uuid.UUID)
 	return r0, r1
 }
 
-// CreateReception provides a mock function with given fields: ctx, pvzID
-func (_m *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID) (*domain.Reception, error) {
-	ret := _m.Called(ctx, pvzID)
+// CreateReception provides a mock function with given fields: ctx, pvzID, shipmentID
+func (_m *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID, shipmentID *uuid.UUID) (*domain.Reception, error) {
+	ret := _m.Called(ctx, pvzID, shipmentID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateReception")
@@ -56,14 +56,44 @@ func (_m *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID
 
 	var r0 *domain.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Reception, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID) (*domain.Reception, error)); ok {
+		return rf(ctx, pvzID, shipmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID) *domain.Reception); ok {
+		r0 = rf(ctx, pvzID, shipmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(ctx, pvzID, shipmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrentReception provides a mock function with given fields: ctx, pvzID
+func (_m *ReceptionService) GetCurrentReception(ctx context.Context, pvzID uuid.UUID) (*domain.ReceptionDetails, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentReception")
+	}
+
+	var r0 *domain.ReceptionDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.ReceptionDetails, error)); ok {
 		return rf(ctx, pvzID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Reception); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.ReceptionDetails); ok {
 		r0 = rf(ctx, pvzID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Reception)
+			r0 = ret.Get(0).(*domain.ReceptionDetails)
 		}
 	}
 
@@ -76,6 +106,91 @@ func (_m *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID
 	return r0, r1
 }
 
+// GetReception provides a mock function with given fields: ctx, id
+func (_m *ReceptionService) GetReception(ctx context.Context, id uuid.UUID) (*domain.ReceptionDetails, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReception")
+	}
+
+	var r0 *domain.ReceptionDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.ReceptionDetails, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.ReceptionDetails); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ReceptionDetails)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPVZReceptions provides a mock function with given fields: ctx, pvzID, filter
+func (_m *ReceptionService) ListPVZReceptions(ctx context.Context, pvzID uuid.UUID, filter domain.ReceptionListFilter) ([]domain.Reception, int, error) {
+	ret := _m.Called(ctx, pvzID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPVZReceptions")
+	}
+
+	var r0 []domain.Reception
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReceptionListFilter) ([]domain.Reception, int, error)); ok {
+		return rf(ctx, pvzID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReceptionListFilter) []domain.Reception); ok {
+		r0 = rf(ctx, pvzID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.ReceptionListFilter) int); ok {
+		r1 = rf(ctx, pvzID, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, domain.ReceptionListFilter) error); ok {
+		r2 = rf(ctx, pvzID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReconcileReception provides a mock function with given fields: ctx, reception
+func (_m *ReceptionService) ReconcileReception(ctx context.Context, reception *domain.Reception) error {
+	ret := _m.Called(ctx, reception)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileReception")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Reception) error); ok {
+		r0 = rf(ctx, reception)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewReceptionService creates a new instance of ReceptionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewReceptionService(t interface {
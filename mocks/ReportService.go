@@ -0,0 +1,131 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ReportService is an autogenerated mock type for the ReportService type
+type ReportService struct {
+	mock.Mock
+}
+
+// CreateReport provides a mock function with given fields: ctx, reportType, format, params
+func (_m *ReportService) CreateReport(ctx context.Context, reportType domain.ReportType, format domain.ExportFormat, params domain.ReportParams) (*domain.Report, error) {
+	ret := _m.Called(ctx, reportType, format, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 *domain.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReportType, domain.ExportFormat, domain.ReportParams) (*domain.Report, error)); ok {
+		return rf(ctx, reportType, format, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReportType, domain.ExportFormat, domain.ReportParams) *domain.Report); ok {
+		r0 = rf(ctx, reportType, format, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ReportType, domain.ExportFormat, domain.ReportParams) error); ok {
+		r1 = rf(ctx, reportType, format, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReport provides a mock function with given fields: ctx, id
+func (_m *ReportService) GetReport(ctx context.Context, id uuid.UUID) (*domain.Report, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 *domain.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Report, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Report); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenReportResult provides a mock function with given fields: ctx, id
+func (_m *ReportService) OpenReportResult(ctx context.Context, id uuid.UUID) (*domain.Report, io.ReadCloser, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenReportResult")
+	}
+
+	var r0 *domain.Report
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Report, io.ReadCloser, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Report); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) io.ReadCloser); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewReportService creates a new instance of ReportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportService {
+	mock := &ReportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -17,44 +17,134 @@ type ServerInterface struct {
 	mock.Mock
 }
 
+// GetDispatchesDispatchId provides a mock function with given fields: c, dispatchId
+func (_m *ServerInterface) GetDispatchesDispatchId(c *gin.Context, dispatchId uuid.UUID) {
+	_m.Called(c, dispatchId)
+}
+
+// GetExpectedShipmentsShipmentId provides a mock function with given fields: c, shipmentId
+func (_m *ServerInterface) GetExpectedShipmentsShipmentId(c *gin.Context, shipmentId uuid.UUID) {
+	_m.Called(c, shipmentId)
+}
+
+// GetExportsReceptions provides a mock function with given fields: c, params
+func (_m *ServerInterface) GetExportsReceptions(c *gin.Context, params api.GetExportsReceptionsParams) {
+	_m.Called(c, params)
+}
+
 // GetPvz provides a mock function with given fields: c, params
 func (_m *ServerInterface) GetPvz(c *gin.Context, params api.GetPvzParams) {
 	_m.Called(c, params)
 }
 
+// GetPvzPvzId provides a mock function with given fields: c, pvzId, params
+func (_m *ServerInterface) GetPvzPvzId(c *gin.Context, pvzId uuid.UUID, params api.GetPvzPvzIdParams) {
+	_m.Called(c, pvzId, params)
+}
+
+// GetPvzPvzIdOccupancy provides a mock function with given fields: c, pvzId
+func (_m *ServerInterface) GetPvzPvzIdOccupancy(c *gin.Context, pvzId uuid.UUID) {
+	_m.Called(c, pvzId)
+}
+
+// GetPvzPvzIdReceptions provides a mock function with given fields: c, pvzId, params
+func (_m *ServerInterface) GetPvzPvzIdReceptions(c *gin.Context, pvzId uuid.UUID, params api.GetPvzPvzIdReceptionsParams) {
+	_m.Called(c, pvzId, params)
+}
+
+// GetPvzPvzIdReceptionsCurrent provides a mock function with given fields: c, pvzId, params
+func (_m *ServerInterface) GetPvzPvzIdReceptionsCurrent(c *gin.Context, pvzId uuid.UUID, params api.GetPvzPvzIdReceptionsCurrentParams) {
+	_m.Called(c, pvzId, params)
+}
+
+// GetReceptionsReceptionId provides a mock function with given fields: c, receptionId, params
+func (_m *ServerInterface) GetReceptionsReceptionId(c *gin.Context, receptionId uuid.UUID, params api.GetReceptionsReceptionIdParams) {
+	_m.Called(c, receptionId, params)
+}
+
+// GetReceptionsReceptionIdProducts provides a mock function with given fields: c, receptionId, params
+func (_m *ServerInterface) GetReceptionsReceptionIdProducts(c *gin.Context, receptionId uuid.UUID, params api.GetReceptionsReceptionIdProductsParams) {
+	_m.Called(c, receptionId, params)
+}
+
+// GetReceptionsReceptionIdReconciliation provides a mock function with given fields: c, receptionId
+func (_m *ServerInterface) GetReceptionsReceptionIdReconciliation(c *gin.Context, receptionId uuid.UUID) {
+	_m.Called(c, receptionId)
+}
+
+// GetReportsReportId provides a mock function with given fields: c, reportId
+func (_m *ServerInterface) GetReportsReportId(c *gin.Context, reportId uuid.UUID) {
+	_m.Called(c, reportId)
+}
+
+// GetReportsReportIdDownload provides a mock function with given fields: c, reportId
+func (_m *ServerInterface) GetReportsReportIdDownload(c *gin.Context, reportId uuid.UUID) {
+	_m.Called(c, reportId)
+}
+
+// GetStatsReceptions provides a mock function with given fields: c, params
+func (_m *ServerInterface) GetStatsReceptions(c *gin.Context, params api.GetStatsReceptionsParams) {
+	_m.Called(c, params)
+}
+
+// PostDispatchesDispatchIdSend provides a mock function with given fields: c, dispatchId, params
+func (_m *ServerInterface) PostDispatchesDispatchIdSend(c *gin.Context, dispatchId uuid.UUID, params api.PostDispatchesDispatchIdSendParams) {
+	_m.Called(c, dispatchId, params)
+}
+
 // PostDummyLogin provides a mock function with given fields: c
 func (_m *ServerInterface) PostDummyLogin(c *gin.Context) {
 	_m.Called(c)
 }
 
+// PostExpectedShipments provides a mock function with given fields: c, params
+func (_m *ServerInterface) PostExpectedShipments(c *gin.Context, params api.PostExpectedShipmentsParams) {
+	_m.Called(c, params)
+}
+
 // PostLogin provides a mock function with given fields: c
 func (_m *ServerInterface) PostLogin(c *gin.Context) {
 	_m.Called(c)
 }
 
-// PostProducts provides a mock function with given fields: c
-func (_m *ServerInterface) PostProducts(c *gin.Context) {
-	_m.Called(c)
+// PostProducts provides a mock function with given fields: c, params
+func (_m *ServerInterface) PostProducts(c *gin.Context, params api.PostProductsParams) {
+	_m.Called(c, params)
 }
 
-// PostPvz provides a mock function with given fields: c
-func (_m *ServerInterface) PostPvz(c *gin.Context) {
-	_m.Called(c)
+// PostProductsProductIdIssue provides a mock function with given fields: c, productId, params
+func (_m *ServerInterface) PostProductsProductIdIssue(c *gin.Context, productId uuid.UUID, params api.PostProductsProductIdIssueParams) {
+	_m.Called(c, productId, params)
 }
 
-// PostPvzPvzIdCloseLastReception provides a mock function with given fields: c, pvzId
-func (_m *ServerInterface) PostPvzPvzIdCloseLastReception(c *gin.Context, pvzId uuid.UUID) {
-	_m.Called(c, pvzId)
+// PostProductsProductIdMarkLost provides a mock function with given fields: c, productId, params
+func (_m *ServerInterface) PostProductsProductIdMarkLost(c *gin.Context, productId uuid.UUID, params api.PostProductsProductIdMarkLostParams) {
+	_m.Called(c, productId, params)
 }
 
-// PostPvzPvzIdDeleteLastProduct provides a mock function with given fields: c, pvzId
-func (_m *ServerInterface) PostPvzPvzIdDeleteLastProduct(c *gin.Context, pvzId uuid.UUID) {
-	_m.Called(c, pvzId)
+// PostProductsProductIdReturn provides a mock function with given fields: c, productId, params
+func (_m *ServerInterface) PostProductsProductIdReturn(c *gin.Context, productId uuid.UUID, params api.PostProductsProductIdReturnParams) {
+	_m.Called(c, productId, params)
 }
 
-// PostReceptions provides a mock function with given fields: c
-func (_m *ServerInterface) PostReceptions(c *gin.Context) {
-	_m.Called(c)
+// PostPvz provides a mock function with given fields: c, params
+func (_m *ServerInterface) PostPvz(c *gin.Context, params api.PostPvzParams) {
+	_m.Called(c, params)
+}
+
+// PostPvzPvzIdCloseLastReception provides a mock function with given fields: c, pvzId, params
+func (_m *ServerInterface) PostPvzPvzIdCloseLastReception(c *gin.Context, pvzId uuid.UUID, params api.PostPvzPvzIdCloseLastReceptionParams) {
+	_m.Called(c, pvzId, params)
+}
+
+// PostPvzPvzIdDeleteLastProduct provides a mock function with given fields: c, pvzId, params
+func (_m *ServerInterface) PostPvzPvzIdDeleteLastProduct(c *gin.Context, pvzId uuid.UUID, params api.PostPvzPvzIdDeleteLastProductParams) {
+	_m.Called(c, pvzId, params)
+}
+
+// PostReceptions provides a mock function with given fields: c, params
+func (_m *ServerInterface) PostReceptions(c *gin.Context, params api.PostReceptionsParams) {
+	_m.Called(c, params)
 }
 
 // PostRegister provides a mock function with given fields: c
@@ -62,6 +152,16 @@ func (_m *ServerInterface) PostRegister(c *gin.Context) {
 	_m.Called(c)
 }
 
+// PostReports provides a mock function with given fields: c, params
+func (_m *ServerInterface) PostReports(c *gin.Context, params api.PostReportsParams) {
+	_m.Called(c, params)
+}
+
+// PutPvzPvzIdCapacity provides a mock function with given fields: c, pvzId, params
+func (_m *ServerInterface) PutPvzPvzIdCapacity(c *gin.Context, pvzId uuid.UUID, params api.PutPvzPvzIdCapacityParams) {
+	_m.Called(c, pvzId, params)
+}
+
 // NewServerInterface creates a new instance of ServerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServerInterface(t interface {
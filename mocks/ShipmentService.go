@@ -0,0 +1,121 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ShipmentService is an autogenerated mock type for the ShipmentService type
+type ShipmentService struct {
+	mock.Mock
+}
+
+// CreateShipment provides a mock function with given fields: ctx, pvzID, reference, lines
+func (_m *ShipmentService) CreateShipment(ctx context.Context, pvzID uuid.UUID, reference *string, lines []domain.ExpectedShipmentLine) (*domain.ExpectedShipment, error) {
+	ret := _m.Called(ctx, pvzID, reference, lines)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShipment")
+	}
+
+	var r0 *domain.ExpectedShipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *string, []domain.ExpectedShipmentLine) (*domain.ExpectedShipment, error)); ok {
+		return rf(ctx, pvzID, reference, lines)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *string, []domain.ExpectedShipmentLine) *domain.ExpectedShipment); ok {
+		r0 = rf(ctx, pvzID, reference, lines)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpectedShipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *string, []domain.ExpectedShipmentLine) error); ok {
+		r1 = rf(ctx, pvzID, reference, lines)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReconciliation provides a mock function with given fields: ctx, receptionID
+func (_m *ShipmentService) GetReconciliation(ctx context.Context, receptionID uuid.UUID) (*domain.Reconciliation, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReconciliation")
+	}
+
+	var r0 *domain.Reconciliation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Reconciliation, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Reconciliation); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Reconciliation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShipment provides a mock function with given fields: ctx, id
+func (_m *ShipmentService) GetShipment(ctx context.Context, id uuid.UUID) (*domain.ExpectedShipment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShipment")
+	}
+
+	var r0 *domain.ExpectedShipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.ExpectedShipment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.ExpectedShipment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExpectedShipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewShipmentService creates a new instance of ShipmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShipmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShipmentService {
+	mock := &ShipmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "pvz-service-avito-internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StatsRepository is an autogenerated mock type for the StatsRepository type
+type StatsRepository struct {
+	mock.Mock
+}
+
+// PVZActivity provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) PVZActivity(ctx context.Context, filter domain.PVZActivityFilter) ([]domain.PVZActivityRow, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PVZActivity")
+	}
+
+	var r0 []domain.PVZActivityRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZActivityFilter) ([]domain.PVZActivityRow, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZActivityFilter) []domain.PVZActivityRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PVZActivityRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PVZActivityFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionStats provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) ReceptionStats(ctx context.Context, filter domain.ReceptionStatsFilter) (*domain.ReceptionStats, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReceptionStats")
+	}
+
+	var r0 *domain.ReceptionStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReceptionStatsFilter) (*domain.ReceptionStats, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReceptionStatsFilter) *domain.ReceptionStats); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ReceptionStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ReceptionStatsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStatsRepository creates a new instance of StatsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatsRepository {
+	mock := &StatsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -30,6 +30,7 @@ func NewPostgresPool(ctx context.Context, dsn string, logger *slog.Logger) (*pgx
 	config.MaxConnIdleTime = defaultMaxConnIdleTime
 	config.MaxConnLifetime = defaultMaxConnLifetime
 	config.HealthCheckPeriod = defaultHealthCheckPeriod
+	config.ConnConfig.Tracer = NewQueryTracer(logger)
 
 	connCtx, cancel := context.WithTimeout(ctx, connectTimeout)
 	defer cancel()
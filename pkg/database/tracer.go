@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"pvz-service-avito-internship/pkg/requestid"
+)
+
+const tracerName = "pvz-service-avito-internship/pkg/database"
+
+// QueryTracer реализует pgx.QueryTracer: создает span для каждого SQL запроса
+// и пишет запрос с аргументами и Request ID в лог на уровне Debug.
+type QueryTracer struct {
+	log    *slog.Logger
+	tracer trace.Tracer
+}
+
+// NewQueryTracer создает новый экземпляр QueryTracer.
+func NewQueryTracer(log *slog.Logger) *QueryTracer {
+	return &QueryTracer{
+		log:    log,
+		tracer: otel.Tracer(tracerName),
+	}
+}
+
+// TraceQueryStart начинает span запроса, дочерний к span из ctx.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryOperation(data.SQL)
+	ctx, span := t.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+
+	t.log.DebugContext(ctx, "Executing SQL query",
+		slog.String("request_id", requestid.FromContext(ctx)),
+		slog.String("trace_id", span.SpanContext().TraceID().String()),
+		slog.String("query", data.SQL),
+		slog.Any("args", data.Args),
+	)
+	return ctx
+}
+
+// TraceQueryEnd завершает span запроса. pgx.ErrNoRows ошибкой не считается.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+// queryOperation возвращает первое ключевое слово запроса (SELECT, INSERT, WITH и т.п.) для имени span.
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "postgresql"
+	}
+	return strings.ToUpper(fields[0])
+}
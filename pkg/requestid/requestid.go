@@ -0,0 +1,43 @@
+package requestid
+
+import "context"
+
+const (
+	// Header - HTTP заголовок с Request ID. В gRPC используется тот же ключ метаданных в нижнем регистре.
+	Header = "X-Request-ID"
+	// MaxLength - максимальная длина Request ID, принимаемого от клиента.
+	MaxLength = 128
+)
+
+// contextKey - ключ контекста, под которым хранится Request ID. Тип неэкспортируемый,
+// поэтому записать и прочитать значение можно только через NewContext и FromContext.
+type contextKey struct{}
+
+// NewContext возвращает копию ctx с Request ID id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext возвращает Request ID из ctx или пустую строку, если его нет.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Valid сообщает, можно ли принять id от клиента: непустая строка не длиннее MaxLength
+// из латинских букв, цифр и символов "-", "_", ".", ":".
+// Ограничение не дает записать в логи и заголовки ответа произвольные данные.
+func Valid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		switch ch := id[i]; {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9':
+		case ch == '-', ch == '_', ch == '.', ch == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package requestid_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"pvz-service-avito-internship/pkg/requestid"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{name: "UUID", id: "0b5c7d5e-3f0a-4d8e-9a51-6a3c2e1f0b9d", want: true},
+		{name: "ProxyFormat", id: "edge-01:1713430000.123_42", want: true},
+		{name: "MaxLength", id: strings.Repeat("a", requestid.MaxLength), want: true},
+		{name: "Empty", id: "", want: false},
+		{name: "TooLong", id: strings.Repeat("a", requestid.MaxLength+1), want: false},
+		{name: "Space", id: "req 1", want: false},
+		{name: "NewLine", id: "req-1\nlevel=ERROR", want: false},
+		{name: "NonASCII", id: "запрос-1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, requestid.Valid(tt.id))
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Empty(t, requestid.FromContext(context.Background()))
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	assert.Equal(t, "req-1", requestid.FromContext(ctx))
+
+	// Значение под другим ключом с тем же именем не считается Request ID.
+	type otherKey string
+	assert.Empty(t, requestid.FromContext(context.WithValue(context.Background(), otherKey("requestID"), "req-1")))
+}
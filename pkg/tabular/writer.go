@@ -0,0 +1,71 @@
+// Package tabular предоставляет построчную запись табличных данных в CSV или XLSX через общий интерфейс.
+package tabular
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"pvz-service-avito-internship/pkg/xlsx"
+)
+
+// Поддерживаемые форматы.
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// csvContentType - MIME тип CSV.
+const csvContentType = "text/csv; charset=utf-8"
+
+// utf8BOM позволяет Excel корректно открыть CSV с кириллицей.
+const utf8BOM = "\xEF\xBB\xBF"
+
+// Writer пишет строки таблицы. Close завершает файл, но не закрывает нижележащий io.Writer.
+type Writer interface {
+	WriteRow(cells []string) error
+	Flush() error
+	Close() error
+}
+
+// NewWriter создает Writer для формата format ("csv" или "xlsx").
+// Для CSV сразу записывается UTF-8 BOM, для XLSX - служебные части книги.
+func NewWriter(format string, w io.Writer, sheetName string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return nil, fmt.Errorf("tabular: writing BOM: %w", err)
+		}
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return xlsx.NewWriter(w, sheetName)
+	default:
+		return nil, fmt.Errorf("tabular: unsupported format '%s'", format)
+	}
+}
+
+// ContentType возвращает MIME тип файла формата format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return xlsx.ContentType
+	}
+	return csvContentType
+}
+
+// csvWriter адаптирует csv.Writer к интерфейсу Writer.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvWriter) WriteRow(cells []string) error {
+	return cw.w.Write(cells)
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvWriter) Close() error {
+	return cw.Flush()
+}
@@ -0,0 +1,72 @@
+package tabular_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/pkg/tabular"
+	"pvz-service-avito-internship/pkg/xlsx"
+)
+
+func TestNewWriter(t *testing.T) {
+	testCases := []struct {
+		name        string
+		format      string
+		contentType string
+		wantErr     bool
+		check       func(t *testing.T, out []byte)
+	}{
+		{
+			name:        "CSV",
+			format:      tabular.FormatCSV,
+			contentType: "text/csv; charset=utf-8",
+			check: func(t *testing.T, out []byte) {
+				assert.Equal(t, "\xEF\xBB\xBFid,city\n1,\"Москва, центр\"\n", string(out))
+			},
+		},
+		{
+			name:        "XLSX",
+			format:      tabular.FormatXLSX,
+			contentType: xlsx.ContentType,
+			check: func(t *testing.T, out []byte) {
+				zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+				require.NoError(t, err)
+				names := make([]string, 0, len(zr.File))
+				for _, f := range zr.File {
+					names = append(names, f.Name)
+				}
+				assert.Contains(t, names, "xl/worksheets/sheet1.xml")
+			},
+		},
+		{
+			name:        "Unknown_Format",
+			format:      "pdf",
+			contentType: "text/csv; charset=utf-8",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.contentType, tabular.ContentType(tc.format))
+
+			var buf bytes.Buffer
+			w, err := tabular.NewWriter(tc.format, &buf, "Sheet")
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "unsupported format 'pdf'")
+				assert.Nil(t, w)
+				assert.Zero(t, buf.Len())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, w.WriteRow([]string{"id", "city"}))
+			require.NoError(t, w.WriteRow([]string{"1", "Москва, центр"}))
+			require.NoError(t, w.Close())
+			tc.check(t, buf.Bytes())
+		})
+	}
+}
@@ -1,38 +1,120 @@
 package validator
 
 import (
+	"bufio"
 	"fmt"
-	"github.com/go-playground/validator/v10"
+	"os"
 	"reflect"
-	"regexp"
+	"strconv"
 	"strings"
-)
+	"unicode/utf8"
 
-const (
-	passwordMinLength = 8
-	passwordMaxLength = 32
-	passwordMinLower  = 1
-	passwordMinUpper  = 1
-	passwordMinDigit  = 1
-	passwordMinSymbol = 1
+	"github.com/go-playground/validator/v10"
 )
 
-var (
-	lengthRegexp    = regexp.MustCompile(fmt.Sprintf(`^.{%d,%d}$`, passwordMinLength, passwordMaxLength))
-	lowerCaseRegexp = regexp.MustCompile(fmt.Sprintf(`[a-z]{%d,}`, passwordMinLower))
-	upperCaseRegexp = regexp.MustCompile(fmt.Sprintf(`[A-Z]{%d,}`, passwordMinUpper))
-	digitRegexp     = regexp.MustCompile(fmt.Sprintf(`[0-9]{%d,}`, passwordMinDigit))
-	symbolRegexp    = regexp.MustCompile(fmt.Sprintf(`[!@#$%%^&*]{%d,}`, passwordMinSymbol))
-)
+// PasswordPolicy задает требования к паролю для правила проверки password.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	MinLower  int
+	MinUpper  int
+	MinDigit  int
+	MinSymbol int
+	// Symbols - набор спецсимволов, учитываемых в MinSymbol.
+	Symbols string
+	// Blocklist - скомпрометированные пароли в нижнем регистре. Пустой список отключает проверку.
+	Blocklist map[string]struct{}
+}
+
+// DefaultPasswordPolicy возвращает требования к паролю по умолчанию.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength: 8,
+		MaxLength: 32,
+		MinLower:  1,
+		MinUpper:  1,
+		MinDigit:  1,
+		MinSymbol: 1,
+		Symbols:   "!@#$%^&*",
+	}
+}
+
+// LoadPasswordBlocklist читает файл со списком скомпрометированных паролей: по одному паролю в строке,
+// пустые строки и строки, начинающиеся с #, пропускаются.
+func LoadPasswordBlocklist(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open password blocklist: %w", err)
+	}
+	defer file.Close()
+
+	blocklist := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		blocklist[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read password blocklist: %w", err)
+	}
+	return blocklist, nil
+}
 
+// Check возвращает все требования политики, которым не соответствует пароль поля field.
+func (p PasswordPolicy) Check(field, password string) ValidationErrors {
+	var errs ValidationErrors
+
+	if length := utf8.RuneCountInString(password); length < p.MinLength || length > p.MaxLength {
+		errs = append(errs, &FieldError{Field: field, Tag: "password_length", Params: []string{strconv.Itoa(p.MinLength), strconv.Itoa(p.MaxLength)}})
+	}
+
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower++
+		case r >= 'A' && r <= 'Z':
+			upper++
+		case r >= '0' && r <= '9':
+			digit++
+		case strings.ContainsRune(p.Symbols, r):
+			symbol++
+		}
+	}
+	if lower < p.MinLower {
+		errs = append(errs, &FieldError{Field: field, Tag: "password_lower", Params: []string{strconv.Itoa(p.MinLower)}})
+	}
+	if upper < p.MinUpper {
+		errs = append(errs, &FieldError{Field: field, Tag: "password_upper", Params: []string{strconv.Itoa(p.MinUpper)}})
+	}
+	if digit < p.MinDigit {
+		errs = append(errs, &FieldError{Field: field, Tag: "password_digit", Params: []string{strconv.Itoa(p.MinDigit)}})
+	}
+	if symbol < p.MinSymbol {
+		errs = append(errs, &FieldError{Field: field, Tag: "password_symbol", Params: []string{strconv.Itoa(p.MinSymbol), p.Symbols}})
+	}
+
+	if _, ok := p.Blocklist[strings.ToLower(password)]; ok {
+		errs = append(errs, &FieldError{Field: field, Tag: "password_breached"})
+	}
+
+	return errs
+}
+
+// CustomValidator проверяет структуры по тегам validate. Не хранит состояния между вызовами,
+// поэтому один экземпляр можно использовать из разных горутин.
 type CustomValidator struct {
-	v         *validator.Validate
-	passwdErr error
+	v      *validator.Validate
+	policy PasswordPolicy
 }
 
-func NewCustomValidator() *CustomValidator {
+// NewCustomValidator создает новый экземпляр CustomValidator с требованиями к паролю policy.
+func NewCustomValidator(policy PasswordPolicy) *CustomValidator {
 	v := validator.New()
-	cv := &CustomValidator{v: v}
+	cv := &CustomValidator{v: v, policy: policy}
 
 	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
 		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
@@ -50,57 +132,99 @@ func NewCustomValidator() *CustomValidator {
 	return cv
 }
 
-func (cv *CustomValidator) Validate(i interface{}) error {
-	err := cv.v.Struct(i)
-	if err != nil {
-		fieldErr := err.(validator.ValidationErrors)[0]
-
-		return cv.newValidationError(fieldErr.Field(), fieldErr.Tag(), fieldErr.Param())
-	}
-	return nil
+// FieldError - ошибка проверки поля Field правилом Tag с параметрами Params.
+// Error возвращает сообщение на английском; переводы хранятся в каталоге сообщений по ключу validation.<Tag>.
+type FieldError struct {
+	Field  string
+	Tag    string
+	Params []string
 }
 
-func (cv *CustomValidator) newValidationError(field string, tag string, param string) error {
-	switch tag {
+func (e *FieldError) Error() string {
+	switch e.Tag {
 	case "required":
-		return fmt.Errorf("field %s is required", field)
+		return fmt.Sprintf("field %s is required", e.Field)
 	case "email":
-		return fmt.Errorf("field %s must be a valid email address", field)
-	case "password":
-		return cv.passwdErr
+		return fmt.Sprintf("field %s must be a valid email address", e.Field)
 	case "min":
-		return fmt.Errorf("field %s must be at least %s characters", field, param)
+		return fmt.Sprintf("field %s must be at least %s characters", e.Field, e.param(0))
 	case "max":
-		return fmt.Errorf("field %s must be at most %s characters", field, param)
+		return fmt.Sprintf("field %s must be at most %s characters", e.Field, e.param(0))
+	case "password_type":
+		return fmt.Sprintf("field %s must be a string", e.Field)
+	case "password_length":
+		return fmt.Sprintf("field %s must be between %s and %s characters", e.Field, e.param(0), e.param(1))
+	case "password_lower":
+		return fmt.Sprintf("field %s must contain at least %s lowercase letter(s)", e.Field, e.param(0))
+	case "password_upper":
+		return fmt.Sprintf("field %s must contain at least %s uppercase letter(s)", e.Field, e.param(0))
+	case "password_digit":
+		return fmt.Sprintf("field %s must contain at least %s digit(s)", e.Field, e.param(0))
+	case "password_symbol":
+		return fmt.Sprintf("field %s must contain at least %s special character(s) from %s", e.Field, e.param(0), e.param(1))
+	case "password_breached":
+		return fmt.Sprintf("field %s is a known compromised password", e.Field)
 	default:
-		return fmt.Errorf("field %s is invalid", field)
+		return fmt.Sprintf("field %s is invalid", e.Field)
 	}
 }
 
-func (cv *CustomValidator) passwordValidate(fl validator.FieldLevel) bool {
-	if fl.Field().Kind() != reflect.String {
-		cv.passwdErr = fmt.Errorf("field %s must be a string", fl.FieldName())
-		return false
+func (e *FieldError) param(i int) string {
+	if i < len(e.Params) {
+		return e.Params[i]
 	}
+	return ""
+}
 
-	fieldValue := fl.Field().String()
+// ValidationErrors - все ошибки проверки одной структуры.
+type ValidationErrors []*FieldError
 
-	if ok := lengthRegexp.MatchString(fieldValue); !ok {
-		cv.passwdErr = fmt.Errorf("field %s must be between %d and %d characters", fl.FieldName(), passwordMinLength, passwordMaxLength)
-		return false
-	} else if ok = lowerCaseRegexp.MatchString(fieldValue); !ok {
-		cv.passwdErr = fmt.Errorf("field %s must contain at least %d lowercase letter(s)", fl.FieldName(), passwordMinLower)
-		return false
-	} else if ok = upperCaseRegexp.MatchString(fieldValue); !ok {
-		cv.passwdErr = fmt.Errorf("field %s must contain at least %d uppercase letter(s)", fl.FieldName(), passwordMinUpper)
-		return false
-	} else if ok = digitRegexp.MatchString(fieldValue); !ok {
-		cv.passwdErr = fmt.Errorf("field %s must contain at least %d digit(s)", fl.FieldName(), passwordMinDigit)
-		return false
-	} else if ok = symbolRegexp.MatchString(fieldValue); !ok {
-		cv.passwdErr = fmt.Errorf("field %s must contain at least %d special character(s)", fl.FieldName(), passwordMinSymbol)
-		return false
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Error())
 	}
+	return strings.Join(messages, "; ")
+}
 
-	return true
+// Validate проверяет структуру и возвращает ValidationErrors со всеми нарушенными правилами всех полей.
+func (cv *CustomValidator) Validate(i interface{}) error {
+	err := cv.v.Struct(i)
+	if err == nil {
+		return nil
+	}
+	fieldErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	var errs ValidationErrors
+	for _, fieldErr := range fieldErrs {
+		if fieldErr.Tag() == "password" {
+			errs = append(errs, cv.passwordErrors(fieldErr)...)
+			continue
+		}
+
+		var params []string
+		if fieldErr.Param() != "" {
+			params = []string{fieldErr.Param()}
+		}
+		errs = append(errs, &FieldError{Field: fieldErr.Field(), Tag: fieldErr.Tag(), Params: params})
+	}
+	return errs
+}
+
+// passwordErrors повторно проверяет значение поля, не прошедшего правило password, и возвращает причины отказа.
+func (cv *CustomValidator) passwordErrors(fieldErr validator.FieldError) ValidationErrors {
+	if fieldErr.Kind() != reflect.String {
+		return ValidationErrors{{Field: fieldErr.Field(), Tag: "password_type"}}
+	}
+	return cv.policy.Check(fieldErr.Field(), reflect.ValueOf(fieldErr.Value()).String())
+}
+
+func (cv *CustomValidator) passwordValidate(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	return len(cv.policy.Check(fl.FieldName(), fl.Field().String())) == 0
 }
@@ -0,0 +1,92 @@
+package validator_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/pkg/validator"
+)
+
+type passwordRequest struct {
+	Password string `json:"password" validate:"required,password"`
+}
+
+func tags(t *testing.T, err error) []string {
+	t.Helper()
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	result := make([]string, 0, len(errs))
+	for _, fieldErr := range errs {
+		assert.Equal(t, "password", fieldErr.Field)
+		result = append(result, fieldErr.Tag)
+	}
+	return result
+}
+
+func TestCustomValidator_Password(t *testing.T) {
+	cv := validator.NewCustomValidator(validator.DefaultPasswordPolicy())
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, cv.Validate(&passwordRequest{Password: "Passw0rd!"}))
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		assert.Equal(t, []string{"required"}, tags(t, cv.Validate(&passwordRequest{})))
+	})
+
+	t.Run("Reports_All_Failed_Rules", func(t *testing.T) {
+		err := cv.Validate(&passwordRequest{Password: "short"})
+		assert.Equal(t, []string{"password_length", "password_upper", "password_digit", "password_symbol"}, tags(t, err))
+	})
+
+	t.Run("Symbol_Params_Include_Allowed_Symbols", func(t *testing.T) {
+		var errs validator.ValidationErrors
+		require.ErrorAs(t, cv.Validate(&passwordRequest{Password: "Password1"}), &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, []string{"1", "!@#$%^&*"}, errs[0].Params)
+	})
+}
+
+func TestCustomValidator_CustomPolicy(t *testing.T) {
+	blocklistFile := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(blocklistFile, []byte("# breached\n\nQwerty123456\n"), 0o600))
+	blocklist, err := validator.LoadPasswordBlocklist(blocklistFile)
+	require.NoError(t, err)
+
+	policy := validator.PasswordPolicy{MinLength: 12, MaxLength: 64, MinDigit: 2, Blocklist: blocklist}
+	cv := validator.NewCustomValidator(policy)
+
+	assert.NoError(t, cv.Validate(&passwordRequest{Password: "long enough 42"}))
+	assert.Equal(t, []string{"password_length", "password_digit"}, tags(t, cv.Validate(&passwordRequest{Password: "short1"})))
+	assert.Equal(t, []string{"password_breached"}, tags(t, cv.Validate(&passwordRequest{Password: "qwerty123456"})))
+}
+
+func TestCustomValidator_Concurrent(t *testing.T) {
+	cv := validator.NewCustomValidator(validator.DefaultPasswordPolicy())
+	cases := map[string]string{
+		"passw0rd!": "password_upper",
+		"PASSW0RD!": "password_lower",
+		"Password!": "password_digit",
+		"Passw0rd1": "password_symbol",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		for password, expected := range cases {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var errs validator.ValidationErrors
+				if assert.ErrorAs(t, cv.Validate(&passwordRequest{Password: password}), &errs) && assert.Len(t, errs, 1) {
+					assert.Equal(t, expected, errs[0].Tag)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,166 @@
+// Package xlsx реализует минимальную потоковую запись XLSX (Office Open XML) с одним листом.
+// Строки пишутся сразу в zip-поток как inline-строки, без таблицы sharedStrings,
+// поэтому память не зависит от количества строк.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ContentType - MIME тип XLSX файла.
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+const (
+	contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+
+	workbookXMLTemplate = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+
+	sheetHeaderXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	sheetFooterXML = `</sheetData></worksheet>`
+)
+
+// ErrClosed возвращается при записи в уже закрытый Writer.
+var ErrClosed = errors.New("xlsx: writer is closed")
+
+// Writer пишет книгу с одним листом. Не безопасен для конкурентного использования.
+type Writer struct {
+	zw     *zip.Writer
+	sheet  *bufio.Writer
+	rowNum int
+	closed bool
+}
+
+// NewWriter записывает служебные части книги и открывает лист sheetName для потоковой записи строк.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+
+	var escapedName strings.Builder
+	if err := xml.EscapeText(&escapedName, []byte(sheetName)); err != nil {
+		return nil, fmt.Errorf("xlsx: escaping sheet name: %w", err)
+	}
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXMLTemplate, escapedName.String())},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+	}
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("xlsx: creating %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(fw, part.body); err != nil {
+			return nil, fmt.Errorf("xlsx: writing %s: %w", part.name, err)
+		}
+	}
+
+	// Лист создается последним: zip допускает только одну открытую запись, и она остается открытой до Close.
+	fw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: creating sheet: %w", err)
+	}
+	sheet := bufio.NewWriter(fw)
+	if _, err := sheet.WriteString(sheetHeaderXML); err != nil {
+		return nil, fmt.Errorf("xlsx: writing sheet header: %w", err)
+	}
+
+	return &Writer{zw: zw, sheet: sheet}, nil
+}
+
+// WriteRow добавляет строку из текстовых ячеек. Пустая строка дает пустую ячейку.
+func (w *Writer) WriteRow(cells []string) error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.rowNum++
+	row := strconv.Itoa(w.rowNum)
+
+	w.sheet.WriteString(`<row r="`)
+	w.sheet.WriteString(row)
+	w.sheet.WriteString(`">`)
+	for i, value := range cells {
+		if value == "" {
+			continue
+		}
+		w.sheet.WriteString(`<c r="`)
+		w.sheet.WriteString(columnName(i))
+		w.sheet.WriteString(row)
+		w.sheet.WriteString(`" t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(w.sheet, []byte(value)); err != nil {
+			return fmt.Errorf("xlsx: writing cell: %w", err)
+		}
+		w.sheet.WriteString(`</t></is></c>`)
+	}
+	if _, err := w.sheet.WriteString(`</row>`); err != nil {
+		return fmt.Errorf("xlsx: writing row %d: %w", w.rowNum, err)
+	}
+	return nil
+}
+
+// Flush передает накопленные строки в нижележащий io.Writer (сжатые данные могут частично остаться в буфере zip).
+func (w *Writer) Flush() error {
+	if w.closed {
+		return ErrClosed
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return fmt.Errorf("xlsx: flushing sheet: %w", err)
+	}
+	return w.zw.Flush()
+}
+
+// Close завершает лист и записывает оглавление zip архива. Нижележащий io.Writer не закрывается.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if _, err := w.sheet.WriteString(sheetFooterXML); err != nil {
+		return fmt.Errorf("xlsx: writing sheet footer: %w", err)
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return fmt.Errorf("xlsx: flushing sheet: %w", err)
+	}
+	if err := w.zw.Close(); err != nil {
+		return fmt.Errorf("xlsx: closing archive: %w", err)
+	}
+	return nil
+}
+
+// columnName переводит индекс колонки (с нуля) в буквенное обозначение: 0 -> A, 25 -> Z, 26 -> AA.
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
@@ -0,0 +1,142 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz-service-avito-internship/pkg/xlsx"
+)
+
+type sheetXML struct {
+	Rows []struct {
+		R     string `xml:"r,attr"`
+		Cells []struct {
+			R    string `xml:"r,attr"`
+			T    string `xml:"t,attr"`
+			Text string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// writeBook пишет строки rows в книгу и возвращает содержимое ее частей по именам.
+func writeBook(t *testing.T, sheetName string, rows ...[]string) map[string]string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := xlsx.NewWriter(&buf, sheetName)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.WriteRow(row))
+	}
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	parts := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		parts[f.Name] = string(body)
+	}
+	return parts
+}
+
+func parseSheet(t *testing.T, parts map[string]string) sheetXML {
+	t.Helper()
+	body, ok := parts["xl/worksheets/sheet1.xml"]
+	require.True(t, ok, "sheet part is missing")
+	var sheet sheetXML
+	require.NoError(t, xml.Unmarshal([]byte(body), &sheet))
+	return sheet
+}
+
+func TestWriter_Package(t *testing.T) {
+	parts := writeBook(t, "Отчет <Q1>", []string{"id", "city"}, []string{"1", "Москва"})
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		body, ok := parts[name]
+		require.True(t, ok, "part %s is missing", name)
+		assert.NoError(t, xml.Unmarshal([]byte(body), new(struct{})), "part %s is not valid XML", name)
+	}
+	assert.Contains(t, parts["xl/workbook.xml"], `name="Отчет &lt;Q1&gt;"`)
+
+	sheet := parseSheet(t, parts)
+	require.Len(t, sheet.Rows, 2)
+	assert.Equal(t, "1", sheet.Rows[0].R)
+	assert.Equal(t, "2", sheet.Rows[1].R)
+	require.Len(t, sheet.Rows[1].Cells, 2)
+	assert.Equal(t, "B2", sheet.Rows[1].Cells[1].R)
+	assert.Equal(t, "inlineStr", sheet.Rows[1].Cells[1].T)
+	assert.Equal(t, "Москва", sheet.Rows[1].Cells[1].Text)
+}
+
+func TestWriter_ColumnNames(t *testing.T) {
+	row := make([]string, 54)
+	for i := range row {
+		row[i] = "x"
+	}
+	sheet := parseSheet(t, writeBook(t, "Sheet", row))
+
+	require.Len(t, sheet.Rows, 1)
+	require.Len(t, sheet.Rows[0].Cells, len(row))
+	refs := make([]string, 0, len(row))
+	for _, c := range sheet.Rows[0].Cells {
+		refs = append(refs, c.R)
+	}
+	assert.Equal(t, "A1", refs[0])
+	assert.Equal(t, "Z1", refs[25])
+	assert.Equal(t, "AA1", refs[26])
+	assert.Equal(t, "AZ1", refs[51])
+	assert.Equal(t, "BA1", refs[52])
+	assert.Equal(t, "BB1", refs[53])
+}
+
+func TestWriter_Escaping(t *testing.T) {
+	parts := writeBook(t, "Sheet", []string{`<b>&"quoted"`, "line1\nline2\ttab", "bell\x07", "", "last"})
+	body := parts["xl/worksheets/sheet1.xml"]
+
+	assert.Contains(t, body, "&lt;b&gt;&amp;&#34;quoted&#34;")
+	assert.NotContains(t, body, "\x07")
+
+	sheet := parseSheet(t, parts)
+	require.Len(t, sheet.Rows, 1)
+	cells := sheet.Rows[0].Cells
+	// Пустая ячейка не пишется, но следующая сохраняет свою колонку.
+	require.Len(t, cells, 4)
+	assert.Equal(t, `<b>&"quoted"`, cells[0].Text)
+	assert.Equal(t, "line1\nline2\ttab", cells[1].Text)
+	// Управляющие символы, недопустимые в XML, заменяются на U+FFFD.
+	assert.Equal(t, "bell\uFFFD", cells[2].Text)
+	assert.Equal(t, "E1", cells[3].R)
+}
+
+func TestWriter_WriteAfterClose(t *testing.T) {
+	w, err := xlsx.NewWriter(io.Discard, "Sheet")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.ErrorIs(t, w.WriteRow([]string{"a"}), xlsx.ErrClosed)
+	assert.ErrorIs(t, w.Flush(), xlsx.ErrClosed)
+	assert.NoError(t, w.Close())
+}
+
+func TestWriter_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := xlsx.NewWriter(&buf, "Sheet")
+	require.NoError(t, err)
+	before := buf.Len()
+
+	require.NoError(t, w.WriteRow([]string{strings.Repeat("data", 1024)}))
+	require.NoError(t, w.Flush())
+	assert.Greater(t, buf.Len(), before)
+	require.NoError(t, w.Close())
+}
@@ -3,7 +3,9 @@ set -e
 
 API_DIR="api"
 OUTPUT_HTTP_API_DIR="internal/handler/http/api"
-OUTPUT_GRPC_DIR="pkg/grpc"
+OUTPUT_GRPC_DIR="pkg/grpc/pvz/v1"
+# google/api/annotations.proto и google/api/http.proto для аннотаций grpc-gateway
+THIRD_PARTY_DIR="third_party"
 HTTP_API_PKG_NAME="api"
 # Путь к стандартным proto файлам (если protoc их не находит сам)
 #PROTOC_INCLUDE_DIR="D:/Proto/include" # Пример для Windows
@@ -27,8 +29,14 @@ mkdir -p $OUTPUT_GRPC_DIR
 #protoc --proto_path=$API_DIR -I "$PROTOC_INCLUDE_DIR" --go_out=$OUTPUT_GRPC_DIR --go_opt=paths=source_relative --go-grpc_out=$OUTPUT_GRPC_DIR --go-grpc_opt=paths=source_relative $API_DIR/pvz.proto
 echo "Running protoc..."
 #Вариант без указания пути к стандартным импортам
-protoc --proto_path=$API_DIR --go_out=$OUTPUT_GRPC_DIR --go_opt=paths=source_relative --go-grpc_out=$OUTPUT_GRPC_DIR --go-grpc_opt=paths=source_relative $API_DIR/pvz.proto
-echo "gRPC code generated successfully."
+#Требуются плагины protoc-gen-grpc-gateway и protoc-gen-openapiv2 (github.com/grpc-ecosystem/grpc-gateway/v2)
+protoc --proto_path=$API_DIR --proto_path=$THIRD_PARTY_DIR \
+  --go_out=$OUTPUT_GRPC_DIR --go_opt=paths=source_relative \
+  --go-grpc_out=$OUTPUT_GRPC_DIR --go-grpc_opt=paths=source_relative \
+  --grpc-gateway_out=$OUTPUT_GRPC_DIR --grpc-gateway_opt=paths=source_relative \
+  --openapiv2_out=$API_DIR \
+  $API_DIR/pvz.proto
+echo "gRPC, gateway and OpenAPI (api/pvz.swagger.json) code generated successfully."
 echo ""
 
 echo "--- Code Generation Complete ---"
\ No newline at end of file